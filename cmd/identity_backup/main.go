@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/buildinfo"
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/providers"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+var build = buildinfo.Revision()
+
+const (
+	exportCommand  = "export"
+	restoreCommand = "restore"
+)
+
+// This is a tool to move an identity between issuer nodes.
+//
+//	identity_backup export --did=<did> --passphrase=<passphrase> [--includeKeys] --file=backup.json
+//	identity_backup restore --passphrase=<passphrase> --file=backup.json
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	exportFlags := flag.NewFlagSet(exportCommand, flag.ExitOnError)
+	fDID := exportFlags.String("did", "", "identity to export")
+	fIncludeKeys := exportFlags.Bool("includeKeys", false, "add the private keys of the identity, encrypted with the passphrase")
+	fExportPassphrase := exportFlags.String("passphrase", "", "passphrase to sign the archive and encrypt the keys")
+	fExportFile := exportFlags.String("file", "", "archive file to write")
+
+	restoreFlags := flag.NewFlagSet(restoreCommand, flag.ExitOnError)
+	fRestorePassphrase := restoreFlags.String("passphrase", "", "passphrase the archive was signed with")
+	fRestoreFile := restoreFlags.String("file", "", "archive file to restore")
+
+	if len(os.Args) < 2 || (os.Args[1] != exportCommand && os.Args[1] != restoreCommand) {
+		fmt.Printf("usage: %s %s|%s [flags]\n", os.Args[0], exportCommand, restoreCommand)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Error(ctx, "cannot load config", "err", err)
+		return
+	}
+	log.Config(cfg.Log.Level, cfg.Log.Mode, os.Stdout)
+	log.Info(ctx, "starting identity backup...", "revision", build)
+
+	storage, err := db.NewStorage(cfg.Database.URL)
+	if err != nil {
+		log.Error(ctx, "cannot connect to database", "err", err)
+		return
+	}
+	defer func(storage *db.Storage) {
+		err := storage.Close()
+		if err != nil {
+			log.Error(ctx, "error closing database connection", "err", err)
+		}
+	}(storage)
+
+	vaultCfg := providers.Config{
+		UserPassAuthEnabled: cfg.KeyStore.VaultUserPassAuthEnabled,
+		Pass:                cfg.KeyStore.VaultUserPassAuthPassword,
+		Address:             cfg.KeyStore.Address,
+		Token:               cfg.KeyStore.Token,
+		TLSEnabled:          cfg.KeyStore.TLSEnabled,
+		CertPath:            cfg.KeyStore.CertPath,
+	}
+	keyStore, err := config.KeyStoreConfig(ctx, cfg, vaultCfg)
+	if err != nil {
+		log.Error(ctx, "cannot initialize key store", "err", err)
+		return
+	}
+
+	mtRepository := repositories.NewIdentityMerkleTreeRepository()
+	backupService := services.NewIdentityBackup(keyStore, repositories.NewIdentity(), mtRepository, repositories.NewIdentityState(), repositories.NewIdentityBackup(), services.NewIdentityMerkleTrees(mtRepository), storage)
+
+	switch os.Args[1] {
+	case exportCommand:
+		if err := exportFlags.Parse(os.Args[2:]); err != nil {
+			log.Error(ctx, "cannot parse flags", "err", err)
+			return
+		}
+		if *fDID == "" || *fExportFile == "" {
+			log.Error(ctx, "did and file are required")
+			return
+		}
+		did, err := w3c.ParseDID(*fDID)
+		if err != nil {
+			log.Error(ctx, "invalid did", "err", err)
+			return
+		}
+		archive, err := backupService.Export(ctx, *did, ports.IdentityBackupRequest{Passphrase: *fExportPassphrase, IncludeKeys: *fIncludeKeys})
+		if err != nil {
+			log.Error(ctx, "cannot export identity", "err", err)
+			return
+		}
+		if err := os.WriteFile(*fExportFile, archive, 0o600); err != nil {
+			log.Error(ctx, "cannot write archive", "err", err)
+			return
+		}
+		log.Info(ctx, "identity exported", "did", did, "file", *fExportFile)
+	case restoreCommand:
+		if err := restoreFlags.Parse(os.Args[2:]); err != nil {
+			log.Error(ctx, "cannot parse flags", "err", err)
+			return
+		}
+		if *fRestoreFile == "" {
+			log.Error(ctx, "file is required")
+			return
+		}
+		archive, err := os.ReadFile(*fRestoreFile)
+		if err != nil {
+			log.Error(ctx, "cannot read archive", "err", err)
+			return
+		}
+		did, err := backupService.Restore(ctx, archive, *fRestorePassphrase)
+		if err != nil {
+			log.Error(ctx, "cannot restore identity", "err", err)
+			return
+		}
+		log.Info(ctx, "identity restored", "did", did)
+	}
+}
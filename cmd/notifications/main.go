@@ -103,6 +103,7 @@ func main() {
 	ps.Subscribe(ctxCancel, event.CreateCredentialEvent, notificationService.SendCreateCredentialNotification)
 	ps.Subscribe(ctxCancel, event.CreateConnectionEvent, notificationService.SendCreateConnectionNotification)
 	ps.Subscribe(ctxCancel, event.CreateStateEvent, notificationService.SendRevokeCredentialNotification)
+	ps.Subscribe(ctxCancel, event.ExpiredCredentialsEvent, notificationService.SendExpiredCredentialNotification)
 
 	gracefulShutdown := make(chan os.Signal, 1)
 	signal.Notify(gracefulShutdown, syscall.SIGINT, syscall.SIGTERM)
@@ -143,7 +144,7 @@ func newCredentialsService(ctx context.Context, cfg *config.Configuration, stora
 	}
 
 	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
-	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 	schemaLoader := loader.NewDocumentLoader(cfg.IPFS.GatewayURL, cfg.SchemaCache)
 
 	mtService := services.NewIdentityMerkleTrees(mtRepository)
@@ -18,6 +18,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/core/services"
 	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/eth"
 	"github.com/polygonid/sh-id-platform/internal/gateways"
 	"github.com/polygonid/sh-id-platform/internal/loader"
 	"github.com/polygonid/sh-id-platform/internal/log"
@@ -110,7 +111,7 @@ func main() {
 	connectionsRepository := repositories.NewConnection()
 
 	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
-	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 
 	mediaTypeManager := services.NewMediaTypeManager(
 		map[iden3comm.ProtocolMessage][]string{
@@ -131,7 +132,7 @@ func main() {
 		log.Error(ctx, "error creating transaction service", "err", err)
 		panic("error creating transaction service")
 	}
-	publisherGateway, err := gateways.NewPublisherEthGateway(*networkResolver, keyStore, cfg.PublishingKeyPath)
+	publisherGateway, err := gateways.NewPublisherEthGateway(*networkResolver, keyStore, services.NewPublishingKeys(*networkResolver, cfg.PublishingKeyPath), eth.NewNonceManager(repositories.NewPublishingNonce(), storage))
 	if err != nil {
 		log.Error(ctx, "error creating publish gateway", "err", err)
 		panic("error creating publish gateway")
@@ -154,6 +155,61 @@ func main() {
 		}
 	}(ctx)
 
+	if cfg.ExpirySweeper.Frequency > 0 {
+		expirySweeper := services.NewExpirySweeper(claimsRepo, claimsService, ps, storage, cfg.ExpirySweeper.RevokeSchemas)
+		go func(ctx context.Context) {
+			ticker := time.NewTicker(cfg.ExpirySweeper.Frequency)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					if err := expirySweeper.Sweep(ctx); err != nil {
+						log.Error(ctx, "error processing expired credentials", "err", err)
+					}
+				case <-ctx.Done():
+					log.Info(ctx, "finishing expiry sweeper job")
+					return
+				}
+			}
+		}(ctx)
+	}
+
+	if cfg.AutoPublisher.Frequency > 0 {
+		autoPublisher := services.NewAutoPublisher(identityRepo, publisher, storage, cfg.AutoPublisher.Concurrency)
+		go func(ctx context.Context) {
+			ticker := time.NewTicker(cfg.AutoPublisher.Frequency)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					if err := autoPublisher.Publish(ctx); err != nil {
+						log.Error(ctx, "error publishing states", "err", err)
+					}
+				case <-ctx.Done():
+					log.Info(ctx, "finishing auto publisher job")
+					return
+				}
+			}
+		}(ctx)
+	}
+
+	if cfg.IdempotencyKeys.TTL > 0 {
+		idempotencyService := services.NewIdempotency(repositories.NewIdempotencyKey(), storage, cfg.IdempotencyKeys.TTL, cfg.IdempotencyKeys.Lease)
+		go func(ctx context.Context) {
+			ticker := time.NewTicker(cfg.IdempotencyKeys.TTL)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					_ = idempotencyService.DeleteExpired(ctx)
+				case <-ctx.Done():
+					log.Info(ctx, "finishing idempotency keys cleanup job")
+					return
+				}
+			}
+		}(ctx)
+	}
+
 	go func() {
 		http.Handle("/status", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			_, err := w.Write([]byte("OK"))
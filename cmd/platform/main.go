@@ -24,6 +24,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/core/services"
 	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/errors"
+	"github.com/polygonid/sh-id-platform/internal/eth"
 	"github.com/polygonid/sh-id-platform/internal/gateways"
 	"github.com/polygonid/sh-id-platform/internal/health"
 	"github.com/polygonid/sh-id-platform/internal/loader"
@@ -112,6 +113,7 @@ func main() {
 	schemaRepository := repositories.NewSchema(*storage)
 	linkRepository := repositories.NewLink(*storage)
 	sessionRepository := repositories.NewSessionCached(cachex)
+	credentialImportRepository := repositories.NewCredentialImport()
 
 	// services initialization
 	mtService := services.NewIdentityMerkleTrees(mtRepository)
@@ -131,26 +133,35 @@ func main() {
 		universalDIDResolverUrl = *cfg.UniversalDIDResolver.UniversalResolverURL
 	}
 	universalDIDResolverHandler := packagemanager.NewUniversalDIDResolverHandler(universalDIDResolverUrl)
+	didDocumentService := services.NewDIDDocument(keyStore, identityRepository, claimsRepository, *networkResolver, storage, cfg.ServerUrl)
+	didResolverHandler := packagemanager.NewLocalDIDResolverHandler(didDocumentService, universalDIDResolverHandler)
 
-	packageManager, err := packagemanager.New(ctx, networkResolver.GetSupportedContracts(), cfg.Circuit.Path, universalDIDResolverHandler)
+	packageManager, err := packagemanager.New(ctx, networkResolver.GetSupportedContracts(), cfg.Circuit.Path, didResolverHandler)
 	if err != nil {
 		log.Error(ctx, "failed init package packagemanager", "err", err)
 		return
 	}
 
 	verificationKeyLoader := &authLoaders.FSKeyLoader{Dir: cfg.Circuit.Path + "/authV2"}
-	verifier, err := auth.NewVerifier(verificationKeyLoader, networkResolver.GetStateResolvers(), auth.WithDIDResolver(universalDIDResolverHandler))
+	verifier, err := auth.NewVerifier(verificationKeyLoader, networkResolver.GetStateResolvers(), auth.WithDIDResolver(didResolverHandler))
 	if err != nil {
 		log.Error(ctx, "failed init verifier", "err", err)
 		return
 	}
 
-	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+	statusListRepository := repositories.NewStatusList()
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, statusListRepository)
 	identityService := services.NewIdentity(keyStore, identityRepository, mtRepository, identityStateRepository, mtService, qrService, claimsRepository, revocationRepository, connectionsRepository, storage, verifier, sessionRepository, ps, *networkResolver, rhsFactory, revocationStatusResolver)
 	claimsService := services.NewClaim(claimsRepository, identityService, qrService, mtService, identityStateRepository, schemaLoader, storage, cfg.ServerUrl, ps, cfg.IPFS.GatewayURL, revocationStatusResolver, mediaTypeManager, cfg.UniversalLinks)
 	proofService := services.NewProver(circuitsLoaderService)
 	schemaService := services.NewSchema(schemaRepository, schemaLoader)
 	linkService := services.NewLinkService(storage, claimsService, qrService, claimsRepository, linkRepository, schemaRepository, schemaLoader, sessionRepository, ps, identityService, *networkResolver, cfg.UniversalLinks)
+	credentialImportService := services.NewCredentialImport(credentialImportRepository, schemaRepository, claimsService, schemaLoader, storage)
+	if err := credentialImportService.Resume(ctx); err != nil {
+		log.Error(ctx, "error resuming credential imports", "err", err)
+	}
+	credentialExportService := services.NewCredentialExport(keyStore)
+	statusListService := services.NewStatusList(statusListRepository, storage, claimsService, identityService, credentialExportService, revocationStatusResolver, schemaLoader)
 
 	transactionService, err := gateways.NewTransaction(*networkResolver)
 	if err != nil {
@@ -158,8 +169,9 @@ func main() {
 		return
 	}
 	accountService := services.NewAccountService(*networkResolver)
+	publishingKeyService := services.NewPublishingKeys(*networkResolver, cfg.PublishingKeyPath)
 
-	publisherGateway, err := gateways.NewPublisherEthGateway(*networkResolver, keyStore, cfg.PublishingKeyPath)
+	publisherGateway, err := gateways.NewPublisherEthGateway(*networkResolver, keyStore, publishingKeyService, eth.NewNonceManager(repositories.NewPublishingNonce(), storage))
 	if err != nil {
 		log.Error(ctx, "error creating publish gateway", "err", err)
 		return
@@ -193,7 +205,7 @@ func main() {
 	)
 	api.HandlerWithOptions(
 		api.NewStrictHandlerWithOptions(
-			api.NewServer(cfg, identityService, accountService, connectionsService, claimsService, qrService, publisher, packageManager, *networkResolver, serverHealth, schemaService, linkService),
+			api.NewServer(cfg, identityService, accountService, connectionsService, claimsService, qrService, publisher, packageManager, *networkResolver, serverHealth, schemaService, linkService, credentialImportService, statusListService, credentialExportService, services.NewIdempotency(repositories.NewIdempotencyKey(), storage, cfg.IdempotencyKeys.TTL, cfg.IdempotencyKeys.Lease), services.NewIdentityBackup(keyStore, identityRepository, mtRepository, identityStateRepository, repositories.NewIdentityBackup(), mtService, storage), didDocumentService, publishingKeyService),
 			middlewares(ctx, cfg.HTTPBasicAuth),
 			api.StrictHTTPServerOptions{
 				RequestErrorHandlerFunc:  errors.RequestErrorHandlerFunc,
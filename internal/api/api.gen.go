@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	protocol "github.com/iden3/iden3comm/v2/protocol"
 	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	timeapi "github.com/polygonid/sh-id-platform/internal/timeapi"
 )
 
@@ -26,6 +28,7 @@ const (
 
 // Defines values for CreateCredentialRequestCredentialStatusType.
 const (
+	CreateCredentialRequestCredentialStatusTypeBitstringStatusListEntry              CreateCredentialRequestCredentialStatusType = "BitstringStatusListEntry"
 	CreateCredentialRequestCredentialStatusTypeIden3OnchainSparseMerkleTreeProof2023 CreateCredentialRequestCredentialStatusType = "Iden3OnchainSparseMerkleTreeProof2023"
 	CreateCredentialRequestCredentialStatusTypeIden3ReverseSparseMerkleTreeProof     CreateCredentialRequestCredentialStatusType = "Iden3ReverseSparseMerkleTreeProof"
 	CreateCredentialRequestCredentialStatusTypeIden3commRevocationStatusV10          CreateCredentialRequestCredentialStatusType = "Iden3commRevocationStatusV1.0"
@@ -46,8 +49,8 @@ const (
 
 // Defines values for CreateIdentityRequestDidMetadataType.
 const (
-	BJJ CreateIdentityRequestDidMetadataType = "BJJ"
-	ETH CreateIdentityRequestDidMetadataType = "ETH"
+	CreateIdentityRequestDidMetadataTypeBJJ CreateIdentityRequestDidMetadataType = "BJJ"
+	CreateIdentityRequestDidMetadataTypeETH CreateIdentityRequestDidMetadataType = "ETH"
 )
 
 // Defines values for CreateIdentityResponseCredentialStatusType.
@@ -57,6 +60,14 @@ const (
 	CreateIdentityResponseCredentialStatusTypeIden3commRevocationStatusV10          CreateIdentityResponseCredentialStatusType = "Iden3commRevocationStatusV1.0"
 )
 
+// Defines values for CredentialImportStatus.
+const (
+	CredentialImportStatusFailed   CredentialImportStatus = "failed"
+	CredentialImportStatusFinished CredentialImportStatus = "finished"
+	CredentialImportStatusPending  CredentialImportStatus = "pending"
+	CredentialImportStatusRunning  CredentialImportStatus = "running"
+)
+
 // Defines values for DisplayMethodType.
 const (
 	Iden3BasicDisplayMethodv2 DisplayMethodType = "Iden3BasicDisplayMethodv2"
@@ -71,9 +82,30 @@ const (
 
 // Defines values for GetIdentityDetailsResponseCredentialStatusType.
 const (
-	Iden3OnchainSparseMerkleTreeProof2023 GetIdentityDetailsResponseCredentialStatusType = "Iden3OnchainSparseMerkleTreeProof2023"
-	Iden3ReverseSparseMerkleTreeProof     GetIdentityDetailsResponseCredentialStatusType = "Iden3ReverseSparseMerkleTreeProof"
-	Iden3commRevocationStatusV10          GetIdentityDetailsResponseCredentialStatusType = "Iden3commRevocationStatusV1.0"
+	GetIdentityDetailsResponseCredentialStatusTypeIden3OnchainSparseMerkleTreeProof2023 GetIdentityDetailsResponseCredentialStatusType = "Iden3OnchainSparseMerkleTreeProof2023"
+	GetIdentityDetailsResponseCredentialStatusTypeIden3ReverseSparseMerkleTreeProof     GetIdentityDetailsResponseCredentialStatusType = "Iden3ReverseSparseMerkleTreeProof"
+	GetIdentityDetailsResponseCredentialStatusTypeIden3commRevocationStatusV10          GetIdentityDetailsResponseCredentialStatusType = "Iden3commRevocationStatusV1.0"
+)
+
+// Defines values for IdentityLifecycleStatus.
+const (
+	IdentityLifecycleStatusActive      IdentityLifecycleStatus = "active"
+	IdentityLifecycleStatusArchived    IdentityLifecycleStatus = "archived"
+	IdentityLifecycleStatusDeactivated IdentityLifecycleStatus = "deactivated"
+)
+
+// Defines values for ImportCredentialsRequestCredentialStatusType.
+const (
+	ImportCredentialsRequestCredentialStatusTypeBitstringStatusListEntry              ImportCredentialsRequestCredentialStatusType = "BitstringStatusListEntry"
+	ImportCredentialsRequestCredentialStatusTypeIden3OnchainSparseMerkleTreeProof2023 ImportCredentialsRequestCredentialStatusType = "Iden3OnchainSparseMerkleTreeProof2023"
+	ImportCredentialsRequestCredentialStatusTypeIden3ReverseSparseMerkleTreeProof     ImportCredentialsRequestCredentialStatusType = "Iden3ReverseSparseMerkleTreeProof"
+	ImportCredentialsRequestCredentialStatusTypeIden3commRevocationStatusV10          ImportCredentialsRequestCredentialStatusType = "Iden3commRevocationStatusV1.0"
+)
+
+// Defines values for ImportCredentialsRequestFormat.
+const (
+	Csv    ImportCredentialsRequestFormat = "csv"
+	Ndjson ImportCredentialsRequestFormat = "ndjson"
 )
 
 // Defines values for LinkStatus.
@@ -83,6 +115,15 @@ const (
 	LinkStatusInactive LinkStatus = "inactive"
 )
 
+// Defines values for PublishingPolicyMode.
+const (
+	Always       PublishingPolicyMode = "always"
+	Cron         PublishingPolicyMode = "cron"
+	Interval     PublishingPolicyMode = "interval"
+	Manual       PublishingPolicyMode = "manual"
+	PendingCount PublishingPolicyMode = "pendingCount"
+)
+
 // Defines values for RefreshServiceType.
 const (
 	Iden3RefreshService2023 RefreshServiceType = "Iden3RefreshService2023"
@@ -90,10 +131,33 @@ const (
 
 // Defines values for StateTransactionStatus.
 const (
-	Created   StateTransactionStatus = "created"
-	Failed    StateTransactionStatus = "failed"
-	Pending   StateTransactionStatus = "pending"
-	Published StateTransactionStatus = "published"
+	StateTransactionStatusCreated   StateTransactionStatus = "created"
+	StateTransactionStatusFailed    StateTransactionStatus = "failed"
+	StateTransactionStatusPending   StateTransactionStatus = "pending"
+	StateTransactionStatusPublished StateTransactionStatus = "published"
+)
+
+// Defines values for SearchIdentitiesParamsKeyType.
+const (
+	SearchIdentitiesParamsKeyTypeBJJ SearchIdentitiesParamsKeyType = "BJJ"
+	SearchIdentitiesParamsKeyTypeETH SearchIdentitiesParamsKeyType = "ETH"
+)
+
+// Defines values for SearchIdentitiesParamsCredentialStatusType.
+const (
+	Iden3OnchainSparseMerkleTreeProof2023 SearchIdentitiesParamsCredentialStatusType = "Iden3OnchainSparseMerkleTreeProof2023"
+	Iden3ReverseSparseMerkleTreeProof     SearchIdentitiesParamsCredentialStatusType = "Iden3ReverseSparseMerkleTreeProof"
+	Iden3commRevocationStatusV10          SearchIdentitiesParamsCredentialStatusType = "Iden3commRevocationStatusV1.0"
+)
+
+// Defines values for SearchIdentitiesParamsSort.
+const (
+	SearchIdentitiesParamsSortCreatedAt              SearchIdentitiesParamsSort = "createdAt"
+	SearchIdentitiesParamsSortCredentialsIssued      SearchIdentitiesParamsSort = "credentialsIssued"
+	SearchIdentitiesParamsSortDisplayName            SearchIdentitiesParamsSort = "displayName"
+	SearchIdentitiesParamsSortMinusCreatedAt         SearchIdentitiesParamsSort = "-createdAt"
+	SearchIdentitiesParamsSortMinusCredentialsIssued SearchIdentitiesParamsSort = "-credentialsIssued"
+	SearchIdentitiesParamsSortMinusDisplayName       SearchIdentitiesParamsSort = "-displayName"
 )
 
 // Defines values for GetConnectionsParamsSort.
@@ -131,6 +195,19 @@ const (
 	GetLinksParamsStatusInactive GetLinksParamsStatus = "inactive"
 )
 
+// Defines values for GetStatusListCredentialParamsFormat.
+const (
+	GetStatusListCredentialParamsFormatJwtVc GetStatusListCredentialParamsFormat = "jwt-vc"
+	GetStatusListCredentialParamsFormatW3c   GetStatusListCredentialParamsFormat = "w3c"
+)
+
+// Defines values for GetCredentialParamsFormat.
+const (
+	GetCredentialParamsFormatJwtVc   GetCredentialParamsFormat = "jwt-vc"
+	GetCredentialParamsFormatSdJwtVc GetCredentialParamsFormat = "sd-jwt-vc"
+	GetCredentialParamsFormatW3c     GetCredentialParamsFormat = "w3c"
+)
+
 // Defines values for GetCredentialOfferParamsType.
 const (
 	GetCredentialOfferParamsTypeDeepLink      GetCredentialOfferParamsType = "deepLink"
@@ -190,6 +267,12 @@ type ConnectionsPaginated struct {
 	Meta  PaginatedMetadata      `json:"meta"`
 }
 
+// CoreClaimSlots Slots of the core claim as decimal numbers
+type CoreClaimSlots struct {
+	Index []string `json:"index"`
+	Value []string `json:"value"`
+}
+
 // CreateConnectionRequest defines model for CreateConnectionRequest.
 type CreateConnectionRequest struct {
 	IssuerDoc map[string]interface{} `json:"issuerDoc"`
@@ -199,6 +282,8 @@ type CreateConnectionRequest struct {
 
 // CreateCredentialRequest defines model for CreateCredentialRequest.
 type CreateCredentialRequest struct {
+	// AuthCredentialID Auth credential of the key that signs the BJJSignature2021 proof. Defaults to the primary key of the identity.
+	AuthCredentialID      *uuid.UUID                                   `json:"authCredentialID,omitempty"`
 	ClaimID               *uuid.UUID                                   `json:"claimID"`
 	CredentialSchema      string                                       `json:"credentialSchema"`
 	CredentialStatusType  *CreateCredentialRequestCredentialStatusType `json:"credentialStatusType,omitempty"`
@@ -211,7 +296,10 @@ type CreateCredentialRequest struct {
 	RevNonce              *uint64                                      `json:"revNonce,omitempty"`
 	SubjectPosition       *string                                      `json:"subjectPosition,omitempty"`
 	Type                  string                                       `json:"type"`
-	Version               *uint32                                      `json:"version,omitempty"`
+
+	// Updatable Allows the credential to be updated later. Defaults to false.
+	Updatable *bool   `json:"updatable,omitempty"`
+	Version   *uint32 `json:"version,omitempty"`
 }
 
 // CreateCredentialRequestCredentialStatusType defines model for CreateCredentialRequest.CredentialStatusType.
@@ -225,14 +313,44 @@ type CreateCredentialResponse struct {
 	Id string `json:"id"`
 }
 
+// CreateCredentialsBatchItem defines model for CreateCredentialsBatchItem.
+type CreateCredentialsBatchItem struct {
+	Error *string `json:"error,omitempty"`
+	Id    *string `json:"id,omitempty"`
+	Index int     `json:"index"`
+}
+
+// CreateCredentialsBatchRequest defines model for CreateCredentialsBatchRequest.
+type CreateCredentialsBatchRequest struct {
+	Credentials []CreateCredentialRequest `json:"credentials"`
+}
+
+// CreateCredentialsBatchResponse defines model for CreateCredentialsBatchResponse.
+type CreateCredentialsBatchResponse struct {
+	Items []CreateCredentialsBatchItem `json:"items"`
+}
+
+// CreateIdentityBackupRequest defines model for CreateIdentityBackupRequest.
+type CreateIdentityBackupRequest struct {
+	// IncludeKeys add the private keys of the identity to the archive
+	IncludeKeys *bool `json:"includeKeys,omitempty"`
+
+	// Passphrase passphrase used to sign the archive and encrypt the keys
+	Passphrase string `json:"passphrase"`
+}
+
 // CreateIdentityRequest defines model for CreateIdentityRequest.
 type CreateIdentityRequest struct {
 	CredentialStatusType *CreateIdentityRequestCredentialStatusType `json:"credentialStatusType,omitempty"`
 	DidMetadata          struct {
-		Blockchain string                               `json:"blockchain"`
-		Method     string                               `json:"method"`
-		Network    string                               `json:"network"`
-		Type       CreateIdentityRequestDidMetadataType `json:"type"`
+		Blockchain string `json:"blockchain"`
+
+		// Method DID method of the identity. Use `web` to create a did:web identity hosted by the node, which has no
+		// state, so blockchain and network are ignored and its credentials are revoked with a status list.
+		// did:web identities sign their credentials with a BJJ signature proof, so they must have a BJJ key.
+		Method  string                               `json:"method"`
+		Network string                               `json:"network"`
+		Type    CreateIdentityRequestDidMetadataType `json:"type"`
 	} `json:"didMetadata"`
 	DisplayName *string `json:"displayName"`
 }
@@ -272,13 +390,39 @@ type CreateLinkRequest struct {
 
 // Credential defines model for Credential.
 type Credential struct {
-	Id         string                   `json:"id"`
+	Id string `json:"id"`
+
+	// PreviousID Id of the credential replaced by this one when it was updated
+	PreviousID *string                  `json:"previousID,omitempty"`
 	ProofTypes []string                 `json:"proofTypes"`
 	Revoked    bool                     `json:"revoked"`
 	SchemaHash string                   `json:"schemaHash"`
 	Vc         verifiable.W3CCredential `json:"vc"`
 }
 
+// CredentialImport defines model for CredentialImport.
+type CredentialImport struct {
+	CreatedAt TimeUTC `json:"createdAt"`
+
+	// Error Reason why the import failed
+	Error      *string    `json:"error,omitempty"`
+	Format     string     `json:"format"`
+	Id         UUIDString `json:"id"`
+	Issued     int        `json:"issued"`
+	ModifiedAt TimeUTC    `json:"modifiedAt"`
+	Processed  int        `json:"processed"`
+
+	// Progress Percentage of processed rows
+	Progress int                    `json:"progress"`
+	Rejected int                    `json:"rejected"`
+	SchemaID UUIDString             `json:"schemaID"`
+	Status   CredentialImportStatus `json:"status"`
+	Total    int                    `json:"total"`
+}
+
+// CredentialImportStatus defines model for CredentialImport.Status.
+type CredentialImportStatus string
+
 // CredentialLinkQrCodeResponse defines model for CredentialLinkQrCodeResponse.
 type CredentialLinkQrCodeResponse struct {
 	DeepLink      string            `json:"deepLink"`
@@ -290,8 +434,9 @@ type CredentialLinkQrCodeResponse struct {
 
 // CredentialOfferResponse defines model for CredentialOfferResponse.
 type CredentialOfferResponse struct {
-	SchemaType    string `json:"schemaType"`
-	UniversalLink string `json:"universalLink"`
+	Issuer        IssuerDescription `json:"issuer"`
+	SchemaType    string            `json:"schemaType"`
+	UniversalLink string            `json:"universalLink"`
 }
 
 // CredentialSubject defines model for CredentialSubject.
@@ -303,6 +448,30 @@ type CredentialsPaginated struct {
 	Meta  PaginatedMetadata `json:"meta"`
 }
 
+// DIDDocument defines model for DIDDocument.
+type DIDDocument = verifiable.DIDDocument
+
+// DIDResolutionResult defines model for DIDResolutionResult.
+type DIDResolutionResult struct {
+	Context             string      `json:"@context"`
+	DidDocument         DIDDocument `json:"didDocument"`
+	DidDocumentMetadata struct {
+		Deactivated bool `json:"deactivated"`
+	} `json:"didDocumentMetadata"`
+	DidResolutionMetadata struct {
+		ContentType string `json:"contentType"`
+	} `json:"didResolutionMetadata"`
+}
+
+// DeactivateIdentityResponse defines model for DeactivateIdentityResponse.
+type DeactivateIdentityResponse struct {
+	PublishedState *PublishIdentityStateResponse `json:"publishedState,omitempty"`
+
+	// RevokedCredentials number of credentials revoked
+	RevokedCredentials int                     `json:"revokedCredentials"`
+	Status             IdentityLifecycleStatus `json:"status"`
+}
+
 // DisplayMethod defines model for DisplayMethod.
 type DisplayMethod struct {
 	Id   string            `json:"id"`
@@ -342,11 +511,19 @@ type GetConnectionsResponse = []GetConnectionResponse
 // GetIdentitiesResponse defines model for GetIdentitiesResponse.
 type GetIdentitiesResponse struct {
 	Blockchain           string                                     `json:"blockchain"`
+	CreatedAt            TimeUTC                                    `json:"createdAt"`
 	CredentialStatusType *GetIdentitiesResponseCredentialStatusType `json:"credentialStatusType,omitempty"`
+	CredentialsIssued    uint                                       `json:"credentialsIssued"`
+	CredentialsRevoked   uint                                       `json:"credentialsRevoked"`
 	DisplayName          *string                                    `json:"displayName"`
 	Identifier           string                                     `json:"identifier"`
+	KeyType              string                                     `json:"keyType"`
 	Method               string                                     `json:"method"`
 	Network              string                                     `json:"network"`
+
+	// PendingStateChanges Credentials and revocations not included in a published state yet
+	PendingStateChanges uint                    `json:"pendingStateChanges"`
+	Status              IdentityLifecycleStatus `json:"status"`
 }
 
 // GetIdentitiesResponseCredentialStatusType defines model for GetIdentitiesResponse.CredentialStatusType.
@@ -360,7 +537,18 @@ type GetIdentityDetailsResponse struct {
 	DisplayName          *string                                        `json:"displayName"`
 	Identifier           string                                         `json:"identifier"`
 	KeyType              string                                         `json:"keyType"`
-	State                IdentityState                                  `json:"state"`
+	Profile              *IdentityProfile                               `json:"profile,omitempty"`
+
+	// PublishingPolicy When the pending publisher publishes the state of the identity:
+	//   * always: as soon as there are pending changes. It is the default policy.
+	//   * manual: never, the state is only published with the publish state endpoint.
+	//   * pendingCount: when there are at least minPendingChanges credentials and revocations pending.
+	//   * interval: at most once every intervalMinutes.
+	//   * cron: at the times of the cron schedule (minute hour day-of-month month day-of-week, in UTC).
+	// With publishRevocationsImmediately, the state is published as soon as there is a pending revocation, whatever the mode.
+	PublishingPolicy *PublishingPolicy       `json:"publishingPolicy,omitempty"`
+	State            IdentityState           `json:"state"`
+	Status           IdentityLifecycleStatus `json:"status"`
 }
 
 // GetIdentityDetailsResponseCredentialStatusType defines model for GetIdentityDetailsResponse.CredentialStatusType.
@@ -369,6 +557,37 @@ type GetIdentityDetailsResponseCredentialStatusType string
 // Health defines model for Health.
 type Health map[string]bool
 
+// IdentitiesPaginated defines model for IdentitiesPaginated.
+type IdentitiesPaginated struct {
+	Items []GetIdentitiesResponse `json:"items"`
+	Meta  PaginatedMetadata       `json:"meta"`
+}
+
+// IdentityKey defines model for IdentityKey.
+type IdentityKey struct {
+	AuthCredentialID uuid.UUID `json:"authCredentialID"`
+
+	// KeyID id of the key in the key management system
+	KeyID string `json:"keyID"`
+
+	// Published the auth credential is in a published state, so the key can sign credentials
+	Published bool   `json:"published"`
+	RevNonce  uint64 `json:"revNonce"`
+	Revoked   bool   `json:"revoked"`
+}
+
+// IdentityLifecycleStatus defines model for IdentityLifecycleStatus.
+type IdentityLifecycleStatus string
+
+// IdentityProfile defines model for IdentityProfile.
+type IdentityProfile struct {
+	Contact     *string `json:"contact,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Logo        *string `json:"logo,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Website     *string `json:"website,omitempty"`
+}
+
 // IdentityState defines model for IdentityState.
 type IdentityState struct {
 	BlockNumber        *int    `json:"blockNumber,omitempty"`
@@ -386,6 +605,25 @@ type IdentityState struct {
 	TxID               *string `json:"txID,omitempty"`
 }
 
+// ImportCredentialsRequest defines model for ImportCredentialsRequest.
+type ImportCredentialsRequest struct {
+	CredentialStatusType *ImportCredentialsRequestCredentialStatusType `json:"credentialStatusType,omitempty"`
+	Expiration           *int64                                        `json:"expiration,omitempty"`
+	File                 openapi_types.File                            `json:"file"`
+
+	// Format File format. If omitted, it is taken from the file extension.
+	Format *ImportCredentialsRequestFormat `json:"format,omitempty"`
+
+	// SchemaID Id of an imported schema
+	SchemaID uuid.UUID `json:"schemaID"`
+}
+
+// ImportCredentialsRequestCredentialStatusType defines model for ImportCredentialsRequest.CredentialStatusType.
+type ImportCredentialsRequestCredentialStatusType string
+
+// ImportCredentialsRequestFormat File format. If omitted, it is taken from the file extension.
+type ImportCredentialsRequestFormat string
+
 // ImportSchemaRequest defines model for ImportSchemaRequest.
 type ImportSchemaRequest struct {
 	Description *string `json:"description,omitempty"`
@@ -397,8 +635,11 @@ type ImportSchemaRequest struct {
 
 // IssuerDescription defines model for IssuerDescription.
 type IssuerDescription struct {
-	DisplayName string `json:"displayName"`
-	Logo        string `json:"logo"`
+	Contact     *string `json:"contact,omitempty"`
+	Description *string `json:"description,omitempty"`
+	DisplayName string  `json:"displayName"`
+	Logo        string  `json:"logo"`
+	Website     *string `json:"website,omitempty"`
 }
 
 // Link defines model for Link.
@@ -459,6 +700,42 @@ type PublishIdentityStateResponse struct {
 	TxID               *string `json:"txID,omitempty"`
 }
 
+// PublishingKeyStatus defines model for PublishingKeyStatus.
+type PublishingKeyStatus struct {
+	Address   string `json:"address"`
+	Available bool   `json:"available"`
+
+	// Balance balance in wei
+	Balance    string `json:"balance"`
+	Blockchain string `json:"blockchain"`
+
+	// InFlight state transitions being sent with the key
+	InFlight int `json:"inFlight"`
+
+	// MinBalance balance in wei below which the key is not used. It is not set if the balance is not checked.
+	MinBalance *string `json:"minBalance,omitempty"`
+	Network    string  `json:"network"`
+}
+
+// PublishingPolicy When the pending publisher publishes the state of the identity:
+//   - always: as soon as there are pending changes. It is the default policy.
+//   - manual: never, the state is only published with the publish state endpoint.
+//   - pendingCount: when there are at least minPendingChanges credentials and revocations pending.
+//   - interval: at most once every intervalMinutes.
+//   - cron: at the times of the cron schedule (minute hour day-of-month month day-of-week, in UTC).
+//
+// With publishRevocationsImmediately, the state is published as soon as there is a pending revocation, whatever the mode.
+type PublishingPolicy struct {
+	Cron                          *string              `json:"cron,omitempty"`
+	IntervalMinutes               *uint                `json:"intervalMinutes,omitempty"`
+	MinPendingChanges             *uint                `json:"minPendingChanges,omitempty"`
+	Mode                          PublishingPolicyMode `json:"mode"`
+	PublishRevocationsImmediately bool                 `json:"publishRevocationsImmediately"`
+}
+
+// PublishingPolicyMode defines model for PublishingPolicy.Mode.
+type PublishingPolicyMode string
+
 // RefreshService defines model for RefreshService.
 type RefreshService struct {
 	Id   string             `json:"id"`
@@ -491,6 +768,51 @@ type RevokeClaimResponse struct {
 	Message string `json:"message"`
 }
 
+// RevokeCredentialsRequest defines model for RevokeCredentialsRequest.
+type RevokeCredentialsRequest struct {
+	// CreatedAfter Only credentials created at or after this time
+	CreatedAfter *time.Time `json:"createdAfter,omitempty"`
+
+	// CreatedBefore Only credentials created before this time
+	CreatedBefore *time.Time `json:"createdBefore,omitempty"`
+
+	// CredentialSubject DID of the holder of the credentials
+	CredentialSubject *string `json:"credentialSubject,omitempty"`
+
+	// Description Reason of the revocation
+	Description *string `json:"description,omitempty"`
+	DryRun      *bool   `json:"dryRun,omitempty"`
+
+	// QueryField Attribute of the credential subject. Requires queryValue.
+	QueryField *string `json:"queryField,omitempty"`
+	QueryValue *string `json:"queryValue,omitempty"`
+
+	// SchemaType Revokes the credentials with exactly this schema type
+	SchemaType *string `json:"schemaType,omitempty"`
+}
+
+// RevokeCredentialsResponse defines model for RevokeCredentialsResponse.
+type RevokeCredentialsResponse struct {
+	Credentials []RevokedCredential `json:"credentials"`
+	DryRun      bool                `json:"dryRun"`
+	Total       int                 `json:"total"`
+}
+
+// RevokedCredential defines model for RevokedCredential.
+type RevokedCredential struct {
+	CredentialSubject string    `json:"credentialSubject"`
+	Id                uuid.UUID `json:"id"`
+	RevNonce          uint64    `json:"revNonce"`
+	SchemaType        string    `json:"schemaType"`
+}
+
+// RotateIdentityKeyResponse defines model for RotateIdentityKeyResponse.
+type RotateIdentityKeyResponse struct {
+	// AuthCredentialID id of the auth credential of the new key
+	AuthCredentialID uuid.UUID                     `json:"authCredentialID"`
+	PublishedState   *PublishIdentityStateResponse `json:"publishedState,omitempty"`
+}
+
 // Schema defines model for Schema.
 type Schema struct {
 	BigInt      string  `json:"bigInt"`
@@ -511,11 +833,19 @@ type StateStatusResponse struct {
 
 // StateTransaction defines model for StateTransaction.
 type StateTransaction struct {
-	Id          int64                  `json:"id"`
-	PublishDate TimeUTC                `json:"publishDate"`
-	State       string                 `json:"state"`
-	Status      StateTransactionStatus `json:"status"`
-	TxID        string                 `json:"txID"`
+	// CancelTxID Transaction sent to cancel the state transition
+	CancelTxID *string `json:"cancelTxID,omitempty"`
+
+	// FailureReason Why the state transition was not published, like an invalid proof or a transition rejected by the state contract
+	FailureReason *string `json:"failureReason,omitempty"`
+	Id            int64   `json:"id"`
+	PublishDate   TimeUTC `json:"publishDate"`
+
+	// ReplacedTxIDs Transactions of the state transition replaced by txID with higher fees, oldest first
+	ReplacedTxIDs *[]string              `json:"replacedTxIDs,omitempty"`
+	State         string                 `json:"state"`
+	Status        StateTransactionStatus `json:"status"`
+	TxID          string                 `json:"txID"`
 }
 
 // StateTransactionStatus defines model for StateTransaction.Status.
@@ -547,9 +877,29 @@ type UUIDResponse struct {
 // UUIDString defines model for UUIDString.
 type UUIDString = string
 
+// UpdateCredentialRequest defines model for UpdateCredentialRequest.
+type UpdateCredentialRequest struct {
+	// CredentialSubject Attributes to change. The ones not present are copied from the credential being updated.
+	CredentialSubject map[string]interface{} `json:"credentialSubject"`
+	Expiration        *int64                 `json:"expiration,omitempty"`
+}
+
+// ValidateCredentialResponse defines model for ValidateCredentialResponse.
+type ValidateCredentialResponse struct {
+	// CoreClaim Slots of the core claim as decimal numbers
+	CoreClaim  *CoreClaimSlots           `json:"coreClaim,omitempty"`
+	Credential *verifiable.W3CCredential `json:"credential,omitempty"`
+	Errors     []string                  `json:"errors"`
+	SchemaHash *string                   `json:"schemaHash,omitempty"`
+	Valid      bool                      `json:"valid"`
+}
+
 // Id defines model for id.
 type Id = uuid.UUID
 
+// IdempotencyKey defines model for idempotencyKey.
+type IdempotencyKey = string
+
 // LinkID defines model for linkID.
 type LinkID = uuid.UUID
 
@@ -562,6 +912,9 @@ type PathIdentifier = string
 // PathNonce defines model for pathNonce.
 type PathNonce = int64
 
+// RevokeCredentials defines model for revokeCredentials.
+type RevokeCredentials = bool
+
 // SessionID defines model for sessionID.
 type SessionID = uuid.UUID
 
@@ -611,9 +964,63 @@ type AuthCallbackParams struct {
 	SessionID SessionID `form:"sessionID" json:"sessionID"`
 }
 
+// GetIdentitiesParams defines parameters for GetIdentities.
+type GetIdentitiesParams struct {
+	// IncludeArchived return the archived identities too
+	IncludeArchived *bool `form:"includeArchived,omitempty" json:"includeArchived,omitempty"`
+}
+
+// SearchIdentitiesParams defines parameters for SearchIdentities.
+type SearchIdentitiesParams struct {
+	// IncludeArchived return the archived identities too
+	IncludeArchived *bool `form:"includeArchived,omitempty" json:"includeArchived,omitempty"`
+
+	// Query Text to search in the display name and the DID of the identities
+	Query      *string                        `form:"query,omitempty" json:"query,omitempty"`
+	Method     *string                        `form:"method,omitempty" json:"method,omitempty"`
+	Blockchain *string                        `form:"blockchain,omitempty" json:"blockchain,omitempty"`
+	Network    *string                        `form:"network,omitempty" json:"network,omitempty"`
+	KeyType    *SearchIdentitiesParamsKeyType `form:"keyType,omitempty" json:"keyType,omitempty"`
+
+	// CredentialStatusType Status type of the auth credential of the identity
+	CredentialStatusType *SearchIdentitiesParamsCredentialStatusType `form:"credentialStatusType,omitempty" json:"credentialStatusType,omitempty"`
+
+	// Page Page to fetch. First is one. Default is 1.
+	Page *uint `form:"page,omitempty" json:"page,omitempty"`
+
+	// MaxResults Number of items to fetch on each page. Default is 50.
+	MaxResults *uint                         `form:"max_results,omitempty" json:"max_results,omitempty"`
+	Sort       *[]SearchIdentitiesParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// SearchIdentitiesParamsKeyType defines parameters for SearchIdentities.
+type SearchIdentitiesParamsKeyType string
+
+// SearchIdentitiesParamsCredentialStatusType defines parameters for SearchIdentities.
+type SearchIdentitiesParamsCredentialStatusType string
+
+// SearchIdentitiesParamsSort defines parameters for SearchIdentities.
+type SearchIdentitiesParamsSort string
+
 // UpdateIdentityJSONBody defines parameters for UpdateIdentity.
 type UpdateIdentityJSONBody struct {
-	DisplayName string `json:"displayName"`
+	DisplayName *string          `json:"displayName,omitempty"`
+	Profile     *IdentityProfile `json:"profile,omitempty"`
+
+	// PublishingPolicy When the pending publisher publishes the state of the identity:
+	//   * always: as soon as there are pending changes. It is the default policy.
+	//   * manual: never, the state is only published with the publish state endpoint.
+	//   * pendingCount: when there are at least minPendingChanges credentials and revocations pending.
+	//   * interval: at most once every intervalMinutes.
+	//   * cron: at the times of the cron schedule (minute hour day-of-month month day-of-week, in UTC).
+	// With publishRevocationsImmediately, the state is published as soon as there is a pending revocation, whatever the mode.
+	PublishingPolicy *PublishingPolicy `json:"publishingPolicy,omitempty"`
+}
+
+// ArchiveIdentityParams defines parameters for ArchiveIdentity.
+type ArchiveIdentityParams struct {
+	// RevokeCredentials revoke all the credentials issued by the identity
+	RevokeCredentials *RevokeCredentials `form:"revokeCredentials,omitempty" json:"revokeCredentials,omitempty"`
 }
 
 // GetConnectionsParams defines parameters for GetConnections.
@@ -659,6 +1066,12 @@ type GetCredentialsParams struct {
 	// Query Query string to do full text search
 	Query *string `form:"query,omitempty" json:"query,omitempty"`
 
+	// SchemaURL Only credentials of this JSON schema. Required when the attributes parameter is used.
+	SchemaURL *string `form:"schemaURL,omitempty" json:"schemaURL,omitempty"`
+
+	// Attributes JSON object with conditions over the attributes of the credential subject. All the conditions must match. Each key is an attribute of the schema given in schemaURL and its value is either a value to compare for equality or an object with the operators `$eq`, `$ne`, `$lt`, `$gt` and `$in` (the latter takes an array). Values are converted to the type of the attribute in the schema.
+	Attributes *string `form:"attributes,omitempty" json:"attributes,omitempty"`
+
 	// MaxResults Number of items to fetch on each page. Minimum is 10. Default is 50. No maximum by the moment.
 	MaxResults *uint                       `form:"max_results,omitempty" json:"max_results,omitempty"`
 	Sort       *[]GetCredentialsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
@@ -670,6 +1083,13 @@ type GetCredentialsParamsStatus string
 // GetCredentialsParamsSort defines parameters for GetCredentials.
 type GetCredentialsParamsSort string
 
+// CreateCredentialParams defines parameters for CreateCredential.
+type CreateCredentialParams struct {
+	// IdempotencyKey Unique value generated by the client to identify the request, e.g: an uuid. Retries of the request with the
+	// same key return the response of the first one. Keys expire after 24 hours by default.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
 // GetLinksParams defines parameters for GetLinks.
 type GetLinksParams struct {
 	// Query Query string to do full text search in schema types and attributes.
@@ -686,6 +1106,13 @@ type GetLinksParams struct {
 // GetLinksParamsStatus defines parameters for GetLinks.
 type GetLinksParamsStatus string
 
+// CreateLinkParams defines parameters for CreateLink.
+type CreateLinkParams struct {
+	// IdempotencyKey Unique value generated by the client to identify the request, e.g: an uuid. Retries of the request with the
+	// same key return the response of the first one. Keys expire after 24 hours by default.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
 // CreateLinkQrCodeCallbackTextBody defines parameters for CreateLinkQrCodeCallback.
 type CreateLinkQrCodeCallbackTextBody = string
 
@@ -700,6 +1127,36 @@ type ActivateLinkJSONBody struct {
 	Active bool `json:"active"`
 }
 
+// CreateLinkOfferParams defines parameters for CreateLinkOffer.
+type CreateLinkOfferParams struct {
+	// IdempotencyKey Unique value generated by the client to identify the request, e.g: an uuid. Retries of the request with the
+	// same key return the response of the first one. Keys expire after 24 hours by default.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetStatusListCredentialParams defines parameters for GetStatusListCredential.
+type GetStatusListCredentialParams struct {
+	// Format Format:
+	//   * `w3c` - (default value) The W3C JSON-LD credential with a BJJ signature proof.
+	//   * `jwt-vc` - The credential encoded as a JWT, as defined in the VC data model.
+	Format *GetStatusListCredentialParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetStatusListCredentialParamsFormat defines parameters for GetStatusListCredential.
+type GetStatusListCredentialParamsFormat string
+
+// GetCredentialParams defines parameters for GetCredential.
+type GetCredentialParams struct {
+	// Format Format:
+	//   * `w3c` - (default value) The W3C JSON-LD credential with iden3 proofs.
+	//   * `jwt-vc` - The credential encoded as a JWT, as defined in the VC data model.
+	//   * `sd-jwt-vc` - A SD-JWT VC where every attribute of the credential subject is selectively disclosable.
+	Format *GetCredentialParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetCredentialParamsFormat defines parameters for GetCredential.
+type GetCredentialParamsFormat string
+
 // GetCredentialOfferParams defines parameters for GetCredentialOffer.
 type GetCredentialOfferParams struct {
 	// Type Type:
@@ -712,6 +1169,18 @@ type GetCredentialOfferParams struct {
 // GetCredentialOfferParamsType defines parameters for GetCredentialOffer.
 type GetCredentialOfferParamsType string
 
+// DeactivateIdentityParams defines parameters for DeactivateIdentity.
+type DeactivateIdentityParams struct {
+	// RevokeCredentials revoke all the credentials issued by the identity
+	RevokeCredentials *RevokeCredentials `form:"revokeCredentials,omitempty" json:"revokeCredentials,omitempty"`
+}
+
+// RotateIdentityKeyParams defines parameters for RotateIdentityKey.
+type RotateIdentityKeyParams struct {
+	// AuthCredentialID id of the auth credential of the key to rotate
+	AuthCredentialID *uuid.UUID `form:"authCredentialID,omitempty" json:"authCredentialID,omitempty"`
+}
+
 // GetSchemasParams defines parameters for GetSchemas.
 type GetSchemasParams struct {
 	// Query Query string to do full text search in schema types and attributes.
@@ -768,12 +1237,21 @@ type CreateIdentityJSONRequestBody = CreateIdentityRequest
 // UpdateIdentityJSONRequestBody defines body for UpdateIdentity for application/json ContentType.
 type UpdateIdentityJSONRequestBody UpdateIdentityJSONBody
 
+// CreateIdentityBackupJSONRequestBody defines body for CreateIdentityBackup for application/json ContentType.
+type CreateIdentityBackupJSONRequestBody = CreateIdentityBackupRequest
+
 // CreateConnectionJSONRequestBody defines body for CreateConnection for application/json ContentType.
 type CreateConnectionJSONRequestBody = CreateConnectionRequest
 
 // CreateCredentialJSONRequestBody defines body for CreateCredential for application/json ContentType.
 type CreateCredentialJSONRequestBody = CreateCredentialRequest
 
+// CreateCredentialsBatchJSONRequestBody defines body for CreateCredentialsBatch for application/json ContentType.
+type CreateCredentialsBatchJSONRequestBody = CreateCredentialsBatchRequest
+
+// ImportCredentialsMultipartRequestBody defines body for ImportCredentials for multipart/form-data ContentType.
+type ImportCredentialsMultipartRequestBody = ImportCredentialsRequest
+
 // CreateLinkJSONRequestBody defines body for CreateLink for application/json ContentType.
 type CreateLinkJSONRequestBody = CreateLinkRequest
 
@@ -783,11 +1261,26 @@ type CreateLinkQrCodeCallbackTextRequestBody = CreateLinkQrCodeCallbackTextBody
 // ActivateLinkJSONRequestBody defines body for ActivateLink for application/json ContentType.
 type ActivateLinkJSONRequestBody ActivateLinkJSONBody
 
+// RevokeCredentialsJSONRequestBody defines body for RevokeCredentials for application/json ContentType.
+type RevokeCredentialsJSONRequestBody = RevokeCredentialsRequest
+
+// ValidateCredentialJSONRequestBody defines body for ValidateCredential for application/json ContentType.
+type ValidateCredentialJSONRequestBody = CreateCredentialRequest
+
+// UpdateCredentialJSONRequestBody defines body for UpdateCredential for application/json ContentType.
+type UpdateCredentialJSONRequestBody = UpdateCredentialRequest
+
 // ImportSchemaJSONRequestBody defines body for ImportSchema for application/json ContentType.
 type ImportSchemaJSONRequestBody = ImportSchemaRequest
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Resolve DID
+	// (GET /1.0/identifiers/{identifier})
+	ResolveDID(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Get did:web DID Document
+	// (GET /identities/{id}/did.json)
+	GetWebDIDDocument(w http.ResponseWriter, r *http.Request, id Id)
 	// Healthcheck
 	// (GET /status)
 	Health(w http.ResponseWriter, r *http.Request)
@@ -808,16 +1301,25 @@ type ServerInterface interface {
 	GetAuthenticationConnection(w http.ResponseWriter, r *http.Request, id Id)
 	// Get Identities
 	// (GET /v2/identities)
-	GetIdentities(w http.ResponseWriter, r *http.Request)
+	GetIdentities(w http.ResponseWriter, r *http.Request, params GetIdentitiesParams)
 	// Create Identity
 	// (POST /v2/identities)
 	CreateIdentity(w http.ResponseWriter, r *http.Request)
+	// Search Identities
+	// (GET /v2/identities/search)
+	SearchIdentities(w http.ResponseWriter, r *http.Request, params SearchIdentitiesParams)
 	// Get Identity Detail
 	// (GET /v2/identities/{identifier})
 	GetIdentityDetails(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
 	// Update Identity
 	// (PATCH /v2/identities/{identifier})
 	UpdateIdentity(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Archive Identity
+	// (POST /v2/identities/{identifier}/archive)
+	ArchiveIdentity(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params ArchiveIdentityParams)
+	// Create Identity Backup
+	// (POST /v2/identities/{identifier}/backup)
+	CreateIdentityBackup(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
 	// Get Connections
 	// (GET /v2/identities/{identifier}/connections)
 	GetConnections(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetConnectionsParams)
@@ -841,13 +1343,25 @@ type ServerInterface interface {
 	GetCredentials(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetCredentialsParams)
 	// Create Credential
 	// (POST /v2/identities/{identifier}/credentials)
-	CreateCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	CreateCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params CreateCredentialParams)
+	// Create Credentials Batch
+	// (POST /v2/identities/{identifier}/credentials/batch)
+	CreateCredentialsBatch(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Import Credential Subjects
+	// (POST /v2/identities/{identifier}/credentials/import)
+	ImportCredentials(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Get Credential Import
+	// (GET /v2/identities/{identifier}/credentials/import/{id})
+	GetCredentialImport(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id)
+	// Get Credential Import Errors
+	// (GET /v2/identities/{identifier}/credentials/import/{id}/errors)
+	GetCredentialImportErrors(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id)
 	// Get Links
 	// (GET /v2/identities/{identifier}/credentials/links)
 	GetLinks(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetLinksParams)
 	// Create Link
 	// (POST /v2/identities/{identifier}/credentials/links)
-	CreateLink(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	CreateLink(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params CreateLinkParams)
 	// Create Link QR Code Callback
 	// (POST /v2/identities/{identifier}/credentials/links/callback)
 	CreateLinkQrCodeCallback(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params CreateLinkQrCodeCallbackParams)
@@ -862,22 +1376,49 @@ type ServerInterface interface {
 	ActivateLink(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id)
 	// Create a credential offer for a link
 	// (POST /v2/identities/{identifier}/credentials/links/{id}/offer)
-	CreateLinkOffer(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id)
+	CreateLinkOffer(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id, params CreateLinkOfferParams)
 	// Get Revocation Status
 	// (GET /v2/identities/{identifier}/credentials/revocation/status/{nonce})
 	GetRevocationStatusV2(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce)
+	// Revoke Credentials
+	// (POST /v2/identities/{identifier}/credentials/revoke)
+	RevokeCredentials(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
 	// Revoke Credential
 	// (POST /v2/identities/{identifier}/credentials/revoke/{nonce})
 	RevokeCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce)
+	// Get Status List Credential
+	// (GET /v2/identities/{identifier}/credentials/status-lists/{id})
+	GetStatusListCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id, params GetStatusListCredentialParams)
+	// Validate Credential
+	// (POST /v2/identities/{identifier}/credentials/validate)
+	ValidateCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
 	// Delete Credential
 	// (DELETE /v2/identities/{identifier}/credentials/{id})
 	DeleteCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim)
 	// Get Credential
 	// (GET /v2/identities/{identifier}/credentials/{id})
-	GetCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim)
+	GetCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim, params GetCredentialParams)
+	// Update Credential
+	// (PATCH /v2/identities/{identifier}/credentials/{id})
+	UpdateCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim)
 	// Get Credentials Offer
 	// (GET /v2/identities/{identifier}/credentials/{id}/offer)
 	GetCredentialOffer(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim, params GetCredentialOfferParams)
+	// Deactivate Identity
+	// (POST /v2/identities/{identifier}/deactivate)
+	DeactivateIdentity(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params DeactivateIdentityParams)
+	// Get DID Document
+	// (GET /v2/identities/{identifier}/did-document)
+	GetDIDDocument(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Get Identity Keys
+	// (GET /v2/identities/{identifier}/keys)
+	GetIdentityKeys(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Create Identity Key
+	// (POST /v2/identities/{identifier}/keys)
+	CreateIdentityKey(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Rotate Identity Key
+	// (POST /v2/identities/{identifier}/keys/rotate)
+	RotateIdentityKey(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params RotateIdentityKeyParams)
 	// Get Schemas
 	// (GET /v2/identities/{identifier}/schemas)
 	GetSchemas(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetSchemasParams)
@@ -887,12 +1428,18 @@ type ServerInterface interface {
 	// Get Schema
 	// (GET /v2/identities/{identifier}/schemas/{id})
 	GetSchema(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id)
+	// Cancel Publish Identity State
+	// (POST /v2/identities/{identifier}/state/cancel)
+	CancelPublishState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
 	// Publish Identity State
 	// (POST /v2/identities/{identifier}/state/publish)
 	PublishIdentityState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
 	// Retry Publish Identity State
 	// (POST /v2/identities/{identifier}/state/retry)
 	RetryPublishState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Speed Up Publish Identity State
+	// (POST /v2/identities/{identifier}/state/speed-up)
+	SpeedUpPublishState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
 	// Get Identity State Status
 	// (GET /v2/identities/{identifier}/state/status)
 	GetStateStatus(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
@@ -902,6 +1449,9 @@ type ServerInterface interface {
 	// Get QrCode from store
 	// (GET /v2/qr-store)
 	GetQrFromStore(w http.ResponseWriter, r *http.Request, params GetQrFromStoreParams)
+	// Get Publishing Keys Status
+	// (GET /v2/status/publishing-keys)
+	GetPublishingKeysStatus(w http.ResponseWriter, r *http.Request)
 	// Get Supported Networks
 	// (GET /v2/supported-networks)
 	GetSupportedNetworks(w http.ResponseWriter, r *http.Request)
@@ -914,6 +1464,18 @@ type ServerInterface interface {
 
 type Unimplemented struct{}
 
+// Resolve DID
+// (GET /1.0/identifiers/{identifier})
+func (_ Unimplemented) ResolveDID(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get did:web DID Document
+// (GET /identities/{id}/did.json)
+func (_ Unimplemented) GetWebDIDDocument(w http.ResponseWriter, r *http.Request, id Id) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Healthcheck
 // (GET /status)
 func (_ Unimplemented) Health(w http.ResponseWriter, r *http.Request) {
@@ -952,7 +1514,7 @@ func (_ Unimplemented) GetAuthenticationConnection(w http.ResponseWriter, r *htt
 
 // Get Identities
 // (GET /v2/identities)
-func (_ Unimplemented) GetIdentities(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) GetIdentities(w http.ResponseWriter, r *http.Request, params GetIdentitiesParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -962,6 +1524,12 @@ func (_ Unimplemented) CreateIdentity(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Search Identities
+// (GET /v2/identities/search)
+func (_ Unimplemented) SearchIdentities(w http.ResponseWriter, r *http.Request, params SearchIdentitiesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get Identity Detail
 // (GET /v2/identities/{identifier})
 func (_ Unimplemented) GetIdentityDetails(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
@@ -974,6 +1542,18 @@ func (_ Unimplemented) UpdateIdentity(w http.ResponseWriter, r *http.Request, id
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Archive Identity
+// (POST /v2/identities/{identifier}/archive)
+func (_ Unimplemented) ArchiveIdentity(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params ArchiveIdentityParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create Identity Backup
+// (POST /v2/identities/{identifier}/backup)
+func (_ Unimplemented) CreateIdentityBackup(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get Connections
 // (GET /v2/identities/{identifier}/connections)
 func (_ Unimplemented) GetConnections(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetConnectionsParams) {
@@ -1018,7 +1598,31 @@ func (_ Unimplemented) GetCredentials(w http.ResponseWriter, r *http.Request, id
 
 // Create Credential
 // (POST /v2/identities/{identifier}/credentials)
-func (_ Unimplemented) CreateCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+func (_ Unimplemented) CreateCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params CreateCredentialParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create Credentials Batch
+// (POST /v2/identities/{identifier}/credentials/batch)
+func (_ Unimplemented) CreateCredentialsBatch(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Import Credential Subjects
+// (POST /v2/identities/{identifier}/credentials/import)
+func (_ Unimplemented) ImportCredentials(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get Credential Import
+// (GET /v2/identities/{identifier}/credentials/import/{id})
+func (_ Unimplemented) GetCredentialImport(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get Credential Import Errors
+// (GET /v2/identities/{identifier}/credentials/import/{id}/errors)
+func (_ Unimplemented) GetCredentialImportErrors(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -1030,7 +1634,7 @@ func (_ Unimplemented) GetLinks(w http.ResponseWriter, r *http.Request, identifi
 
 // Create Link
 // (POST /v2/identities/{identifier}/credentials/links)
-func (_ Unimplemented) CreateLink(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+func (_ Unimplemented) CreateLink(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params CreateLinkParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -1060,7 +1664,7 @@ func (_ Unimplemented) ActivateLink(w http.ResponseWriter, r *http.Request, iden
 
 // Create a credential offer for a link
 // (POST /v2/identities/{identifier}/credentials/links/{id}/offer)
-func (_ Unimplemented) CreateLinkOffer(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id) {
+func (_ Unimplemented) CreateLinkOffer(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id, params CreateLinkOfferParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -1070,12 +1674,30 @@ func (_ Unimplemented) GetRevocationStatusV2(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Revoke Credentials
+// (POST /v2/identities/{identifier}/credentials/revoke)
+func (_ Unimplemented) RevokeCredentials(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Revoke Credential
 // (POST /v2/identities/{identifier}/credentials/revoke/{nonce})
 func (_ Unimplemented) RevokeCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get Status List Credential
+// (GET /v2/identities/{identifier}/credentials/status-lists/{id})
+func (_ Unimplemented) GetStatusListCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id, params GetStatusListCredentialParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Validate Credential
+// (POST /v2/identities/{identifier}/credentials/validate)
+func (_ Unimplemented) ValidateCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Delete Credential
 // (DELETE /v2/identities/{identifier}/credentials/{id})
 func (_ Unimplemented) DeleteCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
@@ -1084,7 +1706,13 @@ func (_ Unimplemented) DeleteCredential(w http.ResponseWriter, r *http.Request,
 
 // Get Credential
 // (GET /v2/identities/{identifier}/credentials/{id})
-func (_ Unimplemented) GetCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
+func (_ Unimplemented) GetCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim, params GetCredentialParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update Credential
+// (PATCH /v2/identities/{identifier}/credentials/{id})
+func (_ Unimplemented) UpdateCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -1094,6 +1722,36 @@ func (_ Unimplemented) GetCredentialOffer(w http.ResponseWriter, r *http.Request
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Deactivate Identity
+// (POST /v2/identities/{identifier}/deactivate)
+func (_ Unimplemented) DeactivateIdentity(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params DeactivateIdentityParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get DID Document
+// (GET /v2/identities/{identifier}/did-document)
+func (_ Unimplemented) GetDIDDocument(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get Identity Keys
+// (GET /v2/identities/{identifier}/keys)
+func (_ Unimplemented) GetIdentityKeys(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create Identity Key
+// (POST /v2/identities/{identifier}/keys)
+func (_ Unimplemented) CreateIdentityKey(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rotate Identity Key
+// (POST /v2/identities/{identifier}/keys/rotate)
+func (_ Unimplemented) RotateIdentityKey(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params RotateIdentityKeyParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get Schemas
 // (GET /v2/identities/{identifier}/schemas)
 func (_ Unimplemented) GetSchemas(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetSchemasParams) {
@@ -1112,6 +1770,12 @@ func (_ Unimplemented) GetSchema(w http.ResponseWriter, r *http.Request, identif
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Cancel Publish Identity State
+// (POST /v2/identities/{identifier}/state/cancel)
+func (_ Unimplemented) CancelPublishState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Publish Identity State
 // (POST /v2/identities/{identifier}/state/publish)
 func (_ Unimplemented) PublishIdentityState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
@@ -1124,6 +1788,12 @@ func (_ Unimplemented) RetryPublishState(w http.ResponseWriter, r *http.Request,
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Speed Up Publish Identity State
+// (POST /v2/identities/{identifier}/state/speed-up)
+func (_ Unimplemented) SpeedUpPublishState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get Identity State Status
 // (GET /v2/identities/{identifier}/state/status)
 func (_ Unimplemented) GetStateStatus(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
@@ -1142,6 +1812,12 @@ func (_ Unimplemented) GetQrFromStore(w http.ResponseWriter, r *http.Request, pa
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get Publishing Keys Status
+// (GET /v2/status/publishing-keys)
+func (_ Unimplemented) GetPublishingKeysStatus(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get Supported Networks
 // (GET /v2/supported-networks)
 func (_ Unimplemented) GetSupportedNetworks(w http.ResponseWriter, r *http.Request) {
@@ -1163,6 +1839,56 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// ResolveDID operation middleware
+func (siw *ServerInterfaceWrapper) ResolveDID(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResolveDID(w, r, identifier)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebDIDDocument operation middleware
+func (siw *ServerInterfaceWrapper) GetWebDIDDocument(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebDIDDocument(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // Health operation middleware
 func (siw *ServerInterfaceWrapper) Health(w http.ResponseWriter, r *http.Request) {
 
@@ -1307,14 +2033,27 @@ func (siw *ServerInterfaceWrapper) GetAuthenticationConnection(w http.ResponseWr
 // GetIdentities operation middleware
 func (siw *ServerInterfaceWrapper) GetIdentities(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetIdentitiesParams
+
+	// ------------- Optional query parameter "includeArchived" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeArchived", r.URL.Query(), &params.IncludeArchived)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeArchived", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetIdentities(w, r)
+		siw.Handler.GetIdentities(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1344,6 +2083,111 @@ func (siw *ServerInterfaceWrapper) CreateIdentity(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
+// SearchIdentities operation middleware
+func (siw *ServerInterfaceWrapper) SearchIdentities(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchIdentitiesParams
+
+	// ------------- Optional query parameter "includeArchived" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeArchived", r.URL.Query(), &params.IncludeArchived)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeArchived", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "query" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "query", r.URL.Query(), &params.Query)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "method" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "method", r.URL.Query(), &params.Method)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "method", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "blockchain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "blockchain", r.URL.Query(), &params.Blockchain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "blockchain", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "network" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "network", r.URL.Query(), &params.Network)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "network", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "keyType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "keyType", r.URL.Query(), &params.KeyType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyType", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "credentialStatusType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "credentialStatusType", r.URL.Query(), &params.CredentialStatusType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "credentialStatusType", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "max_results" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_results", r.URL.Query(), &params.MaxResults)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_results", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchIdentities(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetIdentityDetails operation middleware
 func (siw *ServerInterfaceWrapper) GetIdentityDetails(w http.ResponseWriter, r *http.Request) {
 
@@ -1406,6 +2250,79 @@ func (siw *ServerInterfaceWrapper) UpdateIdentity(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
+// ArchiveIdentity operation middleware
+func (siw *ServerInterfaceWrapper) ArchiveIdentity(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ArchiveIdentityParams
+
+	// ------------- Optional query parameter "revokeCredentials" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "revokeCredentials", r.URL.Query(), &params.RevokeCredentials)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revokeCredentials", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ArchiveIdentity(w, r, identifier, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateIdentityBackup operation middleware
+func (siw *ServerInterfaceWrapper) CreateIdentityBackup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateIdentityBackup(w, r, identifier)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetConnections operation middleware
 func (siw *ServerInterfaceWrapper) GetConnections(w http.ResponseWriter, r *http.Request) {
 
@@ -1745,6 +2662,22 @@ func (siw *ServerInterfaceWrapper) GetCredentials(w http.ResponseWriter, r *http
 		return
 	}
 
+	// ------------- Optional query parameter "schemaURL" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "schemaURL", r.URL.Query(), &params.SchemaURL)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schemaURL", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "attributes" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "attributes", r.URL.Query(), &params.Attributes)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attributes", Err: err})
+		return
+	}
+
 	// ------------- Optional query parameter "max_results" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "max_results", r.URL.Query(), &params.MaxResults)
@@ -1792,58 +2725,32 @@ func (siw *ServerInterfaceWrapper) CreateCredential(w http.ResponseWriter, r *ht
 
 	r = r.WithContext(ctx)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateCredential(w, r, identifier)
-	}))
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
-
-	handler.ServeHTTP(w, r)
-}
-
-// GetLinks operation middleware
-func (siw *ServerInterfaceWrapper) GetLinks(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
-	// ------------- Path parameter "identifier" -------------
-	var identifier PathIdentifier
-
-	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
-		return
-	}
-
-	ctx := r.Context()
-
-	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
-
-	r = r.WithContext(ctx)
-
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetLinksParams
+	var params CreateCredentialParams
 
-	// ------------- Optional query parameter "query" -------------
+	headers := r.Header
 
-	err = runtime.BindQueryParameter("form", true, false, "query", r.URL.Query(), &params.Query)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
-		return
-	}
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
 
-	// ------------- Optional query parameter "status" -------------
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
 
-	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
-		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetLinks(w, r, identifier, params)
+		siw.Handler.CreateCredential(w, r, identifier, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1853,8 +2760,8 @@ func (siw *ServerInterfaceWrapper) GetLinks(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// CreateLink operation middleware
-func (siw *ServerInterfaceWrapper) CreateLink(w http.ResponseWriter, r *http.Request) {
+// CreateCredentialsBatch operation middleware
+func (siw *ServerInterfaceWrapper) CreateCredentialsBatch(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -1874,7 +2781,7 @@ func (siw *ServerInterfaceWrapper) CreateLink(w http.ResponseWriter, r *http.Req
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateLink(w, r, identifier)
+		siw.Handler.CreateCredentialsBatch(w, r, identifier)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1884,8 +2791,8 @@ func (siw *ServerInterfaceWrapper) CreateLink(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// CreateLinkQrCodeCallback operation middleware
-func (siw *ServerInterfaceWrapper) CreateLinkQrCodeCallback(w http.ResponseWriter, r *http.Request) {
+// ImportCredentials operation middleware
+func (siw *ServerInterfaceWrapper) ImportCredentials(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -1898,26 +2805,14 @@ func (siw *ServerInterfaceWrapper) CreateLinkQrCodeCallback(w http.ResponseWrite
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params CreateLinkQrCodeCallbackParams
-
-	// ------------- Required query parameter "linkID" -------------
-
-	if paramValue := r.URL.Query().Get("linkID"); paramValue != "" {
+	ctx := r.Context()
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "linkID"})
-		return
-	}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
 
-	err = runtime.BindQueryParameter("form", true, true, "linkID", r.URL.Query(), &params.LinkID)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "linkID", Err: err})
-		return
-	}
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateLinkQrCodeCallback(w, r, identifier, params)
+		siw.Handler.ImportCredentials(w, r, identifier)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1927,8 +2822,8 @@ func (siw *ServerInterfaceWrapper) CreateLinkQrCodeCallback(w http.ResponseWrite
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteLink operation middleware
-func (siw *ServerInterfaceWrapper) DeleteLink(w http.ResponseWriter, r *http.Request) {
+// GetCredentialImport operation middleware
+func (siw *ServerInterfaceWrapper) GetCredentialImport(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -1957,7 +2852,7 @@ func (siw *ServerInterfaceWrapper) DeleteLink(w http.ResponseWriter, r *http.Req
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteLink(w, r, identifier, id)
+		siw.Handler.GetCredentialImport(w, r, identifier, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1967,8 +2862,8 @@ func (siw *ServerInterfaceWrapper) DeleteLink(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetLink operation middleware
-func (siw *ServerInterfaceWrapper) GetLink(w http.ResponseWriter, r *http.Request) {
+// GetCredentialImportErrors operation middleware
+func (siw *ServerInterfaceWrapper) GetCredentialImportErrors(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -1997,7 +2892,7 @@ func (siw *ServerInterfaceWrapper) GetLink(w http.ResponseWriter, r *http.Reques
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetLink(w, r, identifier, id)
+		siw.Handler.GetCredentialImportErrors(w, r, identifier, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2007,8 +2902,8 @@ func (siw *ServerInterfaceWrapper) GetLink(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// ActivateLink operation middleware
-func (siw *ServerInterfaceWrapper) ActivateLink(w http.ResponseWriter, r *http.Request) {
+// GetLinks operation middleware
+func (siw *ServerInterfaceWrapper) GetLinks(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2021,23 +2916,33 @@ func (siw *ServerInterfaceWrapper) ActivateLink(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	// ------------- Path parameter "id" -------------
-	var id Id
-
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
-
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLinksParams
+
+	// ------------- Optional query parameter "query" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "query", r.URL.Query(), &params.Query)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ActivateLink(w, r, identifier, id)
+		siw.Handler.GetLinks(w, r, identifier, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2047,8 +2952,8 @@ func (siw *ServerInterfaceWrapper) ActivateLink(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// CreateLinkOffer operation middleware
-func (siw *ServerInterfaceWrapper) CreateLinkOffer(w http.ResponseWriter, r *http.Request) {
+// CreateLink operation middleware
+func (siw *ServerInterfaceWrapper) CreateLink(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2061,17 +2966,38 @@ func (siw *ServerInterfaceWrapper) CreateLinkOffer(w http.ResponseWriter, r *htt
 		return
 	}
 
-	// ------------- Path parameter "id" -------------
-	var id Id
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateLinkParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateLinkOffer(w, r, identifier, id)
+		siw.Handler.CreateLink(w, r, identifier, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2081,8 +3007,8 @@ func (siw *ServerInterfaceWrapper) CreateLinkOffer(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// GetRevocationStatusV2 operation middleware
-func (siw *ServerInterfaceWrapper) GetRevocationStatusV2(w http.ResponseWriter, r *http.Request) {
+// CreateLinkQrCodeCallback operation middleware
+func (siw *ServerInterfaceWrapper) CreateLinkQrCodeCallback(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2095,17 +3021,26 @@ func (siw *ServerInterfaceWrapper) GetRevocationStatusV2(w http.ResponseWriter,
 		return
 	}
 
-	// ------------- Path parameter "nonce" -------------
-	var nonce PathNonce
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateLinkQrCodeCallbackParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "nonce", chi.URLParam(r, "nonce"), &nonce, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Required query parameter "linkID" -------------
+
+	if paramValue := r.URL.Query().Get("linkID"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "linkID"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "linkID", r.URL.Query(), &params.LinkID)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nonce", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "linkID", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetRevocationStatusV2(w, r, identifier, nonce)
+		siw.Handler.CreateLinkQrCodeCallback(w, r, identifier, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2115,8 +3050,8 @@ func (siw *ServerInterfaceWrapper) GetRevocationStatusV2(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// RevokeCredential operation middleware
-func (siw *ServerInterfaceWrapper) RevokeCredential(w http.ResponseWriter, r *http.Request) {
+// DeleteLink operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLink(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2129,12 +3064,12 @@ func (siw *ServerInterfaceWrapper) RevokeCredential(w http.ResponseWriter, r *ht
 		return
 	}
 
-	// ------------- Path parameter "nonce" -------------
-	var nonce PathNonce
+	// ------------- Path parameter "id" -------------
+	var id Id
 
-	err = runtime.BindStyledParameterWithOptions("simple", "nonce", chi.URLParam(r, "nonce"), &nonce, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nonce", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -2145,7 +3080,7 @@ func (siw *ServerInterfaceWrapper) RevokeCredential(w http.ResponseWriter, r *ht
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RevokeCredential(w, r, identifier, nonce)
+		siw.Handler.DeleteLink(w, r, identifier, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2155,8 +3090,8 @@ func (siw *ServerInterfaceWrapper) RevokeCredential(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteCredential operation middleware
-func (siw *ServerInterfaceWrapper) DeleteCredential(w http.ResponseWriter, r *http.Request) {
+// GetLink operation middleware
+func (siw *ServerInterfaceWrapper) GetLink(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2170,7 +3105,7 @@ func (siw *ServerInterfaceWrapper) DeleteCredential(w http.ResponseWriter, r *ht
 	}
 
 	// ------------- Path parameter "id" -------------
-	var id PathClaim
+	var id Id
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -2185,7 +3120,7 @@ func (siw *ServerInterfaceWrapper) DeleteCredential(w http.ResponseWriter, r *ht
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteCredential(w, r, identifier, id)
+		siw.Handler.GetLink(w, r, identifier, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2195,8 +3130,8 @@ func (siw *ServerInterfaceWrapper) DeleteCredential(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// GetCredential operation middleware
-func (siw *ServerInterfaceWrapper) GetCredential(w http.ResponseWriter, r *http.Request) {
+// ActivateLink operation middleware
+func (siw *ServerInterfaceWrapper) ActivateLink(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2210,7 +3145,7 @@ func (siw *ServerInterfaceWrapper) GetCredential(w http.ResponseWriter, r *http.
 	}
 
 	// ------------- Path parameter "id" -------------
-	var id PathClaim
+	var id Id
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -2225,7 +3160,7 @@ func (siw *ServerInterfaceWrapper) GetCredential(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCredential(w, r, identifier, id)
+		siw.Handler.ActivateLink(w, r, identifier, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2235,8 +3170,8 @@ func (siw *ServerInterfaceWrapper) GetCredential(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// GetCredentialOffer operation middleware
-func (siw *ServerInterfaceWrapper) GetCredentialOffer(w http.ResponseWriter, r *http.Request) {
+// CreateLinkOffer operation middleware
+func (siw *ServerInterfaceWrapper) CreateLinkOffer(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2250,7 +3185,7 @@ func (siw *ServerInterfaceWrapper) GetCredentialOffer(w http.ResponseWriter, r *
 	}
 
 	// ------------- Path parameter "id" -------------
-	var id PathClaim
+	var id Id
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -2258,25 +3193,32 @@ func (siw *ServerInterfaceWrapper) GetCredentialOffer(w http.ResponseWriter, r *
 		return
 	}
 
-	ctx := r.Context()
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateLinkOfferParams
 
-	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+	headers := r.Header
 
-	r = r.WithContext(ctx)
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetCredentialOfferParams
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
 
-	// ------------- Optional query parameter "type" -------------
+		params.IdempotencyKey = &IdempotencyKey
 
-	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
-		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCredentialOffer(w, r, identifier, id, params)
+		siw.Handler.CreateLinkOffer(w, r, identifier, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2286,8 +3228,8 @@ func (siw *ServerInterfaceWrapper) GetCredentialOffer(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// GetSchemas operation middleware
-func (siw *ServerInterfaceWrapper) GetSchemas(w http.ResponseWriter, r *http.Request) {
+// GetRevocationStatusV2 operation middleware
+func (siw *ServerInterfaceWrapper) GetRevocationStatusV2(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2300,25 +3242,17 @@ func (siw *ServerInterfaceWrapper) GetSchemas(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	ctx := r.Context()
-
-	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
-
-	r = r.WithContext(ctx)
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSchemasParams
-
-	// ------------- Optional query parameter "query" -------------
+	// ------------- Path parameter "nonce" -------------
+	var nonce PathNonce
 
-	err = runtime.BindQueryParameter("form", true, false, "query", r.URL.Query(), &params.Query)
+	err = runtime.BindStyledParameterWithOptions("simple", "nonce", chi.URLParam(r, "nonce"), &nonce, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nonce", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetSchemas(w, r, identifier, params)
+		siw.Handler.GetRevocationStatusV2(w, r, identifier, nonce)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2328,8 +3262,8 @@ func (siw *ServerInterfaceWrapper) GetSchemas(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// ImportSchema operation middleware
-func (siw *ServerInterfaceWrapper) ImportSchema(w http.ResponseWriter, r *http.Request) {
+// RevokeCredentials operation middleware
+func (siw *ServerInterfaceWrapper) RevokeCredentials(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2349,7 +3283,7 @@ func (siw *ServerInterfaceWrapper) ImportSchema(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ImportSchema(w, r, identifier)
+		siw.Handler.RevokeCredentials(w, r, identifier)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2359,8 +3293,8 @@ func (siw *ServerInterfaceWrapper) ImportSchema(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// GetSchema operation middleware
-func (siw *ServerInterfaceWrapper) GetSchema(w http.ResponseWriter, r *http.Request) {
+// RevokeCredential operation middleware
+func (siw *ServerInterfaceWrapper) RevokeCredential(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2373,12 +3307,12 @@ func (siw *ServerInterfaceWrapper) GetSchema(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// ------------- Path parameter "id" -------------
-	var id Id
+	// ------------- Path parameter "nonce" -------------
+	var nonce PathNonce
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "nonce", chi.URLParam(r, "nonce"), &nonce, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nonce", Err: err})
 		return
 	}
 
@@ -2389,7 +3323,7 @@ func (siw *ServerInterfaceWrapper) GetSchema(w http.ResponseWriter, r *http.Requ
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetSchema(w, r, identifier, id)
+		siw.Handler.RevokeCredential(w, r, identifier, nonce)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2399,8 +3333,8 @@ func (siw *ServerInterfaceWrapper) GetSchema(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// PublishIdentityState operation middleware
-func (siw *ServerInterfaceWrapper) PublishIdentityState(w http.ResponseWriter, r *http.Request) {
+// GetStatusListCredential operation middleware
+func (siw *ServerInterfaceWrapper) GetStatusListCredential(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2413,14 +3347,28 @@ func (siw *ServerInterfaceWrapper) PublishIdentityState(w http.ResponseWriter, r
 		return
 	}
 
-	ctx := r.Context()
+	// ------------- Path parameter "id" -------------
+	var id Id
 
-	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-	r = r.WithContext(ctx)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatusListCredentialParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PublishIdentityState(w, r, identifier)
+		siw.Handler.GetStatusListCredential(w, r, identifier, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2430,8 +3378,8 @@ func (siw *ServerInterfaceWrapper) PublishIdentityState(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// RetryPublishState operation middleware
-func (siw *ServerInterfaceWrapper) RetryPublishState(w http.ResponseWriter, r *http.Request) {
+// ValidateCredential operation middleware
+func (siw *ServerInterfaceWrapper) ValidateCredential(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2451,7 +3399,7 @@ func (siw *ServerInterfaceWrapper) RetryPublishState(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RetryPublishState(w, r, identifier)
+		siw.Handler.ValidateCredential(w, r, identifier)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2461,8 +3409,8 @@ func (siw *ServerInterfaceWrapper) RetryPublishState(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// GetStateStatus operation middleware
-func (siw *ServerInterfaceWrapper) GetStateStatus(w http.ResponseWriter, r *http.Request) {
+// DeleteCredential operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCredential(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2475,6 +3423,15 @@ func (siw *ServerInterfaceWrapper) GetStateStatus(w http.ResponseWriter, r *http
 		return
 	}
 
+	// ------------- Path parameter "id" -------------
+	var id PathClaim
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
@@ -2482,7 +3439,7 @@ func (siw *ServerInterfaceWrapper) GetStateStatus(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStateStatus(w, r, identifier)
+		siw.Handler.DeleteCredential(w, r, identifier, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2492,8 +3449,8 @@ func (siw *ServerInterfaceWrapper) GetStateStatus(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// GetStateTransactions operation middleware
-func (siw *ServerInterfaceWrapper) GetStateTransactions(w http.ResponseWriter, r *http.Request) {
+// GetCredential operation middleware
+func (siw *ServerInterfaceWrapper) GetCredential(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2506,6 +3463,15 @@ func (siw *ServerInterfaceWrapper) GetStateTransactions(w http.ResponseWriter, r
 		return
 	}
 
+	// ------------- Path parameter "id" -------------
+	var id PathClaim
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
@@ -2513,42 +3479,58 @@ func (siw *ServerInterfaceWrapper) GetStateTransactions(w http.ResponseWriter, r
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetStateTransactionsParams
+	var params GetCredentialParams
 
-	// ------------- Optional query parameter "filter" -------------
+	// ------------- Optional query parameter "format" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "page" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCredential(w, r, identifier, id, params)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "max_results" -------------
+	handler.ServeHTTP(w, r)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "max_results", r.URL.Query(), &params.MaxResults)
+// UpdateCredential operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCredential(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_results", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "sort" -------------
+	// ------------- Path parameter "id" -------------
+	var id PathClaim
 
-	err = runtime.BindQueryParameter("form", false, false, "sort", r.URL.Query(), &params.Sort)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStateTransactions(w, r, identifier, params)
+		siw.Handler.UpdateCredential(w, r, identifier, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2558,32 +3540,48 @@ func (siw *ServerInterfaceWrapper) GetStateTransactions(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// GetQrFromStore operation middleware
-func (siw *ServerInterfaceWrapper) GetQrFromStore(w http.ResponseWriter, r *http.Request) {
+// GetCredentialOffer operation middleware
+func (siw *ServerInterfaceWrapper) GetCredentialOffer(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetQrFromStoreParams
-
-	// ------------- Optional query parameter "id" -------------
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-	err = runtime.BindQueryParameter("form", true, false, "id", r.URL.Query(), &params.Id)
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id PathClaim
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "issuer" -------------
+	ctx := r.Context()
 
-	err = runtime.BindQueryParameter("form", true, false, "issuer", r.URL.Query(), &params.Issuer)
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCredentialOfferParams
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "issuer", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetQrFromStore(w, r, params)
+		siw.Handler.GetCredentialOffer(w, r, identifier, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2593,8 +3591,19 @@ func (siw *ServerInterfaceWrapper) GetQrFromStore(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// GetSupportedNetworks operation middleware
-func (siw *ServerInterfaceWrapper) GetSupportedNetworks(w http.ResponseWriter, r *http.Request) {
+// DeactivateIdentity operation middleware
+func (siw *ServerInterfaceWrapper) DeactivateIdentity(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -2602,8 +3611,19 @@ func (siw *ServerInterfaceWrapper) GetSupportedNetworks(w http.ResponseWriter, r
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeactivateIdentityParams
+
+	// ------------- Optional query parameter "revokeCredentials" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "revokeCredentials", r.URL.Query(), &params.RevokeCredentials)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revokeCredentials", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetSupportedNetworks(w, r)
+		siw.Handler.DeactivateIdentity(w, r, identifier, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2613,8 +3633,8 @@ func (siw *ServerInterfaceWrapper) GetSupportedNetworks(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// Authentication operation middleware
-func (siw *ServerInterfaceWrapper) Authentication(w http.ResponseWriter, r *http.Request) {
+// GetDIDDocument operation middleware
+func (siw *ServerInterfaceWrapper) GetDIDDocument(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -2627,19 +3647,39 @@ func (siw *ServerInterfaceWrapper) Authentication(w http.ResponseWriter, r *http
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params AuthenticationParams
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDIDDocument(w, r, identifier)
+	}))
 
-	// ------------- Optional query parameter "type" -------------
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	handler.ServeHTTP(w, r)
+}
+
+// GetIdentityKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetIdentityKeys(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Authentication(w, r, identifier, params)
+		siw.Handler.GetIdentityKeys(w, r, identifier)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2649,133 +3689,657 @@ func (siw *ServerInterfaceWrapper) Authentication(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// CreateIdentityKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateIdentityKey(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	ctx := r.Context()
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
 
-type RequiredParamError struct {
-	ParamName string
-}
+	r = r.WithContext(ctx)
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateIdentityKey(w, r, identifier)
+	}))
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+	handler.ServeHTTP(w, r)
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+// RotateIdentityKey operation middleware
+func (siw *ServerInterfaceWrapper) RotateIdentityKey(w http.ResponseWriter, r *http.Request) {
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	var err error
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	ctx := r.Context()
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	r = r.WithContext(ctx)
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RotateIdentityKeyParams
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+	// ------------- Optional query parameter "authCredentialID" -------------
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+	err = runtime.BindQueryParameter("form", true, false, "authCredentialID", r.URL.Query(), &params.AuthCredentialID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "authCredentialID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateIdentityKey(w, r, identifier, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// GetSchemas operation middleware
+func (siw *ServerInterfaceWrapper) GetSchemas(w http.ResponseWriter, r *http.Request) {
 
-	if r == nil {
-		r = chi.NewRouter()
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSchemasParams
+
+	// ------------- Optional query parameter "query" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "query", r.URL.Query(), &params.Query)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		return
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSchemas(w, r, identifier, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/status", wrapper.Health)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/agent", wrapper.AgentV1)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/{identifier}/claims/revocation/status/{nonce}", wrapper.GetRevocationStatus)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v2/agent", wrapper.Agent)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v2/authentication/callback", wrapper.AuthCallback)
+	handler.ServeHTTP(w, r)
+}
+
+// ImportSchema operation middleware
+func (siw *ServerInterfaceWrapper) ImportSchema(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportSchema(w, r, identifier)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSchema operation middleware
+func (siw *ServerInterfaceWrapper) GetSchema(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSchema(w, r, identifier, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelPublishState operation middleware
+func (siw *ServerInterfaceWrapper) CancelPublishState(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelPublishState(w, r, identifier)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PublishIdentityState operation middleware
+func (siw *ServerInterfaceWrapper) PublishIdentityState(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PublishIdentityState(w, r, identifier)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RetryPublishState operation middleware
+func (siw *ServerInterfaceWrapper) RetryPublishState(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RetryPublishState(w, r, identifier)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SpeedUpPublishState operation middleware
+func (siw *ServerInterfaceWrapper) SpeedUpPublishState(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SpeedUpPublishState(w, r, identifier)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStateStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetStateStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStateStatus(w, r, identifier)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStateTransactions operation middleware
+func (siw *ServerInterfaceWrapper) GetStateTransactions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStateTransactionsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "max_results" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_results", r.URL.Query(), &params.MaxResults)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_results", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStateTransactions(w, r, identifier, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetQrFromStore operation middleware
+func (siw *ServerInterfaceWrapper) GetQrFromStore(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetQrFromStoreParams
+
+	// ------------- Optional query parameter "id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "id", r.URL.Query(), &params.Id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "issuer" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "issuer", r.URL.Query(), &params.Issuer)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "issuer", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetQrFromStore(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPublishingKeysStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetPublishingKeysStatus(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPublishingKeysStatus(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSupportedNetworks operation middleware
+func (siw *ServerInterfaceWrapper) GetSupportedNetworks(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSupportedNetworks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Authentication operation middleware
+func (siw *ServerInterfaceWrapper) Authentication(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "identifier", chi.URLParam(r, "identifier"), &identifier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AuthenticationParams
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Authentication(w, r, identifier, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/1.0/identifiers/{identifier}", wrapper.ResolveDID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/identities/{id}/did.json", wrapper.GetWebDIDDocument)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/status", wrapper.Health)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/agent", wrapper.AgentV1)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/claims/revocation/status/{nonce}", wrapper.GetRevocationStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/agent", wrapper.Agent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/authentication/callback", wrapper.AuthCallback)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v2/authentication/sessions/{id}", wrapper.GetAuthenticationConnection)
@@ -2786,12 +4350,21 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/v2/identities", wrapper.CreateIdentity)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/identities/search", wrapper.SearchIdentities)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v2/identities/{identifier}", wrapper.GetIdentityDetails)
 	})
 	r.Group(func(r chi.Router) {
 		r.Patch(options.BaseURL+"/v2/identities/{identifier}", wrapper.UpdateIdentity)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/identities/{identifier}/archive", wrapper.ArchiveIdentity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/identities/{identifier}/backup", wrapper.CreateIdentityBackup)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v2/identities/{identifier}/connections", wrapper.GetConnections)
 	})
@@ -2816,6 +4389,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/v2/identities/{identifier}/credentials", wrapper.CreateCredential)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/identities/{identifier}/credentials/batch", wrapper.CreateCredentialsBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/identities/{identifier}/credentials/import", wrapper.ImportCredentials)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/identities/{identifier}/credentials/import/{id}", wrapper.GetCredentialImport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/identities/{identifier}/credentials/import/{id}/errors", wrapper.GetCredentialImportErrors)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v2/identities/{identifier}/credentials/links", wrapper.GetLinks)
 	})
@@ -2840,18 +4425,45 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v2/identities/{identifier}/credentials/revocation/status/{nonce}", wrapper.GetRevocationStatusV2)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/identities/{identifier}/credentials/revoke", wrapper.RevokeCredentials)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/v2/identities/{identifier}/credentials/revoke/{nonce}", wrapper.RevokeCredential)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/identities/{identifier}/credentials/status-lists/{id}", wrapper.GetStatusListCredential)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/identities/{identifier}/credentials/validate", wrapper.ValidateCredential)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/v2/identities/{identifier}/credentials/{id}", wrapper.DeleteCredential)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v2/identities/{identifier}/credentials/{id}", wrapper.GetCredential)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/v2/identities/{identifier}/credentials/{id}", wrapper.UpdateCredential)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v2/identities/{identifier}/credentials/{id}/offer", wrapper.GetCredentialOffer)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/identities/{identifier}/deactivate", wrapper.DeactivateIdentity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/identities/{identifier}/did-document", wrapper.GetDIDDocument)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/identities/{identifier}/keys", wrapper.GetIdentityKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/identities/{identifier}/keys", wrapper.CreateIdentityKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/identities/{identifier}/keys/rotate", wrapper.RotateIdentityKey)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v2/identities/{identifier}/schemas", wrapper.GetSchemas)
 	})
@@ -2861,12 +4473,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v2/identities/{identifier}/schemas/{id}", wrapper.GetSchema)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/identities/{identifier}/state/cancel", wrapper.CancelPublishState)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/v2/identities/{identifier}/state/publish", wrapper.PublishIdentityState)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/v2/identities/{identifier}/state/retry", wrapper.RetryPublishState)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/identities/{identifier}/state/speed-up", wrapper.SpeedUpPublishState)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v2/identities/{identifier}/state/status", wrapper.GetStateStatus)
 	})
@@ -2876,6 +4494,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v2/qr-store", wrapper.GetQrFromStore)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/status/publishing-keys", wrapper.GetPublishingKeysStatus)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v2/supported-networks", wrapper.GetSupportedNetworks)
 	})
@@ -2883,1279 +4504,2338 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		r.Post(options.BaseURL+"/v2/{identifier}/authentication", wrapper.Authentication)
 	})
 
-	return r
+	return r
+}
+
+type N400JSONResponse GenericErrorMessage
+
+type N401JSONResponse GenericErrorMessage
+
+type N403JSONResponse GenericErrorMessage
+
+type N404JSONResponse GenericErrorMessage
+
+type N409JSONResponse GenericErrorMessage
+
+type N410JSONResponse GenericErrorMessage
+
+type N422JSONResponse GenericErrorMessage
+
+type N500JSONResponse GenericErrorMessage
+
+type N500CreateIdentityJSONResponse struct {
+	Code      *int    `json:"code,omitempty"`
+	Error     *string `json:"error,omitempty"`
+	RequestID *string `json:"requestID,omitempty"`
+}
+
+type ResolveDIDRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type ResolveDIDResponseObject interface {
+	VisitResolveDIDResponse(w http.ResponseWriter) error
+}
+
+type ResolveDID200JSONResponse DIDResolutionResult
+
+func (response ResolveDID200JSONResponse) VisitResolveDIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResolveDID400JSONResponse struct{ N400JSONResponse }
+
+func (response ResolveDID400JSONResponse) VisitResolveDIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResolveDID404JSONResponse struct{ N404JSONResponse }
+
+func (response ResolveDID404JSONResponse) VisitResolveDIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResolveDID500JSONResponse struct{ N500JSONResponse }
+
+func (response ResolveDID500JSONResponse) VisitResolveDIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebDIDDocumentRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type GetWebDIDDocumentResponseObject interface {
+	VisitGetWebDIDDocumentResponse(w http.ResponseWriter) error
+}
+
+type GetWebDIDDocument200JSONResponse DIDDocument
+
+func (response GetWebDIDDocument200JSONResponse) VisitGetWebDIDDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebDIDDocument400JSONResponse struct{ N400JSONResponse }
+
+func (response GetWebDIDDocument400JSONResponse) VisitGetWebDIDDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebDIDDocument404JSONResponse struct{ N404JSONResponse }
+
+func (response GetWebDIDDocument404JSONResponse) VisitGetWebDIDDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebDIDDocument500JSONResponse struct{ N500JSONResponse }
+
+func (response GetWebDIDDocument500JSONResponse) VisitGetWebDIDDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HealthRequestObject struct {
+}
+
+type HealthResponseObject interface {
+	VisitHealthResponse(w http.ResponseWriter) error
+}
+
+type Health200JSONResponse Health
+
+func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Health500JSONResponse struct{ N500JSONResponse }
+
+func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AgentV1RequestObject struct {
+	Body *AgentV1TextRequestBody
+}
+
+type AgentV1ResponseObject interface {
+	VisitAgentV1Response(w http.ResponseWriter) error
+}
+
+type AgentV1200JSONResponse AgentResponse
+
+func (response AgentV1200JSONResponse) VisitAgentV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AgentV1400JSONResponse struct{ N400JSONResponse }
+
+func (response AgentV1400JSONResponse) VisitAgentV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AgentV1500JSONResponse struct{ N500JSONResponse }
+
+func (response AgentV1500JSONResponse) VisitAgentV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRevocationStatusRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Nonce      PathNonce      `json:"nonce"`
+}
+
+type GetRevocationStatusResponseObject interface {
+	VisitGetRevocationStatusResponse(w http.ResponseWriter) error
+}
+
+type GetRevocationStatus200JSONResponse RevocationStatusResponse
+
+func (response GetRevocationStatus200JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRevocationStatus400JSONResponse struct{ N400JSONResponse }
+
+func (response GetRevocationStatus400JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRevocationStatus500JSONResponse struct{ N500JSONResponse }
+
+func (response GetRevocationStatus500JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AgentRequestObject struct {
+	Body *AgentTextRequestBody
+}
+
+type AgentResponseObject interface {
+	VisitAgentResponse(w http.ResponseWriter) error
+}
+
+type Agent200JSONResponse AgentResponse
+
+func (response Agent200JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Agent400JSONResponse struct{ N400JSONResponse }
+
+func (response Agent400JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Agent500JSONResponse struct{ N500JSONResponse }
+
+func (response Agent500JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthCallbackRequestObject struct {
+	Params AuthCallbackParams
+	Body   *AuthCallbackTextRequestBody
+}
+
+type AuthCallbackResponseObject interface {
+	VisitAuthCallbackResponse(w http.ResponseWriter) error
+}
+
+type AuthCallback200Response struct {
+}
+
+func (response AuthCallback200Response) VisitAuthCallbackResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type AuthCallback400JSONResponse struct{ N400JSONResponse }
+
+func (response AuthCallback400JSONResponse) VisitAuthCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthCallback500JSONResponse struct{ N500JSONResponse }
+
+func (response AuthCallback500JSONResponse) VisitAuthCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthenticationConnectionRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type GetAuthenticationConnectionResponseObject interface {
+	VisitGetAuthenticationConnectionResponse(w http.ResponseWriter) error
+}
+
+type GetAuthenticationConnection200JSONResponse GetAuthenticationConnectionResponse
+
+func (response GetAuthenticationConnection200JSONResponse) VisitGetAuthenticationConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthenticationConnection400JSONResponse struct{ N400JSONResponse }
+
+func (response GetAuthenticationConnection400JSONResponse) VisitGetAuthenticationConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthenticationConnection401JSONResponse struct{ N401JSONResponse }
+
+func (response GetAuthenticationConnection401JSONResponse) VisitGetAuthenticationConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthenticationConnection404JSONResponse struct{ N404JSONResponse }
+
+func (response GetAuthenticationConnection404JSONResponse) VisitGetAuthenticationConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthenticationConnection500JSONResponse struct{ N500JSONResponse }
+
+func (response GetAuthenticationConnection500JSONResponse) VisitGetAuthenticationConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentitiesRequestObject struct {
+	Params GetIdentitiesParams
+}
+
+type GetIdentitiesResponseObject interface {
+	VisitGetIdentitiesResponse(w http.ResponseWriter) error
+}
+
+type GetIdentities200JSONResponse []GetIdentitiesResponse
+
+func (response GetIdentities200JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentities401JSONResponse struct{ N401JSONResponse }
+
+func (response GetIdentities401JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentities500JSONResponse struct{ N500JSONResponse }
+
+func (response GetIdentities500JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentityRequestObject struct {
+	Body *CreateIdentityJSONRequestBody
+}
+
+type CreateIdentityResponseObject interface {
+	VisitCreateIdentityResponse(w http.ResponseWriter) error
+}
+
+type CreateIdentity201JSONResponse CreateIdentityResponse
+
+func (response CreateIdentity201JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentity400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateIdentity400JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentity401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateIdentity401JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentity403JSONResponse struct{ N403JSONResponse }
+
+func (response CreateIdentity403JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentity409JSONResponse struct{ N409JSONResponse }
+
+func (response CreateIdentity409JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentity500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateIdentity500JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchIdentitiesRequestObject struct {
+	Params SearchIdentitiesParams
+}
+
+type SearchIdentitiesResponseObject interface {
+	VisitSearchIdentitiesResponse(w http.ResponseWriter) error
+}
+
+type SearchIdentities200JSONResponse IdentitiesPaginated
+
+func (response SearchIdentities200JSONResponse) VisitSearchIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchIdentities400JSONResponse struct{ N400JSONResponse }
+
+func (response SearchIdentities400JSONResponse) VisitSearchIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchIdentities401JSONResponse struct{ N401JSONResponse }
+
+func (response SearchIdentities401JSONResponse) VisitSearchIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchIdentities500JSONResponse struct{ N500JSONResponse }
+
+func (response SearchIdentities500JSONResponse) VisitSearchIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityDetailsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type GetIdentityDetailsResponseObject interface {
+	VisitGetIdentityDetailsResponse(w http.ResponseWriter) error
+}
+
+type GetIdentityDetails200JSONResponse GetIdentityDetailsResponse
+
+func (response GetIdentityDetails200JSONResponse) VisitGetIdentityDetailsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityDetails400JSONResponse struct{ N400JSONResponse }
+
+func (response GetIdentityDetails400JSONResponse) VisitGetIdentityDetailsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityDetails401JSONResponse struct{ N401JSONResponse }
+
+func (response GetIdentityDetails401JSONResponse) VisitGetIdentityDetailsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityDetails500JSONResponse struct{ N500JSONResponse }
+
+func (response GetIdentityDetails500JSONResponse) VisitGetIdentityDetailsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *UpdateIdentityJSONRequestBody
+}
+
+type UpdateIdentityResponseObject interface {
+	VisitUpdateIdentityResponse(w http.ResponseWriter) error
+}
+
+type UpdateIdentity200JSONResponse GenericMessage
+
+func (response UpdateIdentity200JSONResponse) VisitUpdateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentity400JSONResponse struct{ N400JSONResponse }
+
+func (response UpdateIdentity400JSONResponse) VisitUpdateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentity401JSONResponse struct{ N401JSONResponse }
+
+func (response UpdateIdentity401JSONResponse) VisitUpdateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentity403JSONResponse struct{ N403JSONResponse }
+
+func (response UpdateIdentity403JSONResponse) VisitUpdateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentity500JSONResponse struct{ N500CreateIdentityJSONResponse }
+
+func (response UpdateIdentity500JSONResponse) VisitUpdateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveIdentityRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     ArchiveIdentityParams
+}
+
+type ArchiveIdentityResponseObject interface {
+	VisitArchiveIdentityResponse(w http.ResponseWriter) error
+}
+
+type ArchiveIdentity200JSONResponse DeactivateIdentityResponse
+
+func (response ArchiveIdentity200JSONResponse) VisitArchiveIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveIdentity400JSONResponse struct{ N400JSONResponse }
+
+func (response ArchiveIdentity400JSONResponse) VisitArchiveIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveIdentity401JSONResponse struct{ N401JSONResponse }
+
+func (response ArchiveIdentity401JSONResponse) VisitArchiveIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveIdentity404JSONResponse struct{ N404JSONResponse }
+
+func (response ArchiveIdentity404JSONResponse) VisitArchiveIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveIdentity409JSONResponse struct{ N409JSONResponse }
+
+func (response ArchiveIdentity409JSONResponse) VisitArchiveIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveIdentity500JSONResponse struct{ N500JSONResponse }
+
+func (response ArchiveIdentity500JSONResponse) VisitArchiveIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentityBackupRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreateIdentityBackupJSONRequestBody
+}
+
+type CreateIdentityBackupResponseObject interface {
+	VisitCreateIdentityBackupResponse(w http.ResponseWriter) error
+}
+
+type CreateIdentityBackup200ApplicationoctetStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response CreateIdentityBackup200ApplicationoctetStreamResponse) VisitCreateIdentityBackupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type CreateIdentityBackup400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateIdentityBackup400JSONResponse) VisitCreateIdentityBackupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentityBackup401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateIdentityBackup401JSONResponse) VisitCreateIdentityBackupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentityBackup404JSONResponse struct{ N404JSONResponse }
+
+func (response CreateIdentityBackup404JSONResponse) VisitCreateIdentityBackupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentityBackup500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateIdentityBackup500JSONResponse) VisitCreateIdentityBackupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetConnectionsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     GetConnectionsParams
+}
+
+type GetConnectionsResponseObject interface {
+	VisitGetConnectionsResponse(w http.ResponseWriter) error
+}
+
+type GetConnections200JSONResponse ConnectionsPaginated
+
+func (response GetConnections200JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetConnections400JSONResponse struct{ N400JSONResponse }
+
+func (response GetConnections400JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetConnections500JSONResponse struct{ N500JSONResponse }
+
+func (response GetConnections500JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateConnectionRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreateConnectionJSONRequestBody
+}
+
+type CreateConnectionResponseObject interface {
+	VisitCreateConnectionResponse(w http.ResponseWriter) error
+}
+
+type CreateConnection201JSONResponse GenericMessage
+
+func (response CreateConnection201JSONResponse) VisitCreateConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateConnection400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateConnection400JSONResponse) VisitCreateConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateConnection500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateConnection500JSONResponse) VisitCreateConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteConnectionRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         Id             `json:"id"`
+	Params     DeleteConnectionParams
+}
+
+type DeleteConnectionResponseObject interface {
+	VisitDeleteConnectionResponse(w http.ResponseWriter) error
+}
+
+type DeleteConnection200JSONResponse GenericMessage
+
+func (response DeleteConnection200JSONResponse) VisitDeleteConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteConnection400JSONResponse struct{ N400JSONResponse }
+
+func (response DeleteConnection400JSONResponse) VisitDeleteConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteConnection500JSONResponse struct{ N500JSONResponse }
+
+func (response DeleteConnection500JSONResponse) VisitDeleteConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetConnectionRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         Id             `json:"id"`
+}
+
+type GetConnectionResponseObject interface {
+	VisitGetConnectionResponse(w http.ResponseWriter) error
+}
+
+type GetConnection200JSONResponse GetConnectionResponse
+
+func (response GetConnection200JSONResponse) VisitGetConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetConnection400JSONResponse struct{ N400JSONResponse }
+
+func (response GetConnection400JSONResponse) VisitGetConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetConnection500JSONResponse struct{ N500JSONResponse }
+
+func (response GetConnection500JSONResponse) VisitGetConnectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type N400JSONResponse GenericErrorMessage
+type DeleteConnectionCredentialsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         Id             `json:"id"`
+}
 
-type N401JSONResponse GenericErrorMessage
+type DeleteConnectionCredentialsResponseObject interface {
+	VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error
+}
 
-type N403JSONResponse GenericErrorMessage
+type DeleteConnectionCredentials200JSONResponse GenericMessage
 
-type N404JSONResponse GenericErrorMessage
+func (response DeleteConnectionCredentials200JSONResponse) VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-type N409JSONResponse GenericErrorMessage
+	return json.NewEncoder(w).Encode(response)
+}
 
-type N410JSONResponse GenericErrorMessage
+type DeleteConnectionCredentials400JSONResponse struct{ N400JSONResponse }
 
-type N422JSONResponse GenericErrorMessage
+func (response DeleteConnectionCredentials400JSONResponse) VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
-type N500JSONResponse GenericErrorMessage
+	return json.NewEncoder(w).Encode(response)
+}
 
-type N500CreateIdentityJSONResponse struct {
-	Code      *int    `json:"code,omitempty"`
-	Error     *string `json:"error,omitempty"`
-	RequestID *string `json:"requestID,omitempty"`
+type DeleteConnectionCredentials500JSONResponse struct{ N500JSONResponse }
+
+func (response DeleteConnectionCredentials500JSONResponse) VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type HealthRequestObject struct {
+type RevokeConnectionCredentialsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         Id             `json:"id"`
 }
 
-type HealthResponseObject interface {
-	VisitHealthResponse(w http.ResponseWriter) error
+type RevokeConnectionCredentialsResponseObject interface {
+	VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error
 }
 
-type Health200JSONResponse Health
+type RevokeConnectionCredentials202JSONResponse GenericMessage
 
-func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+func (response RevokeConnectionCredentials202JSONResponse) VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeConnectionCredentials400JSONResponse struct{ N400JSONResponse }
+
+func (response RevokeConnectionCredentials400JSONResponse) VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeConnectionCredentials500JSONResponse struct{ N500JSONResponse }
+
+func (response RevokeConnectionCredentials500JSONResponse) VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     GetCredentialsParams
+}
+
+type GetCredentialsResponseObject interface {
+	VisitGetCredentialsResponse(w http.ResponseWriter) error
+}
+
+type GetCredentials200JSONResponse CredentialsPaginated
+
+func (response GetCredentials200JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentials400JSONResponse struct{ N400JSONResponse }
+
+func (response GetCredentials400JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentials404JSONResponse struct{ N404JSONResponse }
+
+func (response GetCredentials404JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentials500JSONResponse struct{ N500JSONResponse }
+
+func (response GetCredentials500JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     CreateCredentialParams
+	Body       *CreateCredentialJSONRequestBody
+}
+
+type CreateCredentialResponseObject interface {
+	VisitCreateCredentialResponse(w http.ResponseWriter) error
+}
+
+type CreateCredential201JSONResponse CreateCredentialResponse
+
+func (response CreateCredential201JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredential400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateCredential400JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredential401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateCredential401JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredential409JSONResponse struct{ N409JSONResponse }
+
+func (response CreateCredential409JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredential422JSONResponse struct{ N422JSONResponse }
+
+func (response CreateCredential422JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredential500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateCredential500JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialsBatchRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreateCredentialsBatchJSONRequestBody
+}
+
+type CreateCredentialsBatchResponseObject interface {
+	VisitCreateCredentialsBatchResponse(w http.ResponseWriter) error
+}
+
+type CreateCredentialsBatch201JSONResponse CreateCredentialsBatchResponse
+
+func (response CreateCredentialsBatch201JSONResponse) VisitCreateCredentialsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialsBatch400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateCredentialsBatch400JSONResponse) VisitCreateCredentialsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialsBatch401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateCredentialsBatch401JSONResponse) VisitCreateCredentialsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialsBatch422JSONResponse CreateCredentialsBatchResponse
+
+func (response CreateCredentialsBatch422JSONResponse) VisitCreateCredentialsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialsBatch500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateCredentialsBatch500JSONResponse) VisitCreateCredentialsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCredentialsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *multipart.Reader
+}
+
+type ImportCredentialsResponseObject interface {
+	VisitImportCredentialsResponse(w http.ResponseWriter) error
+}
+
+type ImportCredentials202JSONResponse CredentialImport
+
+func (response ImportCredentials202JSONResponse) VisitImportCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCredentials400JSONResponse struct{ N400JSONResponse }
+
+func (response ImportCredentials400JSONResponse) VisitImportCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCredentials401JSONResponse struct{ N401JSONResponse }
+
+func (response ImportCredentials401JSONResponse) VisitImportCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCredentials404JSONResponse struct{ N404JSONResponse }
+
+func (response ImportCredentials404JSONResponse) VisitImportCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCredentials422JSONResponse struct{ N422JSONResponse }
+
+func (response ImportCredentials422JSONResponse) VisitImportCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Health500JSONResponse struct{ N500JSONResponse }
+type ImportCredentials500JSONResponse struct{ N500JSONResponse }
 
-func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+func (response ImportCredentials500JSONResponse) VisitImportCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type AgentV1RequestObject struct {
-	Body *AgentV1TextRequestBody
+type GetCredentialImportRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         Id             `json:"id"`
 }
 
-type AgentV1ResponseObject interface {
-	VisitAgentV1Response(w http.ResponseWriter) error
+type GetCredentialImportResponseObject interface {
+	VisitGetCredentialImportResponse(w http.ResponseWriter) error
 }
 
-type AgentV1200JSONResponse AgentResponse
+type GetCredentialImport200JSONResponse CredentialImport
 
-func (response AgentV1200JSONResponse) VisitAgentV1Response(w http.ResponseWriter) error {
+func (response GetCredentialImport200JSONResponse) VisitGetCredentialImportResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type AgentV1400JSONResponse struct{ N400JSONResponse }
+type GetCredentialImport400JSONResponse struct{ N400JSONResponse }
 
-func (response AgentV1400JSONResponse) VisitAgentV1Response(w http.ResponseWriter) error {
+func (response GetCredentialImport400JSONResponse) VisitGetCredentialImportResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type AgentV1500JSONResponse struct{ N500JSONResponse }
+type GetCredentialImport401JSONResponse struct{ N401JSONResponse }
 
-func (response AgentV1500JSONResponse) VisitAgentV1Response(w http.ResponseWriter) error {
+func (response GetCredentialImport401JSONResponse) VisitGetCredentialImportResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRevocationStatusRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Nonce      PathNonce      `json:"nonce"`
-}
-
-type GetRevocationStatusResponseObject interface {
-	VisitGetRevocationStatusResponse(w http.ResponseWriter) error
-}
-
-type GetRevocationStatus200JSONResponse RevocationStatusResponse
+type GetCredentialImport404JSONResponse struct{ N404JSONResponse }
 
-func (response GetRevocationStatus200JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+func (response GetCredentialImport404JSONResponse) VisitGetCredentialImportResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRevocationStatus400JSONResponse struct{ N400JSONResponse }
+type GetCredentialImport500JSONResponse struct{ N500JSONResponse }
 
-func (response GetRevocationStatus400JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+func (response GetCredentialImport500JSONResponse) VisitGetCredentialImportResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRevocationStatus500JSONResponse struct{ N500JSONResponse }
+type GetCredentialImportErrorsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         Id             `json:"id"`
+}
 
-func (response GetRevocationStatus500JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+type GetCredentialImportErrorsResponseObject interface {
+	VisitGetCredentialImportErrorsResponse(w http.ResponseWriter) error
+}
 
-	return json.NewEncoder(w).Encode(response)
+type GetCredentialImportErrors200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
 }
 
-type AgentRequestObject struct {
-	Body *AgentTextRequestBody
+func (response GetCredentialImportErrors200TextcsvResponse) VisitGetCredentialImportErrorsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
 }
 
-type AgentResponseObject interface {
-	VisitAgentResponse(w http.ResponseWriter) error
+type GetCredentialImportErrors400JSONResponse struct{ N400JSONResponse }
+
+func (response GetCredentialImportErrors400JSONResponse) VisitGetCredentialImportErrorsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type Agent200JSONResponse AgentResponse
+type GetCredentialImportErrors401JSONResponse struct{ N401JSONResponse }
 
-func (response Agent200JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
+func (response GetCredentialImportErrors401JSONResponse) VisitGetCredentialImportErrorsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Agent400JSONResponse struct{ N400JSONResponse }
+type GetCredentialImportErrors404JSONResponse struct{ N404JSONResponse }
 
-func (response Agent400JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
+func (response GetCredentialImportErrors404JSONResponse) VisitGetCredentialImportErrorsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Agent500JSONResponse struct{ N500JSONResponse }
+type GetCredentialImportErrors500JSONResponse struct{ N500JSONResponse }
 
-func (response Agent500JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
+func (response GetCredentialImportErrors500JSONResponse) VisitGetCredentialImportErrorsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthCallbackRequestObject struct {
-	Params AuthCallbackParams
-	Body   *AuthCallbackTextRequestBody
+type GetLinksRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     GetLinksParams
 }
 
-type AuthCallbackResponseObject interface {
-	VisitAuthCallbackResponse(w http.ResponseWriter) error
+type GetLinksResponseObject interface {
+	VisitGetLinksResponse(w http.ResponseWriter) error
 }
 
-type AuthCallback200Response struct {
-}
+type GetLinks200JSONResponse []Link
 
-func (response AuthCallback200Response) VisitAuthCallbackResponse(w http.ResponseWriter) error {
+func (response GetLinks200JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
-	return nil
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthCallback400JSONResponse struct{ N400JSONResponse }
+type GetLinks400JSONResponse struct{ N400JSONResponse }
 
-func (response AuthCallback400JSONResponse) VisitAuthCallbackResponse(w http.ResponseWriter) error {
+func (response GetLinks400JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthCallback500JSONResponse struct{ N500JSONResponse }
+type GetLinks404JSONResponse struct{ N404JSONResponse }
 
-func (response AuthCallback500JSONResponse) VisitAuthCallbackResponse(w http.ResponseWriter) error {
+func (response GetLinks404JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAuthenticationConnectionRequestObject struct {
-	Id Id `json:"id"`
-}
-
-type GetAuthenticationConnectionResponseObject interface {
-	VisitGetAuthenticationConnectionResponse(w http.ResponseWriter) error
-}
-
-type GetAuthenticationConnection200JSONResponse GetAuthenticationConnectionResponse
+type GetLinks500JSONResponse struct{ N500JSONResponse }
 
-func (response GetAuthenticationConnection200JSONResponse) VisitGetAuthenticationConnectionResponse(w http.ResponseWriter) error {
+func (response GetLinks500JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAuthenticationConnection400JSONResponse struct{ N400JSONResponse }
+type CreateLinkRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     CreateLinkParams
+	Body       *CreateLinkJSONRequestBody
+}
 
-func (response GetAuthenticationConnection400JSONResponse) VisitGetAuthenticationConnectionResponse(w http.ResponseWriter) error {
+type CreateLinkResponseObject interface {
+	VisitCreateLinkResponse(w http.ResponseWriter) error
+}
+
+type CreateLink201JSONResponse UUIDResponse
+
+func (response CreateLink201JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAuthenticationConnection401JSONResponse struct{ N401JSONResponse }
+type CreateLink400JSONResponse struct{ N400JSONResponse }
 
-func (response GetAuthenticationConnection401JSONResponse) VisitGetAuthenticationConnectionResponse(w http.ResponseWriter) error {
+func (response CreateLink400JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAuthenticationConnection404JSONResponse struct{ N404JSONResponse }
+type CreateLink409JSONResponse struct{ N409JSONResponse }
 
-func (response GetAuthenticationConnection404JSONResponse) VisitGetAuthenticationConnectionResponse(w http.ResponseWriter) error {
+func (response CreateLink409JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAuthenticationConnection500JSONResponse struct{ N500JSONResponse }
+type CreateLink500JSONResponse struct{ N500JSONResponse }
 
-func (response GetAuthenticationConnection500JSONResponse) VisitGetAuthenticationConnectionResponse(w http.ResponseWriter) error {
+func (response CreateLink500JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetIdentitiesRequestObject struct {
+type CreateLinkQrCodeCallbackRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     CreateLinkQrCodeCallbackParams
+	Body       *CreateLinkQrCodeCallbackTextRequestBody
 }
 
-type GetIdentitiesResponseObject interface {
-	VisitGetIdentitiesResponse(w http.ResponseWriter) error
+type CreateLinkQrCodeCallbackResponseObject interface {
+	VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error
 }
 
-type GetIdentities200JSONResponse []GetIdentitiesResponse
+type CreateLinkQrCodeCallback200JSONResponse Offer
 
-func (response GetIdentities200JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
+func (response CreateLinkQrCodeCallback200JSONResponse) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetIdentities401JSONResponse struct{ N401JSONResponse }
+type CreateLinkQrCodeCallback400JSONResponse struct{ N400JSONResponse }
 
-func (response GetIdentities401JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
+func (response CreateLinkQrCodeCallback400JSONResponse) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetIdentities500JSONResponse struct{ N500JSONResponse }
+type CreateLinkQrCodeCallback500JSONResponse struct{ N500JSONResponse }
 
-func (response GetIdentities500JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
+func (response CreateLinkQrCodeCallback500JSONResponse) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateIdentityRequestObject struct {
-	Body *CreateIdentityJSONRequestBody
+type DeleteLinkRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         Id             `json:"id"`
 }
 
-type CreateIdentityResponseObject interface {
-	VisitCreateIdentityResponse(w http.ResponseWriter) error
+type DeleteLinkResponseObject interface {
+	VisitDeleteLinkResponse(w http.ResponseWriter) error
 }
 
-type CreateIdentity201JSONResponse CreateIdentityResponse
+type DeleteLink200JSONResponse GenericMessage
 
-func (response CreateIdentity201JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+func (response DeleteLink200JSONResponse) VisitDeleteLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateIdentity400JSONResponse struct{ N400JSONResponse }
+type DeleteLink400JSONResponse struct{ N400JSONResponse }
 
-func (response CreateIdentity400JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+func (response DeleteLink400JSONResponse) VisitDeleteLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateIdentity401JSONResponse struct{ N401JSONResponse }
+type DeleteLink500JSONResponse struct{ N500JSONResponse }
 
-func (response CreateIdentity401JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+func (response DeleteLink500JSONResponse) VisitDeleteLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateIdentity403JSONResponse struct{ N403JSONResponse }
+type GetLinkRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         Id             `json:"id"`
+}
 
-func (response CreateIdentity403JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+type GetLinkResponseObject interface {
+	VisitGetLinkResponse(w http.ResponseWriter) error
+}
+
+type GetLink200JSONResponse Link
+
+func (response GetLink200JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateIdentity409JSONResponse struct{ N409JSONResponse }
+type GetLink400JSONResponse struct{ N400JSONResponse }
 
-func (response CreateIdentity409JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+func (response GetLink400JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateIdentity500JSONResponse struct{ N500JSONResponse }
+type GetLink404JSONResponse struct{ N404JSONResponse }
 
-func (response CreateIdentity500JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+func (response GetLink404JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLink500JSONResponse struct{ N500JSONResponse }
+
+func (response GetLink500JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetIdentityDetailsRequestObject struct {
+type ActivateLinkRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
+	Id         Id             `json:"id"`
+	Body       *ActivateLinkJSONRequestBody
 }
 
-type GetIdentityDetailsResponseObject interface {
-	VisitGetIdentityDetailsResponse(w http.ResponseWriter) error
+type ActivateLinkResponseObject interface {
+	VisitActivateLinkResponse(w http.ResponseWriter) error
 }
 
-type GetIdentityDetails200JSONResponse GetIdentityDetailsResponse
+type ActivateLink200JSONResponse GenericMessage
 
-func (response GetIdentityDetails200JSONResponse) VisitGetIdentityDetailsResponse(w http.ResponseWriter) error {
+func (response ActivateLink200JSONResponse) VisitActivateLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetIdentityDetails400JSONResponse struct{ N400JSONResponse }
+type ActivateLink400JSONResponse struct{ N400JSONResponse }
 
-func (response GetIdentityDetails400JSONResponse) VisitGetIdentityDetailsResponse(w http.ResponseWriter) error {
+func (response ActivateLink400JSONResponse) VisitActivateLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetIdentityDetails401JSONResponse struct{ N401JSONResponse }
-
-func (response GetIdentityDetails401JSONResponse) VisitGetIdentityDetailsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type GetIdentityDetails500JSONResponse struct{ N500JSONResponse }
+type ActivateLink500JSONResponse struct{ N500JSONResponse }
 
-func (response GetIdentityDetails500JSONResponse) VisitGetIdentityDetailsResponse(w http.ResponseWriter) error {
+func (response ActivateLink500JSONResponse) VisitActivateLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateIdentityRequestObject struct {
+type CreateLinkOfferRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Body       *UpdateIdentityJSONRequestBody
+	Id         Id             `json:"id"`
+	Params     CreateLinkOfferParams
 }
 
-type UpdateIdentityResponseObject interface {
-	VisitUpdateIdentityResponse(w http.ResponseWriter) error
+type CreateLinkOfferResponseObject interface {
+	VisitCreateLinkOfferResponse(w http.ResponseWriter) error
 }
 
-type UpdateIdentity200JSONResponse GenericMessage
+type CreateLinkOffer200JSONResponse CredentialLinkQrCodeResponse
 
-func (response UpdateIdentity200JSONResponse) VisitUpdateIdentityResponse(w http.ResponseWriter) error {
+func (response CreateLinkOffer200JSONResponse) VisitCreateLinkOfferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateIdentity400JSONResponse struct{ N400JSONResponse }
+type CreateLinkOffer400JSONResponse struct{ N400JSONResponse }
 
-func (response UpdateIdentity400JSONResponse) VisitUpdateIdentityResponse(w http.ResponseWriter) error {
+func (response CreateLinkOffer400JSONResponse) VisitCreateLinkOfferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateIdentity401JSONResponse struct{ N401JSONResponse }
+type CreateLinkOffer404JSONResponse struct{ N404JSONResponse }
 
-func (response UpdateIdentity401JSONResponse) VisitUpdateIdentityResponse(w http.ResponseWriter) error {
+func (response CreateLinkOffer404JSONResponse) VisitCreateLinkOfferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateIdentity403JSONResponse struct{ N403JSONResponse }
+type CreateLinkOffer409JSONResponse struct{ N409JSONResponse }
 
-func (response UpdateIdentity403JSONResponse) VisitUpdateIdentityResponse(w http.ResponseWriter) error {
+func (response CreateLinkOffer409JSONResponse) VisitCreateLinkOfferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateIdentity500JSONResponse struct{ N500CreateIdentityJSONResponse }
+type CreateLinkOffer500JSONResponse struct{ N500JSONResponse }
 
-func (response UpdateIdentity500JSONResponse) VisitUpdateIdentityResponse(w http.ResponseWriter) error {
+func (response CreateLinkOffer500JSONResponse) VisitCreateLinkOfferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetConnectionsRequestObject struct {
+type GetRevocationStatusV2RequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Params     GetConnectionsParams
+	Nonce      PathNonce      `json:"nonce"`
 }
 
-type GetConnectionsResponseObject interface {
-	VisitGetConnectionsResponse(w http.ResponseWriter) error
+type GetRevocationStatusV2ResponseObject interface {
+	VisitGetRevocationStatusV2Response(w http.ResponseWriter) error
 }
 
-type GetConnections200JSONResponse ConnectionsPaginated
+type GetRevocationStatusV2200JSONResponse RevocationStatusResponse
 
-func (response GetConnections200JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
+func (response GetRevocationStatusV2200JSONResponse) VisitGetRevocationStatusV2Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetConnections400JSONResponse struct{ N400JSONResponse }
+type GetRevocationStatusV2400JSONResponse struct{ N400JSONResponse }
 
-func (response GetConnections400JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
+func (response GetRevocationStatusV2400JSONResponse) VisitGetRevocationStatusV2Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetConnections500JSONResponse struct{ N500JSONResponse }
+type GetRevocationStatusV2500JSONResponse struct{ N500JSONResponse }
 
-func (response GetConnections500JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
+func (response GetRevocationStatusV2500JSONResponse) VisitGetRevocationStatusV2Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateConnectionRequestObject struct {
+type RevokeCredentialsRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Body       *CreateConnectionJSONRequestBody
-}
-
-type CreateConnectionResponseObject interface {
-	VisitCreateConnectionResponse(w http.ResponseWriter) error
+	Body       *RevokeCredentialsJSONRequestBody
 }
 
-type CreateConnection201JSONResponse GenericMessage
-
-func (response CreateConnection201JSONResponse) VisitCreateConnectionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
-
-	return json.NewEncoder(w).Encode(response)
+type RevokeCredentialsResponseObject interface {
+	VisitRevokeCredentialsResponse(w http.ResponseWriter) error
 }
 
-type CreateConnection400JSONResponse struct{ N400JSONResponse }
+type RevokeCredentials200JSONResponse RevokeCredentialsResponse
 
-func (response CreateConnection400JSONResponse) VisitCreateConnectionResponse(w http.ResponseWriter) error {
+func (response RevokeCredentials200JSONResponse) VisitRevokeCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateConnection500JSONResponse struct{ N500JSONResponse }
+type RevokeCredentials202JSONResponse RevokeCredentialsResponse
 
-func (response CreateConnection500JSONResponse) VisitCreateConnectionResponse(w http.ResponseWriter) error {
+func (response RevokeCredentials202JSONResponse) VisitRevokeCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(202)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteConnectionRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Id         Id             `json:"id"`
-	Params     DeleteConnectionParams
-}
-
-type DeleteConnectionResponseObject interface {
-	VisitDeleteConnectionResponse(w http.ResponseWriter) error
-}
-
-type DeleteConnection200JSONResponse GenericMessage
+type RevokeCredentials400JSONResponse struct{ N400JSONResponse }
 
-func (response DeleteConnection200JSONResponse) VisitDeleteConnectionResponse(w http.ResponseWriter) error {
+func (response RevokeCredentials400JSONResponse) VisitRevokeCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteConnection400JSONResponse struct{ N400JSONResponse }
+type RevokeCredentials401JSONResponse struct{ N401JSONResponse }
 
-func (response DeleteConnection400JSONResponse) VisitDeleteConnectionResponse(w http.ResponseWriter) error {
+func (response RevokeCredentials401JSONResponse) VisitRevokeCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteConnection500JSONResponse struct{ N500JSONResponse }
+type RevokeCredentials500JSONResponse struct{ N500JSONResponse }
 
-func (response DeleteConnection500JSONResponse) VisitDeleteConnectionResponse(w http.ResponseWriter) error {
+func (response RevokeCredentials500JSONResponse) VisitRevokeCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetConnectionRequestObject struct {
+type RevokeCredentialRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Id         Id             `json:"id"`
+	Nonce      PathNonce      `json:"nonce"`
 }
 
-type GetConnectionResponseObject interface {
-	VisitGetConnectionResponse(w http.ResponseWriter) error
+type RevokeCredentialResponseObject interface {
+	VisitRevokeCredentialResponse(w http.ResponseWriter) error
 }
 
-type GetConnection200JSONResponse GetConnectionResponse
+type RevokeCredential202JSONResponse RevokeClaimResponse
 
-func (response GetConnection200JSONResponse) VisitGetConnectionResponse(w http.ResponseWriter) error {
+func (response RevokeCredential202JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(202)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetConnection400JSONResponse struct{ N400JSONResponse }
+type RevokeCredential400JSONResponse struct{ N400JSONResponse }
 
-func (response GetConnection400JSONResponse) VisitGetConnectionResponse(w http.ResponseWriter) error {
+func (response RevokeCredential400JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetConnection500JSONResponse struct{ N500JSONResponse }
+type RevokeCredential401JSONResponse struct{ N401JSONResponse }
 
-func (response GetConnection500JSONResponse) VisitGetConnectionResponse(w http.ResponseWriter) error {
+func (response RevokeCredential401JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteConnectionCredentialsRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Id         Id             `json:"id"`
-}
-
-type DeleteConnectionCredentialsResponseObject interface {
-	VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error
-}
-
-type DeleteConnectionCredentials200JSONResponse GenericMessage
+type RevokeCredential404JSONResponse struct{ N404JSONResponse }
 
-func (response DeleteConnectionCredentials200JSONResponse) VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error {
+func (response RevokeCredential404JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteConnectionCredentials400JSONResponse struct{ N400JSONResponse }
+type RevokeCredential500JSONResponse struct{ N500JSONResponse }
 
-func (response DeleteConnectionCredentials400JSONResponse) VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error {
+func (response RevokeCredential500JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteConnectionCredentials500JSONResponse struct{ N500JSONResponse }
+type GetStatusListCredentialRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         Id             `json:"id"`
+	Params     GetStatusListCredentialParams
+}
 
-func (response DeleteConnectionCredentials500JSONResponse) VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error {
+type GetStatusListCredentialResponseObject interface {
+	VisitGetStatusListCredentialResponse(w http.ResponseWriter) error
+}
+
+type GetStatusListCredential200JSONResponse verifiable.W3CCredential
+
+func (response GetStatusListCredential200JSONResponse) VisitGetStatusListCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RevokeConnectionCredentialsRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Id         Id             `json:"id"`
+type GetStatusListCredential200ApplicationjwtResponse struct {
+	Body          io.Reader
+	ContentLength int64
 }
 
-type RevokeConnectionCredentialsResponseObject interface {
-	VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error
+func (response GetStatusListCredential200ApplicationjwtResponse) VisitGetStatusListCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/jwt")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
 }
 
-type RevokeConnectionCredentials202JSONResponse GenericMessage
+type GetStatusListCredential400JSONResponse struct{ N400JSONResponse }
 
-func (response RevokeConnectionCredentials202JSONResponse) VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error {
+func (response GetStatusListCredential400JSONResponse) VisitGetStatusListCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RevokeConnectionCredentials400JSONResponse struct{ N400JSONResponse }
+type GetStatusListCredential404JSONResponse struct{ N404JSONResponse }
 
-func (response RevokeConnectionCredentials400JSONResponse) VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error {
+func (response GetStatusListCredential404JSONResponse) VisitGetStatusListCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RevokeConnectionCredentials500JSONResponse struct{ N500JSONResponse }
+type GetStatusListCredential500JSONResponse struct{ N500JSONResponse }
 
-func (response RevokeConnectionCredentials500JSONResponse) VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error {
+func (response GetStatusListCredential500JSONResponse) VisitGetStatusListCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentialsRequestObject struct {
+type ValidateCredentialRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Params     GetCredentialsParams
+	Body       *ValidateCredentialJSONRequestBody
 }
 
-type GetCredentialsResponseObject interface {
-	VisitGetCredentialsResponse(w http.ResponseWriter) error
+type ValidateCredentialResponseObject interface {
+	VisitValidateCredentialResponse(w http.ResponseWriter) error
 }
 
-type GetCredentials200JSONResponse CredentialsPaginated
+type ValidateCredential200JSONResponse ValidateCredentialResponse
 
-func (response GetCredentials200JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
+func (response ValidateCredential200JSONResponse) VisitValidateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentials400JSONResponse struct{ N400JSONResponse }
+type ValidateCredential400JSONResponse struct{ N400JSONResponse }
 
-func (response GetCredentials400JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
+func (response ValidateCredential400JSONResponse) VisitValidateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentials404JSONResponse struct{ N404JSONResponse }
+type ValidateCredential401JSONResponse struct{ N401JSONResponse }
 
-func (response GetCredentials404JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
+func (response ValidateCredential401JSONResponse) VisitValidateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentials500JSONResponse struct{ N500JSONResponse }
+type ValidateCredential500JSONResponse struct{ N500JSONResponse }
 
-func (response GetCredentials500JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
+func (response ValidateCredential500JSONResponse) VisitValidateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateCredentialRequestObject struct {
+type DeleteCredentialRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Body       *CreateCredentialJSONRequestBody
+	Id         PathClaim      `json:"id"`
 }
 
-type CreateCredentialResponseObject interface {
-	VisitCreateCredentialResponse(w http.ResponseWriter) error
+type DeleteCredentialResponseObject interface {
+	VisitDeleteCredentialResponse(w http.ResponseWriter) error
 }
 
-type CreateCredential201JSONResponse CreateCredentialResponse
+type DeleteCredential200JSONResponse GenericMessage
 
-func (response CreateCredential201JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+func (response DeleteCredential200JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateCredential400JSONResponse struct{ N400JSONResponse }
+type DeleteCredential400JSONResponse struct{ N400JSONResponse }
 
-func (response CreateCredential400JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+func (response DeleteCredential400JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateCredential401JSONResponse struct{ N401JSONResponse }
+type DeleteCredential401JSONResponse struct{ N401JSONResponse }
 
-func (response CreateCredential401JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+func (response DeleteCredential401JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateCredential422JSONResponse struct{ N422JSONResponse }
-
-func (response CreateCredential422JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type CreateCredential500JSONResponse struct{ N500JSONResponse }
+type DeleteCredential500JSONResponse struct{ N500JSONResponse }
 
-func (response CreateCredential500JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+func (response DeleteCredential500JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLinksRequestObject struct {
+type GetCredentialRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Params     GetLinksParams
+	Id         PathClaim      `json:"id"`
+	Params     GetCredentialParams
 }
 
-type GetLinksResponseObject interface {
-	VisitGetLinksResponse(w http.ResponseWriter) error
+type GetCredentialResponseObject interface {
+	VisitGetCredentialResponse(w http.ResponseWriter) error
 }
 
-type GetLinks200JSONResponse []Link
+type GetCredential200JSONResponse Credential
 
-func (response GetLinks200JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
+func (response GetCredential200JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLinks400JSONResponse struct{ N400JSONResponse }
+type GetCredential200ApplicationjwtResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
 
-func (response GetLinks400JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+func (response GetCredential200ApplicationjwtResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/jwt")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
 }
 
-type GetLinks404JSONResponse struct{ N404JSONResponse }
+type GetCredential200ApplicationvcSdJwtResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
 
-func (response GetLinks404JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+func (response GetCredential200ApplicationvcSdJwtResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/vc+sd-jwt")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
 }
 
-type GetLinks500JSONResponse struct{ N500JSONResponse }
+type GetCredential400JSONResponse struct{ N400JSONResponse }
 
-func (response GetLinks500JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
+func (response GetCredential400JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Body       *CreateLinkJSONRequestBody
-}
-
-type CreateLinkResponseObject interface {
-	VisitCreateLinkResponse(w http.ResponseWriter) error
-}
-
-type CreateLink201JSONResponse UUIDResponse
+type GetCredential401JSONResponse struct{ N401JSONResponse }
 
-func (response CreateLink201JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
+func (response GetCredential401JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLink400JSONResponse struct{ N400JSONResponse }
+type GetCredential404JSONResponse struct{ N404JSONResponse }
 
-func (response CreateLink400JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
+func (response GetCredential404JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLink500JSONResponse struct{ N500JSONResponse }
+type GetCredential500JSONResponse struct{ N500JSONResponse }
 
-func (response CreateLink500JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
+func (response GetCredential500JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkQrCodeCallbackRequestObject struct {
+type UpdateCredentialRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Params     CreateLinkQrCodeCallbackParams
-	Body       *CreateLinkQrCodeCallbackTextRequestBody
+	Id         PathClaim      `json:"id"`
+	Body       *UpdateCredentialJSONRequestBody
 }
 
-type CreateLinkQrCodeCallbackResponseObject interface {
-	VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error
+type UpdateCredentialResponseObject interface {
+	VisitUpdateCredentialResponse(w http.ResponseWriter) error
 }
 
-type CreateLinkQrCodeCallback200JSONResponse Offer
+type UpdateCredential201JSONResponse CreateCredentialResponse
 
-func (response CreateLinkQrCodeCallback200JSONResponse) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
+func (response UpdateCredential201JSONResponse) VisitUpdateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkQrCodeCallback400JSONResponse struct{ N400JSONResponse }
+type UpdateCredential400JSONResponse struct{ N400JSONResponse }
 
-func (response CreateLinkQrCodeCallback400JSONResponse) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
+func (response UpdateCredential400JSONResponse) VisitUpdateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkQrCodeCallback500JSONResponse struct{ N500JSONResponse }
+type UpdateCredential401JSONResponse struct{ N401JSONResponse }
 
-func (response CreateLinkQrCodeCallback500JSONResponse) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
+func (response UpdateCredential401JSONResponse) VisitUpdateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteLinkRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Id         Id             `json:"id"`
-}
-
-type DeleteLinkResponseObject interface {
-	VisitDeleteLinkResponse(w http.ResponseWriter) error
-}
-
-type DeleteLink200JSONResponse GenericMessage
+type UpdateCredential404JSONResponse struct{ N404JSONResponse }
 
-func (response DeleteLink200JSONResponse) VisitDeleteLinkResponse(w http.ResponseWriter) error {
+func (response UpdateCredential404JSONResponse) VisitUpdateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteLink400JSONResponse struct{ N400JSONResponse }
+type UpdateCredential422JSONResponse struct{ N422JSONResponse }
 
-func (response DeleteLink400JSONResponse) VisitDeleteLinkResponse(w http.ResponseWriter) error {
+func (response UpdateCredential422JSONResponse) VisitUpdateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteLink500JSONResponse struct{ N500JSONResponse }
+type UpdateCredential500JSONResponse struct{ N500JSONResponse }
 
-func (response DeleteLink500JSONResponse) VisitDeleteLinkResponse(w http.ResponseWriter) error {
+func (response UpdateCredential500JSONResponse) VisitUpdateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLinkRequestObject struct {
+type GetCredentialOfferRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Id         Id             `json:"id"`
+	Id         PathClaim      `json:"id"`
+	Params     GetCredentialOfferParams
 }
 
-type GetLinkResponseObject interface {
-	VisitGetLinkResponse(w http.ResponseWriter) error
+type GetCredentialOfferResponseObject interface {
+	VisitGetCredentialOfferResponse(w http.ResponseWriter) error
 }
 
-type GetLink200JSONResponse Link
+type GetCredentialOffer200JSONResponse CredentialOfferResponse
 
-func (response GetLink200JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
+func (response GetCredentialOffer200JSONResponse) VisitGetCredentialOfferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLink400JSONResponse struct{ N400JSONResponse }
+type GetCredentialOffer400JSONResponse struct{ N400JSONResponse }
 
-func (response GetLink400JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
+func (response GetCredentialOffer400JSONResponse) VisitGetCredentialOfferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLink404JSONResponse struct{ N404JSONResponse }
+type GetCredentialOffer404JSONResponse struct{ N404JSONResponse }
 
-func (response GetLink404JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
+func (response GetCredentialOffer404JSONResponse) VisitGetCredentialOfferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLink500JSONResponse struct{ N500JSONResponse }
-
-func (response GetLink500JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ActivateLinkRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Id         Id             `json:"id"`
-	Body       *ActivateLinkJSONRequestBody
-}
-
-type ActivateLinkResponseObject interface {
-	VisitActivateLinkResponse(w http.ResponseWriter) error
-}
-
-type ActivateLink200JSONResponse GenericMessage
-
-func (response ActivateLink200JSONResponse) VisitActivateLinkResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ActivateLink400JSONResponse struct{ N400JSONResponse }
+type GetCredentialOffer409JSONResponse struct{ N409JSONResponse }
 
-func (response ActivateLink400JSONResponse) VisitActivateLinkResponse(w http.ResponseWriter) error {
+func (response GetCredentialOffer409JSONResponse) VisitGetCredentialOfferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ActivateLink500JSONResponse struct{ N500JSONResponse }
+type GetCredentialOffer500JSONResponse struct{ N500JSONResponse }
 
-func (response ActivateLink500JSONResponse) VisitActivateLinkResponse(w http.ResponseWriter) error {
+func (response GetCredentialOffer500JSONResponse) VisitGetCredentialOfferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkOfferRequestObject struct {
+type DeactivateIdentityRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Id         Id             `json:"id"`
+	Params     DeactivateIdentityParams
 }
 
-type CreateLinkOfferResponseObject interface {
-	VisitCreateLinkOfferResponse(w http.ResponseWriter) error
+type DeactivateIdentityResponseObject interface {
+	VisitDeactivateIdentityResponse(w http.ResponseWriter) error
 }
 
-type CreateLinkOffer200JSONResponse CredentialLinkQrCodeResponse
+type DeactivateIdentity200JSONResponse DeactivateIdentityResponse
 
-func (response CreateLinkOffer200JSONResponse) VisitCreateLinkOfferResponse(w http.ResponseWriter) error {
+func (response DeactivateIdentity200JSONResponse) VisitDeactivateIdentityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkOffer400JSONResponse struct{ N400JSONResponse }
+type DeactivateIdentity400JSONResponse struct{ N400JSONResponse }
 
-func (response CreateLinkOffer400JSONResponse) VisitCreateLinkOfferResponse(w http.ResponseWriter) error {
+func (response DeactivateIdentity400JSONResponse) VisitDeactivateIdentityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkOffer404JSONResponse struct{ N404JSONResponse }
-
-func (response CreateLinkOffer404JSONResponse) VisitCreateLinkOfferResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type CreateLinkOffer500JSONResponse struct{ N500JSONResponse }
+type DeactivateIdentity401JSONResponse struct{ N401JSONResponse }
 
-func (response CreateLinkOffer500JSONResponse) VisitCreateLinkOfferResponse(w http.ResponseWriter) error {
+func (response DeactivateIdentity401JSONResponse) VisitDeactivateIdentityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRevocationStatusV2RequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Nonce      PathNonce      `json:"nonce"`
-}
-
-type GetRevocationStatusV2ResponseObject interface {
-	VisitGetRevocationStatusV2Response(w http.ResponseWriter) error
-}
+type DeactivateIdentity404JSONResponse struct{ N404JSONResponse }
 
-type GetRevocationStatusV2200JSONResponse RevocationStatusResponse
-
-func (response GetRevocationStatusV2200JSONResponse) VisitGetRevocationStatusV2Response(w http.ResponseWriter) error {
+func (response DeactivateIdentity404JSONResponse) VisitDeactivateIdentityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRevocationStatusV2400JSONResponse struct{ N400JSONResponse }
+type DeactivateIdentity409JSONResponse struct{ N409JSONResponse }
 
-func (response GetRevocationStatusV2400JSONResponse) VisitGetRevocationStatusV2Response(w http.ResponseWriter) error {
+func (response DeactivateIdentity409JSONResponse) VisitDeactivateIdentityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRevocationStatusV2500JSONResponse struct{ N500JSONResponse }
+type DeactivateIdentity500JSONResponse struct{ N500JSONResponse }
 
-func (response GetRevocationStatusV2500JSONResponse) VisitGetRevocationStatusV2Response(w http.ResponseWriter) error {
+func (response DeactivateIdentity500JSONResponse) VisitDeactivateIdentityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RevokeCredentialRequestObject struct {
+type GetDIDDocumentRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Nonce      PathNonce      `json:"nonce"`
 }
 
-type RevokeCredentialResponseObject interface {
-	VisitRevokeCredentialResponse(w http.ResponseWriter) error
+type GetDIDDocumentResponseObject interface {
+	VisitGetDIDDocumentResponse(w http.ResponseWriter) error
 }
 
-type RevokeCredential202JSONResponse RevokeClaimResponse
+type GetDIDDocument200JSONResponse DIDDocument
 
-func (response RevokeCredential202JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
+func (response GetDIDDocument200JSONResponse) VisitGetDIDDocumentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RevokeCredential400JSONResponse struct{ N400JSONResponse }
+type GetDIDDocument400JSONResponse struct{ N400JSONResponse }
 
-func (response RevokeCredential400JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
+func (response GetDIDDocument400JSONResponse) VisitGetDIDDocumentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RevokeCredential401JSONResponse struct{ N401JSONResponse }
-
-func (response RevokeCredential401JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type RevokeCredential404JSONResponse struct{ N404JSONResponse }
+type GetDIDDocument404JSONResponse struct{ N404JSONResponse }
 
-func (response RevokeCredential404JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
+func (response GetDIDDocument404JSONResponse) VisitGetDIDDocumentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RevokeCredential500JSONResponse struct{ N500JSONResponse }
+type GetDIDDocument500JSONResponse struct{ N500JSONResponse }
 
-func (response RevokeCredential500JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
+func (response GetDIDDocument500JSONResponse) VisitGetDIDDocumentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCredentialRequestObject struct {
+type GetIdentityKeysRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Id         PathClaim      `json:"id"`
 }
 
-type DeleteCredentialResponseObject interface {
-	VisitDeleteCredentialResponse(w http.ResponseWriter) error
+type GetIdentityKeysResponseObject interface {
+	VisitGetIdentityKeysResponse(w http.ResponseWriter) error
 }
 
-type DeleteCredential200JSONResponse GenericMessage
+type GetIdentityKeys200JSONResponse []IdentityKey
 
-func (response DeleteCredential200JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+func (response GetIdentityKeys200JSONResponse) VisitGetIdentityKeysResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCredential400JSONResponse struct{ N400JSONResponse }
+type GetIdentityKeys400JSONResponse struct{ N400JSONResponse }
 
-func (response DeleteCredential400JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+func (response GetIdentityKeys400JSONResponse) VisitGetIdentityKeysResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCredential401JSONResponse struct{ N401JSONResponse }
+type GetIdentityKeys401JSONResponse struct{ N401JSONResponse }
 
-func (response DeleteCredential401JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+func (response GetIdentityKeys401JSONResponse) VisitGetIdentityKeysResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCredential500JSONResponse struct{ N500JSONResponse }
+type GetIdentityKeys404JSONResponse struct{ N404JSONResponse }
 
-func (response DeleteCredential500JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+func (response GetIdentityKeys404JSONResponse) VisitGetIdentityKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityKeys500JSONResponse struct{ N500JSONResponse }
+
+func (response GetIdentityKeys500JSONResponse) VisitGetIdentityKeysResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentialRequestObject struct {
+type CreateIdentityKeyRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Id         PathClaim      `json:"id"`
 }
 
-type GetCredentialResponseObject interface {
-	VisitGetCredentialResponse(w http.ResponseWriter) error
+type CreateIdentityKeyResponseObject interface {
+	VisitCreateIdentityKeyResponse(w http.ResponseWriter) error
 }
 
-type GetCredential200JSONResponse Credential
+type CreateIdentityKey201JSONResponse IdentityKey
 
-func (response GetCredential200JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+func (response CreateIdentityKey201JSONResponse) VisitCreateIdentityKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredential400JSONResponse struct{ N400JSONResponse }
+type CreateIdentityKey400JSONResponse struct{ N400JSONResponse }
 
-func (response GetCredential400JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+func (response CreateIdentityKey400JSONResponse) VisitCreateIdentityKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredential401JSONResponse struct{ N401JSONResponse }
+type CreateIdentityKey401JSONResponse struct{ N401JSONResponse }
 
-func (response GetCredential401JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+func (response CreateIdentityKey401JSONResponse) VisitCreateIdentityKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredential404JSONResponse struct{ N404JSONResponse }
+type CreateIdentityKey404JSONResponse struct{ N404JSONResponse }
 
-func (response GetCredential404JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+func (response CreateIdentityKey404JSONResponse) VisitCreateIdentityKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredential500JSONResponse struct{ N500JSONResponse }
+type CreateIdentityKey500JSONResponse struct{ N500JSONResponse }
 
-func (response GetCredential500JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+func (response CreateIdentityKey500JSONResponse) VisitCreateIdentityKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentialOfferRequestObject struct {
+type RotateIdentityKeyRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Id         PathClaim      `json:"id"`
-	Params     GetCredentialOfferParams
+	Params     RotateIdentityKeyParams
 }
 
-type GetCredentialOfferResponseObject interface {
-	VisitGetCredentialOfferResponse(w http.ResponseWriter) error
+type RotateIdentityKeyResponseObject interface {
+	VisitRotateIdentityKeyResponse(w http.ResponseWriter) error
 }
 
-type GetCredentialOffer200JSONResponse CredentialOfferResponse
+type RotateIdentityKey202JSONResponse RotateIdentityKeyResponse
 
-func (response GetCredentialOffer200JSONResponse) VisitGetCredentialOfferResponse(w http.ResponseWriter) error {
+func (response RotateIdentityKey202JSONResponse) VisitRotateIdentityKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(202)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentialOffer400JSONResponse struct{ N400JSONResponse }
+type RotateIdentityKey400JSONResponse struct{ N400JSONResponse }
 
-func (response GetCredentialOffer400JSONResponse) VisitGetCredentialOfferResponse(w http.ResponseWriter) error {
+func (response RotateIdentityKey400JSONResponse) VisitRotateIdentityKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentialOffer404JSONResponse struct{ N404JSONResponse }
+type RotateIdentityKey401JSONResponse struct{ N401JSONResponse }
 
-func (response GetCredentialOffer404JSONResponse) VisitGetCredentialOfferResponse(w http.ResponseWriter) error {
+func (response RotateIdentityKey401JSONResponse) VisitRotateIdentityKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentialOffer409JSONResponse struct{ N409JSONResponse }
+type RotateIdentityKey404JSONResponse struct{ N404JSONResponse }
 
-func (response GetCredentialOffer409JSONResponse) VisitGetCredentialOfferResponse(w http.ResponseWriter) error {
+func (response RotateIdentityKey404JSONResponse) VisitRotateIdentityKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentialOffer500JSONResponse struct{ N500JSONResponse }
+type RotateIdentityKey500JSONResponse struct{ N500JSONResponse }
 
-func (response GetCredentialOffer500JSONResponse) VisitGetCredentialOfferResponse(w http.ResponseWriter) error {
+func (response RotateIdentityKey500JSONResponse) VisitRotateIdentityKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
@@ -4256,23 +6936,58 @@ type GetSchema400JSONResponse struct{ N400JSONResponse }
 
 func (response GetSchema400JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchema404JSONResponse struct{ N404JSONResponse }
+
+func (response GetSchema404JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchema500JSONResponse struct{ N500JSONResponse }
+
+func (response GetSchema500JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelPublishStateRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type CancelPublishStateResponseObject interface {
+	VisitCancelPublishStateResponse(w http.ResponseWriter) error
+}
+
+type CancelPublishState202JSONResponse StateTransaction
+
+func (response CancelPublishState202JSONResponse) VisitCancelPublishStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSchema404JSONResponse struct{ N404JSONResponse }
+type CancelPublishState400JSONResponse struct{ N400JSONResponse }
 
-func (response GetSchema404JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
+func (response CancelPublishState400JSONResponse) VisitCancelPublishStateResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSchema500JSONResponse struct{ N500JSONResponse }
+type CancelPublishState500JSONResponse struct{ N500JSONResponse }
 
-func (response GetSchema500JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
+func (response CancelPublishState500JSONResponse) VisitCancelPublishStateResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
@@ -4367,6 +7082,41 @@ func (response RetryPublishState500JSONResponse) VisitRetryPublishStateResponse(
 	return json.NewEncoder(w).Encode(response)
 }
 
+type SpeedUpPublishStateRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type SpeedUpPublishStateResponseObject interface {
+	VisitSpeedUpPublishStateResponse(w http.ResponseWriter) error
+}
+
+type SpeedUpPublishState202JSONResponse StateTransaction
+
+func (response SpeedUpPublishState202JSONResponse) VisitSpeedUpPublishStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SpeedUpPublishState400JSONResponse struct{ N400JSONResponse }
+
+func (response SpeedUpPublishState400JSONResponse) VisitSpeedUpPublishStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SpeedUpPublishState500JSONResponse struct{ N500JSONResponse }
+
+func (response SpeedUpPublishState500JSONResponse) VisitSpeedUpPublishStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetStateStatusRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
 }
@@ -4491,6 +7241,40 @@ func (response GetQrFromStore500JSONResponse) VisitGetQrFromStoreResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetPublishingKeysStatusRequestObject struct {
+}
+
+type GetPublishingKeysStatusResponseObject interface {
+	VisitGetPublishingKeysStatusResponse(w http.ResponseWriter) error
+}
+
+type GetPublishingKeysStatus200JSONResponse []PublishingKeyStatus
+
+func (response GetPublishingKeysStatus200JSONResponse) VisitGetPublishingKeysStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPublishingKeysStatus401JSONResponse struct{ N401JSONResponse }
+
+func (response GetPublishingKeysStatus401JSONResponse) VisitGetPublishingKeysStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPublishingKeysStatus500JSONResponse struct{ N500JSONResponse }
+
+func (response GetPublishingKeysStatus500JSONResponse) VisitGetPublishingKeysStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetSupportedNetworksRequestObject struct {
 }
 
@@ -4581,6 +7365,12 @@ func (response Authentication500JSONResponse) VisitAuthenticationResponse(w http
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Resolve DID
+	// (GET /1.0/identifiers/{identifier})
+	ResolveDID(ctx context.Context, request ResolveDIDRequestObject) (ResolveDIDResponseObject, error)
+	// Get did:web DID Document
+	// (GET /identities/{id}/did.json)
+	GetWebDIDDocument(ctx context.Context, request GetWebDIDDocumentRequestObject) (GetWebDIDDocumentResponseObject, error)
 	// Healthcheck
 	// (GET /status)
 	Health(ctx context.Context, request HealthRequestObject) (HealthResponseObject, error)
@@ -4605,12 +7395,21 @@ type StrictServerInterface interface {
 	// Create Identity
 	// (POST /v2/identities)
 	CreateIdentity(ctx context.Context, request CreateIdentityRequestObject) (CreateIdentityResponseObject, error)
+	// Search Identities
+	// (GET /v2/identities/search)
+	SearchIdentities(ctx context.Context, request SearchIdentitiesRequestObject) (SearchIdentitiesResponseObject, error)
 	// Get Identity Detail
 	// (GET /v2/identities/{identifier})
 	GetIdentityDetails(ctx context.Context, request GetIdentityDetailsRequestObject) (GetIdentityDetailsResponseObject, error)
 	// Update Identity
 	// (PATCH /v2/identities/{identifier})
 	UpdateIdentity(ctx context.Context, request UpdateIdentityRequestObject) (UpdateIdentityResponseObject, error)
+	// Archive Identity
+	// (POST /v2/identities/{identifier}/archive)
+	ArchiveIdentity(ctx context.Context, request ArchiveIdentityRequestObject) (ArchiveIdentityResponseObject, error)
+	// Create Identity Backup
+	// (POST /v2/identities/{identifier}/backup)
+	CreateIdentityBackup(ctx context.Context, request CreateIdentityBackupRequestObject) (CreateIdentityBackupResponseObject, error)
 	// Get Connections
 	// (GET /v2/identities/{identifier}/connections)
 	GetConnections(ctx context.Context, request GetConnectionsRequestObject) (GetConnectionsResponseObject, error)
@@ -4635,6 +7434,18 @@ type StrictServerInterface interface {
 	// Create Credential
 	// (POST /v2/identities/{identifier}/credentials)
 	CreateCredential(ctx context.Context, request CreateCredentialRequestObject) (CreateCredentialResponseObject, error)
+	// Create Credentials Batch
+	// (POST /v2/identities/{identifier}/credentials/batch)
+	CreateCredentialsBatch(ctx context.Context, request CreateCredentialsBatchRequestObject) (CreateCredentialsBatchResponseObject, error)
+	// Import Credential Subjects
+	// (POST /v2/identities/{identifier}/credentials/import)
+	ImportCredentials(ctx context.Context, request ImportCredentialsRequestObject) (ImportCredentialsResponseObject, error)
+	// Get Credential Import
+	// (GET /v2/identities/{identifier}/credentials/import/{id})
+	GetCredentialImport(ctx context.Context, request GetCredentialImportRequestObject) (GetCredentialImportResponseObject, error)
+	// Get Credential Import Errors
+	// (GET /v2/identities/{identifier}/credentials/import/{id}/errors)
+	GetCredentialImportErrors(ctx context.Context, request GetCredentialImportErrorsRequestObject) (GetCredentialImportErrorsResponseObject, error)
 	// Get Links
 	// (GET /v2/identities/{identifier}/credentials/links)
 	GetLinks(ctx context.Context, request GetLinksRequestObject) (GetLinksResponseObject, error)
@@ -4659,18 +7470,45 @@ type StrictServerInterface interface {
 	// Get Revocation Status
 	// (GET /v2/identities/{identifier}/credentials/revocation/status/{nonce})
 	GetRevocationStatusV2(ctx context.Context, request GetRevocationStatusV2RequestObject) (GetRevocationStatusV2ResponseObject, error)
+	// Revoke Credentials
+	// (POST /v2/identities/{identifier}/credentials/revoke)
+	RevokeCredentials(ctx context.Context, request RevokeCredentialsRequestObject) (RevokeCredentialsResponseObject, error)
 	// Revoke Credential
 	// (POST /v2/identities/{identifier}/credentials/revoke/{nonce})
 	RevokeCredential(ctx context.Context, request RevokeCredentialRequestObject) (RevokeCredentialResponseObject, error)
+	// Get Status List Credential
+	// (GET /v2/identities/{identifier}/credentials/status-lists/{id})
+	GetStatusListCredential(ctx context.Context, request GetStatusListCredentialRequestObject) (GetStatusListCredentialResponseObject, error)
+	// Validate Credential
+	// (POST /v2/identities/{identifier}/credentials/validate)
+	ValidateCredential(ctx context.Context, request ValidateCredentialRequestObject) (ValidateCredentialResponseObject, error)
 	// Delete Credential
 	// (DELETE /v2/identities/{identifier}/credentials/{id})
 	DeleteCredential(ctx context.Context, request DeleteCredentialRequestObject) (DeleteCredentialResponseObject, error)
 	// Get Credential
 	// (GET /v2/identities/{identifier}/credentials/{id})
 	GetCredential(ctx context.Context, request GetCredentialRequestObject) (GetCredentialResponseObject, error)
+	// Update Credential
+	// (PATCH /v2/identities/{identifier}/credentials/{id})
+	UpdateCredential(ctx context.Context, request UpdateCredentialRequestObject) (UpdateCredentialResponseObject, error)
 	// Get Credentials Offer
 	// (GET /v2/identities/{identifier}/credentials/{id}/offer)
 	GetCredentialOffer(ctx context.Context, request GetCredentialOfferRequestObject) (GetCredentialOfferResponseObject, error)
+	// Deactivate Identity
+	// (POST /v2/identities/{identifier}/deactivate)
+	DeactivateIdentity(ctx context.Context, request DeactivateIdentityRequestObject) (DeactivateIdentityResponseObject, error)
+	// Get DID Document
+	// (GET /v2/identities/{identifier}/did-document)
+	GetDIDDocument(ctx context.Context, request GetDIDDocumentRequestObject) (GetDIDDocumentResponseObject, error)
+	// Get Identity Keys
+	// (GET /v2/identities/{identifier}/keys)
+	GetIdentityKeys(ctx context.Context, request GetIdentityKeysRequestObject) (GetIdentityKeysResponseObject, error)
+	// Create Identity Key
+	// (POST /v2/identities/{identifier}/keys)
+	CreateIdentityKey(ctx context.Context, request CreateIdentityKeyRequestObject) (CreateIdentityKeyResponseObject, error)
+	// Rotate Identity Key
+	// (POST /v2/identities/{identifier}/keys/rotate)
+	RotateIdentityKey(ctx context.Context, request RotateIdentityKeyRequestObject) (RotateIdentityKeyResponseObject, error)
 	// Get Schemas
 	// (GET /v2/identities/{identifier}/schemas)
 	GetSchemas(ctx context.Context, request GetSchemasRequestObject) (GetSchemasResponseObject, error)
@@ -4680,12 +7518,18 @@ type StrictServerInterface interface {
 	// Get Schema
 	// (GET /v2/identities/{identifier}/schemas/{id})
 	GetSchema(ctx context.Context, request GetSchemaRequestObject) (GetSchemaResponseObject, error)
+	// Cancel Publish Identity State
+	// (POST /v2/identities/{identifier}/state/cancel)
+	CancelPublishState(ctx context.Context, request CancelPublishStateRequestObject) (CancelPublishStateResponseObject, error)
 	// Publish Identity State
 	// (POST /v2/identities/{identifier}/state/publish)
 	PublishIdentityState(ctx context.Context, request PublishIdentityStateRequestObject) (PublishIdentityStateResponseObject, error)
 	// Retry Publish Identity State
 	// (POST /v2/identities/{identifier}/state/retry)
 	RetryPublishState(ctx context.Context, request RetryPublishStateRequestObject) (RetryPublishStateResponseObject, error)
+	// Speed Up Publish Identity State
+	// (POST /v2/identities/{identifier}/state/speed-up)
+	SpeedUpPublishState(ctx context.Context, request SpeedUpPublishStateRequestObject) (SpeedUpPublishStateResponseObject, error)
 	// Get Identity State Status
 	// (GET /v2/identities/{identifier}/state/status)
 	GetStateStatus(ctx context.Context, request GetStateStatusRequestObject) (GetStateStatusResponseObject, error)
@@ -4695,6 +7539,9 @@ type StrictServerInterface interface {
 	// Get QrCode from store
 	// (GET /v2/qr-store)
 	GetQrFromStore(ctx context.Context, request GetQrFromStoreRequestObject) (GetQrFromStoreResponseObject, error)
+	// Get Publishing Keys Status
+	// (GET /v2/status/publishing-keys)
+	GetPublishingKeysStatus(ctx context.Context, request GetPublishingKeysStatusRequestObject) (GetPublishingKeysStatusResponseObject, error)
 	// Get Supported Networks
 	// (GET /v2/supported-networks)
 	GetSupportedNetworks(ctx context.Context, request GetSupportedNetworksRequestObject) (GetSupportedNetworksResponseObject, error)
@@ -4732,6 +7579,58 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// ResolveDID operation middleware
+func (sh *strictHandler) ResolveDID(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request ResolveDIDRequestObject
+
+	request.Identifier = identifier
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResolveDID(ctx, request.(ResolveDIDRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResolveDID")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResolveDIDResponseObject); ok {
+		if err := validResponse.VisitResolveDIDResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetWebDIDDocument operation middleware
+func (sh *strictHandler) GetWebDIDDocument(w http.ResponseWriter, r *http.Request, id Id) {
+	var request GetWebDIDDocumentRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWebDIDDocument(ctx, request.(GetWebDIDDocumentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWebDIDDocument")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetWebDIDDocumentResponseObject); ok {
+		if err := validResponse.VisitGetWebDIDDocumentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Health operation middleware
 func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
 	var request HealthRequestObject
@@ -4908,9 +7807,11 @@ func (sh *strictHandler) GetAuthenticationConnection(w http.ResponseWriter, r *h
 }
 
 // GetIdentities operation middleware
-func (sh *strictHandler) GetIdentities(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) GetIdentities(w http.ResponseWriter, r *http.Request, params GetIdentitiesParams) {
 	var request GetIdentitiesRequestObject
 
+	request.Params = params
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetIdentities(ctx, request.(GetIdentitiesRequestObject))
 	}
@@ -4962,6 +7863,32 @@ func (sh *strictHandler) CreateIdentity(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// SearchIdentities operation middleware
+func (sh *strictHandler) SearchIdentities(w http.ResponseWriter, r *http.Request, params SearchIdentitiesParams) {
+	var request SearchIdentitiesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchIdentities(ctx, request.(SearchIdentitiesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchIdentities")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SearchIdentitiesResponseObject); ok {
+		if err := validResponse.VisitSearchIdentitiesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetIdentityDetails operation middleware
 func (sh *strictHandler) GetIdentityDetails(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
 	var request GetIdentityDetailsRequestObject
@@ -5021,6 +7948,66 @@ func (sh *strictHandler) UpdateIdentity(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// ArchiveIdentity operation middleware
+func (sh *strictHandler) ArchiveIdentity(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params ArchiveIdentityParams) {
+	var request ArchiveIdentityRequestObject
+
+	request.Identifier = identifier
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ArchiveIdentity(ctx, request.(ArchiveIdentityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ArchiveIdentity")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ArchiveIdentityResponseObject); ok {
+		if err := validResponse.VisitArchiveIdentityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateIdentityBackup operation middleware
+func (sh *strictHandler) CreateIdentityBackup(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreateIdentityBackupRequestObject
+
+	request.Identifier = identifier
+
+	var body CreateIdentityBackupJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateIdentityBackup(ctx, request.(CreateIdentityBackupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateIdentityBackup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateIdentityBackupResponseObject); ok {
+		if err := validResponse.VisitCreateIdentityBackupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetConnections operation middleware
 func (sh *strictHandler) GetConnections(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetConnectionsParams) {
 	var request GetConnectionsRequestObject
@@ -5218,10 +8205,11 @@ func (sh *strictHandler) GetCredentials(w http.ResponseWriter, r *http.Request,
 }
 
 // CreateCredential operation middleware
-func (sh *strictHandler) CreateCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+func (sh *strictHandler) CreateCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params CreateCredentialParams) {
 	var request CreateCredentialRequestObject
 
 	request.Identifier = identifier
+	request.Params = params
 
 	var body CreateCredentialJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -5231,18 +8219,138 @@ func (sh *strictHandler) CreateCredential(w http.ResponseWriter, r *http.Request
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateCredential(ctx, request.(CreateCredentialRequestObject))
+		return sh.ssi.CreateCredential(ctx, request.(CreateCredentialRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCredential")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateCredentialResponseObject); ok {
+		if err := validResponse.VisitCreateCredentialResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateCredentialsBatch operation middleware
+func (sh *strictHandler) CreateCredentialsBatch(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreateCredentialsBatchRequestObject
+
+	request.Identifier = identifier
+
+	var body CreateCredentialsBatchJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateCredentialsBatch(ctx, request.(CreateCredentialsBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCredentialsBatch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateCredentialsBatchResponseObject); ok {
+		if err := validResponse.VisitCreateCredentialsBatchResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ImportCredentials operation middleware
+func (sh *strictHandler) ImportCredentials(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request ImportCredentialsRequestObject
+
+	request.Identifier = identifier
+
+	if reader, err := r.MultipartReader(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+		return
+	} else {
+		request.Body = reader
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportCredentials(ctx, request.(ImportCredentialsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportCredentials")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ImportCredentialsResponseObject); ok {
+		if err := validResponse.VisitImportCredentialsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCredentialImport operation middleware
+func (sh *strictHandler) GetCredentialImport(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id) {
+	var request GetCredentialImportRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCredentialImport(ctx, request.(GetCredentialImportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCredentialImport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCredentialImportResponseObject); ok {
+		if err := validResponse.VisitGetCredentialImportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCredentialImportErrors operation middleware
+func (sh *strictHandler) GetCredentialImportErrors(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id) {
+	var request GetCredentialImportErrorsRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCredentialImportErrors(ctx, request.(GetCredentialImportErrorsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateCredential")
+		handler = middleware(handler, "GetCredentialImportErrors")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateCredentialResponseObject); ok {
-		if err := validResponse.VisitCreateCredentialResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetCredentialImportErrorsResponseObject); ok {
+		if err := validResponse.VisitGetCredentialImportErrorsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5278,10 +8386,11 @@ func (sh *strictHandler) GetLinks(w http.ResponseWriter, r *http.Request, identi
 }
 
 // CreateLink operation middleware
-func (sh *strictHandler) CreateLink(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+func (sh *strictHandler) CreateLink(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params CreateLinkParams) {
 	var request CreateLinkRequestObject
 
 	request.Identifier = identifier
+	request.Params = params
 
 	var body CreateLinkJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -5434,11 +8543,12 @@ func (sh *strictHandler) ActivateLink(w http.ResponseWriter, r *http.Request, id
 }
 
 // CreateLinkOffer operation middleware
-func (sh *strictHandler) CreateLinkOffer(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id) {
+func (sh *strictHandler) CreateLinkOffer(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id, params CreateLinkOfferParams) {
 	var request CreateLinkOfferRequestObject
 
 	request.Identifier = identifier
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.CreateLinkOffer(ctx, request.(CreateLinkOfferRequestObject))
@@ -5487,6 +8597,39 @@ func (sh *strictHandler) GetRevocationStatusV2(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// RevokeCredentials operation middleware
+func (sh *strictHandler) RevokeCredentials(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request RevokeCredentialsRequestObject
+
+	request.Identifier = identifier
+
+	var body RevokeCredentialsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeCredentials(ctx, request.(RevokeCredentialsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeCredentials")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeCredentialsResponseObject); ok {
+		if err := validResponse.VisitRevokeCredentialsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // RevokeCredential operation middleware
 func (sh *strictHandler) RevokeCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce) {
 	var request RevokeCredentialRequestObject
@@ -5514,6 +8657,67 @@ func (sh *strictHandler) RevokeCredential(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// GetStatusListCredential operation middleware
+func (sh *strictHandler) GetStatusListCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id Id, params GetStatusListCredentialParams) {
+	var request GetStatusListCredentialRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetStatusListCredential(ctx, request.(GetStatusListCredentialRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetStatusListCredential")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetStatusListCredentialResponseObject); ok {
+		if err := validResponse.VisitGetStatusListCredentialResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ValidateCredential operation middleware
+func (sh *strictHandler) ValidateCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request ValidateCredentialRequestObject
+
+	request.Identifier = identifier
+
+	var body ValidateCredentialJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ValidateCredential(ctx, request.(ValidateCredentialRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ValidateCredential")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ValidateCredentialResponseObject); ok {
+		if err := validResponse.VisitValidateCredentialResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // DeleteCredential operation middleware
 func (sh *strictHandler) DeleteCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
 	var request DeleteCredentialRequestObject
@@ -5542,11 +8746,12 @@ func (sh *strictHandler) DeleteCredential(w http.ResponseWriter, r *http.Request
 }
 
 // GetCredential operation middleware
-func (sh *strictHandler) GetCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
+func (sh *strictHandler) GetCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim, params GetCredentialParams) {
 	var request GetCredentialRequestObject
 
 	request.Identifier = identifier
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetCredential(ctx, request.(GetCredentialRequestObject))
@@ -5568,6 +8773,40 @@ func (sh *strictHandler) GetCredential(w http.ResponseWriter, r *http.Request, i
 	}
 }
 
+// UpdateCredential operation middleware
+func (sh *strictHandler) UpdateCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
+	var request UpdateCredentialRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
+
+	var body UpdateCredentialJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateCredential(ctx, request.(UpdateCredentialRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateCredential")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateCredentialResponseObject); ok {
+		if err := validResponse.VisitUpdateCredentialResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetCredentialOffer operation middleware
 func (sh *strictHandler) GetCredentialOffer(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim, params GetCredentialOfferParams) {
 	var request GetCredentialOfferRequestObject
@@ -5596,6 +8835,138 @@ func (sh *strictHandler) GetCredentialOffer(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// DeactivateIdentity operation middleware
+func (sh *strictHandler) DeactivateIdentity(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params DeactivateIdentityParams) {
+	var request DeactivateIdentityRequestObject
+
+	request.Identifier = identifier
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeactivateIdentity(ctx, request.(DeactivateIdentityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeactivateIdentity")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeactivateIdentityResponseObject); ok {
+		if err := validResponse.VisitDeactivateIdentityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetDIDDocument operation middleware
+func (sh *strictHandler) GetDIDDocument(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request GetDIDDocumentRequestObject
+
+	request.Identifier = identifier
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDIDDocument(ctx, request.(GetDIDDocumentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDIDDocument")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDIDDocumentResponseObject); ok {
+		if err := validResponse.VisitGetDIDDocumentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetIdentityKeys operation middleware
+func (sh *strictHandler) GetIdentityKeys(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request GetIdentityKeysRequestObject
+
+	request.Identifier = identifier
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetIdentityKeys(ctx, request.(GetIdentityKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetIdentityKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetIdentityKeysResponseObject); ok {
+		if err := validResponse.VisitGetIdentityKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateIdentityKey operation middleware
+func (sh *strictHandler) CreateIdentityKey(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreateIdentityKeyRequestObject
+
+	request.Identifier = identifier
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateIdentityKey(ctx, request.(CreateIdentityKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateIdentityKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateIdentityKeyResponseObject); ok {
+		if err := validResponse.VisitCreateIdentityKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RotateIdentityKey operation middleware
+func (sh *strictHandler) RotateIdentityKey(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params RotateIdentityKeyParams) {
+	var request RotateIdentityKeyRequestObject
+
+	request.Identifier = identifier
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RotateIdentityKey(ctx, request.(RotateIdentityKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RotateIdentityKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RotateIdentityKeyResponseObject); ok {
+		if err := validResponse.VisitRotateIdentityKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetSchemas operation middleware
 func (sh *strictHandler) GetSchemas(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetSchemasParams) {
 	var request GetSchemasRequestObject
@@ -5683,6 +9054,32 @@ func (sh *strictHandler) GetSchema(w http.ResponseWriter, r *http.Request, ident
 	}
 }
 
+// CancelPublishState operation middleware
+func (sh *strictHandler) CancelPublishState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CancelPublishStateRequestObject
+
+	request.Identifier = identifier
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelPublishState(ctx, request.(CancelPublishStateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelPublishState")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CancelPublishStateResponseObject); ok {
+		if err := validResponse.VisitCancelPublishStateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // PublishIdentityState operation middleware
 func (sh *strictHandler) PublishIdentityState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
 	var request PublishIdentityStateRequestObject
@@ -5735,6 +9132,32 @@ func (sh *strictHandler) RetryPublishState(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// SpeedUpPublishState operation middleware
+func (sh *strictHandler) SpeedUpPublishState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request SpeedUpPublishStateRequestObject
+
+	request.Identifier = identifier
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SpeedUpPublishState(ctx, request.(SpeedUpPublishStateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SpeedUpPublishState")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SpeedUpPublishStateResponseObject); ok {
+		if err := validResponse.VisitSpeedUpPublishStateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetStateStatus operation middleware
 func (sh *strictHandler) GetStateStatus(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
 	var request GetStateStatusRequestObject
@@ -5814,6 +9237,30 @@ func (sh *strictHandler) GetQrFromStore(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// GetPublishingKeysStatus operation middleware
+func (sh *strictHandler) GetPublishingKeysStatus(w http.ResponseWriter, r *http.Request) {
+	var request GetPublishingKeysStatusRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPublishingKeysStatus(ctx, request.(GetPublishingKeysStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPublishingKeysStatus")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPublishingKeysStatusResponseObject); ok {
+		if err := validResponse.VisitGetPublishingKeysStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetSupportedNetworks operation middleware
 func (sh *strictHandler) GetSupportedNetworks(w http.ResponseWriter, r *http.Request) {
 	var request GetSupportedNetworksRequestObject
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+const (
+	maxCredentialImportFileSize  = 10 << 20 // maxCredentialImportFileSize is the max size in bytes of a file uploaded to import credentials
+	maxCredentialImportFieldSize = 1 << 10  // maxCredentialImportFieldSize is the max size in bytes of the other fields of the form
+)
+
+var errCredentialImportFileTooLarge = fmt.Errorf("file is larger than %d bytes", maxCredentialImportFileSize)
+
+// ImportCredentials uploads a file with credential subjects and starts issuing a credential for each one of them
+func (s *Server) ImportCredentials(ctx context.Context, request ImportCredentialsRequestObject) (ImportCredentialsResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		return ImportCredentials400JSONResponse{N400JSONResponse{Message: "invalid issuer did"}}, nil
+	}
+
+	req, err := s.toCredentialImportRequest(ctx, did, request.Body)
+	if err != nil {
+		log.Warn(ctx, "import credentials: invalid request", "err", err)
+		return ImportCredentials400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	job, err := s.credentialImportService.Import(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSchemaNotFound):
+			return ImportCredentials404JSONResponse{N404JSONResponse{Message: "schema not found"}}, nil
+		case errors.Is(err, services.ErrInvalidImportFile),
+			errors.Is(err, services.ErrEmptyImportFile),
+			errors.Is(err, services.ErrUnsupportedImportFormat):
+			return ImportCredentials400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		case errors.Is(err, services.ErrLoadingSchema), errors.Is(err, services.ErrProcessSchema):
+			return ImportCredentials422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "import credentials", "err", err)
+		return ImportCredentials500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return ImportCredentials202JSONResponse(credentialImportResponse(job)), nil
+}
+
+// GetCredentialImport returns the status of a credential import
+func (s *Server) GetCredentialImport(ctx context.Context, request GetCredentialImportRequestObject) (GetCredentialImportResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		return GetCredentialImport400JSONResponse{N400JSONResponse{Message: "invalid issuer did"}}, nil
+	}
+	job, err := s.credentialImportService.GetByID(ctx, *did, request.Id)
+	if err != nil {
+		if errors.Is(err, services.ErrCredentialImportNotFound) {
+			return GetCredentialImport404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "loading credential import", "err", err, "id", request.Id)
+		return GetCredentialImport500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetCredentialImport200JSONResponse(credentialImportResponse(job)), nil
+}
+
+// GetCredentialImportErrors returns a csv report with the rows of a credential import that were rejected
+func (s *Server) GetCredentialImportErrors(ctx context.Context, request GetCredentialImportErrorsRequestObject) (GetCredentialImportErrorsResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		return GetCredentialImportErrors400JSONResponse{N400JSONResponse{Message: "invalid issuer did"}}, nil
+	}
+	job, err := s.credentialImportService.GetByID(ctx, *did, request.Id)
+	if err != nil {
+		if errors.Is(err, services.ErrCredentialImportNotFound) {
+			return GetCredentialImportErrors404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "loading credential import", "err", err, "id", request.Id)
+		return GetCredentialImportErrors500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	records := make([][]string, 0, len(job.Rejected)+1)
+	records = append(records, []string{"row", "subject", "error"})
+	for _, rejection := range job.Rejected {
+		records = append(records, []string{strconv.Itoa(rejection.Row), rejection.Subject, rejection.Error})
+	}
+	if err := w.WriteAll(records); err != nil {
+		log.Error(ctx, "writing credential import errors report", "err", err, "id", request.Id)
+		return GetCredentialImportErrors500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetCredentialImportErrors200TextcsvResponse{Body: &buf, ContentLength: int64(buf.Len())}, nil
+}
+
+// toCredentialImportRequest reads the multipart form of an import request and validates it.
+// The returned errors are meant to be sent back to the client.
+func (s *Server) toCredentialImportRequest(ctx context.Context, did *w3c.DID, form *multipart.Reader) (*ports.CredentialImportRequest, error) {
+	if form == nil {
+		return nil, errors.New("missing multipart form")
+	}
+
+	var schemaID, format, fileName, expiration, statusType string
+	var content []byte
+	for {
+		part, err := form.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading multipart form: %w", err)
+		}
+		if part.FormName() == "file" {
+			fileName = part.FileName()
+			content, err = io.ReadAll(io.LimitReader(part, maxCredentialImportFileSize+1))
+			if err != nil {
+				return nil, fmt.Errorf("reading file: %w", err)
+			}
+			if len(content) > maxCredentialImportFileSize {
+				return nil, errCredentialImportFileTooLarge
+			}
+			continue
+		}
+		value, err := io.ReadAll(io.LimitReader(part, maxCredentialImportFieldSize))
+		if err != nil {
+			return nil, fmt.Errorf("reading field %s: %w", part.FormName(), err)
+		}
+		switch part.FormName() {
+		case "schemaID":
+			schemaID = string(value)
+		case "format":
+			format = string(value)
+		case "expiration":
+			expiration = string(value)
+		case "credentialStatusType":
+			statusType = string(value)
+		}
+	}
+
+	if content == nil {
+		return nil, errors.New("missing file")
+	}
+	id, err := uuid.Parse(schemaID)
+	if err != nil {
+		return nil, errors.New("invalid schemaID")
+	}
+
+	req := &ports.CredentialImportRequest{
+		DID:      did,
+		SchemaID: id,
+		Format:   credentialImportFormat(format, fileName),
+		Content:  content,
+		Proofs: ports.ClaimRequestProofs{
+			BJJSignatureProof2021:      true,
+			Iden3SparseMerkleTreeProof: !domain.IsWebDID(*did),
+		},
+	}
+	if expiration != "" {
+		ts, err := strconv.ParseInt(expiration, 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid expiration")
+		}
+		req.Expiration = common.ToPointer(time.Unix(ts, 0))
+	}
+
+	if statusType == "" && domain.IsWebDID(*did) {
+		statusType = string(domain.BitstringStatusListEntry)
+	}
+	credentialStatusType, err := validateCredentialStatusType(&statusType)
+	if err != nil {
+		return nil, err
+	}
+	rhsMode, err := s.rhsMode(ctx, did)
+	if err != nil {
+		return nil, err
+	}
+	if !s.networkResolver.IsCredentialStatusTypeSupported(rhsMode, *credentialStatusType) {
+		return nil, fmt.Errorf("Credential Status Type '%s' is not supported by the issuer", *credentialStatusType)
+	}
+	req.CredentialStatusType = *credentialStatusType
+
+	return req, nil
+}
+
+func credentialImportResponse(job *domain.CredentialImport) CredentialImport {
+	var importError *string
+	if job.Error != "" {
+		importError = common.ToPointer(job.Error)
+	}
+	return CredentialImport{
+		Id:         job.ID.String(),
+		SchemaID:   job.SchemaID.String(),
+		Format:     string(job.Format),
+		Status:     CredentialImportStatus(job.Status),
+		Total:      job.Total,
+		Processed:  job.Processed,
+		Issued:     job.Issued,
+		Rejected:   len(job.Rejected),
+		Progress:   job.Progress(),
+		Error:      importError,
+		CreatedAt:  TimeUTC(job.CreatedAt),
+		ModifiedAt: TimeUTC(job.ModifiedAt),
+	}
+}
+
+// credentialImportFormat returns the format field of the form or, if empty, guesses it from the file name
+func credentialImportFormat(format, fileName string) domain.CredentialImportFormat {
+	if format != "" {
+		return domain.CredentialImportFormat(strings.ToLower(format))
+	}
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return domain.CredentialImportFormatCSV
+	case ".ndjson", ".jsonl":
+		return domain.CredentialImportFormatNDJSON
+	}
+	return ""
+}
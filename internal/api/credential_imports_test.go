@@ -0,0 +1,245 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestServer_ImportCredentials(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	iden, err := server.Services.identity.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+	issuerDID, err := w3c.ParseDID(iden.Identifier)
+	require.NoError(t, err)
+	handler := getHandler(ctx, server)
+
+	type expected struct {
+		httpCode int
+		errorMsg string
+	}
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		fields   map[string]string
+		fileName string
+		file     string
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name: "Not authorized",
+			auth: authWrong,
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name:   "Missing file",
+			auth:   authOk,
+			fields: map[string]string{"schemaID": uuid.NewString()},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				errorMsg: "missing file",
+			},
+		},
+		{
+			name:     "Invalid schema id",
+			auth:     authOk,
+			fields:   map[string]string{"schemaID": "wrong"},
+			fileName: "subjects.csv",
+			file:     "id,birthday\n",
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				errorMsg: "invalid schemaID",
+			},
+		},
+		{
+			name:     "Non existing schema",
+			auth:     authOk,
+			fields:   map[string]string{"schemaID": uuid.NewString()},
+			fileName: "subjects.csv",
+			file:     "id,birthday\n",
+			expected: expected{
+				httpCode: http.StatusNotFound,
+				errorMsg: "schema not found",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			for k, v := range tc.fields {
+				require.NoError(t, form.WriteField(k, v))
+			}
+			if tc.fileName != "" {
+				part, err := form.CreateFormFile("file", tc.fileName)
+				require.NoError(t, err)
+				_, err = part.Write([]byte(tc.file))
+				require.NoError(t, err)
+			}
+			require.NoError(t, form.Close())
+
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/v2/identities/%s/credentials/import", issuerDID), &body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", form.FormDataContentType())
+			req.SetBasicAuth(tc.auth())
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			switch tc.expected.httpCode {
+			case http.StatusBadRequest:
+				var response ImportCredentials400JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.errorMsg, response.Message)
+			case http.StatusNotFound:
+				var response ImportCredentials404JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.errorMsg, response.Message)
+			}
+		})
+	}
+}
+
+func TestServer_GetCredentialImport(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	iden, err := server.Services.identity.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+	issuerDID, err := w3c.ParseDID(iden.Identifier)
+	require.NoError(t, err)
+	handler := getHandler(ctx, server)
+
+	schema := &domain.Schema{
+		ID:        uuid.New(),
+		IssuerDID: *issuerDID,
+		URL:       "https://domain.org/this/is/an/url",
+		Type:      "schemaType",
+		CreatedAt: time.Now(),
+	}
+	schema.Hash = common.CreateSchemaHash([]byte(schema.URL + "#" + schema.Type))
+	repositories.NewFixture(storage).CreateSchema(t, ctx, schema)
+
+	job := domain.NewCredentialImport(*issuerDID, schema.ID, domain.CredentialImportFormatCSV, 4)
+	job.Status = domain.CredentialImportFinished
+	job.Processed, job.Issued = 4, 3
+	job.Reject(2, "did:polygonid:polygon:mumbai:2qFDziX3k3h7To2jDJbQiXFtcozbgSNNasbzNgjxc3", fmt.Errorf("invalid credential subject"))
+	require.NoError(t, repositories.NewCredentialImport().Save(ctx, storage.Pgx, job))
+
+	type expected struct {
+		httpCode int
+		response *CredentialImport
+		report   string
+	}
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		id       uuid.UUID
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name: "Not authorized",
+			auth: authWrong,
+			id:   job.ID,
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "Non existing import",
+			auth: authOk,
+			id:   uuid.New(),
+			expected: expected{
+				httpCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "Happy path",
+			auth: authOk,
+			id:   job.ID,
+			expected: expected{
+				httpCode: http.StatusOK,
+				response: &CredentialImport{
+					Id:        job.ID.String(),
+					SchemaID:  schema.ID.String(),
+					Format:    "csv",
+					Status:    "finished",
+					Total:     4,
+					Processed: 4,
+					Issued:    3,
+					Rejected:  1,
+					Progress:  100,
+				},
+				report: "row,subject,error\n2,did:polygonid:polygon:mumbai:2qFDziX3k3h7To2jDJbQiXFtcozbgSNNasbzNgjxc3,invalid credential subject\n",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v2/identities/%s/credentials/import/%s", issuerDID, tc.id), nil)
+			require.NoError(t, err)
+			req.SetBasicAuth(tc.auth())
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			if tc.expected.httpCode != http.StatusOK {
+				return
+			}
+			var response CredentialImport
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tc.expected.response.Id, response.Id)
+			assert.Equal(t, tc.expected.response.SchemaID, response.SchemaID)
+			assert.Equal(t, tc.expected.response.Format, response.Format)
+			assert.Equal(t, tc.expected.response.Status, response.Status)
+			assert.Equal(t, tc.expected.response.Total, response.Total)
+			assert.Equal(t, tc.expected.response.Processed, response.Processed)
+			assert.Equal(t, tc.expected.response.Issued, response.Issued)
+			assert.Equal(t, tc.expected.response.Rejected, response.Rejected)
+			assert.Equal(t, tc.expected.response.Progress, response.Progress)
+
+			rr = httptest.NewRecorder()
+			req, err = http.NewRequest(http.MethodGet, fmt.Sprintf("/v2/identities/%s/credentials/import/%s/errors", issuerDID, tc.id), nil)
+			require.NoError(t, err)
+			req.SetBasicAuth(tc.auth())
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+			assert.Equal(t, tc.expected.report, rr.Body.String())
+		})
+	}
+}
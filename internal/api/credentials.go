@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -17,6 +18,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/core/services"
 	"github.com/polygonid/sh-id-platform/internal/log"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/internal/revocationstatus"
 	"github.com/polygonid/sh-id-platform/internal/schema"
 )
 
@@ -44,80 +46,114 @@ func (s *Server) CreateCredential(ctx context.Context, request CreateCredentialR
 	if err != nil {
 		return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
-	var expiration *time.Time
-	if request.Body.Expiration != nil {
-		expiration = common.ToPointer(time.Unix(*request.Body.Expiration, 0))
+	rhsMode, err := s.rhsMode(ctx, did)
+	if err != nil {
+		return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
 
-	claimRequestProofs := ports.ClaimRequestProofs{}
-	if request.Body.Proofs == nil {
-		claimRequestProofs.BJJSignatureProof2021 = true
-		claimRequestProofs.Iden3SparseMerkleTreeProof = true
-	} else {
-		for _, proof := range *request.Body.Proofs {
-			if string(proof) == string(verifiable.BJJSignatureProofType) {
-				claimRequestProofs.BJJSignatureProof2021 = true
-				continue
-			}
-			if string(proof) == string(verifiable.Iden3SparseMerkleTreeProofType) {
-				claimRequestProofs.Iden3SparseMerkleTreeProof = true
-				continue
-			}
-			return CreateCredential400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("unsupported proof type: %s", proof)}}, nil
-		}
+	req, err := s.toCreateClaimRequest(ctx, did, rhsMode, *request.Body)
+	if err != nil {
+		return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
 
-	var credentialStatusType *verifiable.CredentialStatusType
-	credentialStatusType, err = validateStatusType((*string)(request.Body.CredentialStatusType))
+	raw, err := s.idempotencyService.Do(ctx, *did, domain.IdempotentOperationCreateCredential, idempotencyKey(request.Params.IdempotencyKey), request.Body, func(ctx context.Context) (any, error) {
+		resp, err := s.claimService.Save(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return CreateCredential201JSONResponse{Id: resp.ID.String()}, nil
+	})
 	if err != nil {
-		return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		if isIdempotencyConflict(err) {
+			return CreateCredential409JSONResponse{N409JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrLoadingSchema) {
+			return CreateCredential422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
+		}
+		if isCreateCredentialBadRequest(err) {
+			return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		return CreateCredential500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 	}
+	var response CreateCredential201JSONResponse
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return CreateCredential500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return response, nil
+}
 
-	resolverPrefix, err := common.ResolverPrefix(did)
+// ValidateCredential is the controller to validate a credential request without issuing the credential
+func (s *Server) ValidateCredential(ctx context.Context, request ValidateCredentialRequestObject) (ValidateCredentialResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
 	if err != nil {
-		return CreateCredential400JSONResponse{N400JSONResponse{Message: "error parsing did"}}, nil
+		return ValidateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	rhsMode, err := s.rhsMode(ctx, did)
+	if err != nil {
+		return ValidateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
 
-	rhsSettings, err := s.networkResolver.GetRhsSettings(ctx, resolverPrefix)
+	req, err := s.toCreateClaimRequest(ctx, did, rhsMode, *request.Body)
 	if err != nil {
-		return CreateCredential400JSONResponse{N400JSONResponse{Message: "error getting reverse hash service settings"}}, nil
+		return ValidateCredential200JSONResponse{Valid: false, Errors: []string{err.Error()}}, nil
 	}
 
-	if !s.networkResolver.IsCredentialStatusTypeSupported(rhsSettings.Mode, *credentialStatusType) {
-		log.Warn(ctx, "unsupported credential status type", "req", request)
-		return CreateCredential400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("Credential Status Type '%s' is not supported by the issuer", *credentialStatusType)}}, nil
+	validation, err := s.claimService.ValidateCredential(ctx, req)
+	if err != nil {
+		log.Error(ctx, "validating credential", "err", err, "req", request)
+		return ValidateCredential500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 	}
+	return toValidateCredentialResponse(validation), nil
+}
 
-	req := ports.NewCreateClaimRequest(did, request.Body.ClaimID, request.Body.CredentialSchema, request.Body.CredentialSubject, expiration, request.Body.Type, request.Body.Version, request.Body.SubjectPosition, request.Body.MerklizedRootPosition, claimRequestProofs, nil, false, *credentialStatusType, toVerifiableRefreshService(request.Body.RefreshService), request.Body.RevNonce,
-		toVerifiableDisplayMethod(request.Body.DisplayMethod))
+// CreateCredentialsBatch is the batch creation credential controller. It creates all the credentials in the request
+// or none of them, and returns the result of each one.
+func (s *Server) CreateCredentialsBatch(ctx context.Context, request CreateCredentialsBatchRequestObject) (CreateCredentialsBatchResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		return CreateCredentialsBatch400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	if len(request.Body.Credentials) == 0 {
+		return CreateCredentialsBatch400JSONResponse{N400JSONResponse{Message: services.ErrEmptyBatch.Error()}}, nil
+	}
 
-	resp, err := s.claimService.Save(ctx, req)
+	rhsMode, err := s.rhsMode(ctx, did)
 	if err != nil {
-		if errors.Is(err, services.ErrLoadingSchema) {
-			return CreateCredential422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
+		return CreateCredentialsBatch400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	reqs := make([]*ports.CreateClaimRequest, len(request.Body.Credentials))
+	items := make([]CreateCredentialsBatchItem, len(request.Body.Credentials))
+	invalid := false
+	for i, credential := range request.Body.Credentials {
+		items[i].Index = i
+		reqs[i], err = s.toCreateClaimRequest(ctx, did, rhsMode, credential)
+		if err != nil {
+			items[i].Error, invalid = common.ToPointer(err.Error()), true
 		}
-		errs := []error{
-			services.ErrJSONLdContext,
-			services.ErrProcessSchema,
-			services.ErrMalformedURL,
-			services.ErrParseClaim,
-			services.ErrInvalidCredentialSubject,
-			services.ErrAssigningMTPProof,
-			services.ErrUnsupportedRefreshServiceType,
-			services.ErrRefreshServiceLacksExpirationTime,
-			services.ErrRefreshServiceLacksURL,
-			services.ErrDisplayMethodLacksURL,
-			services.ErrUnsupportedDisplayMethodType,
-			services.ErrWrongCredentialSubjectID,
+	}
+	if invalid {
+		return CreateCredentialsBatch422JSONResponse{Items: items}, nil
+	}
+
+	results, err := s.claimService.SaveBatch(ctx, reqs)
+	if err != nil {
+		if !errors.Is(err, services.ErrInvalidBatch) {
+			log.Error(ctx, "creating credentials batch", "err", err, "issuer", request.Identifier)
+			return CreateCredentialsBatch500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 		}
-		for _, e := range errs {
-			if errors.Is(err, e) {
-				return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		for i, result := range results {
+			if result.Err != nil {
+				items[i].Error = common.ToPointer(result.Err.Error())
 			}
 		}
-		return CreateCredential500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		return CreateCredentialsBatch422JSONResponse{Items: items}, nil
+	}
+
+	for i, result := range results {
+		items[i].Id = common.ToPointer(result.Claim.ID.String())
 	}
-	return CreateCredential201JSONResponse{Id: resp.ID.String()}, nil
+	return CreateCredentialsBatch201JSONResponse{Items: items}, nil
 }
 
 // RevokeCredential is the revocation claim controller
@@ -142,6 +178,52 @@ func (s *Server) RevokeCredential(ctx context.Context, request RevokeCredentialR
 	}, nil
 }
 
+// RevokeCredentials is the controller to revoke all the credentials that match a filter
+func (s *Server) RevokeCredentials(ctx context.Context, request RevokeCredentialsRequestObject) (RevokeCredentialsResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		log.Warn(ctx, "revoke credentials invalid did", "err", err, "req", request)
+		return RevokeCredentials400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	filter, err := getRevokeCredentialsFilter(ctx, request.Body)
+	if err != nil {
+		return RevokeCredentials400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	dryRun := request.Body.DryRun == nil || *request.Body.DryRun
+	description := ""
+	if request.Body.Description != nil {
+		description = *request.Body.Description
+	}
+	credentials, err := s.claimService.RevokeByFilter(ctx, *did, filter, description, dryRun)
+	if err != nil {
+		if errors.Is(err, services.ErrEmptyRevocationFilter) {
+			return RevokeCredentials400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "revoking credentials", "err", err, "req", request)
+		return RevokeCredentials500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	response := RevokeCredentialsResponse{
+		DryRun:      dryRun,
+		Total:       len(credentials),
+		Credentials: make([]RevokedCredential, len(credentials)),
+	}
+	for i, credential := range credentials {
+		response.Credentials[i] = RevokedCredential{
+			Id:                credential.ID,
+			RevNonce:          uint64(credential.RevNonce),
+			SchemaType:        credential.SchemaType,
+			CredentialSubject: credential.OtherIdentifier,
+		}
+	}
+	if dryRun {
+		return RevokeCredentials200JSONResponse(response), nil
+	}
+	return RevokeCredentials202JSONResponse(response), nil
+}
+
 // GetRevocationStatus is the controller to get revocation status
 func (s *Server) GetRevocationStatus(ctx context.Context, request GetRevocationStatusRequestObject) (GetRevocationStatusResponseObject, error) {
 	issuerDID, err := w3c.ParseDID(request.Identifier)
@@ -219,6 +301,9 @@ func (s *Server) GetCredentials(ctx context.Context, request GetCredentialsReque
 
 	credentials, total, err := s.claimService.GetAll(ctx, *did, filter)
 	if err != nil {
+		if errors.Is(err, ports.ErrInvalidAttributeQuery) {
+			return GetCredentials400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
 		log.Error(ctx, "loading credentials", "err", err, "req", request)
 		return GetCredentials500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 	}
@@ -274,6 +359,10 @@ func (s *Server) GetCredential(ctx context.Context, request GetCredentialRequest
 		return GetCredential500JSONResponse{N500JSONResponse{err.Error()}}, nil
 	}
 
+	if request.Params.Format != nil && *request.Params.Format != GetCredentialParamsFormatW3c {
+		return s.exportCredential(ctx, claim, ports.CredentialExportFormat(*request.Params.Format))
+	}
+
 	w3c, err := schema.FromClaimModelToW3CCredential(*claim)
 	if err != nil {
 		return GetCredential500JSONResponse{N500JSONResponse{"invalid claim format"}}, nil
@@ -282,6 +371,60 @@ func (s *Server) GetCredential(ctx context.Context, request GetCredentialRequest
 	return GetCredential200JSONResponse(toGetCredential200Response(w3c, claim)), nil
 }
 
+func (s *Server) exportCredential(ctx context.Context, claim *domain.Claim, format ports.CredentialExportFormat) (GetCredentialResponseObject, error) {
+	token, err := s.credentialExportService.Export(ctx, claim, format)
+	if err != nil {
+		if errors.Is(err, services.ErrIssuerETHKeyNotFound) || errors.Is(err, services.ErrUnsupportedExportFormat) {
+			return GetCredential400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "exporting credential", "err", err, "id", claim.ID, "format", format)
+		return GetCredential500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	if format == ports.CredentialExportFormatSDJWTVC {
+		return GetCredential200ApplicationvcSdJwtResponse{Body: strings.NewReader(token), ContentLength: int64(len(token))}, nil
+	}
+	return GetCredential200ApplicationjwtResponse{Body: strings.NewReader(token), ContentLength: int64(len(token))}, nil
+}
+
+// UpdateCredential revokes an updatable credential and issues a replacement with the new credential subject attributes
+func (s *Server) UpdateCredential(ctx context.Context, request UpdateCredentialRequestObject) (UpdateCredentialResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		return UpdateCredential400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+	clID, err := uuid.Parse(request.Id)
+	if err != nil {
+		return UpdateCredential400JSONResponse{N400JSONResponse{"invalid claim id"}}, nil
+	}
+
+	req := &ports.UpdateClaimRequest{
+		DID:               did,
+		ClaimID:           clID,
+		CredentialSubject: request.Body.CredentialSubject,
+	}
+	if request.Body.Expiration != nil {
+		req.Expiration = common.ToPointer(time.Unix(*request.Body.Expiration, 0))
+	}
+
+	claim, err := s.claimService.Update(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCredentialNotFound):
+			return UpdateCredential404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		case errors.Is(err, services.ErrLoadingSchema):
+			return UpdateCredential422JSONResponse{N422JSONResponse{err.Error()}}, nil
+		case errors.Is(err, services.ErrCredentialNotUpdatable),
+			errors.Is(err, services.ErrCredentialRevoked),
+			errors.Is(err, services.ErrCredentialSubjectIDChanged),
+			isCreateCredentialBadRequest(err):
+			return UpdateCredential400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "updating credential", "err", err, "id", clID)
+		return UpdateCredential500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return UpdateCredential201JSONResponse{Id: claim.ID.String()}, nil
+}
+
 // GetCredentialOffer returns a GetCredentialQrCodeResponseObject universalLink, raw or deeplink type based on query parameter `type`
 // scan it with privado.id wallet to accept the claim
 func (s *Server) GetCredentialOffer(ctx context.Context, request GetCredentialOfferRequestObject) (GetCredentialOfferResponseObject, error) {
@@ -313,6 +456,12 @@ func (s *Server) GetCredentialOffer(ctx context.Context, request GetCredentialOf
 		}
 		return GetCredentialOffer500JSONResponse{N500JSONResponse{err.Error()}}, nil
 	}
+	issuer, err := s.issuerDescription(ctx, *did)
+	if err != nil {
+		log.Error(ctx, "get credential offer. Getting issuer profile", "err", err, "did", did)
+		return GetCredentialOffer500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
 	qrContent, qrType := resp.UniversalLink, GetCredentialOfferParamsTypeUniversalLink
 	if request.Params.Type != nil {
 		qrType = *request.Params.Type
@@ -327,11 +476,109 @@ func (s *Server) GetCredentialOffer(ctx context.Context, request GetCredentialOf
 	}
 
 	return GetCredentialOffer200JSONResponse{
+		Issuer:        issuer,
 		UniversalLink: qrContent,
 		SchemaType:    resp.SchemaType,
 	}, nil
 }
 
+// rhsMode returns the reverse hash service mode configured for the network of the given identity
+// did:web identities have no network, so they have no mode and only support status lists.
+func (s *Server) rhsMode(ctx context.Context, did *w3c.DID) (string, error) {
+	if domain.IsWebDID(*did) {
+		return "", nil
+	}
+	resolverPrefix, err := common.ResolverPrefix(did)
+	if err != nil {
+		return "", errors.New("error parsing did")
+	}
+
+	rhsSettings, err := s.networkResolver.GetRhsSettings(ctx, resolverPrefix)
+	if err != nil {
+		return "", errors.New("error getting reverse hash service settings")
+	}
+	return rhsSettings.Mode, nil
+}
+
+// toCreateClaimRequest validates the api request to create a credential and converts it to a ports.CreateClaimRequest.
+// The returned errors are meant to be sent back to the client.
+func (s *Server) toCreateClaimRequest(ctx context.Context, did *w3c.DID, rhsMode string, body CreateCredentialRequest) (*ports.CreateClaimRequest, error) {
+	var expiration *time.Time
+	if body.Expiration != nil {
+		expiration = common.ToPointer(time.Unix(*body.Expiration, 0))
+	}
+
+	// did:web issuers have no state, so by default their credentials only have a signature proof and a status list entry
+	isWebDID := domain.IsWebDID(*did)
+	claimRequestProofs := ports.ClaimRequestProofs{}
+	if body.Proofs == nil {
+		claimRequestProofs.BJJSignatureProof2021 = true
+		claimRequestProofs.Iden3SparseMerkleTreeProof = !isWebDID
+	} else {
+		for _, proof := range *body.Proofs {
+			if string(proof) == string(verifiable.BJJSignatureProofType) {
+				claimRequestProofs.BJJSignatureProof2021 = true
+				continue
+			}
+			if string(proof) == string(verifiable.Iden3SparseMerkleTreeProofType) {
+				claimRequestProofs.Iden3SparseMerkleTreeProof = true
+				continue
+			}
+			return nil, fmt.Errorf("unsupported proof type: %s", proof)
+		}
+	}
+
+	credentialStatusTypeRequest := (*string)(body.CredentialStatusType)
+	if isWebDID && (credentialStatusTypeRequest == nil || *credentialStatusTypeRequest == "") {
+		credentialStatusTypeRequest = common.ToPointer(string(domain.BitstringStatusListEntry))
+	}
+	credentialStatusType, err := validateCredentialStatusType(credentialStatusTypeRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.networkResolver.IsCredentialStatusTypeSupported(rhsMode, *credentialStatusType) {
+		log.Warn(ctx, "unsupported credential status type", "req", body)
+		return nil, fmt.Errorf("Credential Status Type '%s' is not supported by the issuer", *credentialStatusType)
+	}
+
+	req := ports.NewCreateClaimRequest(did, body.ClaimID, body.CredentialSchema, body.CredentialSubject, expiration, body.Type, body.Version, body.SubjectPosition, body.MerklizedRootPosition, claimRequestProofs, nil, false, *credentialStatusType, toVerifiableRefreshService(body.RefreshService), body.RevNonce,
+		toVerifiableDisplayMethod(body.DisplayMethod))
+	if body.Updatable != nil {
+		req.Updatable = *body.Updatable
+	}
+	req.AuthClaimID = body.AuthCredentialID
+	return req, nil
+}
+
+// isCreateCredentialBadRequest tells whether the error returned when creating a credential is caused by a wrong request
+func isCreateCredentialBadRequest(err error) bool {
+	errs := []error{
+		services.ErrJSONLdContext,
+		services.ErrProcessSchema,
+		services.ErrMalformedURL,
+		services.ErrParseClaim,
+		services.ErrInvalidCredentialSubject,
+		services.ErrAssigningMTPProof,
+		services.ErrUnsupportedRefreshServiceType,
+		services.ErrRefreshServiceLacksExpirationTime,
+		services.ErrRefreshServiceLacksURL,
+		services.ErrDisplayMethodLacksURL,
+		services.ErrUnsupportedDisplayMethodType,
+		services.ErrWrongCredentialSubjectID,
+		services.ErrInvalidAuthClaim,
+		services.ErrIdentityDeactivated,
+		services.ErrWebIdentityNotSupported,
+		revocationstatus.ErrWebDIDCredentialStatus,
+	}
+	for _, e := range errs {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
+}
+
 func toVerifiableRefreshService(s *RefreshService) *verifiable.RefreshService {
 	if s == nil {
 		return nil
@@ -353,13 +600,65 @@ func toVerifiableDisplayMethod(s *DisplayMethod) *verifiable.DisplayMethod {
 }
 
 func toGetCredential200Response(w3cCredential *verifiable.W3CCredential, cred *domain.Claim) Credential {
-	return Credential{
+	resp := Credential{
 		Vc:         *w3cCredential,
 		Id:         cred.ID.String(),
 		Revoked:    cred.Revoked,
 		SchemaHash: cred.SchemaHash,
 		ProofTypes: getProofs(cred),
 	}
+	if cred.PreviousID != nil {
+		resp.PreviousID = common.ToPointer(cred.PreviousID.String())
+	}
+	return resp
+}
+
+func toValidateCredentialResponse(validation *ports.CredentialValidation) ValidateCredential200JSONResponse {
+	resp := ValidateCredential200JSONResponse{
+		Valid:  validation.Valid(),
+		Errors: make([]string, len(validation.Errors)),
+	}
+	for i, err := range validation.Errors {
+		resp.Errors[i] = err.Error()
+	}
+	if !validation.Valid() {
+		return resp
+	}
+	resp.Credential = validation.Credential
+	resp.SchemaHash = common.ToPointer(validation.SchemaHash)
+	resp.CoreClaim = &CoreClaimSlots{
+		Index: validation.IndexSlots[:],
+		Value: validation.ValueSlots[:],
+	}
+	return resp
+}
+
+func getRevokeCredentialsFilter(ctx context.Context, req *RevokeCredentialsRequest) (*ports.ClaimsFilter, error) {
+	filter := &ports.ClaimsFilter{
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+	}
+	if req.SchemaType != nil {
+		filter.SchemaType = *req.SchemaType
+	}
+	if req.CredentialSubject != nil {
+		did, err := w3c.ParseDID(*req.CredentialSubject)
+		if err != nil {
+			log.Warn(ctx, "revoke credentials. Parsing did", "err", err, "did", *req.CredentialSubject)
+			return nil, errors.New("cannot parse credentialSubject: wrong format")
+		}
+		filter.Subject = did.String()
+	}
+	if req.QueryField != nil && *req.QueryField != "" {
+		if req.QueryValue == nil {
+			return nil, errors.New("queryValue is required when queryField is set")
+		}
+		filter.QueryField, filter.QueryFieldValue = *req.QueryField, *req.QueryValue
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return nil, errors.New("createdAfter must be before createdBefore")
+	}
+	return filter, nil
 }
 
 func getCredentialsFilter(ctx context.Context, req GetCredentialsRequestObject) (*ports.ClaimsFilter, error) {
@@ -388,6 +687,20 @@ func getCredentialsFilter(ctx context.Context, req GetCredentialsRequestObject)
 		filter.FTSQuery = *req.Params.Query
 	}
 
+	if req.Params.SchemaURL != nil {
+		filter.SchemaURL = *req.Params.SchemaURL
+	}
+	if req.Params.Attributes != nil {
+		if filter.SchemaURL == "" {
+			return nil, errors.New("schemaURL param is required to query attributes")
+		}
+		conditions, err := ports.ParseAttributeQuery(*req.Params.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		filter.Attributes = conditions
+	}
+
 	filter.MaxResults = 50
 	if req.Params.MaxResults != nil {
 		if *req.Params.MaxResults <= 0 {
@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core/v2"
 	"github.com/iden3/go-iden3-core/v2/w3c"
@@ -158,6 +161,144 @@ func TestServer_RevokeClaim(t *testing.T) {
 	}
 }
 
+func TestServer_RevokeCredentials(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+		schemaType = "KYCAgeCredential"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	iden, err := server.Services.identity.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+	did := iden.Identifier
+
+	fixture := repositories.NewFixture(storage)
+	for i, schema := range []string{schemaType, schemaType, "KYCCountryOfResidenceCredential", schemaType + "V2"} {
+		claim := fixture.NewClaim(t, did)
+		claim.SchemaType = schema
+		claim.RevNonce = domain.RevNonceUint64(1000 + i)
+		fixture.CreateClaim(t, claim)
+	}
+
+	handler := getHandler(ctx, server)
+
+	type expected struct {
+		httpCode int
+		dryRun   bool
+		total    int
+	}
+
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		did      string
+		body     RevokeCredentialsRequest
+		expected expected
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name:     "No auth header",
+			auth:     authWrong,
+			did:      did,
+			body:     RevokeCredentialsRequest{SchemaType: common.ToPointer(schemaType)},
+			expected: expected{httpCode: http.StatusUnauthorized},
+		},
+		{
+			name:     "Invalid did",
+			auth:     authOk,
+			did:      "wrong",
+			body:     RevokeCredentialsRequest{SchemaType: common.ToPointer(schemaType)},
+			expected: expected{httpCode: http.StatusBadRequest},
+		},
+		{
+			name:     "Empty filter",
+			auth:     authOk,
+			did:      did,
+			body:     RevokeCredentialsRequest{DryRun: common.ToPointer(false)},
+			expected: expected{httpCode: http.StatusBadRequest},
+		},
+		{
+			name:     "Invalid credential subject",
+			auth:     authOk,
+			did:      did,
+			body:     RevokeCredentialsRequest{CredentialSubject: common.ToPointer("wrong")},
+			expected: expected{httpCode: http.StatusBadRequest},
+		},
+		{
+			name:     "Query field without value",
+			auth:     authOk,
+			did:      did,
+			body:     RevokeCredentialsRequest{QueryField: common.ToPointer("documentType")},
+			expected: expected{httpCode: http.StatusBadRequest},
+		},
+		{
+			name: "Wrong date range",
+			auth: authOk,
+			did:  did,
+			body: RevokeCredentialsRequest{
+				CreatedAfter:  common.ToPointer(time.Now()),
+				CreatedBefore: common.ToPointer(time.Now().Add(-time.Hour)),
+			},
+			expected: expected{httpCode: http.StatusBadRequest},
+		},
+		{
+			name:     "Part of the schema type",
+			auth:     authOk,
+			did:      did,
+			body:     RevokeCredentialsRequest{SchemaType: common.ToPointer("KYCAge")},
+			expected: expected{httpCode: http.StatusOK, dryRun: true, total: 0},
+		},
+		{
+			name:     "Dry run by default",
+			auth:     authOk,
+			did:      did,
+			body:     RevokeCredentialsRequest{SchemaType: common.ToPointer(schemaType)},
+			expected: expected{httpCode: http.StatusOK, dryRun: true, total: 2},
+		},
+		{
+			name:     "Revoke",
+			auth:     authOk,
+			did:      did,
+			body:     RevokeCredentialsRequest{SchemaType: common.ToPointer(schemaType), DryRun: common.ToPointer(false), Description: common.ToPointer("bulk")},
+			expected: expected{httpCode: http.StatusAccepted, total: 2},
+		},
+		{
+			name:     "Already revoked credentials are not returned",
+			auth:     authOk,
+			did:      did,
+			body:     RevokeCredentialsRequest{SchemaType: common.ToPointer(schemaType)},
+			expected: expected{httpCode: http.StatusOK, dryRun: true, total: 0},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			url := fmt.Sprintf("/v2/identities/%s/credentials/revoke", tc.did)
+			req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(string(body)))
+			require.NoError(t, err)
+			req.SetBasicAuth(tc.auth())
+			handler.ServeHTTP(rr, req)
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			if tc.expected.httpCode != http.StatusOK && tc.expected.httpCode != http.StatusAccepted {
+				return
+			}
+			var response RevokeCredentialsResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tc.expected.dryRun, response.DryRun)
+			assert.Equal(t, tc.expected.total, response.Total)
+			require.Len(t, response.Credentials, tc.expected.total)
+			for _, credential := range response.Credentials {
+				assert.Equal(t, schemaType, credential.SchemaType)
+			}
+		})
+	}
+}
+
 func TestServer_CreateCredential(t *testing.T) {
 	const (
 		method     = "polygonid"
@@ -290,185 +431,785 @@ func TestServer_CreateCredential(t *testing.T) {
 			},
 		},
 		{
-			name: "Happy path with ipfs schema",
-			auth: authOk,
-			did:  did,
-			body: CreateCredentialRequest{
-				CredentialSchema: "ipfs://QmQVeb5dkz5ekDqBrYVVxBFQZoCbzamnmMUn9B8twCEgDL",
-				Type:             "testNewType",
-				CredentialSubject: map[string]any{
-					"id":             "did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi",
-					"testNewTypeInt": 1234,
-				},
-				Expiration: common.ToPointer(time.Now().Unix()),
-			},
+			name: "Happy path with ipfs schema",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialRequest{
+				CredentialSchema: "ipfs://QmQVeb5dkz5ekDqBrYVVxBFQZoCbzamnmMUn9B8twCEgDL",
+				Type:             "testNewType",
+				CredentialSubject: map[string]any{
+					"id":             "did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi",
+					"testNewTypeInt": 1234,
+				},
+				Expiration: common.ToPointer(time.Now().Unix()),
+			},
+			expected: expected{
+				response:                    CreateCredential201JSONResponse{},
+				httpCode:                    http.StatusCreated,
+				createCredentialEventsCount: 1,
+			},
+		},
+		{
+			name: "Happy path with refresh service",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialRequest{
+				CredentialSchema: "ipfs://QmQVeb5dkz5ekDqBrYVVxBFQZoCbzamnmMUn9B8twCEgDL",
+				Type:             "testNewType",
+				CredentialSubject: map[string]any{
+					"id":             "did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi",
+					"testNewTypeInt": 1234,
+				},
+				Expiration: common.ToPointer(time.Now().Unix()),
+				RefreshService: &RefreshService{
+					Id:   "http://localhost:8080",
+					Type: RefreshServiceType(verifiable.Iden3RefreshService2023),
+				},
+			},
+			expected: expected{
+				response:                    CreateCredential201JSONResponse{},
+				httpCode:                    http.StatusCreated,
+				createCredentialEventsCount: 1,
+			},
+		},
+		{
+			name: "Happy path with credentials id",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialRequest{
+				ClaimID:          common.ToPointer(claimID),
+				CredentialSchema: "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+				Type:             "KYCAgeCredential",
+				CredentialSubject: map[string]any{
+					"id":           "did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi",
+					"birthday":     19960425,
+					"documentType": 2,
+				},
+				Expiration: common.ToPointer(time.Now().Unix()),
+				Proofs: &[]CreateCredentialRequestProofs{
+					"BJJSignature2021",
+					"Iden3SparseMerkleTreeProof",
+				},
+			},
+			expected: expected{
+				response: CreateCredential201JSONResponse{
+					Id: claimID.String(),
+				},
+				httpCode:                    http.StatusCreated,
+				createCredentialEventsCount: 1,
+			},
+		},
+		{
+			name: "Wrong credential url",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialRequest{
+				CredentialSchema: "wrong url",
+				Type:             "KYCAgeCredential",
+				CredentialSubject: map[string]any{
+					"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+					"birthday":     19960424,
+					"documentType": 2,
+				},
+				Expiration: common.ToPointer(time.Now().Unix()),
+			},
+			expected: expected{
+				response: CreateCredential400JSONResponse{N400JSONResponse{Message: "malformed url"}},
+				httpCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "Unreachable well formed credential url",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialRequest{
+				CredentialSchema: "http://www.wrong.url/cannot/get/the/credential",
+				Type:             "KYCAgeCredential",
+				CredentialSubject: map[string]any{
+					"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+					"birthday":     19960424,
+					"documentType": 2,
+				},
+				Expiration: common.ToPointer(time.Now().Unix()),
+			},
+			expected: expected{
+				response: CreateCredential422JSONResponse{N422JSONResponse{Message: "cannot load schema"}},
+				httpCode: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name: "Wrong proof type",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialRequest{
+				CredentialSchema: "http://www.wrong.url/cannot/get/the/credential",
+				Type:             "KYCAgeCredential",
+				CredentialSubject: map[string]any{
+					"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+					"birthday":     19960424,
+					"documentType": 2,
+				},
+				Expiration: common.ToPointer(time.Now().Unix()),
+				Proofs:     &[]CreateCredentialRequestProofs{"wrong proof"},
+			},
+			expected: expected{
+				response: CreateCredential400JSONResponse{N400JSONResponse{Message: "unsupported proof type: wrong proof"}},
+				httpCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "Wrong id for credential receiver",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialRequest{
+				CredentialSchema: "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+				Type:             "KYCAgeCredential",
+				CredentialSubject: map[string]any{
+					"id":           "this:id:is:wrong",
+					"birthday":     19960425,
+					"documentType": 2,
+				},
+				Expiration: common.ToPointer(time.Now().Unix()),
+			},
+			expected: expected{
+				response:                    CreateCredential400JSONResponse{N400JSONResponse{Message: "wrong format for credential subject ID"}},
+				httpCode:                    http.StatusBadRequest,
+				createCredentialEventsCount: 0,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server.Infra.pubSub.Clear(event.CreateCredentialEvent)
+			rr := httptest.NewRecorder()
+			url := fmt.Sprintf("/v2/identities/%s/credentials", tc.did)
+
+			req, err := http.NewRequest(http.MethodPost, url, tests.JSONBody(t, tc.body))
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+
+			assert.Equal(t, tc.expected.createCredentialEventsCount, len(server.Infra.pubSub.AllPublishedEvents(event.CreateCredentialEvent)))
+
+			switch tc.expected.httpCode {
+			case http.StatusCreated:
+				var response CreateCredentialResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				_, err := uuid.Parse(response.Id)
+				assert.NoError(t, err)
+				if tc.body.ClaimID != nil {
+					assert.Equal(t, tc.body.ClaimID.String(), response.Id)
+				}
+			case http.StatusBadRequest:
+				var response CreateCredential400JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.EqualValues(t, tc.expected.response, response)
+			case http.StatusUnprocessableEntity:
+				var response CreateCredential422JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.EqualValues(t, tc.expected.response, response)
+			}
+		})
+	}
+}
+
+func TestServer_CreateCredentialIdempotencyKey(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+	)
+	ctx := context.Background()
+
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	iden, err := server.Services.identity.Create(ctx, "http://polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+	did := iden.Identifier
+
+	body := func(birthday int) CreateCredentialRequest {
+		return CreateCredentialRequest{
+			CredentialSchema: "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+			Type:             "KYCAgeCredential",
+			CredentialSubject: map[string]any{
+				"id":           "did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi",
+				"birthday":     birthday,
+				"documentType": 2,
+			},
+		}
+	}
+	key := uuid.NewString()
+
+	type expected struct {
+		httpCode                    int
+		sameIDAsFirst               bool
+		createCredentialEventsCount int
+	}
+
+	type testConfig struct {
+		name     string
+		key      string
+		body     CreateCredentialRequest
+		expected expected
+	}
+	var firstID string
+	for _, tc := range []testConfig{
+		{
+			name:     "First request creates the credential",
+			key:      key,
+			body:     body(19960425),
+			expected: expected{httpCode: http.StatusCreated, createCredentialEventsCount: 1},
+		},
+		{
+			name:     "Retry returns the same credential",
+			key:      key,
+			body:     body(19960425),
+			expected: expected{httpCode: http.StatusCreated, sameIDAsFirst: true},
+		},
+		{
+			name:     "Same key with a different request",
+			key:      key,
+			body:     body(19960426),
+			expected: expected{httpCode: http.StatusConflict},
+		},
+		{
+			name:     "Other key creates another credential",
+			key:      uuid.NewString(),
+			body:     body(19960425),
+			expected: expected{httpCode: http.StatusCreated, createCredentialEventsCount: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server.Infra.pubSub.Clear(event.CreateCredentialEvent)
+			rr := httptest.NewRecorder()
+			url := fmt.Sprintf("/v2/identities/%s/credentials", did)
+
+			req, err := http.NewRequest(http.MethodPost, url, tests.JSONBody(t, tc.body))
+			require.NoError(t, err)
+			req.SetBasicAuth(authOk())
+			req.Header.Set("Idempotency-Key", tc.key)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			assert.Equal(t, tc.expected.createCredentialEventsCount, len(server.Infra.pubSub.AllPublishedEvents(event.CreateCredentialEvent)))
+			if tc.expected.httpCode != http.StatusCreated {
+				return
+			}
+			var response CreateCredentialResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			if firstID == "" {
+				firstID = response.Id
+				return
+			}
+			if tc.expected.sameIDAsFirst {
+				assert.Equal(t, firstID, response.Id)
+			} else {
+				assert.NotEqual(t, firstID, response.Id)
+			}
+		})
+	}
+
+	t.Run("A key left in progress can be used again after the lease", func(t *testing.T) {
+		repo := repositories.NewIdempotencyKey()
+		reserve := func(createdAt time.Time) string {
+			key := uuid.NewString()
+			reserved, err := repo.Reserve(ctx, storage.Pgx, &domain.IdempotencyKey{
+				IssuerID:    did,
+				Operation:   domain.IdempotentOperationCreateCredential,
+				Key:         key,
+				RequestHash: "hash of a request that never finished",
+				CreatedAt:   createdAt,
+				ExpiresAt:   createdAt.Add(time.Hour),
+			}, createdAt.Add(-time.Minute))
+			require.NoError(t, err)
+			require.True(t, reserved)
+			return key
+		}
+		send := func(key string) int {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/v2/identities/%s/credentials", did), tests.JSONBody(t, body(19960425)))
+			require.NoError(t, err)
+			req.SetBasicAuth(authOk())
+			req.Header.Set("Idempotency-Key", key)
+			handler.ServeHTTP(rr, req)
+			return rr.Code
+		}
+
+		assert.Equal(t, http.StatusConflict, send(reserve(time.Now().UTC())))
+		assert.Equal(t, http.StatusCreated, send(reserve(time.Now().UTC().Add(-2*time.Minute))))
+	})
+}
+
+func TestServer_ValidateCredential(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+		schemaURL  = "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	)
+	ctx := context.Background()
+
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	iden, err := server.Services.identity.Create(ctx, "http://polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+	did := iden.Identifier
+	issuerDID, err := w3c.ParseDID(did)
+	require.NoError(t, err)
+
+	type expected struct {
+		httpCode int
+		valid    bool
+	}
+
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		did      string
+		body     CreateCredentialRequest
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:     "No auth header",
+			auth:     authWrong,
+			did:      did,
+			expected: expected{httpCode: http.StatusUnauthorized},
+		},
+		{
+			name: "Invalid did",
+			auth: authOk,
+			did:  "wrong",
+			body: CreateCredentialRequest{
+				CredentialSchema:  schemaURL,
+				Type:              "KYCAgeCredential",
+				CredentialSubject: map[string]any{"birthday": 19960425, "documentType": 2},
+			},
+			expected: expected{httpCode: http.StatusBadRequest},
+		},
+		{
+			name: "Credential subject does not match the schema",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialRequest{
+				CredentialSchema: schemaURL,
+				Type:             "KYCAgeCredential",
+				CredentialSubject: map[string]any{
+					"id":           "did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi",
+					"birthday":     "yesterday",
+					"documentType": 2,
+				},
+			},
+			expected: expected{httpCode: http.StatusOK, valid: false},
+		},
+		{
+			name: "Wrong schema url",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialRequest{
+				CredentialSchema:  "wrong",
+				Type:              "KYCAgeCredential",
+				CredentialSubject: map[string]any{"birthday": 19960425, "documentType": 2},
+			},
+			expected: expected{httpCode: http.StatusOK, valid: false},
+		},
+		{
+			name: "Valid credential",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialRequest{
+				CredentialSchema: schemaURL,
+				Type:             "KYCAgeCredential",
+				CredentialSubject: map[string]any{
+					"id":           "did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi",
+					"birthday":     19960425,
+					"documentType": 2,
+				},
+				Expiration: common.ToPointer(time.Now().Add(time.Hour).Unix()),
+			},
+			expected: expected{httpCode: http.StatusOK, valid: true},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server.Infra.pubSub.Clear(event.CreateCredentialEvent)
+			rr := httptest.NewRecorder()
+			url := fmt.Sprintf("/v2/identities/%s/credentials/validate", tc.did)
+
+			req, err := http.NewRequest(http.MethodPost, url, tests.JSONBody(t, tc.body))
+			require.NoError(t, err)
+			req.SetBasicAuth(tc.auth())
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			if tc.expected.httpCode != http.StatusOK {
+				return
+			}
+			var response ValidateCredentialResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tc.expected.valid, response.Valid)
+			assert.Empty(t, server.Infra.pubSub.AllPublishedEvents(event.CreateCredentialEvent))
+
+			credentials, _, err := server.Services.credentials.GetAll(ctx, *issuerDID, &ports.ClaimsFilter{})
+			require.NoError(t, err)
+			assert.Empty(t, credentials)
+
+			if !tc.expected.valid {
+				assert.NotEmpty(t, response.Errors)
+				assert.Nil(t, response.Credential)
+				return
+			}
+			assert.Empty(t, response.Errors)
+			require.NotNil(t, response.Credential)
+			assert.Equal(t, did, response.Credential.Issuer)
+			assert.Nil(t, response.Credential.Proof)
+			require.NotNil(t, response.SchemaHash)
+			assert.NotEmpty(t, *response.SchemaHash)
+			require.NotNil(t, response.CoreClaim)
+			assert.Len(t, response.CoreClaim.Index, 4)
+			assert.Len(t, response.CoreClaim.Value, 4)
+		})
+	}
+}
+
+func TestServer_CreateCredentialsBatch(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+	)
+	ctx := context.Background()
+
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	iden, err := server.Services.identity.Create(ctx, "http://polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+	did := iden.Identifier
+
+	claimID, err := uuid.NewUUID()
+	require.NoError(t, err)
+
+	validCredential := func(subjectID string) CreateCredentialRequest {
+		return CreateCredentialRequest{
+			CredentialSchema: "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+			Type:             "KYCAgeCredential",
+			CredentialSubject: map[string]any{
+				"id":           subjectID,
+				"birthday":     19960425,
+				"documentType": 2,
+			},
+			Expiration: common.ToPointer(time.Now().Unix()),
+		}
+	}
+
+	type expected struct {
+		httpCode                    int
+		itemErrors                  []*string
+		createCredentialEventsCount int
+	}
+
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		did      string
+		body     CreateCredentialsBatchRequest
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name: "No auth header",
+			did:  did,
+			auth: authWrong,
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "Empty batch",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialsBatchRequest{Credentials: []CreateCredentialRequest{}},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "Happy path",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialsBatchRequest{Credentials: []CreateCredentialRequest{
+				validCredential("did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi"),
+				validCredential("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"),
+			}},
+			expected: expected{
+				httpCode:                    http.StatusCreated,
+				itemErrors:                  []*string{nil, nil},
+				createCredentialEventsCount: 1,
+			},
+		},
+		{
+			name: "Duplicated credential id",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialsBatchRequest{Credentials: []CreateCredentialRequest{
+				func() CreateCredentialRequest {
+					c := validCredential("did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi")
+					c.ClaimID = common.ToPointer(claimID)
+					return c
+				}(),
+				func() CreateCredentialRequest {
+					c := validCredential("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+					c.ClaimID = common.ToPointer(claimID)
+					return c
+				}(),
+			}},
+			expected: expected{
+				httpCode:   http.StatusUnprocessableEntity,
+				itemErrors: []*string{nil, common.ToPointer("duplicated credential id in the batch")},
+			},
+		},
+		{
+			name: "One invalid credential subject",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialsBatchRequest{Credentials: []CreateCredentialRequest{
+				validCredential("did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi"),
+				validCredential("this:id:is:wrong"),
+			}},
+			expected: expected{
+				httpCode:   http.StatusUnprocessableEntity,
+				itemErrors: []*string{nil, common.ToPointer("wrong format for credential subject ID")},
+			},
+		},
+		{
+			name: "Wrong proof type",
+			auth: authOk,
+			did:  did,
+			body: CreateCredentialsBatchRequest{Credentials: []CreateCredentialRequest{
+				func() CreateCredentialRequest {
+					c := validCredential("did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi")
+					c.Proofs = &[]CreateCredentialRequestProofs{"wrong proof"}
+					return c
+				}(),
+			}},
+			expected: expected{
+				httpCode:   http.StatusUnprocessableEntity,
+				itemErrors: []*string{common.ToPointer("unsupported proof type: wrong proof")},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server.Infra.pubSub.Clear(event.CreateCredentialEvent)
+			rr := httptest.NewRecorder()
+			url := fmt.Sprintf("/v2/identities/%s/credentials/batch", tc.did)
+
+			req, err := http.NewRequest(http.MethodPost, url, tests.JSONBody(t, tc.body))
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+
+			assert.Equal(t, tc.expected.createCredentialEventsCount, len(server.Infra.pubSub.AllPublishedEvents(event.CreateCredentialEvent)))
+
+			switch tc.expected.httpCode {
+			case http.StatusCreated:
+				var response CreateCredentialsBatchResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				require.Len(t, response.Items, len(tc.body.Credentials))
+				for i, item := range response.Items {
+					assert.Equal(t, i, item.Index)
+					assert.Nil(t, item.Error)
+					require.NotNil(t, item.Id)
+					_, err := uuid.Parse(*item.Id)
+					assert.NoError(t, err)
+				}
+			case http.StatusUnprocessableEntity:
+				var response CreateCredentialsBatchResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				require.Len(t, response.Items, len(tc.expected.itemErrors))
+				for i, item := range response.Items {
+					assert.Equal(t, i, item.Index)
+					assert.Nil(t, item.Id)
+					assert.Equal(t, tc.expected.itemErrors[i], item.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_UpdateCredential(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+		schemaURL  = "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+		typeC      = "KYCAgeCredential"
+		subjectID  = "did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi"
+	)
+	ctx := context.Background()
+
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	iden, err := server.Services.identity.Create(ctx, "http://polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+	did, err := w3c.ParseDID(iden.Identifier)
+	require.NoError(t, err)
+
+	createCredential := func(updatable bool) *domain.Claim {
+		credentialSubject := map[string]any{
+			"id":           subjectID,
+			"birthday":     19960425,
+			"documentType": 2,
+		}
+		req := ports.NewCreateClaimRequest(did, nil, schemaURL, credentialSubject, common.ToPointer(time.Now().Add(time.Hour)), typeC, nil, nil, nil, ports.ClaimRequestProofs{BJJSignatureProof2021: true}, nil, false, verifiable.Iden3commRevocationStatusV1, nil, nil, nil)
+		req.Updatable = updatable
+		credential, err := server.Services.credentials.Save(ctx, req)
+		require.NoError(t, err)
+		return credential
+	}
+	updatable := createCredential(true)
+	nonUpdatable := createCredential(false)
+
+	type expected struct {
+		httpCode                    int
+		message                     string
+		createCredentialEventsCount int
+	}
+
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		claimID  string
+		body     UpdateCredentialRequest
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:    "No auth header",
+			auth:    authWrong,
+			claimID: updatable.ID.String(),
 			expected: expected{
-				response:                    CreateCredential201JSONResponse{},
-				httpCode:                    http.StatusCreated,
-				createCredentialEventsCount: 1,
+				httpCode: http.StatusUnauthorized,
 			},
 		},
 		{
-			name: "Happy path with refresh service",
-			auth: authOk,
-			did:  did,
-			body: CreateCredentialRequest{
-				CredentialSchema: "ipfs://QmQVeb5dkz5ekDqBrYVVxBFQZoCbzamnmMUn9B8twCEgDL",
-				Type:             "testNewType",
-				CredentialSubject: map[string]any{
-					"id":             "did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi",
-					"testNewTypeInt": 1234,
-				},
-				Expiration: common.ToPointer(time.Now().Unix()),
-				RefreshService: &RefreshService{
-					Id:   "http://localhost:8080",
-					Type: RefreshServiceType(verifiable.Iden3RefreshService2023),
-				},
-			},
+			name:    "Invalid claim id",
+			auth:    authOk,
+			claimID: "wrong",
+			body:    UpdateCredentialRequest{CredentialSubject: map[string]any{"documentType": 3}},
 			expected: expected{
-				response:                    CreateCredential201JSONResponse{},
-				httpCode:                    http.StatusCreated,
-				createCredentialEventsCount: 1,
+				httpCode: http.StatusBadRequest,
+				message:  "invalid claim id",
 			},
 		},
 		{
-			name: "Happy path with credentials id",
-			auth: authOk,
-			did:  did,
-			body: CreateCredentialRequest{
-				ClaimID:          common.ToPointer(claimID),
-				CredentialSchema: "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
-				Type:             "KYCAgeCredential",
-				CredentialSubject: map[string]any{
-					"id":           "did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi",
-					"birthday":     19960425,
-					"documentType": 2,
-				},
-				Expiration: common.ToPointer(time.Now().Unix()),
-				Proofs: &[]CreateCredentialRequestProofs{
-					"BJJSignature2021",
-					"Iden3SparseMerkleTreeProof",
-				},
-			},
+			name:    "Non existing credential",
+			auth:    authOk,
+			claimID: uuid.NewString(),
+			body:    UpdateCredentialRequest{CredentialSubject: map[string]any{"documentType": 3}},
 			expected: expected{
-				response: CreateCredential201JSONResponse{
-					Id: claimID.String(),
-				},
-				httpCode:                    http.StatusCreated,
-				createCredentialEventsCount: 1,
+				httpCode: http.StatusNotFound,
+				message:  "credential not found",
 			},
 		},
 		{
-			name: "Wrong credential url",
-			auth: authOk,
-			did:  did,
-			body: CreateCredentialRequest{
-				CredentialSchema: "wrong url",
-				Type:             "KYCAgeCredential",
-				CredentialSubject: map[string]any{
-					"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
-					"birthday":     19960424,
-					"documentType": 2,
-				},
-				Expiration: common.ToPointer(time.Now().Unix()),
-			},
+			name:    "Non updatable credential",
+			auth:    authOk,
+			claimID: nonUpdatable.ID.String(),
+			body:    UpdateCredentialRequest{CredentialSubject: map[string]any{"documentType": 3}},
 			expected: expected{
-				response: CreateCredential400JSONResponse{N400JSONResponse{Message: "malformed url"}},
 				httpCode: http.StatusBadRequest,
+				message:  "credential is not updatable",
 			},
 		},
 		{
-			name: "Unreachable well formed credential url",
-			auth: authOk,
-			did:  did,
-			body: CreateCredentialRequest{
-				CredentialSchema: "http://www.wrong.url/cannot/get/the/credential",
-				Type:             "KYCAgeCredential",
-				CredentialSubject: map[string]any{
-					"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
-					"birthday":     19960424,
-					"documentType": 2,
-				},
-				Expiration: common.ToPointer(time.Now().Unix()),
-			},
+			name:    "Subject id cannot change",
+			auth:    authOk,
+			claimID: updatable.ID.String(),
+			body:    UpdateCredentialRequest{CredentialSubject: map[string]any{"id": "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"}},
 			expected: expected{
-				response: CreateCredential422JSONResponse{N422JSONResponse{Message: "cannot load schema"}},
-				httpCode: http.StatusUnprocessableEntity,
+				httpCode: http.StatusBadRequest,
+				message:  "the credential subject id cannot be updated",
 			},
 		},
 		{
-			name: "Wrong proof type",
-			auth: authOk,
-			did:  did,
-			body: CreateCredentialRequest{
-				CredentialSchema: "http://www.wrong.url/cannot/get/the/credential",
-				Type:             "KYCAgeCredential",
-				CredentialSubject: map[string]any{
-					"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
-					"birthday":     19960424,
-					"documentType": 2,
-				},
-				Expiration: common.ToPointer(time.Now().Unix()),
-				Proofs:     &[]CreateCredentialRequestProofs{"wrong proof"},
-			},
+			name:    "Happy path",
+			auth:    authOk,
+			claimID: updatable.ID.String(),
+			body:    UpdateCredentialRequest{CredentialSubject: map[string]any{"documentType": 3}},
 			expected: expected{
-				response: CreateCredential400JSONResponse{N400JSONResponse{Message: "unsupported proof type: wrong proof"}},
-				httpCode: http.StatusBadRequest,
+				httpCode:                    http.StatusCreated,
+				createCredentialEventsCount: 1,
 			},
 		},
 		{
-			name: "Wrong id for credential receiver",
-			auth: authOk,
-			did:  did,
-			body: CreateCredentialRequest{
-				CredentialSchema: "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
-				Type:             "KYCAgeCredential",
-				CredentialSubject: map[string]any{
-					"id":           "this:id:is:wrong",
-					"birthday":     19960425,
-					"documentType": 2,
-				},
-				Expiration: common.ToPointer(time.Now().Unix()),
-			},
+			name:    "Already updated credential",
+			auth:    authOk,
+			claimID: updatable.ID.String(),
+			body:    UpdateCredentialRequest{CredentialSubject: map[string]any{"documentType": 4}},
 			expected: expected{
-				response:                    CreateCredential400JSONResponse{N400JSONResponse{Message: "wrong format for credential subject ID"}},
-				httpCode:                    http.StatusBadRequest,
-				createCredentialEventsCount: 0,
+				httpCode: http.StatusBadRequest,
+				message:  "credential is revoked",
 			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			server.Infra.pubSub.Clear(event.CreateCredentialEvent)
 			rr := httptest.NewRecorder()
-			url := fmt.Sprintf("/v2/identities/%s/credentials", tc.did)
+			url := fmt.Sprintf("/v2/identities/%s/credentials/%s", did, tc.claimID)
 
-			req, err := http.NewRequest(http.MethodPost, url, tests.JSONBody(t, tc.body))
+			req, err := http.NewRequest(http.MethodPatch, url, tests.JSONBody(t, tc.body))
 			req.SetBasicAuth(tc.auth())
 			require.NoError(t, err)
 
 			handler.ServeHTTP(rr, req)
 
 			require.Equal(t, tc.expected.httpCode, rr.Code)
-
 			assert.Equal(t, tc.expected.createCredentialEventsCount, len(server.Infra.pubSub.AllPublishedEvents(event.CreateCredentialEvent)))
 
 			switch tc.expected.httpCode {
 			case http.StatusCreated:
-				var response CreateCredentialResponse
+				var response UpdateCredential201JSONResponse
 				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
-				_, err := uuid.Parse(response.Id)
-				assert.NoError(t, err)
-				if tc.body.ClaimID != nil {
-					assert.Equal(t, tc.body.ClaimID.String(), response.Id)
-				}
+				id, err := uuid.Parse(response.Id)
+				require.NoError(t, err)
+
+				replacement, err := server.Services.credentials.GetByID(ctx, did, id)
+				require.NoError(t, err)
+				require.NotNil(t, replacement.PreviousID)
+				assert.Equal(t, updatable.ID, *replacement.PreviousID)
+				assert.Equal(t, updatable.Version+1, replacement.Version)
+				assert.True(t, replacement.Updatable)
+				vc, err := replacement.GetVerifiableCredential()
+				require.NoError(t, err)
+				assert.Equal(t, subjectID, vc.CredentialSubject["id"])
+				assert.EqualValues(t, 3, vc.CredentialSubject["documentType"])
+				assert.EqualValues(t, 19960425, vc.CredentialSubject["birthday"])
+
+				previous, err := server.Services.credentials.GetByID(ctx, did, updatable.ID)
+				require.NoError(t, err)
+				assert.True(t, previous.Revoked)
 			case http.StatusBadRequest:
-				var response CreateCredential400JSONResponse
+				var response UpdateCredential400JSONResponse
 				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
-				assert.EqualValues(t, tc.expected.response, response)
-			case http.StatusUnprocessableEntity:
-				var response CreateCredential422JSONResponse
+				assert.Equal(t, tc.expected.message, response.Message)
+			case http.StatusNotFound:
+				var response UpdateCredential404JSONResponse
 				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
-				assert.EqualValues(t, tc.expected.response, response)
+				assert.Equal(t, tc.expected.message, response.Message)
 			}
 		})
 	}
@@ -570,6 +1311,12 @@ func TestServer_GetCredentialQrCode(t *testing.T) {
 
 	server := newTestServer(t, nil)
 	handler := getHandler(context.Background(), server)
+	did, err := w3c.ParseDID(idStr)
+	require.NoError(t, err)
+	_, err = server.identityService.UpdateIdentity(context.Background(), *did, ports.UpdateIdentityRequest{
+		Profile: &domain.IdentityProfileUpdate{Name: common.ToPointer("Acme KYC")},
+	})
+	require.NoError(t, err)
 
 	type expected struct {
 		response GetCredentialOfferResponseObject
@@ -702,6 +1449,13 @@ func TestServer_GetCredentialQrCode(t *testing.T) {
 				case "raw":
 					var rawResponse CredentialOfferResponse
 					assert.NoError(t, json.Unmarshal([]byte(response.UniversalLink), &rawResponse))
+					var message struct {
+						Body struct {
+							Issuer domain.IdentityProfile `json:"issuer"`
+						} `json:"body"`
+					}
+					require.NoError(t, json.Unmarshal([]byte(response.UniversalLink), &message))
+					assert.Equal(t, "Acme KYC", message.Body.Issuer.Name)
 				case "universalLink":
 					parsedURL, err := url.Parse(response.UniversalLink)
 					require.NoError(t, err)
@@ -894,6 +1648,132 @@ func TestServer_GetCredential(t *testing.T) {
 	}
 }
 
+func TestServer_GetCredentialExport(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	ethIden, err := server.Services.identity.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: kms.KeyTypeEthereum})
+	require.NoError(t, err)
+	bjjIden, err := server.Services.identity.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: kms.KeyTypeBabyJubJub})
+	require.NoError(t, err)
+
+	fixture := repositories.NewFixture(storage)
+	ethClaim := fixture.NewClaim(t, ethIden.Identifier)
+	fixture.CreateClaim(t, ethClaim)
+	bjjClaim := fixture.NewClaim(t, bjjIden.Identifier)
+	fixture.CreateClaim(t, bjjClaim)
+
+	handler := getHandler(ctx, server)
+
+	type expected struct {
+		httpCode    int
+		contentType string
+		disclosures int
+		errorMsg    string
+	}
+	type testConfig struct {
+		name     string
+		did      string
+		claimID  uuid.UUID
+		format   string
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:     "Unsupported format",
+			did:      ethIden.Identifier,
+			claimID:  ethClaim.ID,
+			format:   "xml",
+			expected: expected{httpCode: http.StatusBadRequest},
+		},
+		{
+			name:    "Issuer without eth key",
+			did:     bjjIden.Identifier,
+			claimID: bjjClaim.ID,
+			format:  "jwt-vc",
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				errorMsg: "the issuer does not have an ethereum key to sign the credential",
+			},
+		},
+		{
+			name:    "JWT-VC",
+			did:     ethIden.Identifier,
+			claimID: ethClaim.ID,
+			format:  "jwt-vc",
+			expected: expected{
+				httpCode:    http.StatusOK,
+				contentType: "application/jwt",
+			},
+		},
+		{
+			name:    "SD-JWT VC",
+			did:     ethIden.Identifier,
+			claimID: ethClaim.ID,
+			format:  "sd-jwt-vc",
+			expected: expected{
+				httpCode:    http.StatusOK,
+				contentType: "application/vc+sd-jwt",
+				disclosures: 2,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v2/identities/%s/credentials/%s?format=%s", tc.did, tc.claimID, tc.format), nil)
+			require.NoError(t, err)
+			req.SetBasicAuth(authOk())
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			if tc.expected.httpCode != http.StatusOK {
+				if tc.expected.errorMsg != "" {
+					var response GetCredential400JSONResponse
+					require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+					assert.Equal(t, tc.expected.errorMsg, response.Message)
+				}
+				return
+			}
+			assert.Equal(t, tc.expected.contentType, rr.Header().Get("Content-Type"))
+
+			parts := strings.Split(strings.TrimSuffix(rr.Body.String(), "~"), "~")
+			assert.Len(t, parts, tc.expected.disclosures+1)
+			token := strings.Split(parts[0], ".")
+			require.Len(t, token, 3)
+
+			var header struct {
+				Alg string            `json:"alg"`
+				JWK map[string]string `json:"jwk"`
+			}
+			rawHeader, err := base64.RawURLEncoding.DecodeString(token[0])
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(rawHeader, &header))
+			assert.Equal(t, "ES256K", header.Alg)
+
+			var payload map[string]any
+			rawPayload, err := base64.RawURLEncoding.DecodeString(token[1])
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(rawPayload, &payload))
+			assert.Equal(t, ethIden.Identifier, payload["iss"])
+			assert.Equal(t, "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ", payload["sub"])
+
+			x, err := base64.RawURLEncoding.DecodeString(header.JWK["x"])
+			require.NoError(t, err)
+			y, err := base64.RawURLEncoding.DecodeString(header.JWK["y"])
+			require.NoError(t, err)
+			signature, err := base64.RawURLEncoding.DecodeString(token[2])
+			require.NoError(t, err)
+			digest := sha256.Sum256([]byte(token[0] + "." + token[1]))
+			assert.True(t, ethcrypto.VerifySignature(append(append([]byte{4}, x...), y...), digest[:], signature))
+		})
+	}
+}
+
 func TestServer_GetCredentials(t *testing.T) {
 	const (
 		method     = "polygonid"
@@ -971,6 +1851,8 @@ func TestServer_GetCredentials(t *testing.T) {
 		status     *string
 		page       *int
 		maxResults *int
+		schemaURL  *string
+		attributes *string
 		expected   expected
 	}
 	for _, tc := range []testConfig{
@@ -1322,6 +2204,71 @@ func TestServer_GetCredentials(t *testing.T) {
 				errorMsg: "repeated sort by value field",
 			},
 		},
+		{
+			name:       "Attributes without schema",
+			auth:       authOk,
+			attributes: common.ToPointer(`{"birthday": {"$lt": 20000101}}`),
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				errorMsg: "schemaURL param is required to query attributes",
+			},
+		},
+		{
+			name:       "Attributes with unsupported operator",
+			auth:       authOk,
+			schemaURL:  common.ToPointer(schemaURL),
+			attributes: common.ToPointer(`{"birthday": {"$regex": "1996"}}`),
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				errorMsg: "invalid attributes query: unsupported operator <$regex> on attribute <birthday>",
+			},
+		},
+		{
+			name:       "Attributes not in the schema",
+			auth:       authOk,
+			schemaURL:  common.ToPointer(schemaURL),
+			attributes: common.ToPointer(`{"name": "John"}`),
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				errorMsg: "invalid attributes query: schema attribute <name> not found",
+			},
+		},
+		{
+			name:       "Attributes with a value of the wrong type",
+			auth:       authOk,
+			schemaURL:  common.ToPointer(schemaURL),
+			attributes: common.ToPointer(`{"birthday": {"$lt": "yesterday"}}`),
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				errorMsg: "invalid attributes query: value <yesterday> of attribute <birthday>: strconv.ParseInt: parsing \"yesterday\": invalid syntax",
+			},
+		},
+		{
+			name:       "Attributes matching all the credentials",
+			auth:       authOk,
+			schemaURL:  common.ToPointer(schemaURL),
+			attributes: common.ToPointer(`{"birthday": {"$lt": 20000101, "$gt": "19900101"}, "documentType": {"$in": [1, 2]}}`),
+			expected: expected{
+				httpCode:         http.StatusOK,
+				total:            4,
+				maxResults:       50,
+				page:             1,
+				credentialsCount: 4,
+			},
+		},
+		{
+			name:       "Attributes matching no credentials",
+			auth:       authOk,
+			schemaURL:  common.ToPointer(schemaURL),
+			attributes: common.ToPointer(`{"birthday": 19960424, "documentType": {"$ne": 2}}`),
+			expected: expected{
+				httpCode:         http.StatusOK,
+				total:            0,
+				maxResults:       50,
+				page:             1,
+				credentialsCount: 0,
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			rr := httptest.NewRecorder()
@@ -1345,6 +2292,12 @@ func TestServer_GetCredentials(t *testing.T) {
 			if tc.maxResults != nil {
 				queryParams = append(queryParams, "max_results="+strconv.Itoa(*tc.maxResults))
 			}
+			if tc.schemaURL != nil {
+				queryParams = append(queryParams, "schemaURL="+url.QueryEscape(*tc.schemaURL))
+			}
+			if tc.attributes != nil {
+				queryParams = append(queryParams, "attributes="+url.QueryEscape(*tc.attributes))
+			}
 			endpoint.RawQuery = strings.Join(queryParams, "&")
 			req, err := http.NewRequest("GET", endpoint.String(), nil)
 			req.SetBasicAuth(tc.auth())
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+const (
+	didResolutionContext = "https://w3id.org/did-resolution/v1"
+	didLDJSONContentType = "application/did+ld+json"
+)
+
+// GetDIDDocument is the public controller to get the DID document of an identity of the node
+func (s *Server) GetDIDDocument(ctx context.Context, request GetDIDDocumentRequestObject) (GetDIDDocumentResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		log.Error(ctx, "get did document. Parsing did", "err", err)
+		return GetDIDDocument400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	resolution, err := s.didDocumentService.Resolve(ctx, *did)
+	if err != nil {
+		if errors.Is(err, repositories.ErrIdentityNotFound) {
+			return GetDIDDocument404JSONResponse{N404JSONResponse{Message: "identity not found"}}, nil
+		}
+		log.Error(ctx, "get did document. Resolving did", "err", err, "did", did)
+		return GetDIDDocument500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	return GetDIDDocument200JSONResponse(*resolution.Document), nil
+}
+
+// GetWebDIDDocument is the public controller that serves the DID document of a did:web identity of the node
+func (s *Server) GetWebDIDDocument(ctx context.Context, request GetWebDIDDocumentRequestObject) (GetWebDIDDocumentResponseObject, error) {
+	did, err := domain.NewWebDID(s.cfg.ServerUrl, request.Id)
+	if err != nil {
+		log.Error(ctx, "get web did document. Building did", "err", err)
+		return GetWebDIDDocument400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resolution, err := s.didDocumentService.Resolve(ctx, *did)
+	if err != nil {
+		if errors.Is(err, repositories.ErrIdentityNotFound) {
+			return GetWebDIDDocument404JSONResponse{N404JSONResponse{Message: "identity not found"}}, nil
+		}
+		log.Error(ctx, "get web did document. Resolving did", "err", err, "did", did)
+		return GetWebDIDDocument500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	return GetWebDIDDocument200JSONResponse(*resolution.Document), nil
+}
+
+// ResolveDID is the public controller that resolves the DIDs of the node like a universal resolver driver
+func (s *Server) ResolveDID(ctx context.Context, request ResolveDIDRequestObject) (ResolveDIDResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		log.Error(ctx, "resolve did. Parsing did", "err", err)
+		return ResolveDID400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	resolution, err := s.didDocumentService.Resolve(ctx, *did)
+	if err != nil {
+		if errors.Is(err, repositories.ErrIdentityNotFound) {
+			return ResolveDID404JSONResponse{N404JSONResponse{Message: "identity not found"}}, nil
+		}
+		log.Error(ctx, "resolve did. Resolving did", "err", err, "did", did)
+		return ResolveDID500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	return ResolveDID200JSONResponse(toDIDResolutionResult(resolution)), nil
+}
+
+func toDIDResolutionResult(resolution *ports.DIDResolution) DIDResolutionResult {
+	result := DIDResolutionResult{
+		Context:     didResolutionContext,
+		DidDocument: *resolution.Document,
+	}
+	result.DidResolutionMetadata.ContentType = didLDJSONContentType
+	result.DidDocumentMetadata.Deactivated = resolution.Deactivated
+	return result
+}
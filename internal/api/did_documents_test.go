@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db/tests"
+)
+
+func TestServer_GetDIDDocument(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	identity, err := server.Services.identity.Create(ctx, "http://polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+
+	type expected struct {
+		httpCode int
+		message  string
+	}
+	type testConfig struct {
+		name       string
+		identifier string
+		expected   expected
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name:       "invalid did",
+			identifier: "did:wrong",
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  "invalid did",
+			},
+		},
+		{
+			name:       "identity of another node",
+			identifier: "did:polygonid:polygon:amoy:2qQ8S2VKdQv7xYgzCn7KW2xgzUWrTRQjoZDYavJHBq",
+			expected: expected{
+				httpCode: http.StatusNotFound,
+				message:  "identity not found",
+			},
+		},
+		{
+			name:       "should get the did document without auth",
+			identifier: identity.Identifier,
+			expected: expected{
+				httpCode: http.StatusOK,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v2/identities/%s/did-document", tc.identifier), nil)
+			require.NoError(t, err)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			switch tc.expected.httpCode {
+			case http.StatusOK:
+				var response verifiable.DIDDocument
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, identity.Identifier, response.ID)
+				require.Len(t, response.VerificationMethod, 1)
+				assert.Equal(t, "JsonWebKey2020", response.VerificationMethod[0].Type)
+				assert.Equal(t, "BJJ", response.VerificationMethod[0].PublicKeyJwk["crv"])
+				require.Len(t, response.Authentication, 1)
+				assert.Equal(t, response.VerificationMethod[0].ID, response.Authentication[0].ID)
+				serviceTypes := make([]string, 0, len(response.Service))
+				for _, s := range response.Service {
+					service, ok := s.(map[string]interface{})
+					require.True(t, ok)
+					serviceTypes = append(serviceTypes, service["type"].(string))
+				}
+				assert.Contains(t, serviceTypes, string(verifiable.Iden3commRevocationStatusV1))
+			case http.StatusBadRequest:
+				var response GetDIDDocument400JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			case http.StatusNotFound:
+				var response GetDIDDocument404JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			}
+		})
+	}
+}
+
+func TestServer_ResolveDID(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	identity, err := server.Services.identity.Create(ctx, "http://polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+
+	resolve := func(t *testing.T) DIDResolutionResult {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/1.0/identifiers/%s", identity.Identifier), nil)
+		require.NoError(t, err)
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response DIDResolutionResult
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("should resolve an active identity", func(t *testing.T) {
+		response := resolve(t)
+		assert.Equal(t, identity.Identifier, response.DidDocument.ID)
+		assert.Equal(t, "application/did+ld+json", response.DidResolutionMetadata.ContentType)
+		assert.False(t, response.DidDocumentMetadata.Deactivated)
+	})
+
+	t.Run("should flag a deactivated identity", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/v2/identities/%s/deactivate", identity.Identifier), nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		assert.True(t, resolve(t).DidDocumentMetadata.Deactivated)
+	})
+}
+
+func TestServer_GetWebDIDDocument(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	t.Run("BJJ", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		body := CreateIdentityRequest{}
+		body.DidMetadata.Method = "web"
+		body.DidMetadata.Type = CreateIdentityRequestDidMetadataTypeBJJ
+		req, err := http.NewRequest(http.MethodPost, "/v2/identities", tests.JSONBody(t, body))
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var identity CreateIdentityResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &identity))
+		require.NotNil(t, identity.Identifier)
+
+		did, err := w3c.ParseDID(*identity.Identifier)
+		require.NoError(t, err)
+		require.Equal(t, "web", did.Method)
+		id := did.IDStrings[len(did.IDStrings)-1]
+
+		rr = httptest.NewRecorder()
+		req, err = http.NewRequest(http.MethodGet, fmt.Sprintf("/identities/%s/did.json", id), nil)
+		require.NoError(t, err)
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response verifiable.DIDDocument
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, *identity.Identifier, response.ID)
+		require.Len(t, response.VerificationMethod, 1)
+		assert.Equal(t, *identity.Identifier+"#key-1", response.VerificationMethod[0].ID)
+		assert.Equal(t, "JsonWebKey2020", response.VerificationMethod[0].Type)
+		assert.Empty(t, response.Service)
+	})
+
+	t.Run("ETH key is rejected", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		body := CreateIdentityRequest{}
+		body.DidMetadata.Method = "web"
+		body.DidMetadata.Type = CreateIdentityRequestDidMetadataTypeETH
+		req, err := http.NewRequest(http.MethodPost, "/v2/identities", tests.JSONBody(t, body))
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("unknown identity", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/identities/8edd8112-c415-11ed-b036-debe37e1cbd6/did.json", nil)
+		require.NoError(t, err)
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+)
+
+func idempotencyKey(key *IdempotencyKey) string {
+	if key == nil {
+		return ""
+	}
+	return *key
+}
+
+func isIdempotencyConflict(err error) bool {
+	return errors.Is(err, services.ErrIdempotencyKeyInProgress) || errors.Is(err, services.ErrIdempotencyKeyMismatch)
+}
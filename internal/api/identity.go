@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -11,10 +12,14 @@ import (
 	core "github.com/iden3/go-iden3-core/v2"
 	"github.com/iden3/go-iden3-core/v2/w3c"
 	"github.com/iden3/go-schema-processor/v2/verifiable"
+	"github.com/jackc/pgtype"
 
 	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/pagination"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/gateways"
 	"github.com/polygonid/sh-id-platform/internal/kms"
 	"github.com/polygonid/sh-id-platform/internal/log"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
@@ -50,14 +55,19 @@ func (s *Server) CreateIdentity(ctx context.Context, request CreateIdentityReque
 		}, nil
 	}
 
-	rhsSettings, err := s.networkResolver.GetRhsSettingsForBlockchainAndNetwork(ctx, blockchain, network)
-	if err != nil {
-		return CreateIdentity400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("error getting reverse hash service settings: %s", err.Error())}}, nil
-	}
+	// did:web identities have no auth credential and are not bound to a network
+	if method == string(domain.DIDMethodWeb) {
+		blockchain, network = "", ""
+	} else {
+		rhsSettings, err := s.networkResolver.GetRhsSettingsForBlockchainAndNetwork(ctx, blockchain, network)
+		if err != nil {
+			return CreateIdentity400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("error getting reverse hash service settings: %s", err.Error())}}, nil
+		}
 
-	if !s.networkResolver.IsCredentialStatusTypeSupported(rhsSettings.Mode, *credentialStatusType) {
-		log.Warn(ctx, "unsupported credential status type", "req", request)
-		return CreateIdentity400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("Credential Status Type '%s' is not supported by the issuer", *credentialStatusType)}}, nil
+		if !s.networkResolver.IsCredentialStatusTypeSupported(rhsSettings.Mode, *credentialStatusType) {
+			log.Warn(ctx, "unsupported credential status type", "req", request)
+			return CreateIdentity400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("Credential Status Type '%s' is not supported by the issuer", *credentialStatusType)}}, nil
+		}
 	}
 
 	identity, err := s.identityService.Create(ctx, s.cfg.ServerUrl, &ports.DIDCreationOptions{
@@ -155,6 +165,15 @@ func validateStatusType(credentialStatusTypeRequest *string) (*verifiable.Creden
 	return &credentialStatusType, nil
 }
 
+// validateCredentialStatusType - validate the status type of a credential.
+// Credentials, unlike identities, can also use a BitstringStatusListEntry status.
+func validateCredentialStatusType(credentialStatusTypeRequest *string) (*verifiable.CredentialStatusType, error) {
+	if credentialStatusTypeRequest != nil && *credentialStatusTypeRequest == string(domain.BitstringStatusListEntry) {
+		return common.ToPointer(domain.BitstringStatusListEntry), nil
+	}
+	return validateStatusType(credentialStatusTypeRequest)
+}
+
 // UpdateIdentity is update identity display name controller
 func (s *Server) UpdateIdentity(ctx context.Context, request UpdateIdentityRequestObject) (UpdateIdentityResponseObject, error) {
 	userDID, err := w3c.ParseDID(request.Identifier)
@@ -167,14 +186,29 @@ func (s *Server) UpdateIdentity(ctx context.Context, request UpdateIdentityReque
 		}, err
 	}
 
-	err = s.identityService.UpdateIdentityDisplayName(ctx, *userDID, request.Body.DisplayName)
+	if request.Body.DisplayName == nil && request.Body.Profile == nil && request.Body.PublishingPolicy == nil {
+		return UpdateIdentity400JSONResponse{N400JSONResponse{Message: "displayName, profile or publishingPolicy must be provided"}}, nil
+	}
+
+	_, err = s.identityService.UpdateIdentity(ctx, *userDID, ports.UpdateIdentityRequest{
+		DisplayName:      request.Body.DisplayName,
+		Profile:          toDomainIdentityProfileUpdate(request.Body.Profile),
+		PublishingPolicy: toDomainPublishingPolicy(request.Body.PublishingPolicy),
+	})
 	if err != nil {
-		log.Error(ctx, "update identity. updating display name", "err", err)
+		log.Error(ctx, "update identity. updating identity", "err", err)
+		if errors.Is(err, domain.ErrInvalidIdentityProfile) || errors.Is(err, domain.ErrInvalidPublishingPolicy) ||
+			errors.Is(err, services.ErrWebIdentityNotSupported) {
+			return UpdateIdentity400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrIdentityDisplayNameDuplicated) {
+			return UpdateIdentity400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("display name field already exists: <%s>", *request.Body.DisplayName)}}, nil
+		}
 		return UpdateIdentity400JSONResponse{
 			N400JSONResponse{
 				Message: "invalid identity",
 			},
-		}, err
+		}, nil
 	}
 
 	return UpdateIdentity200JSONResponse{Message: "Identity display name updated"}, nil
@@ -182,53 +216,156 @@ func (s *Server) UpdateIdentity(ctx context.Context, request UpdateIdentityReque
 
 // GetIdentities is the controller to get identities
 func (s *Server) GetIdentities(ctx context.Context, request GetIdentitiesRequestObject) (GetIdentitiesResponseObject, error) {
-	var err error
-	var response GetIdentities200JSONResponse
-	identities, err := s.identityService.Get(ctx)
+	identities, _, err := s.identityService.Get(ctx, &ports.GetIdentitiesRequest{
+		IncludeArchived: request.Params.IncludeArchived != nil && *request.Params.IncludeArchived,
+	})
+	if err != nil {
+		log.Error(ctx, "get identities", "err", err)
+		return GetIdentities500JSONResponse{N500JSONResponse{
+			Message: err.Error(),
+		}}, nil
+	}
+
+	response, err := identitiesResponse(identities)
 	if err != nil {
 		return GetIdentities500JSONResponse{N500JSONResponse{
 			Message: err.Error(),
 		}}, nil
 	}
+	return GetIdentities200JSONResponse(response), nil
+}
+
+// SearchIdentities is the controller to search, filter and paginate the identities
+func (s *Server) SearchIdentities(ctx context.Context, request SearchIdentitiesRequestObject) (SearchIdentitiesResponseObject, error) {
+	filter, err := searchIdentitiesFilter(request)
+	if err != nil {
+		return SearchIdentities400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	identities, total, err := s.identityService.Get(ctx, filter)
+	if err != nil {
+		log.Error(ctx, "search identities", "err", err)
+		return SearchIdentities500JSONResponse{N500JSONResponse{
+			Message: err.Error(),
+		}}, nil
+	}
 
+	items, err := identitiesResponse(identities)
+	if err != nil {
+		return SearchIdentities500JSONResponse{N500JSONResponse{
+			Message: err.Error(),
+		}}, nil
+	}
+	return SearchIdentities200JSONResponse{
+		Items: items,
+		Meta: PaginatedMetadata{
+			MaxResults: filter.Pagination.MaxResults,
+			Page:       *filter.Pagination.Page,
+			Total:      total,
+		},
+	}, nil
+}
+
+func identitiesResponse(identities []domain.IdentityDisplayName) ([]GetIdentitiesResponse, error) {
 	partsLength := 4
+	items := make([]GetIdentitiesResponse, 0, len(identities))
 	for _, identity := range identities {
 		did, err := w3c.ParseDID(identity.Identifier)
 		if err != nil {
-			return GetIdentities500JSONResponse{N500JSONResponse{
-				Message: err.Error(),
-			}}, nil
+			return nil, err
 		}
 
 		var authBjjCredStatus *GetIdentitiesResponseCredentialStatusType
-		authClaim, _ := s.claimService.GetAuthClaim(ctx, did)
-		if authClaim != nil {
-			credentialStatus, _ := authClaim.GetCredentialStatus()
+		if identity.CredentialStatusType != "" {
+			authBjjCredStatus = common.ToPointer(GetIdentitiesResponseCredentialStatusType(identity.CredentialStatusType))
+		}
 
-			if credentialStatus != nil {
-				credStatusType := GetIdentitiesResponseCredentialStatusType(credentialStatus.Type)
-				authBjjCredStatus = &credStatusType
+		item := GetIdentitiesResponse{
+			Identifier:           identity.Identifier,
+			Method:               did.Method,
+			CredentialStatusType: authBjjCredStatus,
+			DisplayName:          identity.DisplayName,
+			Status:               IdentityLifecycleStatus(identity.Status),
+			KeyType:              identity.KeyType,
+			CreatedAt:            TimeUTC(identity.CreatedAt),
+			CredentialsIssued:    identity.CredentialsIssued,
+			CredentialsRevoked:   identity.CredentialsRevoked,
+			PendingStateChanges:  identity.PendingStateChanges,
+		}
+		if !domain.IsWebDID(*did) {
+			parts := strings.Split(identity.Identifier, ":")
+			if len(parts) < partsLength {
+				return nil, errors.New("invalid identity")
 			}
+			item.Blockchain, item.Network = parts[2], parts[3]
 		}
+		items = append(items, item)
+	}
+	return items, nil
+}
 
-		items := strings.Split(identity.Identifier, ":")
-		if len(items) < partsLength {
-			return GetIdentities500JSONResponse{N500JSONResponse{
-				Message: "Invalid identity",
-			}}, nil
+func searchIdentitiesFilter(req SearchIdentitiesRequestObject) (*ports.GetIdentitiesRequest, error) {
+	const defaultMaxResults = 50
+	filter := &ports.GetIdentitiesRequest{
+		IncludeArchived: req.Params.IncludeArchived != nil && *req.Params.IncludeArchived,
+		Pagination:      pagination.Filter{MaxResults: defaultMaxResults, Page: common.ToPointer(uint(1))},
+	}
+	if req.Params.Query != nil {
+		filter.Query = strings.TrimSpace(*req.Params.Query)
+	}
+	if req.Params.Method != nil {
+		filter.Method = *req.Params.Method
+	}
+	if req.Params.Blockchain != nil {
+		filter.Blockchain = *req.Params.Blockchain
+	}
+	if req.Params.Network != nil {
+		filter.Network = *req.Params.Network
+	}
+	if req.Params.KeyType != nil {
+		if !slices.Contains([]SearchIdentitiesParamsKeyType{SearchIdentitiesParamsKeyTypeBJJ, SearchIdentitiesParamsKeyTypeETH}, *req.Params.KeyType) {
+			return nil, errors.New("wrong keyType value. Allowed values: [BJJ, ETH]")
+		}
+		filter.KeyType = string(*req.Params.KeyType)
+	}
+	if req.Params.CredentialStatusType != nil {
+		credentialStatusType, err := validateStatusType((*string)(req.Params.CredentialStatusType))
+		if err != nil {
+			return nil, err
 		}
+		filter.CredentialStatusType = string(*credentialStatusType)
+	}
 
-		response = append(response, GetIdentitiesResponse{
-			Identifier:           identity.Identifier,
-			Method:               items[1],
-			Blockchain:           items[2],
-			Network:              items[3],
-			CredentialStatusType: authBjjCredStatus,
-			DisplayName:          identity.DisplayName,
-		})
+	if req.Params.MaxResults != nil && *req.Params.MaxResults > 0 {
+		filter.Pagination.MaxResults = *req.Params.MaxResults
+	}
+	if req.Params.Page != nil {
+		if *req.Params.Page <= 0 {
+			return nil, errors.New("page param must be higher than 0")
+		}
+		filter.Pagination.Page = req.Params.Page
 	}
 
-	return response, nil
+	if req.Params.Sort != nil {
+		for _, sortBy := range *req.Params.Sort {
+			var err error
+			field, desc := strings.CutPrefix(strings.TrimSpace(string(sortBy)), "-")
+			switch SearchIdentitiesParamsSort(field) {
+			case SearchIdentitiesParamsSortDisplayName:
+				err = filter.OrderBy.AddWithNullsLast(ports.IdentitiesDisplayName, desc)
+			case SearchIdentitiesParamsSortCreatedAt:
+				err = filter.OrderBy.Add(ports.IdentitiesCreatedAt, desc)
+			case SearchIdentitiesParamsSortCredentialsIssued:
+				err = filter.OrderBy.Add(ports.IdentitiesCredentialsIssued, desc)
+			default:
+				return nil, errors.New("wrong sort by value")
+			}
+			if err != nil {
+				return nil, errors.New("repeated sort by value field")
+			}
+		}
+	}
+	return filter, nil
 }
 
 // GetIdentityDetails is the controller to get identity details
@@ -262,7 +399,7 @@ func (s *Server) GetIdentityDetails(ctx context.Context, request GetIdentityDeta
 	}
 
 	var balance *big.Int
-	if identity.KeyType == string(kms.KeyTypeEthereum) {
+	if identity.KeyType == string(kms.KeyTypeEthereum) && !domain.IsWebDID(*userDID) {
 		did, err := w3c.ParseDID(identity.Identifier)
 		if err != nil {
 			log.Error(ctx, "get identity details. Parsing did", "err", err)
@@ -306,7 +443,343 @@ func (s *Server) GetIdentityDetails(ctx context.Context, request GetIdentityDeta
 		Address:              responseAddress,
 		Balance:              responseBalance,
 		CredentialStatusType: GetIdentityDetailsResponseCredentialStatusType(identity.AuthCoreClaimRevocationStatus.Type),
+		Status:               IdentityLifecycleStatus(identity.LifecycleStatus()),
+		Profile:              toIdentityProfile(identity.Profile),
+	}
+	// did:web identities have no state to publish
+	if !domain.IsWebDID(*userDID) {
+		response.PublishingPolicy = toPublishingPolicy(identity.PublishingPolicy)
+	}
+
+	return response, nil
+}
+
+// RotateIdentityKey rotates the BJJ key of the identity and publishes the state.
+// If the state can not be published now, the rotation is published with the next state.
+func (s *Server) RotateIdentityKey(ctx context.Context, request RotateIdentityKeyRequestObject) (RotateIdentityKeyResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		log.Error(ctx, "rotate identity key. Parsing did", "err", err)
+		return RotateIdentityKey400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
 	}
 
+	authClaim, err := s.identityService.RotateKey(ctx, *did, request.Params.AuthCredentialID)
+	if err != nil {
+		log.Error(ctx, "rotate identity key", "err", err, "did", did)
+		if errors.Is(err, repositories.ErrIdentityNotFound) {
+			return RotateIdentityKey404JSONResponse{N404JSONResponse{Message: "identity not found"}}, nil
+		}
+		if errors.Is(err, services.ErrAuthClaimNotPublished) || errors.Is(err, services.ErrInvalidAuthClaim) || errors.Is(err, services.ErrIdentityDeactivated) ||
+			errors.Is(err, services.ErrWebIdentityNotSupported) {
+			return RotateIdentityKey400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		return RotateIdentityKey500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	response := RotateIdentityKey202JSONResponse{AuthCredentialID: authClaim.ID}
+	publishedState, err := s.publisherGateway.PublishState(ctx, did)
+	if err != nil {
+		// the rotation is committed, so it is pending and it is published with the next state
+		if errors.Is(err, gateways.ErrStateIsBeingProcessed) {
+			log.Info(ctx, "rotate identity key. The key rotation will be published with the next state", "did", did)
+		} else {
+			log.Error(ctx, "rotate identity key. Publishing state, the key rotation will be published with the next state", "err", err, "did", did)
+		}
+		return response, nil
+	}
+
+	response.PublishedState = &PublishIdentityStateResponse{
+		ClaimsTreeRoot:     publishedState.ClaimsTreeRoot,
+		RevocationTreeRoot: publishedState.RevocationTreeRoot,
+		RootOfRoots:        publishedState.RootOfRoots,
+		State:              publishedState.State,
+		TxID:               publishedState.TxID,
+	}
 	return response, nil
 }
+
+// DeactivateIdentity deactivates the identity and, if requested, revokes all its credentials and publishes the state
+func (s *Server) DeactivateIdentity(ctx context.Context, request DeactivateIdentityRequestObject) (DeactivateIdentityResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		log.Error(ctx, "deactivate identity. Parsing did", "err", err)
+		return DeactivateIdentity400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	response, err := s.deactivateIdentity(ctx, did, ports.DeactivateIdentityRequest{
+		RevokeCredentials: request.Params.RevokeCredentials != nil && *request.Params.RevokeCredentials,
+	})
+	if err != nil {
+		log.Error(ctx, "deactivate identity", "err", err, "did", did)
+		if errors.Is(err, repositories.ErrIdentityNotFound) {
+			return DeactivateIdentity404JSONResponse{N404JSONResponse{Message: "identity not found"}}, nil
+		}
+		if errors.Is(err, services.ErrIdentityDeactivated) {
+			return DeactivateIdentity409JSONResponse{N409JSONResponse{Message: err.Error()}}, nil
+		}
+		return DeactivateIdentity500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return DeactivateIdentity200JSONResponse(*response), nil
+}
+
+// ArchiveIdentity archives the identity, deactivating it if needed, so it is hidden from the identities list
+func (s *Server) ArchiveIdentity(ctx context.Context, request ArchiveIdentityRequestObject) (ArchiveIdentityResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		log.Error(ctx, "archive identity. Parsing did", "err", err)
+		return ArchiveIdentity400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	response, err := s.deactivateIdentity(ctx, did, ports.DeactivateIdentityRequest{
+		RevokeCredentials: request.Params.RevokeCredentials != nil && *request.Params.RevokeCredentials,
+		Archive:           true,
+	})
+	if err != nil {
+		log.Error(ctx, "archive identity", "err", err, "did", did)
+		if errors.Is(err, repositories.ErrIdentityNotFound) {
+			return ArchiveIdentity404JSONResponse{N404JSONResponse{Message: "identity not found"}}, nil
+		}
+		if errors.Is(err, services.ErrIdentityDeactivated) {
+			return ArchiveIdentity409JSONResponse{N409JSONResponse{Message: "identity is already archived"}}, nil
+		}
+		return ArchiveIdentity500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return ArchiveIdentity200JSONResponse(*response), nil
+}
+
+// deactivateIdentity deactivates the identity and publishes the revocations, if any.
+// If the state can not be published now, the revocations are published with the next state.
+func (s *Server) deactivateIdentity(ctx context.Context, did *w3c.DID, req ports.DeactivateIdentityRequest) (*DeactivateIdentityResponse, error) {
+	revoked, err := s.identityService.Deactivate(ctx, *did, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeactivateIdentityResponse{
+		Status:             IdentityLifecycleStatusDeactivated,
+		RevokedCredentials: len(revoked),
+	}
+	if req.Archive {
+		response.Status = IdentityLifecycleStatusArchived
+	}
+	if len(revoked) == 0 {
+		return response, nil
+	}
+
+	publishedState, err := s.publisherGateway.PublishState(ctx, did)
+	if err != nil {
+		log.Warn(ctx, "deactivate identity. The revocations will be published with the next state", "err", err, "did", did)
+		return response, nil
+	}
+	response.PublishedState = &PublishIdentityStateResponse{
+		ClaimsTreeRoot:     publishedState.ClaimsTreeRoot,
+		RevocationTreeRoot: publishedState.RevocationTreeRoot,
+		RootOfRoots:        publishedState.RootOfRoots,
+		State:              publishedState.State,
+		TxID:               publishedState.TxID,
+	}
+	return response, nil
+}
+
+// GetIdentityKeys returns the BJJ keys of the identity with their auth credentials
+func (s *Server) GetIdentityKeys(ctx context.Context, request GetIdentityKeysRequestObject) (GetIdentityKeysResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		log.Error(ctx, "get identity keys. Parsing did", "err", err)
+		return GetIdentityKeys400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	if _, err := s.identityService.Exists(ctx, *did); err != nil {
+		log.Error(ctx, "get identity keys. Getting identity", "err", err, "did", did)
+		if errors.Is(err, repositories.ErrIdentityNotFound) {
+			return GetIdentityKeys404JSONResponse{N404JSONResponse{Message: "identity not found"}}, nil
+		}
+		return GetIdentityKeys500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	authClaims, err := s.claimService.GetAuthClaims(ctx, did)
+	if err != nil {
+		log.Error(ctx, "get identity keys. Getting auth claims", "err", err, "did", did)
+		return GetIdentityKeys500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	response := make(GetIdentityKeys200JSONResponse, 0, len(authClaims))
+	for _, authClaim := range authClaims {
+		key, err := s.toIdentityKey(ctx, authClaim)
+		if err != nil {
+			log.Error(ctx, "get identity keys. Getting key id", "err", err, "did", did)
+			return GetIdentityKeys500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		}
+		response = append(response, key)
+	}
+	return response, nil
+}
+
+// CreateIdentityKey adds a BJJ key to the identity
+func (s *Server) CreateIdentityKey(ctx context.Context, request CreateIdentityKeyRequestObject) (CreateIdentityKeyResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		log.Error(ctx, "create identity key. Parsing did", "err", err)
+		return CreateIdentityKey400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	authClaim, err := s.identityService.AddAuthKey(ctx, *did)
+	if err != nil {
+		log.Error(ctx, "create identity key", "err", err, "did", did)
+		if errors.Is(err, repositories.ErrIdentityNotFound) {
+			return CreateIdentityKey404JSONResponse{N404JSONResponse{Message: "identity not found"}}, nil
+		}
+		if errors.Is(err, services.ErrIdentityDeactivated) || errors.Is(err, services.ErrWebIdentityNotSupported) {
+			return CreateIdentityKey400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		return CreateIdentityKey500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	key, err := s.toIdentityKey(ctx, authClaim)
+	if err != nil {
+		log.Error(ctx, "create identity key. Getting key id", "err", err, "did", did)
+		return CreateIdentityKey500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return CreateIdentityKey201JSONResponse(key), nil
+}
+
+// CreateIdentityBackup exports the identity in an archive signed with the passphrase
+func (s *Server) CreateIdentityBackup(ctx context.Context, request CreateIdentityBackupRequestObject) (CreateIdentityBackupResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		log.Error(ctx, "create identity backup. Parsing did", "err", err)
+		return CreateIdentityBackup400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	archive, err := s.identityBackupService.Export(ctx, *did, ports.IdentityBackupRequest{
+		Passphrase:  request.Body.Passphrase,
+		IncludeKeys: request.Body.IncludeKeys != nil && *request.Body.IncludeKeys,
+	})
+	if err != nil {
+		log.Error(ctx, "create identity backup", "err", err, "did", did)
+		if errors.Is(err, repositories.ErrIdentityNotFound) {
+			return CreateIdentityBackup404JSONResponse{N404JSONResponse{Message: "identity not found"}}, nil
+		}
+		if errors.Is(err, services.ErrIdentityBackupPassphraseRequired) || errors.Is(err, kms.ErrKeyExportNotSupported) {
+			return CreateIdentityBackup400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		return CreateIdentityBackup500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return CreateIdentityBackup200ApplicationoctetStreamResponse{
+		Body:          bytes.NewReader(archive),
+		ContentLength: int64(len(archive)),
+	}, nil
+}
+
+func (s *Server) toIdentityKey(ctx context.Context, authClaim *domain.Claim) (IdentityKey, error) {
+	keyID, err := s.identityService.GetKeyIDFromAuthClaim(ctx, authClaim)
+	if err != nil {
+		return IdentityKey{}, err
+	}
+	return IdentityKey{
+		AuthCredentialID: authClaim.ID,
+		KeyID:            keyID.ID,
+		RevNonce:         uint64(authClaim.RevNonce),
+		Revoked:          authClaim.Revoked,
+		Published:        authClaim.MTPProof.Status == pgtype.Present,
+	}, nil
+}
+
+// issuerDescription returns the profile of the issuer shown to the holders in the offers.
+// The name and logo fall back to the ones of the node configuration.
+func (s *Server) issuerDescription(ctx context.Context, issuerDID w3c.DID) (IssuerDescription, error) {
+	identity, err := s.identityService.GetByDID(ctx, issuerDID)
+	if err != nil {
+		return IssuerDescription{}, err
+	}
+	description := IssuerDescription{
+		DisplayName: s.cfg.IssuerName,
+		Logo:        s.cfg.IssuerLogo,
+		Description: toOptionalString(identity.Profile.Description),
+		Website:     toOptionalString(identity.Profile.Website),
+		Contact:     toOptionalString(identity.Profile.Contact),
+	}
+	if identity.Profile.Name != "" {
+		description.DisplayName = identity.Profile.Name
+	}
+	if identity.Profile.Logo != "" {
+		description.Logo = identity.Profile.Logo
+	}
+	return description, nil
+}
+
+func toIdentityProfile(profile domain.IdentityProfile) *IdentityProfile {
+	if profile.IsEmpty() {
+		return nil
+	}
+	return &IdentityProfile{
+		Name:        toOptionalString(profile.Name),
+		Logo:        toOptionalString(profile.Logo),
+		Description: toOptionalString(profile.Description),
+		Website:     toOptionalString(profile.Website),
+		Contact:     toOptionalString(profile.Contact),
+	}
+}
+
+func toDomainIdentityProfileUpdate(profile *IdentityProfile) *domain.IdentityProfileUpdate {
+	if profile == nil {
+		return nil
+	}
+	value := func(s *string) *string {
+		if s == nil {
+			return nil
+		}
+		return common.ToPointer(strings.TrimSpace(*s))
+	}
+	return &domain.IdentityProfileUpdate{
+		Name:        value(profile.Name),
+		Logo:        value(profile.Logo),
+		Description: value(profile.Description),
+		Website:     value(profile.Website),
+		Contact:     value(profile.Contact),
+	}
+}
+
+func toPublishingPolicy(policy domain.PublishingPolicy) *PublishingPolicy {
+	return &PublishingPolicy{
+		Mode:                          PublishingPolicyMode(policy.Mode),
+		MinPendingChanges:             toOptionalUint(policy.MinPendingChanges),
+		IntervalMinutes:               toOptionalUint(policy.IntervalMinutes),
+		Cron:                          toOptionalString(policy.Cron),
+		PublishRevocationsImmediately: policy.PublishRevocationsImmediately,
+	}
+}
+
+// toDomainPublishingPolicy keeps only the parameters of the mode of the policy
+func toDomainPublishingPolicy(policy *PublishingPolicy) *domain.PublishingPolicy {
+	if policy == nil {
+		return nil
+	}
+	res := &domain.PublishingPolicy{
+		Mode:                          domain.PublishingPolicyMode(policy.Mode),
+		PublishRevocationsImmediately: policy.PublishRevocationsImmediately,
+	}
+	switch {
+	case res.Mode == domain.PublishingPolicyPendingCount && policy.MinPendingChanges != nil:
+		res.MinPendingChanges = *policy.MinPendingChanges
+	case res.Mode == domain.PublishingPolicyInterval && policy.IntervalMinutes != nil:
+		res.IntervalMinutes = *policy.IntervalMinutes
+	case res.Mode == domain.PublishingPolicyCron && policy.Cron != nil:
+		res.Cron = strings.TrimSpace(*policy.Cron)
+	}
+	return res
+}
+
+func toOptionalUint(n uint) *uint {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
+func toOptionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
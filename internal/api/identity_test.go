@@ -9,7 +9,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
 	"github.com/iden3/go-schema-processor/v2/verifiable"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,7 +19,9 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/common"
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
 	"github.com/polygonid/sh-id-platform/internal/db/tests"
+	"github.com/polygonid/sh-id-platform/internal/kms"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
 )
 
@@ -284,6 +288,7 @@ func TestServer_GetIdentities(t *testing.T) {
 
 	type expected struct {
 		httpCode int
+		items    int // minimum number of items
 	}
 	type testConfig struct {
 		name     string
@@ -304,6 +309,7 @@ func TestServer_GetIdentities(t *testing.T) {
 			auth: authOk,
 			expected: expected{
 				httpCode: 200,
+				items:    2,
 			},
 		},
 	} {
@@ -318,8 +324,127 @@ func TestServer_GetIdentities(t *testing.T) {
 			if tc.expected.httpCode == http.StatusOK {
 				var response GetIdentities200JSONResponse
 				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
-				assert.Equal(t, tc.expected.httpCode, rr.Code)
-				assert.True(t, len(response) >= 2)
+				assert.True(t, len(response) >= tc.expected.items)
+			}
+		})
+	}
+}
+
+func TestServer_SearchIdentities(t *testing.T) {
+	server := newTestServer(t, nil)
+	handler := getHandler(context.Background(), server)
+
+	identity1 := &domain.Identity{Identifier: "did:polygonid:polygon:mumbai:2qGiu3U6diaSAkU8LfCVjrv4fDyDvgdi4997eQGP7e"}
+	identity2 := &domain.Identity{Identifier: "did:polygonid:polygon:mumbai:2qPoY6vDcEJVXTyQrwHumrcpQXMJ72vtu9T1UtPLkQ"}
+	fixture := repositories.NewFixture(storage)
+	fixture.CreateIdentity(t, identity1)
+	fixture.CreateIdentity(t, identity2)
+
+	type expected struct {
+		httpCode   int
+		items      int // minimum number of items
+		total      uint
+		page       uint
+		maxResults uint
+	}
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		url      string
+		expected expected
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name: "No auth header",
+			auth: authWrong,
+			url:  "/v2/identities/search",
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "should return the first page by default",
+			auth: authOk,
+			url:  "/v2/identities/search",
+			expected: expected{
+				httpCode:   200,
+				items:      2,
+				page:       1,
+				maxResults: 50,
+			},
+		},
+		{
+			name: "should search by did and filter by network",
+			auth: authOk,
+			url:  "/v2/identities/search?query=2qGiu3U6diaSAkU8LfCVjr&network=mumbai&sort=-displayName",
+			expected: expected{
+				httpCode:   200,
+				items:      1,
+				total:      1,
+				page:       1,
+				maxResults: 50,
+			},
+		},
+		{
+			name: "should match the wildcards literally",
+			auth: authOk,
+			url:  "/v2/identities/search?query=2qGiu%25U6dia",
+			expected: expected{
+				httpCode:   200,
+				page:       1,
+				maxResults: 50,
+			},
+		},
+		{
+			name: "should paginate",
+			auth: authOk,
+			url:  "/v2/identities/search?page=2&max_results=1&sort=createdAt",
+			expected: expected{
+				httpCode:   200,
+				items:      1,
+				page:       2,
+				maxResults: 1,
+			},
+		},
+		{
+			name: "wrong page",
+			auth: authOk,
+			url:  "/v2/identities/search?page=0",
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "wrong sort",
+			auth: authOk,
+			url:  "/v2/identities/search?sort=status",
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", tc.url, nil)
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			if tc.expected.httpCode == http.StatusOK {
+				var response SearchIdentities200JSONResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.True(t, len(response.Items) >= tc.expected.items)
+				assert.True(t, len(response.Items) <= int(tc.expected.maxResults))
+				if tc.expected.items == 0 {
+					assert.Empty(t, response.Items)
+				}
+				if tc.expected.total > 0 {
+					assert.Equal(t, tc.expected.total, response.Meta.Total)
+				}
+				assert.Equal(t, tc.expected.page, response.Meta.Page)
+				assert.Equal(t, tc.expected.maxResults, response.Meta.MaxResults)
 			}
 		})
 	}
@@ -423,6 +548,8 @@ func TestServer_UpdateIdentity(t *testing.T) {
 	identity := &domain.Identity{Identifier: "did:polygonid:polygon:amoy:2qQ8S2VKdQv7xYgzCn7KW2xgzUWrTRQjoZDYavJHBq"}
 	fixture := repositories.NewFixture(storage)
 	fixture.CreateIdentity(t, identity)
+	did, err := w3c.ParseDID(identity.Identifier)
+	require.NoError(t, err)
 
 	state := domain.IdentityState{
 		Identifier: identity.Identifier,
@@ -434,8 +561,11 @@ func TestServer_UpdateIdentity(t *testing.T) {
 	fixture.CreateIdentityStatus(t, state)
 
 	type expected struct {
-		httpCode    int
-		displayName *string
+		httpCode         int
+		displayName      *string
+		profile          *IdentityProfile
+		updatedProfile   *IdentityProfile // stored profile when it differs from the one sent
+		publishingPolicy *PublishingPolicy
 	}
 	type testConfig struct {
 		name     string
@@ -460,11 +590,78 @@ func TestServer_UpdateIdentity(t *testing.T) {
 				displayName: common.ToPointer("new display name"),
 			},
 		},
+		{
+			name: "should update the profile",
+			auth: authOk,
+			expected: expected{
+				httpCode: 200,
+				profile: &IdentityProfile{
+					Name:    common.ToPointer("Acme KYC"),
+					Logo:    common.ToPointer("https://acme.com/logo.png"),
+					Website: common.ToPointer("https://acme.com"),
+					Contact: common.ToPointer("support@acme.com"),
+				},
+			},
+		},
+		{
+			name: "should change only the profile fields sent",
+			auth: authOk,
+			expected: expected{
+				httpCode: 200,
+				profile: &IdentityProfile{
+					Description: common.ToPointer("KYC credentials issued by Acme"),
+					Contact:     common.ToPointer(""),
+				},
+				updatedProfile: &IdentityProfile{
+					Name:        common.ToPointer("Acme KYC"),
+					Logo:        common.ToPointer("https://acme.com/logo.png"),
+					Description: common.ToPointer("KYC credentials issued by Acme"),
+					Website:     common.ToPointer("https://acme.com"),
+				},
+			},
+		},
+		{
+			name: "invalid logo",
+			auth: authOk,
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				profile:  &IdentityProfile{Logo: common.ToPointer("logo.png")},
+			},
+		},
+		{
+			name: "should update the publishing policy",
+			auth: authOk,
+			expected: expected{
+				httpCode: 200,
+				publishingPolicy: &PublishingPolicy{
+					Mode:                          PublishingPolicyMode(domain.PublishingPolicyCron),
+					Cron:                          common.ToPointer("0 */6 * * *"),
+					PublishRevocationsImmediately: true,
+				},
+			},
+		},
+		{
+			name: "invalid publishing policy",
+			auth: authOk,
+			expected: expected{
+				httpCode:         http.StatusBadRequest,
+				publishingPolicy: &PublishingPolicy{Mode: PublishingPolicyMode(domain.PublishingPolicyPendingCount)},
+			},
+		},
+		{
+			name: "nothing to update",
+			auth: authOk,
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			rr := httptest.NewRecorder()
 			body := UpdateIdentityJSONBody{
-				DisplayName: *tc.expected.displayName,
+				DisplayName:      tc.expected.displayName,
+				Profile:          tc.expected.profile,
+				PublishingPolicy: tc.expected.publishingPolicy,
 			}
 
 			url := fmt.Sprintf("/v2/identities/%s", identity.Identifier)
@@ -477,7 +674,468 @@ func TestServer_UpdateIdentity(t *testing.T) {
 			if tc.expected.httpCode == http.StatusOK {
 				var response UpdateIdentity200JSONResponse
 				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				if tc.expected.profile != nil {
+					updated, err := server.identityService.GetByDID(context.Background(), *did)
+					require.NoError(t, err)
+					expectedProfile := tc.expected.profile
+					if tc.expected.updatedProfile != nil {
+						expectedProfile = tc.expected.updatedProfile
+					}
+					assert.Equal(t, expectedProfile, toIdentityProfile(updated.Profile))
+				}
+				if tc.expected.publishingPolicy != nil {
+					updated, err := server.identityService.GetByDID(context.Background(), *did)
+					require.NoError(t, err)
+					assert.Equal(t, tc.expected.publishingPolicy, toPublishingPolicy(updated.PublishingPolicy))
+				}
+			}
+		})
+	}
+}
+
+func TestServer_RotateIdentityKey(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		ETH        = "ETH"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	ethIdentity, err := server.Services.identity.Create(ctx, "http://polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: ETH})
+	require.NoError(t, err)
+	bjjIdentity, err := server.Services.identity.Create(ctx, "http://polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: "BJJ"})
+	require.NoError(t, err)
+
+	type expected struct {
+		httpCode int
+		message  string
+	}
+	type testConfig struct {
+		name       string
+		auth       func() (string, string)
+		identifier string
+		expected   expected
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name:       "No auth header",
+			auth:       authWrong,
+			identifier: ethIdentity.Identifier,
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name:       "invalid did",
+			auth:       authOk,
+			identifier: "did:wrong",
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  "invalid did",
+			},
+		},
+		{
+			name:       "unknown identity",
+			auth:       authOk,
+			identifier: "did:polygonid:polygon:amoy:2qQ8S2VKdQv7xYgzCn7KW2xgzUWrTRQjoZDYavJHBq",
+			expected: expected{
+				httpCode: http.StatusNotFound,
+				message:  "identity not found",
+			},
+		},
+		{
+			name:       "auth credential not published",
+			auth:       authOk,
+			identifier: ethIdentity.Identifier,
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  services.ErrAuthClaimNotPublished.Error(),
+			},
+		},
+		{
+			name:       "key rotated, the state is pending because it can not be published",
+			auth:       authOk,
+			identifier: bjjIdentity.Identifier,
+			expected: expected{
+				httpCode: http.StatusAccepted,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			url := fmt.Sprintf("/v2/identities/%s/keys/rotate", tc.identifier)
+			req, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+			req.SetBasicAuth(tc.auth())
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			switch tc.expected.httpCode {
+			case http.StatusAccepted:
+				var response RotateIdentityKey202JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.NotEqual(t, uuid.Nil, response.AuthCredentialID)
+				assert.Nil(t, response.PublishedState)
+			case http.StatusBadRequest:
+				var response RotateIdentityKey400JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			case http.StatusNotFound:
+				var response RotateIdentityKey404JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			}
+		})
+	}
+}
+
+func TestServer_IdentityKeys(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	identity, err := server.Services.identity.Create(ctx, "http://polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+	url := fmt.Sprintf("/v2/identities/%s/keys", identity.Identifier)
+
+	t.Run("should create a key", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, url, nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var response CreateIdentityKey201JSONResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.NotEmpty(t, response.KeyID)
+		assert.False(t, response.Published)
+		assert.False(t, response.Revoked)
+	})
+
+	t.Run("should not create a key without auth", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, url, nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authWrong())
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should list the keys", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response GetIdentityKeys200JSONResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response, 2)
+		assert.True(t, response[0].Published)
+		assert.False(t, response[1].Published)
+		assert.NotEqual(t, response[0].KeyID, response[1].KeyID)
+	})
+
+	t.Run("should not list the keys of an invalid did", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/v2/identities/did:wrong/keys", nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should not list the keys of an unknown identity", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/v2/identities/did:polygonid:polygon:amoy:2qE1ZT16aqEWhh9mX9aqM2pe2ZwV995dTkReeKwCaQ/keys", nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestServer_CreateIdentityBackup(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	identity, err := server.Services.identity.Create(ctx, "http://polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+
+	type expected struct {
+		httpCode int
+		message  string
+	}
+	type testConfig struct {
+		name       string
+		auth       func() (string, string)
+		identifier string
+		body       CreateIdentityBackupRequest
+		expected   expected
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name:       "No auth header",
+			auth:       authWrong,
+			identifier: identity.Identifier,
+			body:       CreateIdentityBackupRequest{Passphrase: "passphrase"},
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name:       "invalid did",
+			auth:       authOk,
+			identifier: "did:wrong",
+			body:       CreateIdentityBackupRequest{Passphrase: "passphrase"},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  "invalid did",
+			},
+		},
+		{
+			name:       "unknown identity",
+			auth:       authOk,
+			identifier: "did:polygonid:polygon:amoy:2qQ8S2VKdQv7xYgzCn7KW2xgzUWrTRQjoZDYavJHBq",
+			body:       CreateIdentityBackupRequest{Passphrase: "passphrase"},
+			expected: expected{
+				httpCode: http.StatusNotFound,
+				message:  "identity not found",
+			},
+		},
+		{
+			name:       "empty passphrase",
+			auth:       authOk,
+			identifier: identity.Identifier,
+			body:       CreateIdentityBackupRequest{Passphrase: ""},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  services.ErrIdentityBackupPassphraseRequired.Error(),
+			},
+		},
+		{
+			name:       "keys of the vault plugin can not be exported",
+			auth:       authOk,
+			identifier: identity.Identifier,
+			body:       CreateIdentityBackupRequest{Passphrase: "passphrase", IncludeKeys: common.ToPointer(true)},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  kms.ErrKeyExportNotSupported.Error(),
+			},
+		},
+		{
+			name:       "happy path",
+			auth:       authOk,
+			identifier: identity.Identifier,
+			body:       CreateIdentityBackupRequest{Passphrase: "passphrase"},
+			expected: expected{
+				httpCode: http.StatusOK,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			url := fmt.Sprintf("/v2/identities/%s/backup", tc.identifier)
+			req, err := http.NewRequest(http.MethodPost, url, tests.JSONBody(t, tc.body))
+			require.NoError(t, err)
+			req.SetBasicAuth(tc.auth())
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			switch tc.expected.httpCode {
+			case http.StatusOK:
+				assert.Equal(t, "application/octet-stream", rr.Header().Get("Content-Type"))
+				var archive map[string]any
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &archive))
+				assert.NotEmpty(t, archive["bundle"])
+				assert.NotEmpty(t, archive["signature"])
+			case http.StatusBadRequest:
+				var response CreateIdentityBackup400JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			case http.StatusNotFound:
+				var response CreateIdentityBackup404JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
 			}
 		})
 	}
 }
+
+func TestServer_DeactivateIdentity(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	identity, err := server.Services.identity.Create(ctx, "http://polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+
+	type expected struct {
+		httpCode int
+		status   IdentityLifecycleStatus
+		message  string
+	}
+	type testConfig struct {
+		name       string
+		auth       func() (string, string)
+		identifier string
+		operation  string
+		expected   expected
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name:       "No auth header",
+			auth:       authWrong,
+			identifier: identity.Identifier,
+			operation:  "deactivate",
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name:       "invalid did",
+			auth:       authOk,
+			identifier: "did:wrong",
+			operation:  "deactivate",
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  "invalid did",
+			},
+		},
+		{
+			name:       "unknown identity",
+			auth:       authOk,
+			identifier: "did:polygonid:polygon:amoy:2qQ8S2VKdQv7xYgzCn7KW2xgzUWrTRQjoZDYavJHBq",
+			operation:  "deactivate",
+			expected: expected{
+				httpCode: http.StatusNotFound,
+				message:  "identity not found",
+			},
+		},
+		{
+			name:       "should deactivate the identity",
+			auth:       authOk,
+			identifier: identity.Identifier,
+			operation:  "deactivate",
+			expected: expected{
+				httpCode: http.StatusOK,
+				status:   IdentityLifecycleStatusDeactivated,
+			},
+		},
+		{
+			name:       "should not deactivate the identity twice",
+			auth:       authOk,
+			identifier: identity.Identifier,
+			operation:  "deactivate",
+			expected: expected{
+				httpCode: http.StatusConflict,
+				message:  services.ErrIdentityDeactivated.Error(),
+			},
+		},
+		{
+			name:       "should archive the identity",
+			auth:       authOk,
+			identifier: identity.Identifier,
+			operation:  "archive",
+			expected: expected{
+				httpCode: http.StatusOK,
+				status:   IdentityLifecycleStatusArchived,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			url := fmt.Sprintf("/v2/identities/%s/%s?revokeCredentials=true", tc.identifier, tc.operation)
+			req, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+			req.SetBasicAuth(tc.auth())
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			switch tc.expected.httpCode {
+			case http.StatusOK:
+				var response DeactivateIdentity200JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.status, response.Status)
+				assert.Equal(t, 0, response.RevokedCredentials)
+			case http.StatusBadRequest:
+				var response DeactivateIdentity400JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			case http.StatusNotFound:
+				var response DeactivateIdentity404JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			case http.StatusConflict:
+				var response DeactivateIdentity409JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			}
+		})
+	}
+
+	t.Run("should hide the archived identity", func(t *testing.T) {
+		for _, includeArchived := range []bool{false, true} {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v2/identities?includeArchived=%t", includeArchived), nil)
+			require.NoError(t, err)
+			req.SetBasicAuth(authOk())
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			var response GetIdentities200JSONResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			found := false
+			for _, item := range response {
+				if item.Identifier == identity.Identifier {
+					found = true
+					assert.Equal(t, IdentityLifecycleStatusArchived, item.Status)
+				}
+			}
+			assert.Equal(t, includeArchived, found)
+		}
+	})
+
+	t.Run("should not create keys for an archived identity", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/v2/identities/%s/keys", identity.Identifier), nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		var response CreateIdentityKey400JSONResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, services.ErrIdentityDeactivated.Error(), response.Message)
+	})
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -73,15 +74,28 @@ func (s *Server) CreateLink(ctx context.Context, request CreateLinkRequestObject
 		expirationDate = request.Body.CredentialExpiration
 	}
 
-	createdLink, err := s.linkService.Save(ctx, *issuerDID, request.Body.LimitedClaims, request.Body.Expiration, request.Body.SchemaID, expirationDate, request.Body.SignatureProof, request.Body.MtProof, credSubject, toVerifiableRefreshService(request.Body.RefreshService), toDisplayMethodService(request.Body.DisplayMethod))
+	raw, err := s.idempotencyService.Do(ctx, *issuerDID, domain.IdempotentOperationCreateLink, idempotencyKey(request.Params.IdempotencyKey), request.Body, func(ctx context.Context) (any, error) {
+		createdLink, err := s.linkService.Save(ctx, *issuerDID, request.Body.LimitedClaims, request.Body.Expiration, request.Body.SchemaID, expirationDate, request.Body.SignatureProof, request.Body.MtProof, credSubject, toVerifiableRefreshService(request.Body.RefreshService), toDisplayMethodService(request.Body.DisplayMethod))
+		if err != nil {
+			return nil, err
+		}
+		return CreateLink201JSONResponse{Id: createdLink.ID.String()}, nil
+	})
 	if err != nil {
+		if isIdempotencyConflict(err) {
+			return CreateLink409JSONResponse{N409JSONResponse{Message: err.Error()}}, nil
+		}
 		log.Error(ctx, "error saving the link", "err", err.Error())
 		if errors.Is(err, services.ErrLoadingSchema) {
 			return CreateLink500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 		}
 		return CreateLink400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
-	return CreateLink201JSONResponse{Id: createdLink.ID.String()}, nil
+	var response CreateLink201JSONResponse
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return CreateLink500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return response, nil
 }
 
 // CreateLinkQrCodeCallback - Callback endpoint for the link qr code creation.
@@ -189,28 +203,45 @@ func (s *Server) CreateLinkOffer(ctx context.Context, req CreateLinkOfferRequest
 		log.Error(ctx, "parsing issuer did", "err", err, "did", req.Identifier)
 		return CreateLinkOffer400JSONResponse{N400JSONResponse{Message: "invalid issuer did"}}, nil
 	}
-	createLinkQrCodeResponse, err := s.linkService.CreateQRCode(ctx, *issuerDID, req.Id, s.cfg.ServerUrl)
+	raw, err := s.idempotencyService.Do(ctx, *issuerDID, domain.IdempotentOperationCreateLinkOffer, idempotencyKey(req.Params.IdempotencyKey), req.Id, func(ctx context.Context) (any, error) {
+		createLinkQrCodeResponse, err := s.linkService.CreateQRCode(ctx, *issuerDID, req.Id, s.cfg.ServerUrl)
+		if err != nil {
+			return nil, err
+		}
+		issuer, err := s.issuerDescription(ctx, *issuerDID)
+		if err != nil {
+			return nil, err
+		}
+		return CreateLinkOffer200JSONResponse{
+			Issuer:        issuer,
+			DeepLink:      createLinkQrCodeResponse.DeepLink,
+			UniversalLink: createLinkQrCodeResponse.UniversalLink,
+			Message:       createLinkQrCodeResponse.QrCodeRaw,
+			LinkDetail:    getLinkSimpleResponse(*createLinkQrCodeResponse.Link),
+		}, nil
+	})
 	if err != nil {
+		if isIdempotencyConflict(err) {
+			return CreateLinkOffer409JSONResponse{N409JSONResponse{Message: err.Error()}}, nil
+		}
 		if errors.Is(err, services.ErrLinkNotFound) {
 			return CreateLinkOffer404JSONResponse{N404JSONResponse{Message: "error: link not found"}}, nil
 		}
 		if errors.Is(err, services.ErrLinkAlreadyExpired) || errors.Is(err, services.ErrLinkMaxExceeded) || errors.Is(err, services.ErrLinkInactive) {
 			return CreateLinkOffer404JSONResponse{N404JSONResponse{Message: "error: " + err.Error()}}, nil
 		}
+		if errors.Is(err, services.ErrIdentityDeactivated) {
+			return CreateLinkOffer400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
 		log.Error(ctx, "Unexpected error while creating qr code", "err", err)
 		return CreateLinkOffer500JSONResponse{N500JSONResponse{"Unexpected error while creating qr code"}}, nil
 	}
 
-	return CreateLinkOffer200JSONResponse{
-		Issuer: IssuerDescription{
-			DisplayName: s.cfg.IssuerName,
-			Logo:        s.cfg.IssuerLogo,
-		},
-		DeepLink:      createLinkQrCodeResponse.DeepLink,
-		UniversalLink: createLinkQrCodeResponse.UniversalLink,
-		Message:       createLinkQrCodeResponse.QrCodeRaw,
-		LinkDetail:    getLinkSimpleResponse(*createLinkQrCodeResponse.Link),
-	}, nil
+	var response CreateLinkOffer200JSONResponse
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return CreateLinkOffer500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return response, nil
 }
 
 func toDisplayMethodService(s *DisplayMethod) *verifiable.DisplayMethod {
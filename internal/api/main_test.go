@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/hashicorp/vault/api"
@@ -198,6 +199,15 @@ func (kpm *KMSMock) LinkToIdentity(ctx context.Context, keyID kms.KeyID, identit
 	return key, nil
 }
 
+func (kpm *KMSMock) ExportPrivateKey(ctx context.Context, keyID kms.KeyID) ([]byte, error) {
+	var privateKey []byte
+	return privateKey, nil
+}
+
+func (kpm *KMSMock) ImportPrivateKey(ctx context.Context, keyID kms.KeyID, privateKey []byte) error {
+	return nil
+}
+
 // TODO: add package manager mocks
 func NewPackageManagerMock() *iden3comm.PackageManager {
 	return &iden3comm.PackageManager{}
@@ -226,15 +236,18 @@ func NewLinkMock() ports.LinkService {
 }
 
 type repos struct {
-	claims         ports.ClaimRepository
-	connection     ports.ConnectionRepository
-	identity       ports.IndentityRepository
-	idenMerkleTree ports.IdentityMerkleTreeRepository
-	identityState  ports.IdentityStateRepository
-	links          ports.LinkRepository
-	schemas        ports.SchemaRepository
-	sessions       ports.SessionRepository
-	revocation     ports.RevocationRepository
+	claims            ports.ClaimRepository
+	connection        ports.ConnectionRepository
+	credentialImports ports.CredentialImportRepository
+	statusLists       ports.StatusListRepository
+	idempotencyKeys   ports.IdempotencyKeyRepository
+	identity          ports.IndentityRepository
+	idenMerkleTree    ports.IdentityMerkleTreeRepository
+	identityState     ports.IdentityStateRepository
+	links             ports.LinkRepository
+	schemas           ports.SchemaRepository
+	sessions          ports.SessionRepository
+	revocation        ports.RevocationRepository
 }
 
 type servicex struct {
@@ -263,22 +276,25 @@ func newTestServer(t *testing.T, st *db.Storage) *testServer {
 		st = storage
 	}
 	repos := repos{
-		claims:         repositories.NewClaim(),
-		connection:     repositories.NewConnection(),
-		identity:       repositories.NewIdentity(),
-		idenMerkleTree: repositories.NewIdentityMerkleTreeRepository(),
-		identityState:  repositories.NewIdentityState(),
-		links:          repositories.NewLink(*st),
-		sessions:       repositories.NewSessionCached(cachex),
-		schemas:        repositories.NewSchema(*st),
-		revocation:     repositories.NewRevocation(),
+		claims:            repositories.NewClaim(),
+		connection:        repositories.NewConnection(),
+		credentialImports: repositories.NewCredentialImport(),
+		statusLists:       repositories.NewStatusList(),
+		idempotencyKeys:   repositories.NewIdempotencyKey(),
+		identity:          repositories.NewIdentity(),
+		idenMerkleTree:    repositories.NewIdentityMerkleTreeRepository(),
+		identityState:     repositories.NewIdentityState(),
+		links:             repositories.NewLink(*st),
+		sessions:          repositories.NewSessionCached(cachex),
+		schemas:           repositories.NewSchema(*st),
+		revocation:        repositories.NewRevocation(),
 	}
 
 	pubSub := pubsub.NewMock()
 
 	networkResolver, err := network.NewResolver(context.Background(), cfg, keyStore, common.CreateFile(t))
 	require.NoError(t, err)
-	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repos.statusLists)
 
 	mtService := services.NewIdentityMerkleTrees(repos.idenMerkleTree)
 	qrService := services.NewQrStoreService(cachex)
@@ -298,7 +314,10 @@ func newTestServer(t *testing.T, st *db.Storage) *testServer {
 	claimsService := services.NewClaim(repos.claims, identityService, qrService, mtService, repos.identityState, schemaLoader, st, cfg.ServerUrl, pubSub, ipfsGatewayURL, revocationStatusResolver, mediaTypeManager, cfg.UniversalLinks)
 	accountService := services.NewAccountService(*networkResolver)
 	linkService := services.NewLinkService(storage, claimsService, qrService, repos.claims, repos.links, repos.schemas, schemaLoader, repos.sessions, pubSub, identityService, *networkResolver, cfg.UniversalLinks)
-	server := NewServer(&cfg, identityService, accountService, connectionService, claimsService, qrService, NewPublisherMock(), NewPackageManagerMock(), *networkResolver, nil, schemaService, linkService)
+	credentialImportService := services.NewCredentialImport(repos.credentialImports, repos.schemas, claimsService, schemaLoader, st)
+	credentialExportService := services.NewCredentialExport(keyStore)
+	statusListService := services.NewStatusList(repos.statusLists, st, claimsService, identityService, credentialExportService, revocationStatusResolver, schemaLoader)
+	server := NewServer(&cfg, identityService, accountService, connectionService, claimsService, qrService, NewPublisherMock(), NewPackageManagerMock(), *networkResolver, nil, schemaService, linkService, credentialImportService, statusListService, credentialExportService, services.NewIdempotency(repos.idempotencyKeys, st, time.Hour, time.Minute), services.NewIdentityBackup(keyStore, repos.identity, repos.idenMerkleTree, repos.identityState, repositories.NewIdentityBackup(), mtService, st), services.NewDIDDocument(keyStore, repos.identity, repos.claims, *networkResolver, st, cfg.ServerUrl), services.NewPublishingKeys(*networkResolver, cfg.PublishingKeyPath))
 
 	return &testServer{
 		Server: server,
@@ -2,6 +2,9 @@ package api
 
 import (
 	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/log"
 )
 
 // GetSupportedNetworks is the controller to get supported networks
@@ -37,3 +40,30 @@ func (s *Server) supportedNetworksData(ctx context.Context, blockchain string, n
 	}
 	return nd
 }
+
+// GetPublishingKeysStatus is the controller to get the balance of the publishing keys
+func (s *Server) GetPublishingKeysStatus(ctx context.Context, _ GetPublishingKeysStatusRequestObject) (GetPublishingKeysStatusResponseObject, error) {
+	statuses, err := s.publishingKeyService.Status(ctx)
+	if err != nil {
+		log.Error(ctx, "get publishing keys status", "err", err)
+		return GetPublishingKeysStatus500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp := make(GetPublishingKeysStatus200JSONResponse, 0, len(statuses))
+	for _, status := range statuses {
+		var minBalance *string
+		if status.MinBalance != nil {
+			minBalance = common.ToPointer(status.MinBalance.String())
+		}
+		resp = append(resp, PublishingKeyStatus{
+			Blockchain: status.Blockchain,
+			Network:    status.Network,
+			Address:    status.Address,
+			Balance:    status.Balance.String(),
+			MinBalance: minBalance,
+			InFlight:   status.InFlight,
+			Available:  status.Available,
+		})
+	}
+	return resp, nil
+}
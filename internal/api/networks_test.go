@@ -3,12 +3,18 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	ethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/kms"
 )
 
 func TestServer_GetSupportedNetworks(t *testing.T) {
@@ -64,3 +70,77 @@ func TestServer_GetSupportedNetworks(t *testing.T) {
 		})
 	}
 }
+
+type publishingKeysStub struct {
+	statuses []domain.PublishingKeyStatus
+}
+
+func (p *publishingKeysStub) Acquire(_ context.Context, _ string) (kms.KeyID, func(), error) {
+	return kms.KeyID{}, func() {}, nil
+}
+
+func (p *publishingKeysStub) KeyByAddress(_ context.Context, _ string, _ ethCommon.Address) (kms.KeyID, error) {
+	return kms.KeyID{}, nil
+}
+
+func (p *publishingKeysStub) Status(_ context.Context) ([]domain.PublishingKeyStatus, error) {
+	return p.statuses, nil
+}
+
+func TestServer_GetPublishingKeysStatus(t *testing.T) {
+	server := newTestServer(t, nil)
+	server.publishingKeyService = &publishingKeysStub{
+		statuses: []domain.PublishingKeyStatus{
+			{Blockchain: "polygon", Network: "amoy", Address: "0x85f0Bf2D4E0A3a4B4A7A69E3d9F1A8Bfb4e9bD31", Balance: big.NewInt(250), MinBalance: big.NewInt(100), Available: true},
+			{Blockchain: "polygon", Network: "amoy", Address: "0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124", Balance: big.NewInt(50), MinBalance: big.NewInt(100), InFlight: 1},
+		},
+	}
+	handler := getHandler(context.Background(), server)
+
+	type expected struct {
+		httpCode int
+		response GetPublishingKeysStatus200JSONResponse
+	}
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		expected expected
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name: "No auth header",
+			auth: authWrong,
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "should return the balance of the publishing keys",
+			auth: authOk,
+			expected: expected{
+				httpCode: http.StatusOK,
+				response: GetPublishingKeysStatus200JSONResponse{
+					{Blockchain: "polygon", Network: "amoy", Address: "0x85f0Bf2D4E0A3a4B4A7A69E3d9F1A8Bfb4e9bD31", Balance: "250", MinBalance: common.ToPointer("100"), Available: true},
+					{Blockchain: "polygon", Network: "amoy", Address: "0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124", Balance: "50", MinBalance: common.ToPointer("100"), InFlight: 1},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			req, err := http.NewRequest("GET", "/v2/status/publishing-keys", nil)
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			if tc.expected.httpCode == http.StatusOK {
+				var response GetPublishingKeysStatus200JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.response, response)
+			}
+		})
+	}
+}
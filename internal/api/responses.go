@@ -259,13 +259,19 @@ func toStateTransaction(state domain.IdentityState) StateTransaction {
 	if state.TxID != nil {
 		txID = *state.TxID
 	}
-	return StateTransaction{
-		Id:          state.StateID,
-		PublishDate: TimeUTC(state.ModifiedAt),
-		State:       stateTran,
-		Status:      getTransactionStatus(state.Status),
-		TxID:        txID,
+	res := StateTransaction{
+		Id:            state.StateID,
+		PublishDate:   TimeUTC(state.ModifiedAt),
+		State:         stateTran,
+		Status:        getTransactionStatus(state.Status),
+		TxID:          txID,
+		CancelTxID:    state.CancelTxID,
+		FailureReason: state.FailureReason,
 	}
+	if len(state.ReplacedTxIDs) > 0 {
+		res.ReplacedTxIDs = &state.ReplacedTxIDs
+	}
+	return res
 }
 
 func getTransactionStatus(status domain.IdentityStatus) StateTransactionStatus {
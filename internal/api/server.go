@@ -17,35 +17,49 @@ import (
 // Server implements StrictServerInterface and holds the implementation of all API controllers
 // This is the glue to the API autogenerated code
 type Server struct {
-	cfg                *config.Configuration
-	accountService     ports.AccountService
-	claimService       ports.ClaimService
-	connectionsService ports.ConnectionService
-	health             *health.Status
-	identityService    ports.IdentityService
-	linkService        ports.LinkService
-	networkResolver    network.Resolver
-	packageManager     *iden3comm.PackageManager
-	publisherGateway   ports.Publisher
-	qrService          ports.QrStoreService
-	schemaService      ports.SchemaService
+	cfg                     *config.Configuration
+	accountService          ports.AccountService
+	claimService            ports.ClaimService
+	connectionsService      ports.ConnectionService
+	credentialExportService ports.CredentialExportService
+	credentialImportService ports.CredentialImportService
+	didDocumentService      ports.DIDDocumentService
+	health                  *health.Status
+	idempotencyService      ports.IdempotencyService
+	identityBackupService   ports.IdentityBackupService
+	identityService         ports.IdentityService
+	linkService             ports.LinkService
+	networkResolver         network.Resolver
+	packageManager          *iden3comm.PackageManager
+	publisherGateway        ports.Publisher
+	publishingKeyService    ports.PublishingKeyService
+	qrService               ports.QrStoreService
+	schemaService           ports.SchemaService
+	statusListService       ports.StatusListService
 }
 
 // NewServer is a Server constructor
-func NewServer(cfg *config.Configuration, identityService ports.IdentityService, accountService ports.AccountService, connectionsService ports.ConnectionService, claimsService ports.ClaimService, qrService ports.QrStoreService, publisherGateway ports.Publisher, packageManager *iden3comm.PackageManager, networkResolver network.Resolver, health *health.Status, schemaService ports.SchemaService, linkService ports.LinkService) *Server {
+func NewServer(cfg *config.Configuration, identityService ports.IdentityService, accountService ports.AccountService, connectionsService ports.ConnectionService, claimsService ports.ClaimService, qrService ports.QrStoreService, publisherGateway ports.Publisher, packageManager *iden3comm.PackageManager, networkResolver network.Resolver, health *health.Status, schemaService ports.SchemaService, linkService ports.LinkService, credentialImportService ports.CredentialImportService, statusListService ports.StatusListService, credentialExportService ports.CredentialExportService, idempotencyService ports.IdempotencyService, identityBackupService ports.IdentityBackupService, didDocumentService ports.DIDDocumentService, publishingKeyService ports.PublishingKeyService) *Server {
 	return &Server{
-		cfg:                cfg,
-		accountService:     accountService,
-		claimService:       claimsService,
-		connectionsService: connectionsService,
-		health:             health,
-		identityService:    identityService,
-		linkService:        linkService,
-		networkResolver:    networkResolver,
-		publisherGateway:   publisherGateway,
-		packageManager:     packageManager,
-		qrService:          qrService,
-		schemaService:      schemaService,
+		cfg:                     cfg,
+		accountService:          accountService,
+		claimService:            claimsService,
+		connectionsService:      connectionsService,
+		credentialExportService: credentialExportService,
+		credentialImportService: credentialImportService,
+		didDocumentService:      didDocumentService,
+		health:                  health,
+		idempotencyService:      idempotencyService,
+		identityBackupService:   identityBackupService,
+		identityService:         identityService,
+		linkService:             linkService,
+		networkResolver:         networkResolver,
+		publisherGateway:        publisherGateway,
+		publishingKeyService:    publishingKeyService,
+		packageManager:          packageManager,
+		qrService:               qrService,
+		schemaService:           schemaService,
+		statusListService:       statusListService,
 	}
 }
 
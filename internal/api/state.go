@@ -8,8 +8,10 @@ import (
 
 	"github.com/iden3/go-iden3-core/v2/w3c"
 
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/eth"
 	"github.com/polygonid/sh-id-platform/internal/gateways"
 	"github.com/polygonid/sh-id-platform/internal/log"
 	"github.com/polygonid/sh-id-platform/internal/sqltools"
@@ -21,12 +23,18 @@ func (s *Server) PublishIdentityState(ctx context.Context, request PublishIdenti
 	if err != nil {
 		return PublishIdentityState400JSONResponse{N400JSONResponse{"invalid did"}}, nil
 	}
+	if domain.IsWebDID(*did) {
+		return PublishIdentityState400JSONResponse{N400JSONResponse{services.ErrWebIdentityNotSupported.Error()}}, nil
+	}
 
 	publishedState, err := s.publisherGateway.PublishState(ctx, did)
 	if err != nil {
 		if errors.Is(err, gateways.ErrNoStatesToProcess) || errors.Is(err, gateways.ErrStateIsBeingProcessed) {
 			return PublishIdentityState200JSONResponse{Message: err.Error()}, nil
 		}
+		if errors.Is(err, services.ErrIdentityDeactivated) {
+			return PublishIdentityState400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
 
 		var customErr *services.PublishingStateError
 		if errors.As(err, &customErr) {
@@ -69,6 +77,49 @@ func (s *Server) RetryPublishState(ctx context.Context, request RetryPublishStat
 	}, nil
 }
 
+// SpeedUpPublishState - replaces the pending transaction of the state transition with higher fees
+func (s *Server) SpeedUpPublishState(ctx context.Context, request SpeedUpPublishStateRequestObject) (SpeedUpPublishStateResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		return SpeedUpPublishState400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	state, err := s.publisherGateway.SpeedUpPublishState(ctx, did)
+	if err != nil {
+		log.Error(ctx, "error speeding up the publishing of the state", "err", err)
+		if isStateReplacementError(err) {
+			return SpeedUpPublishState400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		return SpeedUpPublishState500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return SpeedUpPublishState202JSONResponse(toStateTransaction(*state)), nil
+}
+
+// CancelPublishState - replaces the pending transaction of the state transition with an empty one
+func (s *Server) CancelPublishState(ctx context.Context, request CancelPublishStateRequestObject) (CancelPublishStateResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		return CancelPublishState400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	state, err := s.publisherGateway.CancelPublishState(ctx, did)
+	if err != nil {
+		log.Error(ctx, "error cancelling the publishing of the state", "err", err)
+		if isStateReplacementError(err) {
+			return CancelPublishState400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		return CancelPublishState500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return CancelPublishState202JSONResponse(toStateTransaction(*state)), nil
+}
+
+// isStateReplacementError returns true if the state transaction can not be replaced because of its status
+func isStateReplacementError(err error) bool {
+	return errors.Is(err, gateways.ErrNoPendingStateToReplace) || errors.Is(err, gateways.ErrStateTransitionCancelled) ||
+		errors.Is(err, eth.ErrTransactionNotPending) || errors.Is(err, eth.ErrTransactionNotFound) ||
+		errors.Is(err, eth.ErrMaxGasPriceReached)
+}
+
 // GetStateTransactions - get state transactions
 func (s *Server) GetStateTransactions(ctx context.Context, request GetStateTransactionsRequestObject) (GetStateTransactionsResponseObject, error) {
 	filter, err := getStateTransitionsFilter(request)
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// GetStatusListCredential returns the signed BitstringStatusListCredential of a status list. It is a public endpoint.
+func (s *Server) GetStatusListCredential(ctx context.Context, request GetStatusListCredentialRequestObject) (GetStatusListCredentialResponseObject, error) {
+	did, err := w3c.ParseDID(request.Identifier)
+	if err != nil {
+		return GetStatusListCredential400JSONResponse{N400JSONResponse{Message: "invalid issuer did"}}, nil
+	}
+	if request.Params.Format != nil && *request.Params.Format == GetStatusListCredentialParamsFormatJwtVc {
+		return s.getStatusListCredentialJWT(ctx, *did, request)
+	}
+	credential, err := s.statusListService.GetCredential(ctx, *did, request.Id)
+	if err != nil {
+		if errors.Is(err, services.ErrStatusListNotFound) {
+			return GetStatusListCredential404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "getting status list credential", "err", err, "id", request.Id)
+		return GetStatusListCredential500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetStatusListCredential200JSONResponse(*credential), nil
+}
+
+func (s *Server) getStatusListCredentialJWT(ctx context.Context, did w3c.DID, request GetStatusListCredentialRequestObject) (GetStatusListCredentialResponseObject, error) {
+	token, err := s.statusListService.GetCredentialJWT(ctx, did, request.Id)
+	if err != nil {
+		if errors.Is(err, services.ErrStatusListNotFound) {
+			return GetStatusListCredential404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrIssuerETHKeyNotFound) {
+			return GetStatusListCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "getting status list credential as jwt", "err", err, "id", request.Id)
+		return GetStatusListCredential500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetStatusListCredential200ApplicationjwtResponse{Body: strings.NewReader(token), ContentLength: int64(len(token))}, nil
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db/tests"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+)
+
+func TestServer_GetStatusListCredential(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	iden, err := server.Services.identity.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+	issuerDID, err := w3c.ParseDID(iden.Identifier)
+	require.NoError(t, err)
+	handler := getHandler(ctx, server)
+
+	list, index, err := server.Repos.statusLists.Allocate(ctx, server.Infra.db.Pgx, *issuerDID, domain.StatusPurposeRevocation)
+	require.NoError(t, err)
+	require.NoError(t, server.Repos.statusLists.Set(ctx, server.Infra.db.Pgx, *issuerDID, list.ID, index))
+
+	type expected struct {
+		httpCode int
+	}
+	type testConfig struct {
+		name     string
+		did      string
+		id       uuid.UUID
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:     "Invalid did",
+			did:      "wrong",
+			id:       list.ID,
+			expected: expected{httpCode: http.StatusBadRequest},
+		},
+		{
+			name:     "Non existing status list",
+			did:      issuerDID.String(),
+			id:       uuid.New(),
+			expected: expected{httpCode: http.StatusNotFound},
+		},
+		{
+			name:     "Happy path, no auth required",
+			did:      issuerDID.String(),
+			id:       list.ID,
+			expected: expected{httpCode: http.StatusOK},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v2/identities/%s/credentials/status-lists/%s", tc.did, tc.id), nil)
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			if tc.expected.httpCode != http.StatusOK {
+				return
+			}
+			var response verifiable.W3CCredential
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Contains(t, response.Type, "BitstringStatusListCredential")
+			assert.Equal(t, issuerDID.String(), response.Issuer)
+			assert.Equal(t, "revocation", response.CredentialSubject["statusPurpose"])
+			assert.NotEmpty(t, response.CredentialSubject["encodedList"])
+			assert.NotNil(t, response.Proof)
+		})
+	}
+}
+
+func TestServer_GetStatusListCredentialJWT(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	ethIden, err := server.Services.identity.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: kms.KeyTypeEthereum})
+	require.NoError(t, err)
+	ethDID, err := w3c.ParseDID(ethIden.Identifier)
+	require.NoError(t, err)
+	bjjIden, err := server.Services.identity.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: kms.KeyTypeBabyJubJub})
+	require.NoError(t, err)
+	bjjDID, err := w3c.ParseDID(bjjIden.Identifier)
+	require.NoError(t, err)
+
+	ethList, _, err := server.Repos.statusLists.Allocate(ctx, server.Infra.db.Pgx, *ethDID, domain.StatusPurposeRevocation)
+	require.NoError(t, err)
+	bjjList, _, err := server.Repos.statusLists.Allocate(ctx, server.Infra.db.Pgx, *bjjDID, domain.StatusPurposeRevocation)
+	require.NoError(t, err)
+
+	t.Run("issuer without eth key", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v2/identities/%s/credentials/status-lists/%s?format=jwt-vc", bjjDID, bjjList.ID), nil)
+		require.NoError(t, err)
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("JWT-VC", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v2/identities/%s/credentials/status-lists/%s?format=jwt-vc", ethDID, ethList.ID), nil)
+		require.NoError(t, err)
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/jwt", rr.Header().Get("Content-Type"))
+		token := rr.Body.String()
+
+		// the token is stored and served until the list changes
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, token, rr.Body.String())
+
+		// a token signed with another key is signed again
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256K","typ":"JWT","jwk":{"kty":"EC","crv":"secp256k1","x":"AA","y":"AA"}}`))
+		staleToken := header + ".e30.AA"
+		list, err := server.Repos.statusLists.GetByID(ctx, server.Infra.db.Pgx, *ethDID, ethList.ID)
+		require.NoError(t, err)
+		list.CredentialJWT = &staleToken
+		require.NoError(t, server.Repos.statusLists.SaveCredentials(ctx, server.Infra.db.Pgx, list))
+
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotEqual(t, staleToken, rr.Body.String())
+		tokenHeader, _, _ := strings.Cut(token, ".")
+		assert.True(t, strings.HasPrefix(rr.Body.String(), tokenHeader+"."))
+	})
+}
+
+// TestServer_StatusListRevocation issues a credential with a BitstringStatusListEntry status, revokes it and checks
+// that its entry of the status list credential is set.
+func TestServer_StatusListRevocation(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+		BJJ        = "BJJ"
+	)
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+	handler := getHandler(ctx, server)
+
+	iden, err := server.Services.identity.Create(ctx, "http://polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+	issuerDID, err := w3c.ParseDID(iden.Identifier)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	body := CreateCredentialRequest{
+		CredentialSchema: "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+		Type:             "KYCAgeCredential",
+		CredentialSubject: map[string]any{
+			"id":           "did:polygonid:polygon:mumbai:2qFDkNkWePjd6URt6kGQX14a7wVKhBZt8bpy7HZJZi",
+			"birthday":     19960425,
+			"documentType": 2,
+		},
+		Expiration:           common.ToPointer(time.Now().Add(time.Hour).Unix()),
+		Proofs:               &[]CreateCredentialRequestProofs{"BJJSignature2021"},
+		CredentialStatusType: common.ToPointer(CreateCredentialRequestCredentialStatusTypeBitstringStatusListEntry),
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/v2/identities/%s/credentials", issuerDID), tests.JSONBody(t, body))
+	require.NoError(t, err)
+	req.SetBasicAuth(authOk())
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var created CreateCredentialResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+
+	claim, err := server.Repos.claims.GetByIdAndIssuer(ctx, server.Infra.db.Pgx, issuerDID, uuid.MustParse(created.Id))
+	require.NoError(t, err)
+	var entry domain.BitstringStatusListEntryStatus
+	require.NoError(t, json.Unmarshal(claim.CredentialStatus.Bytes, &entry))
+	require.Equal(t, domain.BitstringStatusListEntry, entry.Type)
+	listID, index, err := entry.StatusList()
+	require.NoError(t, err)
+
+	getList := func(t *testing.T) verifiable.W3CCredential {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v2/identities/%s/credentials/status-lists/%s", issuerDID, listID), nil)
+		require.NoError(t, err)
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var list verifiable.W3CCredential
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+		return list
+	}
+
+	issued := getList(t)
+	assert.False(t, isStatusListEntrySet(t, issued, index))
+	assert.Equal(t, issued, getList(t), "the signed list is stored until an entry is set")
+
+	rr = httptest.NewRecorder()
+	req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/v2/identities/%s/credentials/revoke/%d", issuerDID, entry.RevocationNonce), nil)
+	require.NoError(t, err)
+	req.SetBasicAuth(authOk())
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusAccepted, rr.Code)
+
+	revoked := getList(t)
+	assert.True(t, isStatusListEntrySet(t, revoked, index))
+	assert.NotEqual(t, issued.Proof, revoked.Proof)
+}
+
+func isStatusListEntrySet(t *testing.T, list verifiable.W3CCredential, index int) bool {
+	t.Helper()
+	encoded, ok := list.CredentialSubject["encodedList"].(string)
+	require.True(t, ok)
+	compressed, err := base64.RawURLEncoding.DecodeString(encoded[1:])
+	require.NoError(t, err)
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	bits, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return (&domain.StatusList{Bits: bits}).IsSet(index)
+}
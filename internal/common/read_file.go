@@ -146,3 +146,37 @@ func CreateFileWithRHSAll(t *testing.T) *MyYAMLReader {
 	reader := NewMyYAMLReader(yamlData)
 	return reader
 }
+
+// CreateFileWithSimulatedNetwork - Helper for testing. The amoy network runs in an in-process simulated chain
+// that mines a block for every transaction.
+func CreateFileWithSimulatedNetwork(t *testing.T) *MyYAMLReader {
+	t.Helper()
+	yamlData := []byte(`polygon:
+  amoy:
+    contractAddress: 0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124
+    defaultGasLimit: 600000
+    confirmationTimeout: 10s
+    confirmationBlockCount: 1
+    receiptTimeout: 10s
+    minGasPrice: 0
+    maxGasPrice: 1000000000000
+    rpcResponseTimeout: 5s
+    waitReceiptCycleTime: 100ms
+    waitBlockCycleTime: 100ms
+    gasLess: false
+    transferAmountWei: 1000000000000000000
+    simulated:
+      blockPeriod: 0s
+      accounts:
+        - 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266
+    rhsSettings:
+      mode: None
+      rhsUrl: https://rhs-staging.polygonid.me
+      contractAddress: 0x3d3763eC0a50CE1AdF83d0b5D99FBE0e3fEB43fb
+      chainID: 80002
+      publishingKey: pbkey
+`)
+
+	reader := NewMyYAMLReader(yamlData)
+	return reader
+}
@@ -62,6 +62,9 @@ type Configuration struct {
 	MediaTypeManager            MediaTypeManager
 	UniversalLinks              UniversalLinks
 	UniversalDIDResolver        UniversalDIDResolver
+	ExpirySweeper               ExpirySweeper
+	AutoPublisher               AutoPublisher
+	IdempotencyKeys             IdempotencyKeys
 }
 
 // Database has the database configuration
@@ -186,6 +189,32 @@ type UniversalLinks struct {
 	BaseUrl string `env:"ISSUER_UNIVERSAL_LINKS_BASE_URL" envDefault:"https://wallet.privado.id"`
 }
 
+// ExpirySweeper configures the job of the pending publisher that processes expired credentials.
+// Frequency: how often the job runs. The job is disabled if it is 0.
+// RevokeSchemas: comma separated list of schema types or schema urls whose credentials are revoked when they
+// expire. Use * to revoke the expired credentials of every schema. Holders are notified in any case.
+type ExpirySweeper struct {
+	Frequency     time.Duration `env:"ISSUER_EXPIRY_SWEEPER_FREQUENCY" envDefault:"0s"`
+	RevokeSchemas []string      `env:"ISSUER_EXPIRY_SWEEPER_REVOKE_SCHEMAS" envSeparator:","`
+}
+
+// AutoPublisher configures the job of the pending publisher that publishes the states of the identities
+// following their publishing policies.
+// Frequency: how often the policies are evaluated. The job is disabled if it is 0.
+// Concurrency: how many states are published at the same time.
+type AutoPublisher struct {
+	Frequency   time.Duration `env:"ISSUER_AUTO_PUBLISHER_FREQUENCY" envDefault:"0s"`
+	Concurrency int           `env:"ISSUER_AUTO_PUBLISHER_CONCURRENCY" envDefault:"4"`
+}
+
+// IdempotencyKeys configures the idempotency keys of the API.
+// TTL: how long a key is kept. Expired keys are removed by the pending publisher.
+// Lease: how long a retry waits for the first request before using the key again, if that request never finished.
+type IdempotencyKeys struct {
+	TTL   time.Duration `env:"ISSUER_IDEMPOTENCY_KEY_TTL" envDefault:"24h"`
+	Lease time.Duration `env:"ISSUER_IDEMPOTENCY_KEY_LEASE" envDefault:"1m"`
+}
+
 // Load loads the configuration from a file
 func Load() (*Configuration, error) {
 	ctx := context.Background()
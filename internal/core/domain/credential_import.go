@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+)
+
+// CredentialImportFormat is the format of a file with credential subjects to import
+type CredentialImportFormat string
+
+const (
+	CredentialImportFormatCSV    CredentialImportFormat = "csv"    // CredentialImportFormatCSV comma separated values with a header row
+	CredentialImportFormatNDJSON CredentialImportFormat = "ndjson" // CredentialImportFormatNDJSON one json object per line
+)
+
+// CredentialImportStatus is the status of a credential import job
+type CredentialImportStatus string
+
+const (
+	CredentialImportPending  CredentialImportStatus = "pending"  // CredentialImportPending the job has not started yet
+	CredentialImportRunning  CredentialImportStatus = "running"  // CredentialImportRunning the job is issuing credentials
+	CredentialImportFinished CredentialImportStatus = "finished" // CredentialImportFinished all the rows have been processed
+	CredentialImportFailed   CredentialImportStatus = "failed"   // CredentialImportFailed the job stopped before processing all the rows
+)
+
+// CredentialImportRejection describes a row of an imported file that could not be issued.
+// Row is the position of the record in the file, starting at 1 and not counting the csv header.
+type CredentialImportRejection struct {
+	Row     int    `json:"row"`
+	Subject string `json:"subject,omitempty"`
+	Error   string `json:"error"`
+}
+
+// CredentialImportRow is a row of an imported file waiting to be processed.
+// Number is the position of the record in the file. Error is set if the row could not be parsed.
+type CredentialImportRow struct {
+	Number  int
+	Subject map[string]any
+	Error   string
+}
+
+// CredentialImport is a background job that issues a credential for each row of an uploaded file.
+// The rows and the options of the credentials are stored with the job, so it can be resumed from the first row
+// not processed if the process running it stops.
+type CredentialImport struct {
+	ID                   uuid.UUID
+	IssuerDID            w3c.DID
+	SchemaID             uuid.UUID
+	Format               CredentialImportFormat
+	Status               CredentialImportStatus
+	Total                int
+	Processed            int
+	Issued               int
+	Rejected             []CredentialImportRejection
+	Expiration           *time.Time
+	SignatureProof       bool
+	MTPProof             bool
+	CredentialStatusType verifiable.CredentialStatusType
+	Error                string // Error is the reason why the job failed
+	CreatedAt            time.Time
+	ModifiedAt           time.Time
+}
+
+// NewCredentialImport creates a new pending credential import job
+func NewCredentialImport(issuerDID w3c.DID, schemaID uuid.UUID, format CredentialImportFormat, total int) *CredentialImport {
+	now := time.Now().UTC()
+	return &CredentialImport{
+		ID:         uuid.New(),
+		IssuerDID:  issuerDID,
+		SchemaID:   schemaID,
+		Format:     format,
+		Status:     CredentialImportPending,
+		Total:      total,
+		Rejected:   make([]CredentialImportRejection, 0),
+		CreatedAt:  now,
+		ModifiedAt: now,
+	}
+}
+
+// Reject records that the given row could not be issued
+func (ci *CredentialImport) Reject(row int, subject string, err error) {
+	ci.Rejected = append(ci.Rejected, CredentialImportRejection{Row: row, Subject: subject, Error: err.Error()})
+}
+
+// Fail stops the job because of err
+func (ci *CredentialImport) Fail(err error) {
+	ci.Status = CredentialImportFailed
+	ci.Error = err.Error()
+}
+
+// IsDone returns true if the job will not process more rows
+func (ci *CredentialImport) IsDone() bool {
+	return ci.Status == CredentialImportFinished || ci.Status == CredentialImportFailed
+}
+
+// Progress returns the percentage of processed rows
+func (ci *CredentialImport) Progress() int {
+	const completed = 100
+	if ci.Total == 0 {
+		return completed
+	}
+	return ci.Processed * completed / ci.Total
+}
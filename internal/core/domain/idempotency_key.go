@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// IdempotentOperation is an operation of the API that can be retried safely with an idempotency key
+type IdempotentOperation string
+
+const (
+	IdempotentOperationCreateCredential IdempotentOperation = "createCredential" // IdempotentOperationCreateCredential is the credential creation
+	IdempotentOperationCreateLink       IdempotentOperation = "createLink"       // IdempotentOperationCreateLink is the link creation
+	IdempotentOperationCreateLinkOffer  IdempotentOperation = "createLinkOffer"  // IdempotentOperationCreateLinkOffer is the credential offer of a link
+)
+
+// IdempotencyKey is a key sent by a client to identify a request, so the result of the first one is returned on retries.
+// Response is nil while the first request is being processed.
+type IdempotencyKey struct {
+	IssuerID    string
+	Operation   IdempotentOperation
+	Key         string
+	RequestHash string
+	Response    json.RawMessage
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// InProgress returns true if the request that used the key has not finished yet
+func (k *IdempotencyKey) InProgress() bool {
+	return k.Response == nil
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"math/big"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/iden3/go-iden3-core/v2/w3c"
 
 	"github.com/polygonid/sh-id-platform/internal/common"
@@ -32,12 +34,48 @@ type Identity struct {
 	Address                       *string                       `json:"address"`
 	Balance                       *big.Int                      `json:"balance"`
 	AuthCoreClaimRevocationStatus AuthCoreClaimRevocationStatus `json:"authCoreClaimRevocationStatus"`
+	DeactivatedAt                 *time.Time                    `json:"deactivatedAt"`
+	ArchivedAt                    *time.Time                    `json:"archivedAt"`
+	Profile                       IdentityProfile               `json:"profile"`
+	PublishingPolicy              PublishingPolicy              `json:"publishingPolicy"`
+	AuthClaimID                   *uuid.UUID                    `json:"authClaimID"` // Primary auth claim, the one that signs the credentials
 }
 
-// IdentityDisplayName struct
+// IdentityLifecycleStatus represents whether an identity is active, deactivated or archived
+type IdentityLifecycleStatus string
+
+const (
+	// IdentityActive is the status of an identity that issues credentials and publishes states
+	IdentityActive IdentityLifecycleStatus = "active"
+	// IdentityDeactivated is the status of an identity that can not issue credentials, create links or publish new changes
+	IdentityDeactivated IdentityLifecycleStatus = "deactivated"
+	// IdentityArchived is the status of a deactivated identity that is not listed by default
+	IdentityArchived IdentityLifecycleStatus = "archived"
+)
+
+// NewIdentityLifecycleStatus returns the status of an identity from its deactivation and archival times
+func NewIdentityLifecycleStatus(deactivatedAt, archivedAt *time.Time) IdentityLifecycleStatus {
+	switch {
+	case archivedAt != nil:
+		return IdentityArchived
+	case deactivatedAt != nil:
+		return IdentityDeactivated
+	default:
+		return IdentityActive
+	}
+}
+
+// IdentityDisplayName is the summary of an identity shown in the identities list
 type IdentityDisplayName struct {
-	Identifier  string  `json:"identifier"`
-	DisplayName *string `json:"displayName"`
+	Identifier           string                  `json:"identifier"`
+	DisplayName          *string                 `json:"displayName"`
+	Status               IdentityLifecycleStatus `json:"status"`
+	KeyType              string                  `json:"keyType"`
+	CredentialStatusType string                  `json:"credentialStatusType"` // Status type of the auth credential. Empty for did:web identities.
+	CreatedAt            time.Time               `json:"createdAt"`
+	CredentialsIssued    uint                    `json:"credentialsIssued"`
+	CredentialsRevoked   uint                    `json:"credentialsRevoked"`
+	PendingStateChanges  uint                    `json:"pendingStateChanges"` // Credentials and revocations not included in a state yet
 }
 
 // NewIdentityFromIdentifier default identity model from identity and root state
@@ -64,6 +102,16 @@ func NewIdentityFromIdentifier(did *w3c.DID, rootState string) (*Identity, error
 	}, nil
 }
 
+// LifecycleStatus returns whether the identity is active, deactivated or archived
+func (i *Identity) LifecycleStatus() IdentityLifecycleStatus {
+	return NewIdentityLifecycleStatus(i.DeactivatedAt, i.ArchivedAt)
+}
+
+// IsActive returns true if the identity is neither deactivated nor archived
+func (i *Identity) IsActive() bool {
+	return i.DeactivatedAt == nil
+}
+
 // GetResolverPrefix get resolver prefix
 func (i *Identity) GetResolverPrefix() (string, error) {
 	const itemsLen = 4
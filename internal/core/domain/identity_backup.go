@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// IdentityBackupVersion is the version of the identity backup bundle
+const IdentityBackupVersion = 1
+
+// IdentityBackup is everything a node stores about one identity, so it can be restored into another node.
+// Rows are kept as the json representation postgres gives them, so the bundle does not depend on the go models.
+type IdentityBackup struct {
+	Version     int                        `json:"version"`
+	Identifier  string                     `json:"identifier"`
+	CreatedAt   time.Time                  `json:"createdAt"`
+	Tables      []IdentityBackupTable      `json:"tables"`
+	MerkleTrees []IdentityBackupMerkleTree `json:"merkleTrees"`
+	Keys        []IdentityBackupKey        `json:"keys"`
+}
+
+// IdentityBackupTable holds the rows of a table that belong to the identity, in restore order
+type IdentityBackupTable struct {
+	Name string            `json:"name"`
+	Rows []json.RawMessage `json:"rows"`
+}
+
+// IdentityBackupMerkleTree holds the nodes and the root of one of the identity merkle trees.
+// Merkle tree ids are assigned again on restore.
+type IdentityBackupMerkleTree struct {
+	Type  uint16            `json:"type"`
+	Nodes []json.RawMessage `json:"nodes"`
+	Roots []json.RawMessage `json:"roots"`
+}
+
+// IdentityBackupKey is a private key of the identity. PrivateKey is encrypted with the backup passphrase.
+type IdentityBackupKey struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	PrivateKey []byte `json:"privateKey"`
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ErrInvalidIdentityProfile means that some field of the identity profile is not valid
+var ErrInvalidIdentityProfile = errors.New("invalid identity profile")
+
+// IdentityProfile is the public information of an issuer identity, shown to the holders in the offers and
+// published in the DID document. Empty fields fall back to the issuer name and logo of the node configuration.
+type IdentityProfile struct {
+	Name        string `json:"name,omitempty"`
+	Logo        string `json:"logo,omitempty"`
+	Description string `json:"description,omitempty"`
+	Website     string `json:"website,omitempty"`
+	Contact     string `json:"contact,omitempty"`
+}
+
+// Validate checks that the logo and the website, when present, are absolute http urls
+func (p IdentityProfile) Validate() error {
+	for field, value := range map[string]string{"logo": p.Logo, "website": p.Website} {
+		if value == "" {
+			continue
+		}
+		u, err := url.ParseRequestURI(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %s must be an http url", ErrInvalidIdentityProfile, field)
+		}
+	}
+	return nil
+}
+
+// IsEmpty returns true if no field of the profile is set
+func (p IdentityProfile) IsEmpty() bool {
+	return p == IdentityProfile{}
+}
+
+// IdentityProfileUpdate holds the fields of an identity profile to change. Nil fields keep their current value
+// and empty ones are removed.
+type IdentityProfileUpdate struct {
+	Name        *string
+	Logo        *string
+	Description *string
+	Website     *string
+	Contact     *string
+}
+
+// Apply returns the profile with the fields of the update merged into it
+func (u IdentityProfileUpdate) Apply(profile IdentityProfile) IdentityProfile {
+	set := func(field *string, value *string) {
+		if value != nil {
+			*field = *value
+		}
+	}
+	set(&profile.Name, u.Name)
+	set(&profile.Logo, u.Logo)
+	set(&profile.Description, u.Description)
+	set(&profile.Website, u.Website)
+	set(&profile.Contact, u.Contact)
+	return profile
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+)
+
+func TestIdentityProfile_Validate(t *testing.T) {
+	type testConfig struct {
+		name    string
+		profile IdentityProfile
+		err     error
+	}
+	for _, tc := range []testConfig{
+		{
+			name:    "empty profile",
+			profile: IdentityProfile{},
+		},
+		{
+			name: "full profile",
+			profile: IdentityProfile{
+				Name:        "Acme KYC",
+				Logo:        "https://acme.com/logo.png",
+				Description: "KYC credentials issued by Acme",
+				Website:     "https://acme.com",
+				Contact:     "support@acme.com",
+			},
+		},
+		{
+			name:    "relative logo",
+			profile: IdentityProfile{Logo: "logo.png"},
+			err:     ErrInvalidIdentityProfile,
+		},
+		{
+			name:    "website without http scheme",
+			profile: IdentityProfile{Website: "ftp://acme.com"},
+			err:     ErrInvalidIdentityProfile,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.profile.Validate()
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestIdentityProfileUpdate_Apply(t *testing.T) {
+	profile := IdentityProfile{Name: "Acme", Logo: "https://acme.com/logo.png", Contact: "support@acme.com"}
+	updated := IdentityProfileUpdate{
+		Name:        common.ToPointer("Acme KYC"),
+		Description: common.ToPointer("KYC credentials issued by Acme"),
+		Contact:     common.ToPointer(""),
+	}.Apply(profile)
+	assert.Equal(t, IdentityProfile{
+		Name:        "Acme KYC",
+		Logo:        "https://acme.com/logo.png",
+		Description: "KYC credentials issued by Acme",
+	}, updated)
+	assert.Equal(t, "Acme", profile.Name)
+}
@@ -38,6 +38,15 @@ type IdentityState struct {
 	Status             IdentityStatus `json:"status,omitempty"`
 	ModifiedAt         time.Time      `json:"modified_at,omitempty"`
 	CreatedAt          time.Time      `json:"created_at,omitempty"`
+	// ReplacedTxIDs are the transactions of the state transition replaced by TxID with higher fees, oldest first.
+	// Any of them can still be the one mined.
+	ReplacedTxIDs []string `json:"replaced_tx_ids,omitempty"`
+	// CancelTxID is the transaction sent to cancel the state transition, if any
+	CancelTxID *string `json:"cancel_tx_id,omitempty"`
+	// BlockHash is the hash of the block BlockNumber that includes the transaction, used to detect reorgs
+	BlockHash *string `json:"block_hash,omitempty"`
+	// FailureReason is why the state transition was not published, when it is detected before sending it
+	FailureReason *string `json:"failure_reason,omitempty"`
 }
 
 // PublishedState defines the domain object of publish state on chain
@@ -49,6 +58,18 @@ type PublishedState struct {
 	RootOfRoots        *string
 }
 
+// TxIDs returns the transactions of the state transition, the current one first
+func (i *IdentityState) TxIDs() []string {
+	txIDs := make([]string, 0, len(i.ReplacedTxIDs)+1)
+	if i.TxID != nil {
+		txIDs = append(txIDs, *i.TxID)
+	}
+	for j := len(i.ReplacedTxIDs) - 1; j >= 0; j-- {
+		txIDs = append(txIDs, i.ReplacedTxIDs[j])
+	}
+	return txIDs
+}
+
 // ToTreeState returns circuits.TreeState structure
 func (i *IdentityState) ToTreeState() (circuits.TreeState, error) {
 	return BuildTreeState(i.State, i.ClaimsTreeRoot, i.RevocationTreeRoot, i.RootOfRoots)
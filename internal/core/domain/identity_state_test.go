@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+)
+
+func TestIdentityState_TxIDs(t *testing.T) {
+	assert.Empty(t, (&IdentityState{}).TxIDs())
+
+	state := IdentityState{
+		TxID:          common.ToPointer("0x3"),
+		ReplacedTxIDs: []string{"0x1", "0x2"},
+	}
+	assert.Equal(t, []string{"0x3", "0x2", "0x1"}, state.TxIDs())
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "math/big"
+
+// PublishingKeyStatus is the status of a key of the pool that publishes the states of the identities of a network.
+// Available is false if the balance of the key is below MinBalance, so it is not used until it is funded.
+// MinBalance is nil if the balance of the keys of the network is not checked.
+type PublishingKeyStatus struct {
+	Blockchain string
+	Network    string
+	Address    string
+	Balance    *big.Int
+	MinBalance *big.Int
+	InFlight   int
+	Available  bool
+}
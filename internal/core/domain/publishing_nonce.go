@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// PublishingNonceStatus is the status of a nonce allocated to a transaction of a publishing key
+type PublishingNonceStatus string
+
+const (
+	PublishingNonceReserved PublishingNonceStatus = "reserved" // PublishingNonceReserved the transaction is being signed and sent
+	PublishingNonceSent     PublishingNonceStatus = "sent"     // PublishingNonceSent the transaction was sent to the network
+)
+
+// PublishingNonce is a nonce of an address that is in use by a transaction not mined yet.
+// TxID is the hash of the last transaction sent with the nonce, nil while it is reserved, and RawTx is that
+// transaction signed, so it can be sent again if the node drops it.
+type PublishingNonce struct {
+	ChainID    uint64
+	Address    string
+	Nonce      uint64
+	Status     PublishingNonceStatus
+	TxID       *string
+	RawTx      []byte
+	ModifiedAt time.Time
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/cron"
+)
+
+// PublishingPolicyMode is the rule the pending publisher follows to publish the state of an identity
+type PublishingPolicyMode string
+
+const (
+	// PublishingPolicyAlways publishes the state as soon as there are pending changes. It is the default policy.
+	PublishingPolicyAlways PublishingPolicyMode = "always"
+	// PublishingPolicyManual never publishes the state automatically, only through the publish state endpoint
+	PublishingPolicyManual PublishingPolicyMode = "manual"
+	// PublishingPolicyPendingCount publishes the state when there are at least MinPendingChanges pending changes
+	PublishingPolicyPendingCount PublishingPolicyMode = "pendingCount"
+	// PublishingPolicyInterval publishes the state at most once every IntervalMinutes
+	PublishingPolicyInterval PublishingPolicyMode = "interval"
+	// PublishingPolicyCron publishes the state at the times of the Cron schedule, in UTC
+	PublishingPolicyCron PublishingPolicyMode = "cron"
+)
+
+// ErrInvalidPublishingPolicy means that the publishing policy is not valid
+var ErrInvalidPublishingPolicy = errors.New("invalid publishing policy")
+
+// PublishingPolicy is the per identity policy that decides when the pending publisher publishes the state of
+// an identity, so busy issuers can batch their changes in fewer transactions.
+// PublishRevocationsImmediately publishes the state as soon as there is a pending revocation, whatever the mode.
+type PublishingPolicy struct {
+	Mode                          PublishingPolicyMode `json:"mode"`
+	MinPendingChanges             uint                 `json:"minPendingChanges,omitempty"`
+	IntervalMinutes               uint                 `json:"intervalMinutes,omitempty"`
+	Cron                          string               `json:"cron,omitempty"`
+	PublishRevocationsImmediately bool                 `json:"publishRevocationsImmediately"`
+}
+
+// PendingStateChanges are the changes of the state of an identity that are not published yet
+// LastPublishedAt is when the state was published for the last time, or when the identity was created.
+type PendingStateChanges struct {
+	Credentials     uint
+	Revocations     uint
+	LastPublishedAt time.Time
+}
+
+// Total returns the number of pending changes
+func (p PendingStateChanges) Total() uint {
+	return p.Credentials + p.Revocations
+}
+
+// DefaultPublishingPolicy returns the policy of the identities that have not set one
+func DefaultPublishingPolicy() PublishingPolicy {
+	return PublishingPolicy{Mode: PublishingPolicyAlways}
+}
+
+// Validate checks that the policy has the parameters its mode needs
+func (p PublishingPolicy) Validate() error {
+	switch p.Mode {
+	case PublishingPolicyAlways, PublishingPolicyManual:
+	case PublishingPolicyPendingCount:
+		if p.MinPendingChanges == 0 {
+			return fmt.Errorf("%w: minPendingChanges must be greater than 0", ErrInvalidPublishingPolicy)
+		}
+	case PublishingPolicyInterval:
+		if p.IntervalMinutes == 0 {
+			return fmt.Errorf("%w: intervalMinutes must be greater than 0", ErrInvalidPublishingPolicy)
+		}
+	case PublishingPolicyCron:
+		if _, err := cron.Parse(p.Cron); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidPublishingPolicy, err)
+		}
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidPublishingPolicy, p.Mode)
+	}
+	return nil
+}
+
+// ShouldPublish returns true if the state must be published at the given time with the given pending changes
+func (p PublishingPolicy) ShouldPublish(pending PendingStateChanges, now time.Time) bool {
+	if pending.Total() == 0 {
+		return false
+	}
+	if p.PublishRevocationsImmediately && pending.Revocations > 0 {
+		return true
+	}
+	switch p.Mode {
+	case PublishingPolicyAlways:
+		return true
+	case PublishingPolicyPendingCount:
+		return pending.Total() >= p.MinPendingChanges
+	case PublishingPolicyInterval:
+		return !now.Before(pending.LastPublishedAt.Add(time.Duration(p.IntervalMinutes) * time.Minute))
+	case PublishingPolicyCron:
+		// the state is published if the schedule fired since the last publication
+		schedule, err := cron.Parse(p.Cron)
+		if err != nil {
+			return false
+		}
+		next := schedule.Next(pending.LastPublishedAt.UTC())
+		return !next.IsZero() && !now.Before(next)
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishingPolicy_Validate(t *testing.T) {
+	type testConfig struct {
+		name   string
+		policy PublishingPolicy
+		valid  bool
+	}
+	for _, tc := range []testConfig{
+		{name: "always", policy: PublishingPolicy{Mode: PublishingPolicyAlways}, valid: true},
+		{name: "manual", policy: PublishingPolicy{Mode: PublishingPolicyManual}, valid: true},
+		{name: "pending count", policy: PublishingPolicy{Mode: PublishingPolicyPendingCount, MinPendingChanges: 10}, valid: true},
+		{name: "pending count without count", policy: PublishingPolicy{Mode: PublishingPolicyPendingCount}},
+		{name: "interval", policy: PublishingPolicy{Mode: PublishingPolicyInterval, IntervalMinutes: 30}, valid: true},
+		{name: "interval without minutes", policy: PublishingPolicy{Mode: PublishingPolicyInterval}},
+		{name: "cron", policy: PublishingPolicy{Mode: PublishingPolicyCron, Cron: "0 */6 * * *"}, valid: true},
+		{name: "invalid cron", policy: PublishingPolicy{Mode: PublishingPolicyCron, Cron: "every hour"}},
+		{name: "unknown mode", policy: PublishingPolicy{Mode: "sometimes"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidPublishingPolicy)
+		})
+	}
+}
+
+func TestPublishingPolicy_ShouldPublish(t *testing.T) {
+	lastPublishedAt := time.Date(2024, time.October, 17, 10, 0, 0, 0, time.UTC)
+	now := lastPublishedAt.Add(20 * time.Minute)
+	credentials := PendingStateChanges{Credentials: 3, LastPublishedAt: lastPublishedAt}
+	revocation := PendingStateChanges{Revocations: 1, LastPublishedAt: lastPublishedAt}
+
+	type testConfig struct {
+		name     string
+		policy   PublishingPolicy
+		pending  PendingStateChanges
+		expected bool
+	}
+	for _, tc := range []testConfig{
+		{name: "nothing pending", policy: DefaultPublishingPolicy(), pending: PendingStateChanges{LastPublishedAt: lastPublishedAt}},
+		{name: "always", policy: DefaultPublishingPolicy(), pending: credentials, expected: true},
+		{name: "manual", policy: PublishingPolicy{Mode: PublishingPolicyManual}, pending: credentials},
+		{name: "manual with urgent revocation", policy: PublishingPolicy{Mode: PublishingPolicyManual, PublishRevocationsImmediately: true}, pending: revocation, expected: true},
+		{name: "below pending count", policy: PublishingPolicy{Mode: PublishingPolicyPendingCount, MinPendingChanges: 5}, pending: credentials},
+		{name: "pending count reached", policy: PublishingPolicy{Mode: PublishingPolicyPendingCount, MinPendingChanges: 3}, pending: credentials, expected: true},
+		{name: "below pending count with urgent revocation", policy: PublishingPolicy{Mode: PublishingPolicyPendingCount, MinPendingChanges: 5, PublishRevocationsImmediately: true}, pending: revocation, expected: true},
+		{name: "interval not elapsed", policy: PublishingPolicy{Mode: PublishingPolicyInterval, IntervalMinutes: 30}, pending: credentials},
+		{name: "interval elapsed", policy: PublishingPolicy{Mode: PublishingPolicyInterval, IntervalMinutes: 15}, pending: credentials, expected: true},
+		{name: "cron not fired", policy: PublishingPolicy{Mode: PublishingPolicyCron, Cron: "30 * * * *"}, pending: credentials},
+		{name: "cron fired", policy: PublishingPolicy{Mode: PublishingPolicyCron, Cron: "15 * * * *"}, pending: credentials, expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.policy.ShouldPublish(tc.pending, now))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+)
+
+const (
+	// BitstringStatusListEntry is the W3C Bitstring Status List credential status type
+	BitstringStatusListEntry verifiable.CredentialStatusType = "BitstringStatusListEntry"
+
+	// StatusListSize is the number of entries of a status list. The W3C recommendation sets a minimum of 131072 entries
+	// (16KB) so a verifier cannot correlate a credential by its position in the list.
+	StatusListSize = 131072
+
+	bitsPerByte = 8
+	// multibaseBase64URL is the multibase prefix for base64url without padding
+	multibaseBase64URL = "u"
+)
+
+// ErrInvalidStatusListEntry means that a credential status cannot be parsed as a BitstringStatusListEntry
+var ErrInvalidStatusListEntry = errors.New("invalid bitstring status list entry")
+
+// StatusPurpose is the purpose of a status list
+type StatusPurpose string
+
+// StatusPurposeRevocation is the only purpose supported at the moment
+const StatusPurposeRevocation StatusPurpose = "revocation"
+
+// StatusList is a bitstring where each bit is the status of a credential of the issuer
+type StatusList struct {
+	ID         uuid.UUID
+	IssuerDID  w3c.DID
+	Purpose    StatusPurpose
+	Size       int
+	Allocated  int
+	Bits       []byte
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+	// Credential is the signed BitstringStatusListCredential of the current bits. It is nil until the list is
+	// requested and it is cleared every time an entry is set.
+	Credential *verifiable.W3CCredential
+	// CredentialJWT is the same credential as a JWT-VC, with the same lifecycle as Credential
+	CredentialJWT *string
+}
+
+// NewStatusList creates an empty status list
+func NewStatusList(issuerDID w3c.DID, purpose StatusPurpose, size int) *StatusList {
+	now := time.Now().UTC()
+	return &StatusList{
+		ID:         uuid.New(),
+		IssuerDID:  issuerDID,
+		Purpose:    purpose,
+		Size:       size,
+		Bits:       make([]byte, (size+bitsPerByte-1)/bitsPerByte),
+		CreatedAt:  now,
+		ModifiedAt: now,
+	}
+}
+
+// IsSet returns the status of the entry at the given index. The first index is the left-most bit of the list.
+func (sl *StatusList) IsSet(index int) bool {
+	return sl.Bits[index/bitsPerByte]&(1<<(bitsPerByte-1-index%bitsPerByte)) != 0
+}
+
+// Set sets to 1 the entry at the given index
+func (sl *StatusList) Set(index int) {
+	sl.Bits[index/bitsPerByte] |= 1 << (bitsPerByte - 1 - index%bitsPerByte)
+}
+
+// EncodedList returns the list gzip compressed and multibase base64url encoded, as the encodedList of a
+// BitstringStatusList credential subject
+func (sl *StatusList) EncodedList() (string, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(sl.Bits); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return multibaseBase64URL + base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// BitstringStatusListEntryStatus is the credential status of a credential whose status is kept in a status list.
+// RevocationNonce is not part of the W3C entry, but it is kept so the credential can still be revoked by nonce.
+type BitstringStatusListEntryStatus struct {
+	ID                   string                          `json:"id"`
+	Type                 verifiable.CredentialStatusType `json:"type"`
+	StatusPurpose        StatusPurpose                   `json:"statusPurpose"`
+	StatusListIndex      string                          `json:"statusListIndex"`
+	StatusListCredential string                          `json:"statusListCredential"`
+	RevocationNonce      uint64                          `json:"revocationNonce"`
+}
+
+// NewBitstringStatusListEntryStatus builds the credential status for the entry at the given index of a status list
+// published at statusListCredential
+func NewBitstringStatusListEntryStatus(statusListCredential string, purpose StatusPurpose, index int, nonce uint64) *BitstringStatusListEntryStatus {
+	return &BitstringStatusListEntryStatus{
+		ID:                   fmt.Sprintf("%s#%d", statusListCredential, index),
+		Type:                 BitstringStatusListEntry,
+		StatusPurpose:        purpose,
+		StatusListIndex:      strconv.Itoa(index),
+		StatusListCredential: statusListCredential,
+		RevocationNonce:      nonce,
+	}
+}
+
+// StatusList returns the id of the status list, the last segment of the status list credential url, and the index
+// of the entry
+func (e *BitstringStatusListEntryStatus) StatusList() (uuid.UUID, int, error) {
+	u, err := url.Parse(e.StatusListCredential)
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("%w: %s", ErrInvalidStatusListEntry, err)
+	}
+	id, err := uuid.Parse(path.Base(u.Path))
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("%w: %s", ErrInvalidStatusListEntry, err)
+	}
+	index, err := strconv.Atoi(e.StatusListIndex)
+	if err != nil || index < 0 {
+		return uuid.Nil, 0, fmt.Errorf("%w: wrong index %s", ErrInvalidStatusListEntry, e.StatusListIndex)
+	}
+	return id, index, nil
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusList_Set(t *testing.T) {
+	did, err := w3c.ParseDID("did:polygonid:polygon:amoy:2qQ68JkRcf3xrHPQPWZei3YeVzHPP58wYNxx2mEouR")
+	require.NoError(t, err)
+	list := NewStatusList(*did, StatusPurposeRevocation, StatusListSize)
+	require.Len(t, list.Bits, StatusListSize/8)
+
+	for _, index := range []int{0, 7, 8, 1000, StatusListSize - 1} {
+		assert.False(t, list.IsSet(index))
+		list.Set(index)
+		assert.True(t, list.IsSet(index))
+	}
+	assert.False(t, list.IsSet(1))
+	assert.Equal(t, byte(0x81), list.Bits[0])
+	assert.Equal(t, byte(0x80), list.Bits[1])
+	assert.Equal(t, byte(0x01), list.Bits[StatusListSize/8-1])
+
+	encoded, err := list.EncodedList()
+	require.NoError(t, err)
+	require.Equal(t, "u", encoded[:1])
+	compressed, err := base64.RawURLEncoding.DecodeString(encoded[1:])
+	require.NoError(t, err)
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, list.Bits, decoded)
+}
+
+func TestBitstringStatusListEntryStatus_StatusList(t *testing.T) {
+	id := uuid.New()
+	url := "https://issuer.example.com/v2/identities/did:polygonid:polygon:amoy:2qQ68JkRcf3xrHPQPWZei3YeVzHPP58wYNxx2mEouR/credentials/status-lists/" + id.String()
+
+	type expected struct {
+		id    uuid.UUID
+		index int
+		err   error
+	}
+	type testConfig struct {
+		name     string
+		entry    *BitstringStatusListEntryStatus
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:     "Happy path",
+			entry:    NewBitstringStatusListEntryStatus(url, StatusPurposeRevocation, 42, 1),
+			expected: expected{id: id, index: 42},
+		},
+		{
+			name:     "Wrong status list id",
+			entry:    NewBitstringStatusListEntryStatus("https://issuer.example.com/status-lists/wrong", StatusPurposeRevocation, 42, 1),
+			expected: expected{err: ErrInvalidStatusListEntry},
+		},
+		{
+			name: "Wrong index",
+			entry: &BitstringStatusListEntryStatus{
+				StatusListCredential: url,
+				StatusListIndex:      "-1",
+			},
+			expected: expected{err: ErrInvalidStatusListEntry},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			listID, index, err := tc.entry.StatusList()
+			if tc.expected.err != nil {
+				assert.ErrorIs(t, err, tc.expected.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected.id, listID)
+			assert.Equal(t, tc.expected.index, index)
+			assert.Equal(t, url+"#42", tc.entry.ID)
+		})
+	}
+}
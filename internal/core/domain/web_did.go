@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+)
+
+const (
+	// DIDMethodWeb is the did:web method. did:web identities are backed by a key of the kms, they have no
+	// merkle trees and no state, and their DID document is served by the node.
+	DIDMethodWeb core.DIDMethod = "web"
+
+	webDIDIdentitiesPath = "identities"
+	webDIDMinParts       = 3 // host, identities and the identity id
+)
+
+// ErrInvalidWebDIDHost means that the server url can not be the host of a did:web identity
+var ErrInvalidWebDIDHost = errors.New("the server url must be an url without port to create did:web identities")
+
+// NewWebDID returns the did:web of the identity with the given id hosted at hostURL.
+// The DID document is served at <hostURL>/identities/<id>/did.json
+func NewWebDID(hostURL string, id uuid.UUID) (*w3c.DID, error) {
+	u, err := url.Parse(hostURL)
+	if err != nil || u.Host == "" || u.Port() != "" {
+		return nil, ErrInvalidWebDIDHost
+	}
+	parts := []string{u.Hostname()}
+	for _, segment := range strings.Split(strings.Trim(u.Path, "/"), "/") {
+		if segment != "" {
+			parts = append(parts, segment)
+		}
+	}
+	parts = append(parts, webDIDIdentitiesPath, id.String())
+	return w3c.ParseDID(fmt.Sprintf("did:%s:%s", DIDMethodWeb, strings.Join(parts, ":")))
+}
+
+// IsWebDID returns true if the DID is a did:web
+func IsWebDID(did w3c.DID) bool {
+	return did.Method == string(DIDMethodWeb)
+}
+
+// WebDIDHostURL returns the url of the node that hosts the did:web identity, the one it was created with
+func WebDIDHostURL(did w3c.DID) (string, error) {
+	parts := did.IDStrings
+	if !IsWebDID(did) || len(parts) < webDIDMinParts || parts[len(parts)-2] != webDIDIdentitiesPath {
+		return "", fmt.Errorf("%w: %s", ErrInvalidIdentifier, did.String())
+	}
+	return "https://" + strings.Join(parts[:len(parts)-2], "/"), nil
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebDID(t *testing.T) {
+	id := uuid.MustParse("8edd8112-c415-11ed-b036-debe37e1cbd6")
+	type expected struct {
+		did     string
+		hostURL string
+		err     error
+	}
+	type testConfig struct {
+		name     string
+		hostURL  string
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:    "host only",
+			hostURL: "https://issuer.example.com",
+			expected: expected{
+				did:     "did:web:issuer.example.com:identities:8edd8112-c415-11ed-b036-debe37e1cbd6",
+				hostURL: "https://issuer.example.com",
+			},
+		},
+		{
+			name:    "host with path",
+			hostURL: "https://example.com/issuer/",
+			expected: expected{
+				did:     "did:web:example.com:issuer:identities:8edd8112-c415-11ed-b036-debe37e1cbd6",
+				hostURL: "https://example.com/issuer",
+			},
+		},
+		{
+			name:     "host with port",
+			hostURL:  "http://localhost:3001",
+			expected: expected{err: ErrInvalidWebDIDHost},
+		},
+		{
+			name:     "no host",
+			hostURL:  "issuer",
+			expected: expected{err: ErrInvalidWebDIDHost},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			did, err := NewWebDID(tc.hostURL, id)
+			if tc.expected.err != nil {
+				assert.ErrorIs(t, err, tc.expected.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected.did, did.String())
+			assert.True(t, IsWebDID(*did))
+
+			hostURL, err := WebDIDHostURL(*did)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected.hostURL, hostURL)
+		})
+	}
+}
+
+func TestWebDIDHostURL_NotWebDID(t *testing.T) {
+	did, err := w3c.ParseDID("did:polygonid:polygon:amoy:2qQ8S2VKdQv7xYgzCn7KW2xgzUWrTRQjoZDYavJHBq")
+	require.NoError(t, err)
+	assert.False(t, IsWebDID(*did))
+	_, err = WebDIDHostURL(*did)
+	assert.ErrorIs(t, err, ErrInvalidIdentifier)
+}
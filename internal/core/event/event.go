@@ -7,9 +7,10 @@ import (
 )
 
 const (
-	CreateCredentialEvent = "createCredentialEvent" // CreateCredentialEvent create credential event
-	CreateConnectionEvent = "createConnectionEvent" // CreateConnectionEvent create connection MyEvent
-	CreateStateEvent      = "createStateEvent"      // CreateStateEvent create state event
+	CreateCredentialEvent   = "createCredentialEvent"   // CreateCredentialEvent create credential event
+	CreateConnectionEvent   = "createConnectionEvent"   // CreateConnectionEvent create connection MyEvent
+	CreateStateEvent        = "createStateEvent"        // CreateStateEvent create state event
+	ExpiredCredentialsEvent = "expiredCredentialsEvent" // ExpiredCredentialsEvent expired credentials event
 )
 
 // CreateState defines the createState data
@@ -58,3 +59,19 @@ func (ev *CreateConnection) Marshal() (msg pubsub.Message, err error) {
 func (ev *CreateConnection) Unmarshal(msg pubsub.Message) error {
 	return json.Unmarshal(msg, &ev)
 }
+
+// ExpiredCredentials defines the expiredCredentials data
+type ExpiredCredentials struct {
+	CredentialIDs []string `json:"credentialsID"`
+	IssuerID      string   `json:"issuerID"`
+}
+
+// Marshal marshals the event into a pubsub.Message
+func (ev *ExpiredCredentials) Marshal() (msg pubsub.Message, err error) {
+	return json.Marshal(ev)
+}
+
+// Unmarshal creates an event from that message
+func (ev *ExpiredCredentials) Unmarshal(msg pubsub.Message) error {
+	return json.Unmarshal(msg, &ev)
+}
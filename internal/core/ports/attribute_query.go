@@ -0,0 +1,115 @@
+package ports
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AttributeQueryOperator is a comparison operator of an attribute query
+type AttributeQueryOperator string
+
+const (
+	AttributeQueryOperatorEq AttributeQueryOperator = "$eq" // AttributeQueryOperatorEq matches values equal to the given one
+	AttributeQueryOperatorNe AttributeQueryOperator = "$ne" // AttributeQueryOperatorNe matches values not equal to the given one, or missing attributes
+	AttributeQueryOperatorLt AttributeQueryOperator = "$lt" // AttributeQueryOperatorLt matches values lower than the given one
+	AttributeQueryOperatorGt AttributeQueryOperator = "$gt" // AttributeQueryOperatorGt matches values greater than the given one
+	AttributeQueryOperatorIn AttributeQueryOperator = "$in" // AttributeQueryOperatorIn matches values equal to any of the given ones
+)
+
+// ErrInvalidAttributeQuery means that the attribute query is malformed or does not match the schema
+var ErrInvalidAttributeQuery = errors.New("invalid attributes query")
+
+// AttributeCondition is a condition over an attribute of the credential subject.
+// Values has one element for all the operators but $in.
+// Values are strings after parsing the query and they are converted to Type, the type of the attribute
+// in the json schema, before querying the repository.
+type AttributeCondition struct {
+	Field    string
+	Operator AttributeQueryOperator
+	Type     string
+	Values   []any
+}
+
+// ParseAttributeQuery parses a query like {"birthday": {"$lt": 20000101}, "documentType": {"$in": [1, 2]}, "country": "ES"}
+// A value that is not an object is an equality condition. Conditions are returned sorted by field and operator.
+func ParseAttributeQuery(query string) ([]AttributeCondition, error) {
+	decoder := json.NewDecoder(bytes.NewBufferString(query))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAttributeQuery, err)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: no conditions", ErrInvalidAttributeQuery)
+	}
+
+	conditions := make([]AttributeCondition, 0, len(fields))
+	for field, value := range fields {
+		operators, ok := value.(map[string]any)
+		if !ok {
+			operators = map[string]any{string(AttributeQueryOperatorEq): value}
+		}
+		if len(operators) == 0 {
+			return nil, fmt.Errorf("%w: no operators for attribute <%s>", ErrInvalidAttributeQuery, field)
+		}
+		for op, operand := range operators {
+			condition, err := newAttributeCondition(field, AttributeQueryOperator(op), operand)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, *condition)
+		}
+	}
+	slices.SortFunc(conditions, func(a, b AttributeCondition) int {
+		if c := strings.Compare(a.Field, b.Field); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.Operator), string(b.Operator))
+	})
+	return conditions, nil
+}
+
+func newAttributeCondition(field string, op AttributeQueryOperator, operand any) (*AttributeCondition, error) {
+	condition := &AttributeCondition{Field: field, Operator: op}
+	switch op {
+	case AttributeQueryOperatorEq, AttributeQueryOperatorNe, AttributeQueryOperatorLt, AttributeQueryOperatorGt:
+		value, err := attributeQueryValue(field, operand)
+		if err != nil {
+			return nil, err
+		}
+		condition.Values = []any{value}
+	case AttributeQueryOperatorIn:
+		operands, ok := operand.([]any)
+		if !ok || len(operands) == 0 {
+			return nil, fmt.Errorf("%w: %s on attribute <%s> requires a non empty array", ErrInvalidAttributeQuery, op, field)
+		}
+		for _, o := range operands {
+			value, err := attributeQueryValue(field, o)
+			if err != nil {
+				return nil, err
+			}
+			condition.Values = append(condition.Values, value)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported operator <%s> on attribute <%s>", ErrInvalidAttributeQuery, op, field)
+	}
+	return condition, nil
+}
+
+func attributeQueryValue(field string, operand any) (string, error) {
+	switch v := operand.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("%w: attribute <%s> must be compared with a string, number or boolean", ErrInvalidAttributeQuery, field)
+	}
+}
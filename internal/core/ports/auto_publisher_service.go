@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+)
+
+// AutoPublisherService is the interface implemented by the service that publishes the states of the identities
+// following their publishing policies
+type AutoPublisherService interface {
+	Publish(ctx context.Context) error
+}
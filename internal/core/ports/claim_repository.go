@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/iden3/go-iden3-core/v2/w3c"
@@ -22,10 +23,14 @@ type ClaimRepository interface {
 	FindOneClaimBySchemaHash(ctx context.Context, conn db.Querier, subject *w3c.DID, schemaHash string) (*domain.Claim, error)
 	GetAllByIssuerID(ctx context.Context, conn db.Querier, identifier w3c.DID, filter *ClaimsFilter) ([]*domain.Claim, uint, error)
 	GetNonRevokedByConnectionAndIssuerID(ctx context.Context, conn db.Querier, connID uuid.UUID, issuerID w3c.DID) ([]*domain.Claim, error)
+	GetExpiredNotProcessed(ctx context.Context, conn db.Querier, expiredOn time.Time, limit int) ([]*domain.Claim, error)
+	MarkExpirationProcessed(ctx context.Context, conn db.Querier, ids []uuid.UUID) error
 	GetAllByState(ctx context.Context, conn db.Querier, did *w3c.DID, state *merkletree.Hash) (claims []domain.Claim, err error)
 	GetAllByStateWithMTProof(ctx context.Context, conn db.Querier, did *w3c.DID, state *merkletree.Hash) (claims []domain.Claim, err error)
 	UpdateState(ctx context.Context, conn db.Querier, claim *domain.Claim) (int64, error)
 	GetAuthClaimsForPublishing(ctx context.Context, conn db.Querier, identifier *w3c.DID, publishingState string, schemaHash string) ([]*domain.Claim, error)
+	GetAuthClaims(ctx context.Context, conn db.Querier, identifier *w3c.DID, schemaHash string) ([]*domain.Claim, error)
+	GetSigningAuthClaim(ctx context.Context, conn db.Querier, identifier *w3c.DID, schemaHash string) (*domain.Claim, error)
 	UpdateClaimMTP(ctx context.Context, conn db.Querier, claim *domain.Claim) (int64, error)
 	Delete(ctx context.Context, conn db.Querier, id uuid.UUID) error
 	GetClaimsIssuedForUser(ctx context.Context, conn db.Querier, identifier w3c.DID, userDID w3c.DID, linkID uuid.UUID) ([]*domain.Claim, error)
@@ -14,6 +14,7 @@ import (
 	"github.com/iden3/iden3comm/v2/protocol"
 
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/sqltools"
 )
 
@@ -43,6 +44,41 @@ type CreateClaimRequest struct {
 	RefreshService        *verifiable.RefreshService
 	RevNonce              *uint64
 	DisplayMethod         *verifiable.DisplayMethod
+	Updatable             bool
+	PreviousID            *uuid.UUID
+	AuthClaimID           *uuid.UUID // Auth claim that signs the BJJSignatureProof2021. The oldest non revoked one if nil.
+}
+
+// UpdateClaimRequest struct. CredentialSubject holds the attributes that change, the rest are copied from the
+// credential being updated. If Expiration is nil, the expiration of the previous credential is kept.
+type UpdateClaimRequest struct {
+	DID               *w3c.DID
+	ClaimID           uuid.UUID
+	CredentialSubject map[string]any
+	Expiration        *time.Time
+}
+
+// CreateClaimBatchResult is the result of a single request of a batch credential creation.
+// Claim is nil when the credential could not be created and Err holds the reason.
+type CreateClaimBatchResult struct {
+	Claim *domain.Claim
+	Err   error
+}
+
+// CredentialValidation is the result of validating a credential request without issuing the credential.
+// Credential, SchemaHash and the slots of the core claim are only set when there are no errors.
+// Slots are decimal numbers.
+type CredentialValidation struct {
+	Credential *verifiable.W3CCredential
+	SchemaHash string
+	IndexSlots [4]string
+	ValueSlots [4]string
+	Errors     []error
+}
+
+// Valid returns true if the credential can be issued
+func (v *CredentialValidation) Valid() bool {
+	return len(v.Errors) == 0
 }
 
 // AgentRequest struct
@@ -76,9 +112,14 @@ type ClaimsFilter struct {
 	ExpiredOn       *time.Time
 	SchemaHash      string
 	SchemaType      string
+	ExactSchemaType bool // SchemaType must be equal to the credential schema type instead of part of it
 	Subject         string
 	QueryField      string
 	QueryFieldValue string
+	SchemaURL       string
+	Attributes      []AttributeCondition // Conditions over the credential subject. Requires SchemaURL to know the attribute types.
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
 	FTSQuery        string
 	FTSAndCond      bool
 	Proofs          []verifiable.ProofType
@@ -213,16 +254,21 @@ type GetCredentialQrCodeResponse struct {
 // ClaimService is the interface implemented by the claim service
 type ClaimService interface {
 	Save(ctx context.Context, claimReq *CreateClaimRequest) (*domain.Claim, error)
+	SaveBatch(ctx context.Context, claimReqs []*CreateClaimRequest) ([]CreateClaimBatchResult, error)
+	Update(ctx context.Context, req *UpdateClaimRequest) (*domain.Claim, error)
 	GetRevoked(ctx context.Context, currentState string) ([]*domain.Claim, error)
-	CreateCredential(ctx context.Context, req *CreateClaimRequest) (*domain.Claim, error)
+	CreateCredential(ctx context.Context, conn db.Querier, req *CreateClaimRequest) (*domain.Claim, error)
+	ValidateCredential(ctx context.Context, req *CreateClaimRequest) (*CredentialValidation, error)
 	Revoke(ctx context.Context, id w3c.DID, nonce uint64, description string) error
 	GetAll(ctx context.Context, did w3c.DID, filter *ClaimsFilter) ([]*domain.Claim, uint, error)
 	RevokeAllFromConnection(ctx context.Context, connID uuid.UUID, issuerID w3c.DID) error
+	RevokeByFilter(ctx context.Context, issuerID w3c.DID, filter *ClaimsFilter, description string, dryRun bool) ([]*domain.Claim, error)
 	GetRevocationStatus(ctx context.Context, issuerDID w3c.DID, nonce uint64) (*verifiable.RevocationStatus, error)
 	GetByID(ctx context.Context, issID *w3c.DID, id uuid.UUID) (*domain.Claim, error)
 	GetCredentialQrCode(ctx context.Context, issID *w3c.DID, id uuid.UUID, hostURL string) (*GetCredentialQrCodeResponse, error)
 	Agent(ctx context.Context, req *AgentRequest, mediatype iden3comm.MediaType) (*domain.Agent, error)
 	GetAuthClaim(ctx context.Context, did *w3c.DID) (*domain.Claim, error)
+	GetAuthClaims(ctx context.Context, did *w3c.DID) ([]*domain.Claim, error)
 	GetAuthClaimForPublishing(ctx context.Context, did *w3c.DID, state string) (*domain.Claim, error)
 	UpdateClaimsMTPAndState(ctx context.Context, currentState *domain.IdentityState) error
 	Delete(ctx context.Context, id uuid.UUID) error
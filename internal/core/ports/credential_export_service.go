@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// CredentialExportFormat is a format other than the iden3 JSON-LD one a credential can be exported to
+type CredentialExportFormat string
+
+const (
+	CredentialExportFormatJWTVC   CredentialExportFormat = "jwt-vc"    // CredentialExportFormatJWTVC is the JWT encoding of the W3C VC data model
+	CredentialExportFormatSDJWTVC CredentialExportFormat = "sd-jwt-vc" // CredentialExportFormatSDJWTVC is the IETF SD-JWT VC format
+)
+
+// CredentialExportService is the interface implemented by the credential export service
+type CredentialExportService interface {
+	Export(ctx context.Context, claim *domain.Claim, format CredentialExportFormat) (string, error)
+	ExportW3CCredential(ctx context.Context, issuerDID w3c.DID, credential *verifiable.W3CCredential, format CredentialExportFormat) (string, error)
+	IsSignedWithCurrentKey(ctx context.Context, issuerDID w3c.DID, token string) (bool, error)
+}
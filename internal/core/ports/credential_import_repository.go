@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// CredentialImportRepository defines the methods to persist credential import jobs
+type CredentialImportRepository interface {
+	Save(ctx context.Context, conn db.Querier, credentialImport *domain.CredentialImport) error
+	UpdateProgress(ctx context.Context, conn db.Querier, credentialImport *domain.CredentialImport) error
+	Claim(ctx context.Context, conn db.Querier, credentialImport *domain.CredentialImport) (bool, error)
+	GetByID(ctx context.Context, conn db.Querier, issuerDID w3c.DID, id uuid.UUID) (*domain.CredentialImport, error)
+	GetUnfinished(ctx context.Context, conn db.Querier) ([]*domain.CredentialImport, error)
+	SaveRows(ctx context.Context, conn db.Querier, importID uuid.UUID, rows []domain.CredentialImportRow) error
+	GetRows(ctx context.Context, conn db.Querier, importID uuid.UUID, after int, limit int) ([]domain.CredentialImportRow, error)
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// CredentialImportRequest defines the request to issue credentials for all the subjects of a file
+type CredentialImportRequest struct {
+	DID                  *w3c.DID
+	SchemaID             uuid.UUID
+	Format               domain.CredentialImportFormat
+	Content              []byte
+	Expiration           *time.Time
+	Proofs               ClaimRequestProofs
+	CredentialStatusType verifiable.CredentialStatusType
+}
+
+// CredentialImportService defines the methods to issue credentials in bulk from a file
+type CredentialImportService interface {
+	Import(ctx context.Context, req *CredentialImportRequest) (*domain.CredentialImport, error)
+	GetByID(ctx context.Context, issuerDID w3c.DID, id uuid.UUID) (*domain.CredentialImport, error)
+	Resume(ctx context.Context) error
+}
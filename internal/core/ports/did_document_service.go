@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+)
+
+// DIDResolution is the result of resolving the DID of an identity of the node
+type DIDResolution struct {
+	Document    *verifiable.DIDDocument
+	Deactivated bool
+}
+
+// DIDDocumentService is the interface implemented by the DID document service
+type DIDDocumentService interface {
+	Resolve(ctx context.Context, did w3c.DID) (*DIDResolution, error)
+}
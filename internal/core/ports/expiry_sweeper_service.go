@@ -0,0 +1,10 @@
+package ports
+
+import (
+	"context"
+)
+
+// ExpirySweeperService is the interface implemented by the service that processes expired credentials
+type ExpirySweeperService interface {
+	Sweep(ctx context.Context) error
+}
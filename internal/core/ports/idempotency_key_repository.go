@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// IdempotencyKeyRepository is the interface implemented by the idempotency keys repository
+type IdempotencyKeyRepository interface {
+	Reserve(ctx context.Context, conn db.Querier, key *domain.IdempotencyKey, staleBefore time.Time) (bool, error)
+	Get(ctx context.Context, conn db.Querier, issuerDID w3c.DID, operation domain.IdempotentOperation, key string) (*domain.IdempotencyKey, error)
+	SetResponse(ctx context.Context, conn db.Querier, key *domain.IdempotencyKey, response json.RawMessage) error
+	Delete(ctx context.Context, conn db.Querier, key *domain.IdempotencyKey) error
+	DeleteExpired(ctx context.Context, conn db.Querier) (int64, error)
+}
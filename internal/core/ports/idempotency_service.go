@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// IdempotencyService is the interface implemented by the idempotency service
+type IdempotencyService interface {
+	Do(ctx context.Context, issuerDID w3c.DID, operation domain.IdempotentOperation, key string, request any, fn func(ctx context.Context) (any, error)) (json.RawMessage, error)
+	DeleteExpired(ctx context.Context) error
+}
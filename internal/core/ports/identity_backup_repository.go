@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// IdentityBackupRepository is the interface implemented by the repository that dumps and restores the rows of an identity
+type IdentityBackupRepository interface {
+	GetTables(ctx context.Context, conn db.Querier, identifier w3c.DID) ([]domain.IdentityBackupTable, error)
+	GetMerkleTrees(ctx context.Context, conn db.Querier, identifier w3c.DID) ([]domain.IdentityBackupMerkleTree, error)
+	RestoreTable(ctx context.Context, conn db.Querier, identifier w3c.DID, table domain.IdentityBackupTable) error
+	RestoreMerkleTree(ctx context.Context, conn db.Querier, mtID uint64, tree domain.IdentityBackupMerkleTree) error
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+)
+
+// IdentityBackupRequest are the options of an identity backup
+type IdentityBackupRequest struct {
+	Passphrase  string
+	IncludeKeys bool
+}
+
+// IdentityBackupService is the interface implemented by the identity backup service
+type IdentityBackupService interface {
+	Export(ctx context.Context, did w3c.DID, req IdentityBackupRequest) ([]byte, error)
+	Restore(ctx context.Context, archive []byte, passphrase string) (*w3c.DID, error)
+}
@@ -13,9 +13,13 @@ import (
 type IndentityRepository interface {
 	Save(ctx context.Context, conn db.Querier, identity *domain.Identity) error
 	GetByID(ctx context.Context, conn db.Querier, identifier w3c.DID) (*domain.Identity, error)
-	Get(ctx context.Context, conn db.Querier) (identities []domain.IdentityDisplayName, err error)
+	IsActive(ctx context.Context, conn db.Querier, identifier w3c.DID) (bool, error)
+	Get(ctx context.Context, conn db.Querier, req *GetIdentitiesRequest) (identities []domain.IdentityDisplayName, total uint, err error)
 	GetUnprocessedIssuersIDs(ctx context.Context, conn db.Querier) (issuersIDs []*w3c.DID, err error)
+	GetPendingStateChanges(ctx context.Context, conn db.Querier, identifier w3c.DID) (*domain.PendingStateChanges, error)
 	HasUnprocessedStatesByID(ctx context.Context, conn db.Querier, identifier *w3c.DID) (bool, error)
 	HasUnprocessedAndFailedStatesByID(ctx context.Context, conn db.Querier, identifier *w3c.DID) (bool, error)
-	UpdateDisplayName(ctx context.Context, conn db.Querier, identity *domain.Identity) error
+	Update(ctx context.Context, conn db.Querier, identity *domain.Identity) error
+	UpdateStatus(ctx context.Context, conn db.Querier, identity *domain.Identity) error
+	UpdateAuthClaimID(ctx context.Context, conn db.Querier, identity *domain.Identity) error
 }
@@ -10,7 +10,10 @@ import (
 	"github.com/iden3/iden3comm/v2/protocol"
 
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/pagination"
+	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/sqltools"
 )
 
 const (
@@ -18,6 +21,13 @@ const (
 	AuthorizationRequestQRCallbackURL = "%s/v2/authentication/callback?sessionID=%s"
 )
 
+// Constants defining the sort by fields of the identities list
+const (
+	IdentitiesDisplayName       sqltools.SQLFieldName = "identities.display_name"
+	IdentitiesCreatedAt         sqltools.SQLFieldName = "identities.created_at"
+	IdentitiesCredentialsIssued sqltools.SQLFieldName = "credentials.issued"
+)
+
 // DIDCreationOptions represents options for DID creation
 type DIDCreationOptions struct {
 	Method               core.DIDMethod                  `json:"method"`
@@ -28,6 +38,32 @@ type DIDCreationOptions struct {
 	DisplayName          *string                         `json:"displayName,omitempty"`
 }
 
+// UpdateIdentityRequest represents the fields of an identity to update. Nil fields are not changed.
+type UpdateIdentityRequest struct {
+	DisplayName      *string
+	Profile          *domain.IdentityProfileUpdate // Merged into the current profile
+	PublishingPolicy *domain.PublishingPolicy      // Replaces the whole policy
+}
+
+// DeactivateIdentityRequest represents the options to deactivate an identity
+type DeactivateIdentityRequest struct {
+	RevokeCredentials bool // Revoke all the credentials issued by the identity in the same state transition
+	Archive           bool // Archived identities are not listed by default
+}
+
+// GetIdentitiesRequest is the request to list the identities of the node. Empty filters match every identity.
+type GetIdentitiesRequest struct {
+	IncludeArchived      bool
+	Query                string // Text searched in the display name and the DID
+	Method               string
+	Blockchain           string
+	Network              string
+	KeyType              string
+	CredentialStatusType string
+	Pagination           pagination.Filter // If Page is nil, all the identities are returned
+	OrderBy              sqltools.OrderByFilters
+}
+
 // CreateAuthenticationQRCodeResponse represents the response of the CreateAuthenticationQRCode method
 type CreateAuthenticationQRCodeResponse struct {
 	QRCodeURL string `json:"qrCodeURL"`
@@ -40,7 +76,8 @@ type IdentityService interface {
 	GetByDID(ctx context.Context, identifier w3c.DID) (*domain.Identity, error)
 	Create(ctx context.Context, hostURL string, didOptions *DIDCreationOptions) (*domain.Identity, error)
 	SignClaimEntry(ctx context.Context, authClaim *domain.Claim, claimEntry *core.Claim) (*verifiable.BJJSignatureProof2021, error)
-	Get(ctx context.Context) (identities []domain.IdentityDisplayName, err error)
+	SignWebClaimEntry(ctx context.Context, did w3c.DID, claimEntry *core.Claim) (*verifiable.BJJSignatureProof2021, error)
+	Get(ctx context.Context, req *GetIdentitiesRequest) (identities []domain.IdentityDisplayName, total uint, err error)
 	UpdateState(ctx context.Context, did w3c.DID) (*domain.IdentityState, error)
 	Exists(ctx context.Context, identifier w3c.DID) (bool, error)
 	GetLatestStateByID(ctx context.Context, identifier w3c.DID) (*domain.IdentityState, error)
@@ -56,6 +93,11 @@ type IdentityService interface {
 	Authenticate(ctx context.Context, message string, sessionID uuid.UUID, serverURL string) (*protocol.AuthorizationResponseMessage, error)
 	AuthenticateWithRequest(ctx context.Context, sessionID *uuid.UUID, authReq protocol.AuthorizationRequestMessage, message string, serverURL string) (*protocol.AuthorizationResponseMessage, error)
 	GetFailedState(ctx context.Context, identifier w3c.DID) (*domain.IdentityState, error)
+	GetTransactedState(ctx context.Context, identifier w3c.DID) (*domain.IdentityState, error)
 	PublishGenesisStateToRHS(ctx context.Context, did *w3c.DID) error
-	UpdateIdentityDisplayName(ctx context.Context, did w3c.DID, displayName string) error
+	UpdateIdentity(ctx context.Context, did w3c.DID, req UpdateIdentityRequest) (*domain.Identity, error)
+	RotateKey(ctx context.Context, did w3c.DID, authClaimID *uuid.UUID) (*domain.Claim, error)
+	AddAuthKey(ctx context.Context, did w3c.DID) (*domain.Claim, error)
+	Deactivate(ctx context.Context, did w3c.DID, req DeactivateIdentityRequest) ([]*domain.Claim, error)
+	CheckActive(ctx context.Context, conn db.Querier, did w3c.DID) error
 }
@@ -15,6 +15,7 @@ type NotificationService interface {
 	SendCreateCredentialNotification(ctx context.Context, payload pubsub.Message) error
 	SendCreateConnectionNotification(ctx context.Context, payload pubsub.Message) error
 	SendRevokeCredentialNotification(ctx context.Context, payload pubsub.Message) error
+	SendExpiredCredentialNotification(ctx context.Context, payload pubsub.Message) error
 }
 
 // NotificationGateway represents the notification interface
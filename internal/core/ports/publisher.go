@@ -13,4 +13,6 @@ type Publisher interface {
 	PublishState(ctx context.Context, identity *w3c.DID) (*domain.PublishedState, error)
 	RetryPublishState(ctx context.Context, identifier *w3c.DID) (*domain.PublishedState, error)
 	CheckTransactionStatus(ctx context.Context, identity *domain.Identity)
+	SpeedUpPublishState(ctx context.Context, identifier *w3c.DID) (*domain.IdentityState, error)
+	CancelPublishState(ctx context.Context, identifier *w3c.DID) (*domain.IdentityState, error)
 }
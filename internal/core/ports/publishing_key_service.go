@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+)
+
+// PublishingKeyService is the interface implemented by the pool of keys that publish the states of the identities
+type PublishingKeyService interface {
+	Acquire(ctx context.Context, resolverPrefix string) (kms.KeyID, func(), error)
+	KeyByAddress(ctx context.Context, resolverPrefix string, address common.Address) (kms.KeyID, error)
+	Status(ctx context.Context) ([]domain.PublishingKeyStatus, error)
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// ErrPublishingNonceNotFound means that no nonce in use was sent with the transaction
+var ErrPublishingNonceNotFound = errors.New("publishing nonce not found")
+
+// PublishingNonceRepository is the interface implemented by the publishing nonces repository
+type PublishingNonceRepository interface {
+	Lock(ctx context.Context, conn db.Querier, chainID uint64, address string) error
+	GetFrom(ctx context.Context, conn db.Querier, chainID uint64, address string, from uint64) ([]uint64, error)
+	GetSentFrom(ctx context.Context, conn db.Querier, chainID uint64, address string, from uint64) ([]*domain.PublishingNonce, error)
+	GetByTxID(ctx context.Context, conn db.Querier, chainID uint64, txID string) (*domain.PublishingNonce, error)
+	Save(ctx context.Context, conn db.Querier, nonce *domain.PublishingNonce) error
+	Delete(ctx context.Context, conn db.Querier, chainID uint64, address string, nonce uint64) error
+	DeleteExpired(ctx context.Context, conn db.Querier, chainID uint64, address string, minedBelow uint64, reservedBefore time.Time) (int64, error)
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// StatusListRepository defines the methods to persist the bitstring status lists of the issuers
+type StatusListRepository interface {
+	Allocate(ctx context.Context, conn db.Querier, issuerDID w3c.DID, purpose domain.StatusPurpose) (*domain.StatusList, int, error)
+	Next(ctx context.Context, conn db.Querier, issuerDID w3c.DID, purpose domain.StatusPurpose) (*domain.StatusList, int, error)
+	GetByID(ctx context.Context, conn db.Querier, issuerDID w3c.DID, id uuid.UUID) (*domain.StatusList, error)
+	Set(ctx context.Context, conn db.Querier, issuerDID w3c.DID, id uuid.UUID, index int) error
+	SaveCredentials(ctx context.Context, conn db.Querier, list *domain.StatusList) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+)
+
+// StatusListService is the interface implemented by the status list service
+type StatusListService interface {
+	GetCredential(ctx context.Context, issuerDID w3c.DID, id uuid.UUID) (*verifiable.W3CCredential, error)
+	GetCredentialJWT(ctx context.Context, issuerDID w3c.DID, id uuid.UUID) (string, error)
+}
@@ -16,4 +16,5 @@ type TransactionService interface {
 	GetHeaderByNumber(ctx context.Context, identity *domain.Identity, blockNumber *big.Int) (*types.Header, error)
 	CheckConfirmation(ctx context.Context, identity *domain.Identity, receipt *types.Receipt, confirmationBlockCount int64) (bool, error)
 	GetTransactionReceiptByID(ctx context.Context, identity *domain.Identity, txID string) (*types.Receipt, error)
+	IsTransactionPending(ctx context.Context, identity *domain.Identity, txID string) (bool, error)
 }
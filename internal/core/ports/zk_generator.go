@@ -3,11 +3,16 @@ package ports
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"github.com/iden3/go-rapidsnark/types"
 )
 
+// ErrInvalidProof means that a proof does not verify with the verification key of its circuit
+var ErrInvalidProof = errors.New("invalid proof")
+
 // ZKGenerator interface
 type ZKGenerator interface {
 	Generate(ctx context.Context, inputs json.RawMessage, circuitName string) (*types.ZKProof, error)
+	VerifyProof(ctx context.Context, zkProof *types.ZKProof, circuitName string) error
 }
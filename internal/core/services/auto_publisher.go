@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/gateways"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+type autoPublisher struct {
+	identityRepo ports.IndentityRepository
+	publisher    ports.Publisher
+	storage      *db.Storage
+	concurrency  int
+}
+
+// NewAutoPublisher is the auto publisher service constructor. Up to concurrency states are published at the same time.
+func NewAutoPublisher(identityRepo ports.IndentityRepository, publisher ports.Publisher, storage *db.Storage, concurrency int) ports.AutoPublisherService {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &autoPublisher{
+		identityRepo: identityRepo,
+		publisher:    publisher,
+		storage:      storage,
+		concurrency:  concurrency,
+	}
+}
+
+// Publish publishes the state of the identities with pending changes whose publishing policy says so.
+// Identities with a state transition in progress are skipped, their changes go with the next one.
+// A failure to publish one identity is logged and does not stop the others.
+// The states of different identities are published concurrently.
+func (p *autoPublisher) Publish(ctx context.Context) error {
+	issuers, err := p.identityRepo.GetUnprocessedIssuersIDs(ctx, p.storage.Pgx)
+	if err != nil {
+		log.Error(ctx, "auto publisher: loading issuers with pending changes", "err", err)
+		return err
+	}
+
+	now := time.Now()
+	sem := make(chan struct{}, p.concurrency)
+	var wg sync.WaitGroup
+	for _, did := range issuers {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(did *w3c.DID) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			p.publish(ctx, did, now)
+		}(did)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (p *autoPublisher) publish(ctx context.Context, did *w3c.DID, now time.Time) {
+	identity, err := p.identityRepo.GetByID(ctx, p.storage.Pgx, *did)
+	if err != nil {
+		log.Error(ctx, "auto publisher: loading identity", "err", err, "issuer", did)
+		return
+	}
+	pending, err := p.identityRepo.GetPendingStateChanges(ctx, p.storage.Pgx, *did)
+	if err != nil {
+		log.Error(ctx, "auto publisher: loading pending changes", "err", err, "issuer", did)
+		return
+	}
+	if !identity.PublishingPolicy.ShouldPublish(*pending, now) {
+		return
+	}
+
+	if _, err := p.publisher.PublishState(ctx, did); err != nil {
+		if errors.Is(err, gateways.ErrStateIsBeingProcessed) || errors.Is(err, gateways.ErrNoStatesToProcess) {
+			log.Info(ctx, "auto publisher: nothing to publish now", "issuer", did, "reason", err)
+			return
+		}
+		log.Error(ctx, "auto publisher: publishing state", "err", err, "issuer", did)
+		return
+	}
+	log.Info(ctx, "auto publisher: state published", "issuer", did, "policy", identity.PublishingPolicy.Mode,
+		"credentials", pending.Credentials, "revocations", pending.Revocations)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
 	"net/url"
 	"strings"
@@ -21,6 +22,7 @@ import (
 	"github.com/iden3/iden3comm/v2/packers"
 	"github.com/iden3/iden3comm/v2/protocol"
 	shell "github.com/ipfs/go-ipfs-api"
+	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
 
 	"github.com/polygonid/sh-id-platform/internal/common"
@@ -42,13 +44,22 @@ import (
 
 var (
 	ErrCredentialNotFound                = errors.New("credential not found")                                          // ErrCredentialNotFound Cannot retrieve the given claim
+	ErrCredentialNotUpdatable            = errors.New("credential is not updatable")                                   // ErrCredentialNotUpdatable means that the credential was not issued as updatable
+	ErrCredentialRevoked                 = errors.New("credential is revoked")                                         // ErrCredentialRevoked means that the credential is revoked and cannot be updated
+	ErrCredentialSubjectIDChanged        = errors.New("the credential subject id cannot be updated")                   // ErrCredentialSubjectIDChanged means that an update tried to change the holder of the credential
 	ErrDisplayMethodLacksURL             = errors.New("credential request with display method lacks url")              // ErrDisplayMethodLacksURL means the credential request includes a display method, but the url is not set
+	ErrDuplicatedClaimID                 = errors.New("duplicated credential id in the batch")                         // ErrDuplicatedClaimID means that two credentials of the same batch have the same id
+	ErrEmptyBatch                        = errors.New("the batch must contain at least one credential")                // ErrEmptyBatch means that a batch creation was requested without credentials
+	ErrEmptyRevocationFilter             = errors.New("at least one filter is required to revoke credentials")         // ErrEmptyRevocationFilter means that a bulk revocation was requested without any criteria
 	ErrEmptyMTPProof                     = errors.New("mtp credentials must have a mtp proof to be fetched")           // ErrEmptyMTPProof means that a credential of MTP type can not be fetched if it does not contain the proof
 	ErrJSONLdContext                     = errors.New("jsonLdContext must be a string")                                // ErrJSONLdContext Field jsonLdContext must be a string
+	ErrInvalidBatch                      = errors.New("one or more credentials of the batch are invalid")              // ErrInvalidBatch means that at least one credential of a batch could not be created, so none was stored
 	ErrInvalidCredentialSubject          = errors.New("credential subject does not match the provided schema")         // ErrInvalidCredentialSubject means the credentialSubject does not match the schema provided
+	ErrInvalidAuthClaim                  = errors.New("invalid auth credential to sign the credential")                // ErrInvalidAuthClaim means that the requested auth credential does not exist, is revoked or is not an auth credential
 	ErrLinkNotFound                      = errors.New("link not found")                                                // ErrLinkNotFound Cannot get the given link from the DB
 	ErrLoadingSchema                     = errors.New("cannot load schema")                                            // ErrLoadingSchema means the system cannot load the schema file
 	ErrMalformedURL                      = errors.New("malformed url")                                                 // ErrMalformedURL The schema url is wrong
+	ErrMixedIssuersInBatch               = errors.New("all the credentials of a batch must have the same issuer")      // ErrMixedIssuersInBatch means that a batch contains credentials of different issuers
 	ErrParseClaim                        = errors.New("cannot parse claim")                                            // ErrParseClaim Cannot parse claim
 	ErrProcessSchema                     = errors.New("cannot process schema")                                         // ErrProcessSchema Cannot process schema
 	ErrRefreshServiceLacksExpirationTime = errors.New("credential request with refresh service lacks expiration time") // ErrRefreshServiceLacksExpirationTime means the credential request includes a refresh service, but the expiration time is not set
@@ -76,6 +87,23 @@ type claim struct {
 	mediatypeManager         ports.MediatypeManager
 }
 
+// credentialsOfferMessage is the iden3comm credential offer message with the profile of the issuer in the body.
+// Wallets that don't know the issuer field ignore it.
+type credentialsOfferMessage struct {
+	ID       string                      `json:"id"`
+	Typ      iden3comm.MediaType         `json:"typ,omitempty"`
+	Type     iden3comm.ProtocolMessage   `json:"type"`
+	ThreadID string                      `json:"thid,omitempty"`
+	Body     credentialsOfferMessageBody `json:"body,omitempty"`
+	From     string                      `json:"from,omitempty"`
+	To       string                      `json:"to,omitempty"`
+}
+
+type credentialsOfferMessageBody struct {
+	protocol.CredentialsOfferMessageBody
+	Issuer *domain.IdentityProfile `json:"issuer,omitempty"`
+}
+
 // NewClaim creates a new claim service
 func NewClaim(repo ports.ClaimRepository, idenSrv ports.IdentityService, qrService ports.QrStoreService, mtService ports.MtService, identityStateRepository ports.IdentityStateRepository, ld loader.DocumentLoader, storage *db.Storage, host string, ps pubsub.Publisher, ipfsGatewayURL string, revocationStatusResolver *revocationstatus.Resolver, mediatypeManager ports.MediatypeManager, cfg config.UniversalLinks) ports.ClaimService {
 	s := &claim{
@@ -103,15 +131,144 @@ func NewClaim(repo ports.ClaimRepository, idenSrv ports.IdentityService, qrServi
 // 2.- Signature proof
 // 3.- MerkelTree proof
 func (c *claim) Save(ctx context.Context, req *ports.CreateClaimRequest) (*domain.Claim, error) {
-	claim, err := c.CreateCredential(ctx, req)
+	var claim *domain.Claim
+	err := c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var err error
+		claim, err = c.CreateCredential(ctx, tx, req)
+		if err != nil {
+			return err
+		}
+		claim.ID, err = c.icRepo.Save(ctx, tx, claim)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if req.SignatureProof {
+		err = c.publisher.Publish(ctx, event.CreateCredentialEvent, &event.CreateCredential{CredentialIDs: []string{claim.ID.String()}, IssuerID: req.DID.String()})
+		if err != nil {
+			log.Error(ctx, "publish CreateCredentialEvent", "err", err.Error(), "credential", claim.ID.String())
+		}
+	}
+
+	return claim, nil
+}
+
+// SaveBatch creates several credentials of the same issuer at once.
+// Every request is validated and built before anything is stored. If any of them fails, nothing is persisted,
+// ErrInvalidBatch is returned and the results carry the error of each failing request.
+// Otherwise, all the credentials are stored in a single transaction and only one CreateCredentialEvent is
+// published with all the credentials that include a signature proof.
+func (c *claim) SaveBatch(ctx context.Context, reqs []*ports.CreateClaimRequest) ([]ports.CreateClaimBatchResult, error) {
+	if len(reqs) == 0 {
+		return nil, ErrEmptyBatch
+	}
+
+	issuerDID := reqs[0].DID
+	results := make([]ports.CreateClaimBatchResult, len(reqs))
+	err := c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		claimIDs := make(map[uuid.UUID]struct{}, len(reqs))
+		invalid := false
+		for i, req := range reqs {
+			if req.DID.String() != issuerDID.String() {
+				results[i].Err, invalid = ErrMixedIssuersInBatch, true
+				continue
+			}
+			// every credential is built in a savepoint, so a failing one does not abort the transaction
+			var claim *domain.Claim
+			err := tx.BeginFunc(ctx, func(savepoint pgx.Tx) error {
+				var err error
+				claim, err = c.CreateCredential(ctx, savepoint, req)
+				return err
+			})
+			if err != nil {
+				results[i].Err, invalid = err, true
+				continue
+			}
+			if _, found := claimIDs[claim.ID]; found {
+				results[i].Err, invalid = ErrDuplicatedClaimID, true
+				continue
+			}
+			claimIDs[claim.ID] = struct{}{}
+			results[i].Claim = claim
+		}
+		if invalid {
+			return ErrInvalidBatch
+		}
+
+		for i := range results {
+			id, err := c.icRepo.Save(ctx, tx, results[i].Claim)
+			if err != nil {
+				return err
+			}
+			results[i].Claim.ID = id
+		}
+		return nil
+	})
+	if errors.Is(err, ErrInvalidBatch) {
+		log.Warn(ctx, "batch credential creation: invalid credentials found", "issuer", issuerDID.String(), "count", len(reqs))
+		return results, ErrInvalidBatch
+	}
 	if err != nil {
+		log.Error(ctx, "batch credential creation: saving credentials", "err", err, "issuer", issuerDID.String())
 		return nil, err
 	}
-	claim.ID, err = c.icRepo.Save(ctx, c.storage.Pgx, claim)
+
+	credentialIDs := make([]string, 0, len(results))
+	for i, req := range reqs {
+		if req.SignatureProof {
+			credentialIDs = append(credentialIDs, results[i].Claim.ID.String())
+		}
+	}
+	if len(credentialIDs) > 0 {
+		err = c.publisher.Publish(ctx, event.CreateCredentialEvent, &event.CreateCredential{CredentialIDs: credentialIDs, IssuerID: issuerDID.String()})
+		if err != nil {
+			log.Error(ctx, "publish CreateCredentialEvent", "err", err.Error(), "credentials", credentialIDs)
+		}
+	}
+
+	return results, nil
+}
+
+// Update re-issues an updatable credential with new subject attributes.
+// The replacement keeps the schema, holder, proofs and credential status type of the previous credential, increments its
+// version and links to it. The previous credential is revoked and the new one is stored in the same transaction.
+// Finally, a CreateCredentialEvent is published so the holder is offered the new credential.
+func (c *claim) Update(ctx context.Context, req *ports.UpdateClaimRequest) (*domain.Claim, error) {
+	previous, err := c.GetByID(ctx, req.DID, req.ClaimID)
 	if err != nil {
 		return nil, err
 	}
-	if req.SignatureProof {
+	if previous.Revoked {
+		return nil, ErrCredentialRevoked
+	}
+	if !previous.Updatable {
+		return nil, ErrCredentialNotUpdatable
+	}
+
+	createReq, err := c.updateClaimRequest(previous, req)
+	if err != nil {
+		log.Error(ctx, "update credential: building the replacement request", "err", err, "id", req.ClaimID)
+		return nil, err
+	}
+	var claim *domain.Claim
+	err = c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		claim, err = c.CreateCredential(ctx, tx, createReq)
+		if err != nil {
+			return err
+		}
+		if err := c.revoke(ctx, req.DID, uint64(previous.RevNonce), "credential updated", tx); err != nil {
+			return err
+		}
+		claim.ID, err = c.icRepo.Save(ctx, tx, claim)
+		return err
+	})
+	if err != nil {
+		log.Error(ctx, "update credential: saving the replacement", "err", err, "id", req.ClaimID)
+		return nil, err
+	}
+
+	if createReq.SignatureProof {
 		err = c.publisher.Publish(ctx, event.CreateCredentialEvent, &event.CreateCredential{CredentialIDs: []string{claim.ID.String()}, IssuerID: req.DID.String()})
 		if err != nil {
 			log.Error(ctx, "publish CreateCredentialEvent", "err", err.Error(), "credential", claim.ID.String())
@@ -121,17 +278,142 @@ func (c *claim) Save(ctx context.Context, req *ports.CreateClaimRequest) (*domai
 	return claim, nil
 }
 
+// updateClaimRequest builds the request to issue the replacement of the previous credential
+func (c *claim) updateClaimRequest(previous *domain.Claim, req *ports.UpdateClaimRequest) (*ports.CreateClaimRequest, error) {
+	vc, err := previous.GetVerifiableCredential()
+	if err != nil {
+		return nil, err
+	}
+	credentialStatus, err := previous.GetCredentialStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	credentialSubject := make(map[string]any, len(vc.CredentialSubject)+len(req.CredentialSubject))
+	for key, value := range vc.CredentialSubject {
+		if key == "type" {
+			continue
+		}
+		credentialSubject[key] = value
+	}
+	for key, value := range req.CredentialSubject {
+		if key == "id" && value != credentialSubject["id"] {
+			return nil, ErrCredentialSubjectIDChanged
+		}
+		credentialSubject[key] = value
+	}
+
+	expiration := vc.Expiration
+	if req.Expiration != nil {
+		expiration = req.Expiration
+	}
+
+	coreClaim := previous.CoreClaim.Get()
+	subjectPosition, err := coreClaim.GetIDPosition()
+	if err != nil {
+		return nil, err
+	}
+	merklizedPosition, err := coreClaim.GetMerklizedPosition()
+	if err != nil {
+		return nil, err
+	}
+
+	proofs := ports.ClaimRequestProofs{
+		BJJSignatureProof2021:      previous.SignatureProof.Status == pgtype.Present,
+		Iden3SparseMerkleTreeProof: previous.MtProof,
+	}
+	version := previous.Version + 1
+	createReq := ports.NewCreateClaimRequest(req.DID, nil, previous.SchemaURL, credentialSubject, expiration, previous.SchemaType, &version,
+		subjectPositionString(subjectPosition), merklizedRootPositionString(merklizedPosition), proofs, nil, false, credentialStatus.Type,
+		vc.RefreshService, nil, vc.DisplayMethod)
+	createReq.Updatable = true
+	createReq.PreviousID = &previous.ID
+	return createReq, nil
+}
+
+func subjectPositionString(position core.IDPosition) *string {
+	switch position {
+	case core.IDPositionIndex:
+		return common.ToPointer(verifiable.CredentialSubjectPositionIndex)
+	case core.IDPositionValue:
+		return common.ToPointer(verifiable.CredentialSubjectPositionValue)
+	}
+	return nil
+}
+
+func merklizedRootPositionString(position core.MerklizedRootPosition) *string {
+	switch position {
+	case core.MerklizedRootPositionIndex:
+		return common.ToPointer(verifiable.CredentialMerklizedRootPositionIndex)
+	case core.MerklizedRootPositionValue:
+		return common.ToPointer(verifiable.CredentialMerklizedRootPositionValue)
+	}
+	return nil
+}
+
 // GetRevoked returns all the revoked credentials for the given state
 func (c *claim) GetRevoked(ctx context.Context, currentState string) ([]*domain.Claim, error) {
 	return c.icRepo.GetRevoked(ctx, c.storage.Pgx, currentState)
 }
 
 // CreateCredential - Create a new Credential, but this method doesn't save it in the repository.
-func (c *claim) CreateCredential(ctx context.Context, req *ports.CreateClaimRequest) (*domain.Claim, error) {
+// The credential status is reserved with conn, so it should be the transaction that saves the credential.
+func (c *claim) CreateCredential(ctx context.Context, conn db.Querier, req *ports.CreateClaimRequest) (*domain.Claim, error) {
+	return c.newCredential(ctx, conn, req, false)
+}
+
+// ValidateCredential runs the credential creation pipeline and returns the credential that would be issued.
+// Nothing is stored and nothing is signed, so the credential has no proofs and its credential status is not reserved.
+// Problems with the request are returned in the result, the error is only returned for unexpected failures.
+func (c *claim) ValidateCredential(ctx context.Context, req *ports.CreateClaimRequest) (*ports.CredentialValidation, error) {
+	result := &ports.CredentialValidation{}
+	if _, err := url.ParseRequestURI(req.Schema); err == nil {
+		if err := jsonschema.ValidateCredentialSubject(ctx, c.loader, req.Schema, req.Type, maps.Clone(req.CredentialSubject)); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("%w: %s", ErrInvalidCredentialSubject, err))
+			return result, nil
+		}
+	}
+
+	claim, err := c.newCredential(ctx, c.storage.Pgx, req, true)
+	if err != nil {
+		if !isCredentialRequestError(err) {
+			return nil, err
+		}
+		result.Errors = append(result.Errors, err)
+		return result, nil
+	}
+	credential, err := claim.GetVerifiableCredential()
+	if err != nil {
+		return nil, err
+	}
+	slots := claim.CoreClaim.Get().RawSlotsAsInts()
+	result.Credential = &credential
+	result.SchemaHash = claim.SchemaHash
+	for i := range result.IndexSlots {
+		result.IndexSlots[i] = slots[i].String()
+		result.ValueSlots[i] = slots[len(result.IndexSlots)+i].String()
+	}
+	return result, nil
+}
+
+// newCredential builds the credential of the request. In dry run mode the credential is not signed and its status is
+// only previewed, so nothing is stored.
+func (c *claim) newCredential(ctx context.Context, conn db.Querier, req *ports.CreateClaimRequest, dryRun bool) (*domain.Claim, error) {
 	if err := c.guardCreateClaimRequest(req); err != nil {
 		log.Error(ctx, "create claim request validation", "req", req, "err", err)
 		return nil, err
 	}
+	if err := c.identitySrv.CheckActive(ctx, conn, *req.DID); err != nil {
+		return nil, err
+	}
+	isWebDID := domain.IsWebDID(*req.DID)
+	if isWebDID && req.MTProof {
+		return nil, fmt.Errorf("%w: credentials can not have a merkle tree proof", ErrWebIdentityNotSupported)
+	}
+	// the signature is the only proof of the credentials of did:web issuers, so they are never issued unsigned
+	if isWebDID && !req.SignatureProof {
+		return nil, fmt.Errorf("%w: credentials must have a signature proof", ErrWebIdentityNotSupported)
+	}
 
 	var nonce uint64
 	var err error
@@ -172,7 +454,7 @@ func (c *claim) CreateCredential(ctx context.Context, req *ports.CreateClaimRequ
 		}
 	}
 
-	vc, err := c.createVC(ctx, req, vcID, jsonLdContext, nonce)
+	vc, err := c.createVC(ctx, conn, req, vcID, jsonLdContext, nonce, dryRun)
 	if err != nil {
 		log.Error(ctx, "creating verifiable credential", "err", err)
 		return nil, err
@@ -193,7 +475,7 @@ func (c *claim) CreateCredential(ctx context.Context, req *ports.CreateClaimRequ
 		MerklizedRootPosition: common.DefineMerklizedRootPosition(schema.Metadata, req.MerklizedRootPosition),
 		Version:               req.Version,
 		SubjectPosition:       req.SubjectPos,
-		Updatable:             false,
+		Updatable:             req.Updatable,
 	}
 	if c.ipfsClient != nil {
 		opts.MerklizerOpts = []merklize.MerklizeOption{merklize.WithDocumentLoader(c.loader)}
@@ -228,36 +510,44 @@ func (c *claim) CreateCredential(ctx context.Context, req *ports.CreateClaimRequ
 	claim.Issuer = issuerDIDString
 	claim.ID = vcID
 
-	if req.SignatureProof {
-		authClaim, err := c.GetAuthClaim(ctx, req.DID)
+	if req.SignatureProof && isWebDID {
+		if !dryRun {
+			if err := c.signWebCredential(ctx, req, coreClaim, claim); err != nil {
+				return nil, err
+			}
+		}
+	} else if req.SignatureProof {
+		authClaim, err := c.signingAuthClaim(ctx, req)
 		if err != nil {
 			log.Error(ctx, "cannot retrieve the auth claim", "err", err)
 			return nil, err
 		}
 
-		proof, err := c.identitySrv.SignClaimEntry(ctx, authClaim, coreClaim)
-		if err != nil {
-			log.Error(ctx, "cannot sign claim entry", "err", err)
-			return nil, err
-		}
+		if !dryRun {
+			proof, err := c.identitySrv.SignClaimEntry(ctx, authClaim, coreClaim)
+			if err != nil {
+				log.Error(ctx, "cannot sign claim entry", "err", err)
+				return nil, err
+			}
 
-		authCs, err := authClaim.GetCredentialStatus()
-		if err != nil {
-			log.Error(ctx, "cannot get the auth claim credential status", "err", err)
-			return nil, err
-		}
+			authCs, err := authClaim.GetCredentialStatus()
+			if err != nil {
+				log.Error(ctx, "cannot get the auth claim credential status", "err", err)
+				return nil, err
+			}
 
-		proof.IssuerData.CredentialStatus = authCs
+			proof.IssuerData.CredentialStatus = authCs
 
-		jsonSignatureProof, err := json.Marshal(proof)
-		if err != nil {
-			log.Error(ctx, "cannot encode the json signature proof", "err", err)
-			return nil, err
-		}
-		err = claim.SignatureProof.Set(jsonSignatureProof)
-		if err != nil {
-			log.Error(ctx, "cannot set the json signature proof", "err", err)
-			return nil, err
+			jsonSignatureProof, err := json.Marshal(proof)
+			if err != nil {
+				log.Error(ctx, "cannot encode the json signature proof", "err", err)
+				return nil, err
+			}
+			err = claim.SignatureProof.Set(jsonSignatureProof)
+			if err != nil {
+				log.Error(ctx, "cannot set the json signature proof", "err", err)
+				return nil, err
+			}
 		}
 	}
 
@@ -275,12 +565,31 @@ func (c *claim) CreateCredential(ctx context.Context, req *ports.CreateClaimRequ
 
 	claim.MtProof = req.MTProof
 	claim.LinkID = req.LinkID
+	claim.PreviousID = req.PreviousID
 	claim.CreatedAt = *vc.IssuanceDate
 	return claim, nil
 }
 
+// signWebCredential sets the BJJ signature proof of a credential of a did:web issuer, signed with the key of its
+// DID document.
+func (c *claim) signWebCredential(ctx context.Context, req *ports.CreateClaimRequest, coreClaim *core.Claim, claim *domain.Claim) error {
+	proof, err := c.identitySrv.SignWebClaimEntry(ctx, *req.DID, coreClaim)
+	if err != nil {
+		log.Error(ctx, "cannot sign claim entry with the did:web key", "err", err)
+		return err
+	}
+	jsonSignatureProof, err := json.Marshal(proof)
+	if err != nil {
+		log.Error(ctx, "cannot encode the json signature proof", "err", err)
+		return err
+	}
+	return claim.SignatureProof.Set(jsonSignatureProof)
+}
+
 func (c *claim) Revoke(ctx context.Context, id w3c.DID, nonce uint64, description string) error {
-	return c.revoke(ctx, &id, nonce, description, c.storage.Pgx)
+	return c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		return c.revoke(ctx, &id, nonce, description, tx)
+	})
 }
 
 func (c *claim) RevokeAllFromConnection(ctx context.Context, connID uuid.UUID, issuerID w3c.DID) error {
@@ -301,6 +610,75 @@ func (c *claim) RevokeAllFromConnection(ctx context.Context, connID uuid.UUID, i
 		})
 }
 
+// RevokeByFilter revokes all the non revoked credentials of the issuer that match the filter and returns them.
+// If dryRun is true nothing is revoked, the credentials that would be revoked are returned.
+// The schema type of the filter must match exactly, unlike in the credentials listing.
+// All the credentials are revoked in one transaction with a single update of the revocation tree, so the next state
+// transition covers all of them.
+func (c *claim) RevokeByFilter(ctx context.Context, issuerID w3c.DID, filter *ports.ClaimsFilter, description string, dryRun bool) ([]*domain.Claim, error) {
+	if isEmptyRevocationFilter(filter) {
+		return nil, ErrEmptyRevocationFilter
+	}
+	filter.Revoked = common.ToPointer(false)
+	filter.ExactSchemaType = true
+	filter.Page = nil
+	credentials, _, err := c.icRepo.GetAllByIssuerID(ctx, c.storage.Pgx, issuerID, filter)
+	if err != nil {
+		log.Error(ctx, "loading credentials to revoke", "err", err, "issuer", issuerID)
+		return nil, err
+	}
+	if dryRun || len(credentials) == 0 {
+		return credentials, nil
+	}
+
+	err = c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		// did:web identities have no revocation tree, their credentials are only revoked in the status lists
+		var identityTrees *domain.IdentityMerkleTrees
+		if !domain.IsWebDID(issuerID) {
+			identityTrees, err = c.mtService.GetIdentityMerkleTrees(ctx, tx, &issuerID)
+			if err != nil {
+				return fmt.Errorf("error getting merkle trees: %w", err)
+			}
+		}
+		revokedNonces := make(map[domain.RevNonceUint64]bool, len(credentials))
+		for _, credential := range credentials {
+			if identityTrees != nil && !revokedNonces[credential.RevNonce] {
+				if err := identityTrees.RevokeClaim(ctx, new(big.Int).SetUint64(uint64(credential.RevNonce))); err != nil {
+					return fmt.Errorf("error revoking the claim: %w", err)
+				}
+				revocation := &domain.Revocation{
+					Identifier:  issuerID.String(),
+					Nonce:       credential.RevNonce,
+					Description: description,
+				}
+				if err := c.icRepo.RevokeNonce(ctx, tx, revocation); err != nil {
+					return fmt.Errorf("error saving the revocation: %w", err)
+				}
+				revokedNonces[credential.RevNonce] = true
+			}
+			credential.Revoked = true
+			if _, err := c.icRepo.Save(ctx, tx, credential); err != nil {
+				return fmt.Errorf("error saving the claim: %w", err)
+			}
+			if err := c.revocationStatusResolver.Revoke(ctx, tx, credential); err != nil {
+				return fmt.Errorf("error updating the credential status list: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, "error revoking credentials by filter", "err", err, "issuer", issuerID)
+		return nil, err
+	}
+	return credentials, nil
+}
+
+func isEmptyRevocationFilter(filter *ports.ClaimsFilter) bool {
+	return filter == nil || (filter.SchemaType == "" && filter.SchemaHash == "" && filter.Subject == "" &&
+		filter.QueryField == "" && filter.CreatedAfter == nil && filter.CreatedBefore == nil && filter.ExpiredOn == nil &&
+		(filter.Self == nil || !*filter.Self))
+}
+
 func (c *claim) Delete(ctx context.Context, id uuid.UUID) error {
 	err := c.icRepo.Delete(ctx, c.storage.Pgx, id)
 	if err != nil {
@@ -347,16 +725,23 @@ func (c *claim) GetCredentialQrCode(ctx context.Context, issID *w3c.DID, id uuid
 		log.Error(ctx, "getCredentialQrQrCode: invalid proof", "id", id)
 		return nil, ErrEmptyMTPProof
 	}
+	issuer, err := c.identitySrv.GetByDID(ctx, *issID)
+	if err != nil {
+		log.Error(ctx, "getCredentialQrQrCode: get issuer identity", "err", err, "issuerDID", issID)
+		return nil, err
+	}
 	credID := uuid.New()
-	qrCode := protocol.CredentialsOfferMessage{
-		Body: protocol.CredentialsOfferMessageBody{
-			Credentials: []protocol.CredentialOffer{
-				{
-					Description: getCredentialType(*claim),
-					ID:          claim.ID.String(),
+	qrCode := credentialsOfferMessage{
+		Body: credentialsOfferMessageBody{
+			CredentialsOfferMessageBody: protocol.CredentialsOfferMessageBody{
+				Credentials: []protocol.CredentialOffer{
+					{
+						Description: getCredentialType(*claim),
+						ID:          claim.ID.String(),
+					},
 				},
+				URL: fmt.Sprintf(ports.AgentUrl, strings.TrimSuffix(hostURL, "/")),
 			},
-			URL: fmt.Sprintf(ports.AgentUrl, strings.TrimSuffix(hostURL, "/")),
 		},
 		From:     claim.Issuer,
 		ID:       credID.String(),
@@ -365,6 +750,9 @@ func (c *claim) GetCredentialQrCode(ctx context.Context, issID *w3c.DID, id uuid
 		Typ:      packers.MediaTypePlainMessage,
 		Type:     protocol.CredentialOfferMessageType,
 	}
+	if !issuer.Profile.IsEmpty() {
+		qrCode.Body.Issuer = &issuer.Profile
+	}
 
 	raw, err := json.Marshal(qrCode)
 	if err != nil {
@@ -413,15 +801,27 @@ func (c *claim) Agent(ctx context.Context, req *ports.AgentRequest, mediatype id
 	}
 }
 
+// GetAuthClaim returns the auth claim that signs the credentials: the primary one of the identity, or after the rotation
+// of the primary key, the previous key until the state transition is confirmed.
+// If the identity has not published any auth claim yet, it returns the first one.
 func (c *claim) GetAuthClaim(ctx context.Context, did *w3c.DID) (*domain.Claim, error) {
 	authHash, err := core.AuthSchemaHash.MarshalText()
 	if err != nil {
 		return nil, err
 	}
-	return c.icRepo.FindOneClaimBySchemaHash(ctx, c.storage.Pgx, did, string(authHash))
+	authClaim, err := c.icRepo.GetSigningAuthClaim(ctx, c.storage.Pgx, did, string(authHash))
+	if errors.Is(err, repositories.ErrClaimDoesNotExist) {
+		return c.icRepo.FindOneClaimBySchemaHash(ctx, c.storage.Pgx, did, string(authHash))
+	}
+	return authClaim, err
 }
 
 func (c *claim) GetAll(ctx context.Context, did w3c.DID, filter *ports.ClaimsFilter) ([]*domain.Claim, uint, error) {
+	if len(filter.Attributes) > 0 {
+		if err := c.typeAttributeConditions(ctx, filter.SchemaURL, filter.Attributes); err != nil {
+			return nil, 0, err
+		}
+	}
 	claims, total, err := c.icRepo.GetAllByIssuerID(ctx, c.storage.Pgx, did, filter)
 	if err != nil {
 		if errors.Is(err, repositories.ErrClaimDoesNotExist) {
@@ -433,6 +833,45 @@ func (c *claim) GetAll(ctx context.Context, did w3c.DID, filter *ports.ClaimsFil
 	return claims, total, nil
 }
 
+// typeAttributeConditions sets the type of the conditions and converts their values to it, according to the
+// attributes defined in the json schema.
+func (c *claim) typeAttributeConditions(ctx context.Context, schemaURL string, conditions []ports.AttributeCondition) error {
+	if schemaURL == "" {
+		return fmt.Errorf("%w: a schema is required to query attributes", ports.ErrInvalidAttributeQuery)
+	}
+	jsonSchema, err := jsonschema.Load(ctx, schemaURL, c.loader)
+	if err != nil {
+		log.Error(ctx, "loading schema to query attributes", "err", err, "url", schemaURL)
+		return fmt.Errorf("%w: cannot load schema: %s", ports.ErrInvalidAttributeQuery, err)
+	}
+	for i := range conditions {
+		attribute, err := jsonSchema.AttributeByID(conditions[i].Field)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ports.ErrInvalidAttributeQuery, err)
+		}
+		switch attribute.Type {
+		case "integer", "number", "string":
+		case "boolean":
+			if conditions[i].Operator == ports.AttributeQueryOperatorLt || conditions[i].Operator == ports.AttributeQueryOperatorGt {
+				return fmt.Errorf("%w: boolean attribute <%s> does not support %s", ports.ErrInvalidAttributeQuery, attribute.ID, conditions[i].Operator)
+			}
+		default:
+			return fmt.Errorf("%w: attribute <%s> of type <%s> cannot be queried", ports.ErrInvalidAttributeQuery, attribute.ID, attribute.Type)
+		}
+		conditions[i].Type = attribute.Type
+		for j, value := range conditions[i].Values {
+			text, ok := value.(string)
+			if !ok {
+				continue // already typed
+			}
+			if conditions[i].Values[j], err = attribute.ParseValue(text); err != nil {
+				return fmt.Errorf("%w: value <%s> of attribute <%s>: %s", ports.ErrInvalidAttributeQuery, text, attribute.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
 func (c *claim) GetRevocationStatus(ctx context.Context, issuerDID w3c.DID, nonce uint64) (*verifiable.RevocationStatus, error) {
 	rID := new(big.Int).SetUint64(nonce)
 	revocationStatus := &verifiable.RevocationStatus{}
@@ -477,6 +916,40 @@ func (c *claim) GetRevocationStatus(ctx context.Context, issuerDID w3c.DID, nonc
 	return revocationStatus, nil
 }
 
+// GetAuthClaims returns all the auth claims of the identity, oldest first
+func (c *claim) GetAuthClaims(ctx context.Context, did *w3c.DID) ([]*domain.Claim, error) {
+	authHash, err := core.AuthSchemaHash.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return c.icRepo.GetAuthClaims(ctx, c.storage.Pgx, did, string(authHash))
+}
+
+// signingAuthClaim returns the auth claim requested to sign the credential or the default one
+func (c *claim) signingAuthClaim(ctx context.Context, req *ports.CreateClaimRequest) (*domain.Claim, error) {
+	if req.AuthClaimID == nil {
+		return c.GetAuthClaim(ctx, req.DID)
+	}
+	authHash, err := core.AuthSchemaHash.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	authClaim, err := c.icRepo.GetByIdAndIssuer(ctx, c.storage.Pgx, req.DID, *req.AuthClaimID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrClaimDoesNotExist) {
+			return nil, fmt.Errorf("%w: auth credential <%s> not found", ErrInvalidAuthClaim, req.AuthClaimID)
+		}
+		return nil, err
+	}
+	if authClaim.SchemaHash != string(authHash) {
+		return nil, fmt.Errorf("%w: <%s> is not an auth credential", ErrInvalidAuthClaim, req.AuthClaimID)
+	}
+	if authClaim.Revoked {
+		return nil, fmt.Errorf("%w: auth credential <%s> is revoked", ErrInvalidAuthClaim, req.AuthClaimID)
+	}
+	return authClaim, nil
+}
+
 func (c *claim) GetAuthClaimForPublishing(ctx context.Context, did *w3c.DID, state string) (*domain.Claim, error) {
 	authHash, err := core.AuthSchemaHash.MarshalText()
 	if err != nil {
@@ -587,6 +1060,8 @@ func (c *claim) GetByStateIDWithMTPProof(ctx context.Context, did *w3c.DID, stat
 	return c.icRepo.GetByStateIDWithMTPProof(ctx, c.storage.Pgx, did, state)
 }
 
+// revoke revokes the credentials with the revocation nonce. All the changes are made with querier, so they are
+// committed or rolled back with the transaction of the caller.
 func (c *claim) revoke(ctx context.Context, did *w3c.DID, nonce uint64, description string, querier db.Querier) error {
 	rID := new(big.Int).SetUint64(nonce)
 	revocation := domain.Revocation{
@@ -597,18 +1072,21 @@ func (c *claim) revoke(ctx context.Context, did *w3c.DID, nonce uint64, descript
 		Description: description,
 	}
 
-	identityTrees, err := c.mtService.GetIdentityMerkleTrees(ctx, querier, did)
-	if err != nil {
-		return fmt.Errorf("error getting merkle trees: %w", err)
-	}
+	// did:web identities have no revocation tree, their credentials are only revoked in the status lists
+	isWebDID := domain.IsWebDID(*did)
+	if !isWebDID {
+		identityTrees, err := c.mtService.GetIdentityMerkleTrees(ctx, querier, did)
+		if err != nil {
+			return fmt.Errorf("error getting merkle trees: %w", err)
+		}
 
-	err = identityTrees.RevokeClaim(ctx, rID)
-	if err != nil {
-		return fmt.Errorf("error revoking the claim: %w", err)
+		err = identityTrees.RevokeClaim(ctx, rID)
+		if err != nil {
+			return fmt.Errorf("error revoking the claim: %w", err)
+		}
 	}
 
-	var claims []*domain.Claim
-	claims, err = c.icRepo.GetByRevocationNonce(ctx, querier, did, domain.RevNonceUint64(nonce))
+	claims, err := c.icRepo.GetByRevocationNonce(ctx, querier, did, domain.RevNonceUint64(nonce))
 	if err != nil {
 		if errors.Is(err, repositories.ErrClaimDoesNotExist) {
 			return err
@@ -616,24 +1094,26 @@ func (c *claim) revoke(ctx context.Context, did *w3c.DID, nonce uint64, descript
 		return fmt.Errorf("error getting the claim by revocation nonce: %w", err)
 	}
 
-	err = c.storage.Pgx.BeginFunc(ctx,
-		func(tx pgx.Tx) error {
-			for _, claim := range claims {
-				claim.Revoked = true
-				_, err = c.icRepo.Save(ctx, tx, claim)
-				if err != nil {
-					log.Error(ctx, "error saving the claim", "err", err)
-					return fmt.Errorf("error saving the claim: %w", err)
-				}
-			}
+	for _, claim := range claims {
+		claim.Revoked = true
+		_, err = c.icRepo.Save(ctx, querier, claim)
+		if err != nil {
+			log.Error(ctx, "error saving the claim", "err", err)
+			return fmt.Errorf("error saving the claim: %w", err)
+		}
+		if err = c.revocationStatusResolver.Revoke(ctx, querier, claim); err != nil {
+			log.Error(ctx, "error updating the credential status list", "err", err)
+			return fmt.Errorf("error updating the credential status list: %w", err)
+		}
+	}
 
-			return c.icRepo.RevokeNonce(ctx, tx, &revocation)
-		})
-	if err != nil {
-		log.Error(ctx, "error saving the revoked claims", "err", err)
+	if isWebDID {
+		return nil
+	}
+	if err := c.icRepo.RevokeNonce(ctx, querier, &revocation); err != nil {
+		log.Error(ctx, "error saving the revocation", "err", err)
 		return err
 	}
-
 	return nil
 }
 
@@ -707,8 +1187,8 @@ func (c *claim) getAgentCredential(ctx context.Context, basicMessage *ports.Agen
 	}, err
 }
 
-func (c *claim) createVC(ctx context.Context, claimReq *ports.CreateClaimRequest, vcID uuid.UUID, jsonLdContext string, nonce uint64) (verifiable.W3CCredential, error) {
-	vCredential, err := c.newVerifiableCredential(ctx, claimReq, vcID, jsonLdContext, nonce) // create vc credential
+func (c *claim) createVC(ctx context.Context, conn db.Querier, claimReq *ports.CreateClaimRequest, vcID uuid.UUID, jsonLdContext string, nonce uint64, dryRun bool) (verifiable.W3CCredential, error) {
+	vCredential, err := c.newVerifiableCredential(ctx, conn, claimReq, vcID, jsonLdContext, nonce, dryRun) // create vc credential
 	if err != nil {
 		return verifiable.W3CCredential{}, err
 	}
@@ -716,6 +1196,34 @@ func (c *claim) createVC(ctx context.Context, claimReq *ports.CreateClaimRequest
 	return vCredential, nil
 }
 
+// isCredentialRequestError returns true if the credential cannot be created because of the request
+func isCredentialRequestError(err error) bool {
+	errs := []error{
+		ErrLoadingSchema,
+		ErrJSONLdContext,
+		ErrProcessSchema,
+		ErrMalformedURL,
+		ErrParseClaim,
+		ErrInvalidCredentialSubject,
+		ErrUnsupportedRefreshServiceType,
+		ErrRefreshServiceLacksExpirationTime,
+		ErrRefreshServiceLacksURL,
+		ErrDisplayMethodLacksURL,
+		ErrUnsupportedDisplayMethodType,
+		ErrWrongCredentialSubjectID,
+		ErrInvalidAuthClaim,
+		ErrIdentityDeactivated,
+		ErrWebIdentityNotSupported,
+		revocationstatus.ErrWebDIDCredentialStatus,
+	}
+	for _, e := range errs {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *claim) guardCreateClaimRequest(req *ports.CreateClaimRequest) error {
 	type guardFunc func() error
 
@@ -803,7 +1311,7 @@ func (c *claim) guardCreateClaimRequest(req *ports.CreateClaimRequest) error {
 	return nil
 }
 
-func (c *claim) newVerifiableCredential(ctx context.Context, claimReq *ports.CreateClaimRequest, vcID uuid.UUID, jsonLdContext string, nonce uint64) (verifiable.W3CCredential, error) {
+func (c *claim) newVerifiableCredential(ctx context.Context, conn db.Querier, claimReq *ports.CreateClaimRequest, vcID uuid.UUID, jsonLdContext string, nonce uint64, dryRun bool) (verifiable.W3CCredential, error) {
 	credentialCtx := []string{verifiable.JSONLDSchemaW3CCredential2018, verifiable.JSONLDSchemaIden3Credential, jsonLdContext}
 	credentialType := []string{verifiable.TypeW3CVerifiableCredential, claimReq.Type}
 
@@ -819,12 +1327,21 @@ func (c *claim) newVerifiableCredential(ctx context.Context, claimReq *ports.Cre
 
 	credentialSubject["type"] = claimReq.Type
 
-	latestIssuerState, err := c.identitySrv.GetLatestStateByID(ctx, *claimReq.DID)
-	if err != nil {
-		log.Error(ctx, "getting latest issuer state", "err", err)
-		return verifiable.W3CCredential{}, err
+	// did:web issuers have no state
+	var issuerState string
+	if !domain.IsWebDID(*claimReq.DID) {
+		latestIssuerState, err := c.identitySrv.GetLatestStateByID(ctx, *claimReq.DID)
+		if err != nil {
+			log.Error(ctx, "getting latest issuer state", "err", err)
+			return verifiable.W3CCredential{}, err
+		}
+		issuerState = *latestIssuerState.State
+	}
+	resolveStatus := c.revocationStatusResolver.GetCredentialRevocationStatus
+	if dryRun {
+		resolveStatus = c.revocationStatusResolver.PreviewCredentialRevocationStatus
 	}
-	cs, err := c.revocationStatusResolver.GetCredentialRevocationStatus(ctx, *claimReq.DID, nonce, *latestIssuerState.State, claimReq.CredentialStatusType)
+	cs, err := resolveStatus(ctx, conn, *claimReq.DID, nonce, issuerState, claimReq.CredentialStatusType)
 	if err != nil {
 		log.Error(ctx, "getting credential status", "err", err)
 		return verifiable.W3CCredential{}, err
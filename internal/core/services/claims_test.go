@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/network"
+	"github.com/polygonid/sh-id-platform/internal/pubsub"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/internal/reversehash"
+	"github.com/polygonid/sh-id-platform/internal/revocationstatus"
+)
+
+var errSaveFailed = errors.New("save failed")
+
+// failingSaveClaimRepository fails to save the credentials that are not revoked
+type failingSaveClaimRepository struct {
+	ports.ClaimRepository
+}
+
+func (r *failingSaveClaimRepository) Save(ctx context.Context, conn db.Querier, claim *domain.Claim) (uuid.UUID, error) {
+	if !claim.Revoked {
+		return uuid.Nil, errSaveFailed
+	}
+	return r.ClaimRepository.Save(ctx, conn, claim)
+}
+
+func Test_claim_Update(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaim()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := NewIdentityMerkleTrees(mtRepo)
+	connectionsRepository := repositories.NewConnection()
+
+	networkResolver, err := network.NewResolver(ctx, cfg, keyStore, common.CreateFile(t))
+	require.NoError(t, err)
+
+	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
+	identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactory, revocationStatusResolver)
+	claimsService := NewClaim(claimsRepo, identityService, nil, mtService, identityStateRepo, docLoader, storage, cfg.ServerUrl, pubsub.NewMock(), ipfsGateway, revocationStatusResolver, nil, cfg.UniversalLinks)
+	failingClaimsService := NewClaim(&failingSaveClaimRepository{claimsRepo}, identityService, nil, mtService, identityStateRepo, docLoader, storage, cfg.ServerUrl, pubsub.NewMock(), ipfsGateway, revocationStatusResolver, nil, cfg.UniversalLinks)
+
+	identity, err := identityService.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
+	require.NoError(t, err)
+	did, err := w3c.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	createReq := ports.NewCreateClaimRequest(did, nil, "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+		map[string]any{
+			"id":           "did:polygonid:polygon:amoy:2qSuD8ZDpsAG3s8WJjwzqhMsqGLz8RUG1BHVUe3Gwu",
+			"birthday":     19960424,
+			"documentType": 2,
+		},
+		common.ToPointer(time.Now().Add(time.Hour)), "KYCAgeCredential", nil, nil, nil,
+		ports.ClaimRequestProofs{BJJSignatureProof2021: true}, nil, false, verifiable.Iden3commRevocationStatusV1, nil, nil, nil)
+	createReq.Updatable = true
+	previous, err := claimsService.Save(ctx, createReq)
+	require.NoError(t, err)
+
+	updateReq := &ports.UpdateClaimRequest{DID: did, ClaimID: previous.ID, CredentialSubject: map[string]any{"documentType": 3}}
+
+	t.Run("should not revoke the previous credential if the replacement is not saved", func(t *testing.T) {
+		_, err := failingClaimsService.Update(ctx, updateReq)
+		require.ErrorIs(t, err, errSaveFailed)
+
+		credential, err := claimsRepo.GetByIdAndIssuer(ctx, storage.Pgx, did, previous.ID)
+		require.NoError(t, err)
+		assert.False(t, credential.Revoked)
+	})
+
+	t.Run("should revoke the previous credential", func(t *testing.T) {
+		updated, err := claimsService.Update(ctx, updateReq)
+		require.NoError(t, err)
+		assert.Equal(t, previous.Version+1, updated.Version)
+
+		credential, err := claimsRepo.GetByIdAndIssuer(ctx, storage.Pgx, did, previous.ID)
+		require.NoError(t, err)
+		assert.True(t, credential.Revoked)
+	})
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	schemaPkg "github.com/polygonid/sh-id-platform/internal/schema"
+)
+
+const (
+	jwtAlgES256K            = "ES256K"
+	jwtTypJWT               = "JWT"
+	jwtTypSDJWTVC           = "vc+sd-jwt"
+	sdJWTHashAlg            = "sha-256"
+	sdJWTSeparator          = "~"
+	sdJWTSaltSize           = 16
+	ethSignatureSize        = 64 // R || S, the recovery byte returned by the kms is not part of a JWS signature
+	ethPubKeySize           = 65 // uncompressed public key
+	ethCompressedKeySize    = 33
+	secp256k1CoordinateSize = 32
+)
+
+var (
+	ErrIssuerETHKeyNotFound        = errors.New("the issuer does not have an ethereum key to sign the credential") // ErrIssuerETHKeyNotFound means that the issuer identity was not created with an ETH key
+	ErrUnsupportedExportFormat     = errors.New("unsupported credential format")                                   // ErrUnsupportedExportFormat means that the requested format is not jwt-vc or sd-jwt-vc
+	errUnexpectedSignatureLength   = errors.New("unexpected signature length")                                     // errUnexpectedSignatureLength means that the kms returned a signature that is not a secp256k1 one
+	errUnexpectedPublicKeyFormat   = errors.New("unexpected public key format")                                    // errUnexpectedPublicKeyFormat means that the kms returned a public key that cannot be decoded
+	sdJWTNonSelectivelyDisclosable = []string{"id", "type"}
+)
+
+type credentialExport struct {
+	kms kms.KMSType
+}
+
+// NewCredentialExport is the credential export service constructor
+func NewCredentialExport(keyStore kms.KMSType) ports.CredentialExportService {
+	return &credentialExport{
+		kms: keyStore,
+	}
+}
+
+// Export encodes the credential as a JWT-VC or a SD-JWT VC signed with the issuer's ETH key (ES256K).
+// The public key is sent in the `jwk` header so standard libraries can verify the signature without resolving the DID.
+// Only issuers created with an ETH key can export credentials.
+func (ce *credentialExport) Export(ctx context.Context, claim *domain.Claim, format ports.CredentialExportFormat) (string, error) {
+	issuerDID, err := w3c.ParseDID(claim.Issuer)
+	if err != nil {
+		return "", err
+	}
+	credential, err := schemaPkg.FromClaimModelToW3CCredential(*claim)
+	if err != nil {
+		log.Error(ctx, "converting claim to w3c credential", "err", err, "id", claim.ID)
+		return "", err
+	}
+	return ce.ExportW3CCredential(ctx, *issuerDID, credential, format)
+}
+
+// ExportW3CCredential encodes a credential of the issuer that is not stored as a claim, e.g. a status list
+// credential, as Export does.
+func (ce *credentialExport) ExportW3CCredential(ctx context.Context, issuerDID w3c.DID, credential *verifiable.W3CCredential, format ports.CredentialExportFormat) (string, error) {
+	keyID, err := ce.ethKeyID(ctx, issuerDID)
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case ports.CredentialExportFormatJWTVC:
+		return ce.sign(ctx, keyID, jwtTypJWT, jwtVCPayload(credential))
+	case ports.CredentialExportFormatSDJWTVC:
+		payload, disclosures, err := sdJWTVCPayload(credential)
+		if err != nil {
+			return "", err
+		}
+		token, err := ce.sign(ctx, keyID, jwtTypSDJWTVC, payload)
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(append([]string{token}, disclosures...), sdJWTSeparator) + sdJWTSeparator, nil
+	}
+	return "", ErrUnsupportedExportFormat
+}
+
+func (ce *credentialExport) ethKeyID(ctx context.Context, issuerDID w3c.DID) (kms.KeyID, error) {
+	keyIDs, err := ce.kms.KeysByIdentity(ctx, issuerDID)
+	if err != nil {
+		log.Error(ctx, "loading issuer keys", "err", err, "did", issuerDID)
+		return kms.KeyID{}, err
+	}
+	for _, keyID := range keyIDs {
+		if keyID.Type == kms.KeyTypeEthereum {
+			return keyID, nil
+		}
+	}
+	return kms.KeyID{}, ErrIssuerETHKeyNotFound
+}
+
+// sign returns a compact JWS of the payload signed with ES256K
+func (ce *credentialExport) sign(ctx context.Context, keyID kms.KeyID, typ string, payload map[string]any) (string, error) {
+	pubKey, err := ce.publicKey(ctx, keyID)
+	if err != nil {
+		return "", err
+	}
+	header, err := json.Marshal(map[string]any{
+		"alg": jwtAlgES256K,
+		"typ": typ,
+		"jwk": jwk(pubKey),
+	})
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := ce.kms.Sign(ctx, keyID, digest[:])
+	if err != nil {
+		log.Error(ctx, "signing credential", "err", err)
+		return "", err
+	}
+	if len(signature) < ethSignatureSize {
+		return "", errUnexpectedSignatureLength
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature[:ethSignatureSize]), nil
+}
+
+// IsSignedWithCurrentKey tells whether a token encoded by ExportW3CCredential was signed with the ETH key the issuer
+// signs with now, comparing it with the key of the `jwk` header.
+func (ce *credentialExport) IsSignedWithCurrentKey(ctx context.Context, issuerDID w3c.DID, token string) (bool, error) {
+	keyID, err := ce.ethKeyID(ctx, issuerDID)
+	if err != nil {
+		return false, err
+	}
+	pubKey, err := ce.publicKey(ctx, keyID)
+	if err != nil {
+		return false, err
+	}
+	encodedHeader, _, _ := strings.Cut(token, ".")
+	rawHeader, err := base64.RawURLEncoding.DecodeString(encodedHeader)
+	if err != nil {
+		return false, nil
+	}
+	var header struct {
+		JWK map[string]string `json:"jwk"`
+	}
+	if err := json.Unmarshal(rawHeader, &header); err != nil {
+		return false, nil
+	}
+	return maps.Equal(header.JWK, jwk(pubKey)), nil
+}
+
+// jwk returns the JSON Web Key of the ETH public key
+func jwk(pubKey *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"crv": "secp256k1",
+		"x":   base64.RawURLEncoding.EncodeToString(pubKey.X.FillBytes(make([]byte, secp256k1CoordinateSize))),
+		"y":   base64.RawURLEncoding.EncodeToString(pubKey.Y.FillBytes(make([]byte, secp256k1CoordinateSize))),
+	}
+}
+
+// publicKey decodes the ETH public key, that depending on the key provider is compressed, uncompressed or DER encoded
+func (ce *credentialExport) publicKey(ctx context.Context, keyID kms.KeyID) (*ecdsa.PublicKey, error) {
+	raw, err := ce.kms.PublicKey(keyID)
+	if err != nil {
+		log.Error(ctx, "loading issuer public key", "err", err)
+		return nil, err
+	}
+	var pubKey *ecdsa.PublicKey
+	switch len(raw) {
+	case ethCompressedKeySize:
+		pubKey, err = crypto.DecompressPubkey(raw)
+	case ethPubKeySize:
+		pubKey, err = crypto.UnmarshalPubkey(raw)
+	default:
+		pubKey, err = kms.DecodeAWSETHPubKey(ctx, raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errUnexpectedPublicKeyFormat, err)
+	}
+	return pubKey, nil
+}
+
+// jwtVCPayload maps the credential to the JWT claims as defined in the VC data model (JWT encoding).
+// The iden3 proofs are left out, the JWS is the proof.
+func jwtVCPayload(credential *verifiable.W3CCredential) map[string]any {
+	vc := *credential
+	vc.Proof = nil
+	payload := registeredClaims(credential)
+	payload["vc"] = vc
+	return payload
+}
+
+// sdJWTVCPayload builds a SD-JWT VC where each attribute of the credential subject is selectively disclosable.
+// It returns the payload and the disclosures.
+func sdJWTVCPayload(credential *verifiable.W3CCredential) (map[string]any, []string, error) {
+	payload := registeredClaims(credential)
+	payload["vct"] = credential.CredentialSchema.ID
+	if credential.CredentialStatus != nil {
+		payload["credentialStatus"] = credential.CredentialStatus
+	}
+
+	disclosures := make([]string, 0, len(credential.CredentialSubject))
+	digests := make([]string, 0, len(credential.CredentialSubject))
+	for name, value := range credential.CredentialSubject {
+		if slices.Contains(sdJWTNonSelectivelyDisclosable, name) {
+			continue
+		}
+		disclosure, err := sdJWTDisclosure(name, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		digest := sha256.Sum256([]byte(disclosure))
+		disclosures = append(disclosures, disclosure)
+		digests = append(digests, base64.RawURLEncoding.EncodeToString(digest[:]))
+	}
+	// digests are sorted so their order does not reveal the order of the attributes
+	slices.Sort(digests)
+	payload["_sd"] = digests
+	payload["_sd_alg"] = sdJWTHashAlg
+	return payload, disclosures, nil
+}
+
+func sdJWTDisclosure(name string, value any) (string, error) {
+	salt := make([]byte, sdJWTSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	disclosure, err := json.Marshal([]any{base64.RawURLEncoding.EncodeToString(salt), name, value})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(disclosure), nil
+}
+
+func registeredClaims(credential *verifiable.W3CCredential) map[string]any {
+	claims := map[string]any{
+		"iss": credential.Issuer,
+		"jti": credential.ID,
+	}
+	if subject, ok := credential.CredentialSubject["id"].(string); ok {
+		claims["sub"] = subject
+	}
+	if credential.IssuanceDate != nil {
+		claims["iat"] = credential.IssuanceDate.Unix()
+		claims["nbf"] = credential.IssuanceDate.Unix()
+	}
+	if credential.Expiration != nil {
+		claims["exp"] = credential.Expiration.Unix()
+	}
+	return claims
+}
@@ -0,0 +1,436 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/jsonschema"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+const (
+	// credentialImportBatchSize is the number of rows stored or loaded from the database at once
+	credentialImportBatchSize = 100
+	// credentialImportLease is the time without saving progress after which a running job is considered abandoned
+	credentialImportLease = 2 * time.Minute
+)
+
+var (
+	ErrCredentialImportNotFound = errors.New("credential import not found")                      // ErrCredentialImportNotFound Cannot retrieve the given credential import
+	ErrEmptyImportFile          = errors.New("the file does not contain any credential subject") // ErrEmptyImportFile means that the imported file has no rows
+	ErrInvalidImportFile        = errors.New("invalid import file")                              // ErrInvalidImportFile means that the imported file cannot be parsed
+	ErrUnsupportedImportFormat  = errors.New("unsupported import format")                        // ErrUnsupportedImportFormat means that the import format is not csv or ndjson
+	errImportSubjectIDMissing   = errors.New("missing credential subject id")                    // errImportSubjectIDMissing means that a row has no subject DID
+)
+
+type credentialImport struct {
+	repo          ports.CredentialImportRepository
+	schemaRepo    ports.SchemaRepository
+	claimsService ports.ClaimService
+	loader        loader.DocumentLoader
+	storage       *db.Storage
+}
+
+// NewCredentialImport is the credential import service constructor
+func NewCredentialImport(repo ports.CredentialImportRepository, schemaRepo ports.SchemaRepository, claimsService ports.ClaimService, ld loader.DocumentLoader, storage *db.Storage) ports.CredentialImportService {
+	return &credentialImport{
+		repo:          repo,
+		schemaRepo:    schemaRepo,
+		claimsService: claimsService,
+		loader:        ld,
+		storage:       storage,
+	}
+}
+
+// Import parses the file in the request and starts a background job that issues a credential of the given schema
+// for each row. Rows are mapped to the schema attributes by column name (csv) or by key (ndjson). The `id` column
+// holds the subject DID. The returned job can be polled to follow the progress and the rejected rows.
+// The rows are stored with the job, so the job does not keep the file in memory and can be resumed.
+func (ci *credentialImport) Import(ctx context.Context, req *ports.CredentialImportRequest) (*domain.CredentialImport, error) {
+	schema, attributes, err := ci.loadSchema(ctx, *req.DID, req.SchemaID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the file is read twice, first to validate it and count the rows, and then to store them
+	rows, err := newCredentialImportReader(req.Format, bytes.NewReader(req.Content), attributes)
+	if err != nil {
+		log.Warn(ctx, "parsing credential import file", "err", err, "format", req.Format)
+		return nil, err
+	}
+	total := 0
+	for {
+		if _, err = rows.Next(); err != nil {
+			break
+		}
+		total++
+	}
+	if !errors.Is(err, io.EOF) {
+		log.Warn(ctx, "parsing credential import file", "err", err, "format", req.Format)
+		return nil, err
+	}
+	if total == 0 {
+		return nil, ErrEmptyImportFile
+	}
+
+	job := domain.NewCredentialImport(*req.DID, schema.ID, req.Format, total)
+	job.Expiration = req.Expiration
+	job.SignatureProof = req.Proofs.BJJSignatureProof2021
+	job.MTPProof = req.Proofs.Iden3SparseMerkleTreeProof
+	job.CredentialStatusType = req.CredentialStatusType
+	err = ci.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := ci.repo.Save(ctx, tx, job); err != nil {
+			return err
+		}
+		rows, err := newCredentialImportReader(req.Format, bytes.NewReader(req.Content), attributes)
+		if err != nil {
+			return err
+		}
+		batch := make([]domain.CredentialImportRow, 0, credentialImportBatchSize)
+		for {
+			row, err := rows.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if batch = append(batch, *row); len(batch) == credentialImportBatchSize {
+				if err := ci.repo.SaveRows(ctx, tx, job.ID, batch); err != nil {
+					return err
+				}
+				batch = batch[:0]
+			}
+		}
+		return ci.repo.SaveRows(ctx, tx, job.ID, batch)
+	})
+	if err != nil {
+		log.Error(ctx, "saving credential import", "err", err)
+		return nil, err
+	}
+
+	go ci.process(context.WithoutCancel(ctx), job, schema)
+
+	return job, nil
+}
+
+// GetByID returns a credential import job
+func (ci *credentialImport) GetByID(ctx context.Context, issuerDID w3c.DID, id uuid.UUID) (*domain.CredentialImport, error) {
+	job, err := ci.repo.GetByID(ctx, ci.storage.Pgx, issuerDID, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCredentialImportDoesNotExist) {
+			return nil, ErrCredentialImportNotFound
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// Resume continues the credential imports left unfinished by a process that stopped, from the first row that was
+// not processed. A job is taken over once its progress has not been saved for credentialImportLease, so the jobs
+// still running in other processes are not resumed. The jobs that can't be resumed are marked as failed.
+func (ci *credentialImport) Resume(ctx context.Context) error {
+	jobs, err := ci.repo.GetUnfinished(ctx, ci.storage.Pgx)
+	if err != nil {
+		log.Error(ctx, "loading unfinished credential imports", "err", err)
+		return err
+	}
+	for _, job := range jobs {
+		go ci.resume(context.WithoutCancel(ctx), job)
+	}
+	return nil
+}
+
+func (ci *credentialImport) resume(ctx context.Context, job *domain.CredentialImport) {
+	time.Sleep(time.Until(job.ModifiedAt.Add(credentialImportLease)))
+	claimed, err := ci.repo.Claim(ctx, ci.storage.Pgx, job)
+	if err != nil {
+		log.Error(ctx, "claiming credential import", "err", err, "import", job.ID)
+		return
+	}
+	if !claimed {
+		log.Info(ctx, "credential import is being processed by another process", "import", job.ID)
+		return
+	}
+
+	log.Info(ctx, "resuming credential import", "import", job.ID, "processed", job.Processed, "total", job.Total)
+	schema, _, err := ci.loadSchema(ctx, job.IssuerDID, job.SchemaID)
+	if err != nil {
+		ci.fail(ctx, job, err)
+		return
+	}
+	ci.process(ctx, job, schema)
+}
+
+// process issues a credential for each row not processed yet. The progress is saved after every row, so a resumed
+// job issues again at most the row that was being processed when the process running it stopped.
+// The job is marked as failed if it can't go on.
+func (ci *credentialImport) process(ctx context.Context, job *domain.CredentialImport, schema *domain.Schema) {
+	defer func() {
+		if r := recover(); r != nil {
+			ci.fail(ctx, job, fmt.Errorf("unexpected error: %v", r))
+		}
+	}()
+
+	if err := ci.processRows(ctx, job, schema); err != nil {
+		ci.fail(ctx, job, err)
+		return
+	}
+	job.Status = domain.CredentialImportFinished
+	if err := ci.repo.UpdateProgress(ctx, ci.storage.Pgx, job); err != nil {
+		log.Error(ctx, "saving credential import progress", "err", err, "import", job.ID)
+		return
+	}
+	log.Info(ctx, "credential import finished", "import", job.ID, "issued", job.Issued, "rejected", len(job.Rejected))
+}
+
+func (ci *credentialImport) processRows(ctx context.Context, job *domain.CredentialImport, schema *domain.Schema) error {
+	job.Status = domain.CredentialImportRunning
+	if err := ci.repo.UpdateProgress(ctx, ci.storage.Pgx, job); err != nil {
+		return fmt.Errorf("saving credential import progress: %w", err)
+	}
+
+	for job.Processed < job.Total {
+		rows, err := ci.repo.GetRows(ctx, ci.storage.Pgx, job.ID, job.Processed, credentialImportBatchSize)
+		if err != nil {
+			return fmt.Errorf("loading credential import rows: %w", err)
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("missing credential import rows after row %d", job.Processed)
+		}
+		for _, row := range rows {
+			subjectID, _ := row.Subject["id"].(string)
+			if err := ci.issue(ctx, job, schema, row); err != nil {
+				log.Warn(ctx, "credential import: row rejected", "err", err, "import", job.ID, "row", row.Number)
+				job.Reject(row.Number, subjectID, err)
+			} else {
+				job.Issued++
+			}
+			job.Processed = row.Number
+			if err := ci.repo.UpdateProgress(ctx, ci.storage.Pgx, job); err != nil {
+				return fmt.Errorf("saving credential import progress: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (ci *credentialImport) issue(ctx context.Context, job *domain.CredentialImport, schema *domain.Schema, row domain.CredentialImportRow) error {
+	if row.Error != "" {
+		return errors.New(row.Error)
+	}
+
+	// ValidateCredentialSubject overwrites some fields of the subject, so it works on a copy
+	if err := jsonschema.ValidateCredentialSubject(ctx, ci.loader, schema.URL, schema.Type, maps.Clone(row.Subject)); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCredentialSubject, err)
+	}
+
+	proofs := ports.ClaimRequestProofs{BJJSignatureProof2021: job.SignatureProof, Iden3SparseMerkleTreeProof: job.MTPProof}
+	claimReq := ports.NewCreateClaimRequest(&job.IssuerDID, nil, schema.URL, row.Subject, job.Expiration, schema.Type, nil, nil, nil, proofs, nil, false, job.CredentialStatusType, nil, nil, nil)
+	_, err := ci.claimsService.Save(ctx, claimReq)
+	return err
+}
+
+// fail marks the job as failed because of err
+func (ci *credentialImport) fail(ctx context.Context, job *domain.CredentialImport, err error) {
+	log.Error(ctx, "credential import failed", "err", err, "import", job.ID, "processed", job.Processed)
+	job.Fail(err)
+	if err := ci.repo.UpdateProgress(ctx, ci.storage.Pgx, job); err != nil {
+		log.Error(ctx, "saving credential import failure", "err", err, "import", job.ID)
+	}
+}
+
+// loadSchema returns the schema of the issuer with the given id and its attributes
+func (ci *credentialImport) loadSchema(ctx context.Context, issuerDID w3c.DID, schemaID uuid.UUID) (*domain.Schema, jsonschema.Attributes, error) {
+	schema, err := ci.schemaRepo.GetByID(ctx, issuerDID, schemaID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrSchemaDoesNotExist) {
+			return nil, nil, ErrSchemaNotFound
+		}
+		return nil, nil, err
+	}
+
+	remoteSchema, err := jsonschema.Load(ctx, schema.URL, ci.loader)
+	if err != nil {
+		log.Error(ctx, "loading jsonschema", "err", err, "jsonschema", schema.URL)
+		return nil, nil, ErrLoadingSchema
+	}
+	attributes, err := remoteSchema.Attributes()
+	if err != nil {
+		log.Error(ctx, "processing jsonschema", "err", err, "jsonschema", schema.URL)
+		return nil, nil, ErrProcessSchema
+	}
+	return schema, attributes, nil
+}
+
+// credentialImportReader returns the rows of an imported file one by one, or io.EOF after the last one.
+// The rows that can't be parsed are returned with an error, so they are rejected when processed.
+type credentialImportReader interface {
+	Next() (*domain.CredentialImportRow, error)
+}
+
+func newCredentialImportReader(format domain.CredentialImportFormat, r io.Reader, attributes jsonschema.Attributes) (credentialImportReader, error) {
+	switch format {
+	case domain.CredentialImportFormatCSV:
+		return newCSVImportReader(r, attributes)
+	case domain.CredentialImportFormatNDJSON:
+		return &ndjsonImportReader{scanner: bufio.NewScanner(r), attributes: attributes}, nil
+	}
+	return nil, ErrUnsupportedImportFormat
+}
+
+// csvImportReader reads a csv file with a header row. The header must contain the `id` column and every other
+// column must be an attribute of the schema. Empty cells are omitted from the credential subject.
+type csvImportReader struct {
+	reader  *csv.Reader
+	columns []*jsonschema.Attribute
+	number  int
+}
+
+func newCSVImportReader(r io.Reader, attributes jsonschema.Attributes) (*csvImportReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrEmptyImportFile
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImportFile, err)
+	}
+
+	columns := make([]*jsonschema.Attribute, len(header))
+	hasID := false
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if name == "id" {
+			hasID = true
+			continue
+		}
+		attr, err := importAttribute(attributes, name)
+		if err != nil {
+			return nil, err
+		}
+		if attr.Type == "object" {
+			return nil, fmt.Errorf("%w: nested attribute <%s> cannot be imported from csv", ErrInvalidImportFile, name)
+		}
+		columns[i] = attr
+	}
+	if !hasID {
+		return nil, fmt.Errorf("%w: missing id column", ErrInvalidImportFile)
+	}
+	return &csvImportReader{reader: reader, columns: columns}, nil
+}
+
+// Next returns the next record of the file
+func (r *csvImportReader) Next() (*domain.CredentialImportRow, error) {
+	record, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	r.number++
+	row := &domain.CredentialImportRow{Number: r.number, Subject: make(map[string]any, len(record))}
+	if err != nil {
+		row.Error = fmt.Errorf("%w: %s", ErrInvalidImportFile, err).Error()
+		return row, nil
+	}
+	for i, cell := range record {
+		cell = strings.TrimSpace(cell)
+		if cell == "" {
+			continue
+		}
+		if r.columns[i] == nil {
+			row.Subject["id"] = cell
+			continue
+		}
+		value, err := r.columns[i].ParseValue(cell)
+		if err != nil {
+			row.Error = fmt.Errorf("column <%s>: %w", r.columns[i].ID, err).Error()
+			return row, nil
+		}
+		row.Subject[r.columns[i].ID] = value
+	}
+	if err := guardImportSubjectID(row.Subject); err != nil {
+		row.Error = err.Error()
+	}
+	return row, nil
+}
+
+// ndjsonImportReader reads a file with a json object per line. Every key but `id` must be an attribute of the schema.
+// Empty lines are skipped.
+type ndjsonImportReader struct {
+	scanner    *bufio.Scanner
+	attributes jsonschema.Attributes
+	number     int
+}
+
+// Next returns the next json object of the file
+func (r *ndjsonImportReader) Next() (*domain.CredentialImportRow, error) {
+	for r.scanner.Scan() {
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		r.number++
+		row := &domain.CredentialImportRow{Number: r.number}
+		if err := json.Unmarshal(line, &row.Subject); err != nil {
+			row.Subject = nil
+			row.Error = fmt.Errorf("%w: %s", ErrInvalidImportFile, err).Error()
+			return row, nil
+		}
+		for key := range row.Subject {
+			if key == "id" {
+				continue
+			}
+			if _, err := importAttribute(r.attributes, key); err != nil {
+				row.Error = err.Error()
+				return row, nil
+			}
+		}
+		if err := guardImportSubjectID(row.Subject); err != nil {
+			row.Error = err.Error()
+		}
+		return row, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImportFile, err)
+	}
+	return nil, io.EOF
+}
+
+func importAttribute(attributes jsonschema.Attributes, name string) (*jsonschema.Attribute, error) {
+	for i := range attributes {
+		if attributes[i].ID == name {
+			return &attributes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: <%s> is not an attribute of the schema", ErrInvalidImportFile, name)
+}
+
+func guardImportSubjectID(subject map[string]any) error {
+	id, ok := subject["id"].(string)
+	if !ok || id == "" {
+		return errImportSubjectIDMissing
+	}
+	if _, err := w3c.ParseDID(id); err != nil {
+		return ErrWrongCredentialSubjectID
+	}
+	return nil
+}
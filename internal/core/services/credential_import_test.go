@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/jsonschema"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestCredentialImportReader(t *testing.T) {
+	const subject = "did:iden3:privado:main:2Scn2RfosbkQDMQzQM5nCz3Nk5GnbzZCWzGCd3tc2G"
+	attributes := jsonschema.Attributes{{ID: "age", Type: "integer"}, {ID: "address", Type: "object"}}
+
+	readAll := func(t *testing.T, reader credentialImportReader) []domain.CredentialImportRow {
+		t.Helper()
+		var rows []domain.CredentialImportRow
+		for {
+			row, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				return rows
+			}
+			require.NoError(t, err)
+			rows = append(rows, *row)
+		}
+	}
+
+	t.Run("should read the csv rows one by one", func(t *testing.T) {
+		reader, err := newCredentialImportReader(domain.CredentialImportFormatCSV, strings.NewReader("id,age\n"+subject+",20\n,21\n"+subject+",old\n"), attributes)
+		require.NoError(t, err)
+		rows := readAll(t, reader)
+		require.Len(t, rows, 3)
+		assert.Equal(t, domain.CredentialImportRow{Number: 1, Subject: map[string]any{"id": subject, "age": int64(20)}}, rows[0])
+		assert.Equal(t, 2, rows[1].Number)
+		assert.Equal(t, errImportSubjectIDMissing.Error(), rows[1].Error)
+		assert.Equal(t, 3, rows[2].Number)
+		assert.Contains(t, rows[2].Error, "column <age>")
+	})
+
+	t.Run("should reject the csv header without id", func(t *testing.T) {
+		_, err := newCredentialImportReader(domain.CredentialImportFormatCSV, strings.NewReader("age\n20\n"), attributes)
+		assert.ErrorIs(t, err, ErrInvalidImportFile)
+	})
+
+	t.Run("should reject nested attributes in csv", func(t *testing.T) {
+		_, err := newCredentialImportReader(domain.CredentialImportFormatCSV, strings.NewReader("id,address\n"), attributes)
+		assert.ErrorIs(t, err, ErrInvalidImportFile)
+	})
+
+	t.Run("should read the ndjson objects one by one", func(t *testing.T) {
+		file := `{"id": "` + subject + `", "address": {"city": "Barcelona"}}` + "\n\n" + `{"id": "` + subject + `", "unknown": 1}` + "\nwrong\n"
+		reader, err := newCredentialImportReader(domain.CredentialImportFormatNDJSON, strings.NewReader(file), attributes)
+		require.NoError(t, err)
+		rows := readAll(t, reader)
+		require.Len(t, rows, 3)
+		assert.Equal(t, 1, rows[0].Number)
+		assert.Empty(t, rows[0].Error)
+		assert.Equal(t, map[string]any{"city": "Barcelona"}, rows[0].Subject["address"])
+		assert.Equal(t, 2, rows[1].Number)
+		assert.Contains(t, rows[1].Error, "<unknown> is not an attribute of the schema")
+		assert.Equal(t, 3, rows[2].Number)
+		assert.Nil(t, rows[2].Subject)
+		assert.Contains(t, rows[2].Error, ErrInvalidImportFile.Error())
+	})
+
+	t.Run("should reject unsupported formats", func(t *testing.T) {
+		_, err := newCredentialImportReader("xlsx", strings.NewReader(""), attributes)
+		assert.ErrorIs(t, err, ErrUnsupportedImportFormat)
+	})
+}
+
+func TestCredentialImport_Resume(t *testing.T) {
+	ctx := context.Background()
+	const idStr = "did:polygonid:polygon:amoy:2qQ68JkRcf3xrHPQPWZei3YeVzHPP7Pv6tebCCEYqo"
+	issuerDID, err := w3c.ParseDID(idStr)
+	require.NoError(t, err)
+	fixture := repositories.NewFixture(storage)
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+	// the schema can't be loaded, so the job can't go on
+	schema := &domain.Schema{
+		ID:        uuid.New(),
+		IssuerDID: *issuerDID,
+		URL:       "http://localhost:1/schema.json",
+		Type:      "schemaType",
+		CreatedAt: time.Now(),
+	}
+	schema.Hash = common.CreateSchemaHash([]byte(schema.URL + "#" + schema.Type))
+	fixture.CreateSchema(t, ctx, schema)
+
+	repo := repositories.NewCredentialImport()
+	job := domain.NewCredentialImport(*issuerDID, schema.ID, domain.CredentialImportFormatCSV, 1)
+	job.Status = domain.CredentialImportRunning
+	require.NoError(t, repo.Save(ctx, storage.Pgx, job))
+	require.NoError(t, repo.SaveRows(ctx, storage.Pgx, job.ID, []domain.CredentialImportRow{{Number: 1, Error: "missing credential subject id"}}))
+	// the job has been abandoned by the process that was running it
+	_, err = storage.Pgx.Exec(ctx, `UPDATE credential_imports SET modified_at = $2 WHERE id = $1`, job.ID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	importService := NewCredentialImport(repo, repositories.NewSchema(*storage), nil, docLoader, storage)
+	require.NoError(t, importService.Resume(ctx))
+
+	require.Eventually(t, func() bool {
+		stored, err := importService.GetByID(ctx, *issuerDID, job.ID)
+		require.NoError(t, err)
+		return stored.Status == domain.CredentialImportFailed
+	}, 10*time.Second, 100*time.Millisecond)
+	stored, err := importService.GetByID(ctx, *issuerDID, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ErrLoadingSchema.Error(), stored.Error)
+	assert.Equal(t, 0, stored.Processed)
+}
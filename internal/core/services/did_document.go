@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+	"github.com/jackc/pgtype"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/network"
+)
+
+const (
+	jsonWebKey2020Context        = "https://w3id.org/security/suites/jws-2020/v1"
+	secp256k1Recovery2020Context = "https://w3id.org/security/suites/secp256k1recovery-2020/v2"
+	jsonWebKey2020Type           = "JsonWebKey2020"
+	ecdsaSecp256k1RecoveryMethod = "EcdsaSecp256k1RecoveryMethod2020"
+	ethereumVerificationMethodID = "ethereum-based-id"
+	bjjJWKCurve                  = "BJJ"
+	bjjCoordinateLength          = 32
+	caip10EthereumNamespace      = "eip155"
+	webDIDKeyFragment            = "key-1"
+	linkedDomainsServiceType     = "LinkedDomains"
+	issuerProfileServiceType     = "IssuerProfile"
+	issuerProfileServiceFragment = "issuer-profile"
+	websiteServiceFragment       = "website"
+)
+
+type didDocument struct {
+	kms                kms.KMSType
+	identityRepository ports.IndentityRepository
+	claimsRepository   ports.ClaimRepository
+	networkResolver    network.Resolver
+	storage            *db.Storage
+	serverURL          string
+}
+
+// NewDIDDocument returns the service that builds the DID documents of the identities of the node
+func NewDIDDocument(keyStore kms.KMSType, identityRepository ports.IndentityRepository, claimsRepository ports.ClaimRepository, networkResolver network.Resolver, storage *db.Storage, serverURL string) ports.DIDDocumentService {
+	return &didDocument{
+		kms:                keyStore,
+		identityRepository: identityRepository,
+		claimsRepository:   claimsRepository,
+		networkResolver:    networkResolver,
+		storage:            storage,
+		serverURL:          serverURL,
+	}
+}
+
+// Resolve builds the DID document of an identity of the node. Besides the iden3comm agent service, it has
+// the published and non revoked BJJ auth keys, the ethereum address of ETH identities and one service for each
+// revocation status type supported by the identity network.
+func (d *didDocument) Resolve(ctx context.Context, did w3c.DID) (*ports.DIDResolution, error) {
+	// not found is the expected error for the DIDs of other nodes, so it is not logged
+	identity, err := d.identityRepository.GetByID(ctx, d.storage.Pgx, did)
+	if err != nil {
+		return nil, err
+	}
+	if domain.IsWebDID(did) {
+		return d.resolveWebDID(ctx, did, identity)
+	}
+
+	doc := newDIDDocument(d.serverURL, did)
+	contexts := []string{serviceContext}
+
+	if identity.KeyType == string(kms.KeyTypeEthereum) {
+		vm, err := ethereumVerificationMethod(did, identity)
+		if err != nil {
+			log.Error(ctx, "resolving did document. Building ethereum verification method", "err", err, "did", did)
+			return nil, err
+		}
+		contexts = append(contexts, secp256k1Recovery2020Context)
+		doc.VerificationMethod = append(doc.VerificationMethod, vm)
+		doc.Authentication = append(doc.Authentication, verifiable.Authentication{CommonVerificationMethod: vm})
+	}
+
+	authHash, err := core.AuthSchemaHash.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	authClaims, err := d.claimsRepository.GetAuthClaims(ctx, d.storage.Pgx, &did, string(authHash))
+	if err != nil {
+		log.Error(ctx, "resolving did document. Getting auth credentials", "err", err, "did", did)
+		return nil, err
+	}
+	bjjKeys := 0
+	for _, authClaim := range authClaims {
+		if authClaim.Revoked || authClaim.MTPProof.Status != pgtype.Present {
+			continue
+		}
+		vm := bjjVerificationMethod(did, authClaim)
+		doc.VerificationMethod = append(doc.VerificationMethod, vm)
+		doc.Authentication = append(doc.Authentication, verifiable.Authentication{CommonVerificationMethod: vm})
+		bjjKeys++
+	}
+	if bjjKeys > 0 {
+		contexts = append(contexts, jsonWebKey2020Context)
+	}
+
+	revocationServices, err := d.revocationServices(ctx, did)
+	if err != nil {
+		log.Error(ctx, "resolving did document. Getting revocation services", "err", err, "did", did)
+		return nil, err
+	}
+	doc.Service = append(doc.Service, revocationServices...)
+	doc.Service = append(doc.Service, profileServices(did, identity.Profile)...)
+	doc.Context = contexts
+
+	return &ports.DIDResolution{Document: &doc, Deactivated: !identity.IsActive()}, nil
+}
+
+// resolveWebDID builds the DID document of a did:web identity, that has the key of the identity as the only
+// verification method. did:web identities have no state, so there is no iden3comm agent or revocation service.
+func (d *didDocument) resolveWebDID(ctx context.Context, did w3c.DID, identity *domain.Identity) (*ports.DIDResolution, error) {
+	keyIDs, err := d.kms.KeysByIdentity(ctx, did)
+	if err != nil {
+		log.Error(ctx, "resolving did document. Loading did:web keys", "err", err, "did", did)
+		return nil, err
+	}
+
+	var jwk map[string]any
+	for _, keyID := range keyIDs {
+		if keyID.Type != kms.KeyTypeBabyJubJub {
+			continue
+		}
+		pubKey, err := bjjPubKey(d.kms, keyID)
+		if err != nil {
+			return nil, err
+		}
+		jwk = bjjJWK(pubKey.X, pubKey.Y)
+		break
+	}
+	if jwk == nil {
+		log.Error(ctx, "resolving did document. did:web key not found", "did", did)
+		return nil, ErrWebIdentityKeyNotFound
+	}
+
+	vm := verifiable.CommonVerificationMethod{
+		ID:           webVerificationMethodID(did),
+		Type:         jsonWebKey2020Type,
+		Controller:   did.String(),
+		PublicKeyJwk: jwk,
+	}
+	doc := verifiable.DIDDocument{
+		Context:            []string{serviceContext, jsonWebKey2020Context},
+		ID:                 did.String(),
+		VerificationMethod: []verifiable.CommonVerificationMethod{vm},
+		Authentication:     []verifiable.Authentication{{CommonVerificationMethod: vm}},
+		Service:            profileServices(did, identity.Profile),
+	}
+	return &ports.DIDResolution{Document: &doc, Deactivated: !identity.IsActive()}, nil
+}
+
+// webVerificationMethodID returns the id of the verification method of the key of a did:web identity
+func webVerificationMethodID(did w3c.DID) string {
+	return fmt.Sprintf("%s#%s", did, webDIDKeyFragment)
+}
+
+// revocationServices returns a service for each credential status type the network of the identity supports
+func (d *didDocument) revocationServices(ctx context.Context, did w3c.DID) ([]interface{}, error) {
+	resolverPrefix, err := common.ResolverPrefix(&did)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := d.networkResolver.GetRhsSettings(ctx, resolverPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []interface{}{
+		verifiable.Service{
+			ID:              fmt.Sprintf("%s#%s", did, verifiable.Iden3commRevocationStatusV1),
+			Type:            string(verifiable.Iden3commRevocationStatusV1),
+			ServiceEndpoint: fmt.Sprintf(ports.AgentUrl, settings.Iden3CommAgentStatus),
+		},
+	}
+	if (settings.Mode == network.OffChain || settings.Mode == network.All) && settings.RhsUrl != nil {
+		entries = append(entries, verifiable.Service{
+			ID:              fmt.Sprintf("%s#%s", did, verifiable.Iden3ReverseSparseMerkleTreeProof),
+			Type:            string(verifiable.Iden3ReverseSparseMerkleTreeProof),
+			ServiceEndpoint: *settings.RhsUrl,
+		})
+	}
+	if (settings.Mode == network.OnChain || settings.Mode == network.All) && settings.ContractAddress != nil && settings.ChainID != nil {
+		entries = append(entries, verifiable.Service{
+			ID:              fmt.Sprintf("%s#%s", did, verifiable.Iden3OnchainSparseMerkleTreeProof2023),
+			Type:            string(verifiable.Iden3OnchainSparseMerkleTreeProof2023),
+			ServiceEndpoint: fmt.Sprintf("%s:%s:%s", caip10EthereumNamespace, *settings.ChainID, ethCommon.HexToAddress(*settings.ContractAddress).Hex()),
+		})
+	}
+	return entries, nil
+}
+
+// profileServices returns the services that publish the profile of the identity: the website as a linked domain
+// and the whole profile as the endpoint of an issuer profile service.
+func profileServices(did w3c.DID, profile domain.IdentityProfile) []interface{} {
+	if profile.IsEmpty() {
+		return nil
+	}
+	var services []interface{}
+	if profile.Website != "" {
+		services = append(services, verifiable.Service{
+			ID:              fmt.Sprintf("%s#%s", did, websiteServiceFragment),
+			Type:            linkedDomainsServiceType,
+			ServiceEndpoint: profile.Website,
+		})
+	}
+	return append(services, map[string]interface{}{
+		"id":              fmt.Sprintf("%s#%s", did, issuerProfileServiceFragment),
+		"type":            issuerProfileServiceType,
+		"serviceEndpoint": profile,
+	})
+}
+
+// ethereumVerificationMethod returns the verification method of the ethereum address an ETH identity is based on
+func ethereumVerificationMethod(did w3c.DID, identity *domain.Identity) (verifiable.CommonVerificationMethod, error) {
+	chainID, err := core.ChainIDfromDID(did)
+	if err != nil {
+		return verifiable.CommonVerificationMethod{}, err
+	}
+	var address string
+	if identity.Address != nil {
+		address = *identity.Address
+	}
+	return verifiable.CommonVerificationMethod{
+		ID:                  fmt.Sprintf("%s#%s", did, ethereumVerificationMethodID),
+		Type:                ecdsaSecp256k1RecoveryMethod,
+		Controller:          did.String(),
+		BlockchainAccountID: fmt.Sprintf("%s:%d:%s", caip10EthereumNamespace, chainID, ethCommon.HexToAddress(address).Hex()),
+	}, nil
+}
+
+// bjjVerificationMethod returns the BJJ key of the auth credential as a JSON web key. The id of the verification
+// method is the id of the auth credential, the one used by the keys endpoints.
+func bjjVerificationMethod(did w3c.DID, authClaim *domain.Claim) verifiable.CommonVerificationMethod {
+	slots := authClaim.CoreClaim.Get().RawSlotsAsInts()
+	return verifiable.CommonVerificationMethod{
+		ID:           fmt.Sprintf("%s#%s", did, authClaim.ID),
+		Type:         jsonWebKey2020Type,
+		Controller:   did.String(),
+		PublicKeyJwk: bjjJWK(slots[2], slots[3]),
+	}
+}
+
+// bjjJWK returns the BJJ public key with the given coordinates as a JSON web key
+func bjjJWK(x, y *big.Int) map[string]any {
+	return map[string]any{
+		"kty": "EC",
+		"crv": bjjJWKCurve,
+		"x":   encodeBJJCoordinate(x),
+		"y":   encodeBJJCoordinate(y),
+	}
+}
+
+func encodeBJJCoordinate(c *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(c.FillBytes(make([]byte, bjjCoordinateLength)))
+}
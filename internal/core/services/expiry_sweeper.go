@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/event"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/pubsub"
+)
+
+const (
+	// expirySweeperBatchSize is the number of expired credentials loaded from the database at once
+	expirySweeperBatchSize = 100
+	// ExpirySweeperRevokeAll is the revocation policy value that matches the credentials of every schema
+	ExpirySweeperRevokeAll = "*"
+
+	expiredCredentialRevocationReason = "credential expired"
+)
+
+type expirySweeper struct {
+	claimsRepo            ports.ClaimRepository
+	claimService          ports.ClaimService
+	notificationPublisher pubsub.Publisher
+	storage               *db.Storage
+	revokeSchemas         []string
+}
+
+// NewExpirySweeper is the expiry sweeper service constructor.
+// revokeSchemas is the per-schema revocation policy, a list of schema types or schema urls whose credentials
+// are revoked when they expire. ExpirySweeperRevokeAll revokes the expired credentials of every schema.
+func NewExpirySweeper(claimsRepo ports.ClaimRepository, claimService ports.ClaimService, notificationPublisher pubsub.Publisher, storage *db.Storage, revokeSchemas []string) ports.ExpirySweeperService {
+	return &expirySweeper{
+		claimsRepo:            claimsRepo,
+		claimService:          claimService,
+		notificationPublisher: notificationPublisher,
+		storage:               storage,
+		revokeSchemas:         revokeSchemas,
+	}
+}
+
+// Sweep processes the credentials that expired since the last run. Revoked credentials are skipped. Credentials whose
+// schema is in the revocation policy are revoked. The revocations are published with the next state of their issuers,
+// following the publishing policy of each one, and then their holders get a revocation notification. The holders of
+// the other ones are notified through an ExpiredCredentialsEvent. Each credential is processed only once; the ones
+// that fail to be revoked are retried in the next run.
+func (s *expirySweeper) Sweep(ctx context.Context) error {
+	now := time.Now()
+	for {
+		claims, err := s.claimsRepo.GetExpiredNotProcessed(ctx, s.storage.Pgx, now, expirySweeperBatchSize)
+		if err != nil {
+			log.Error(ctx, "expiry sweeper: loading expired credentials", "err", err)
+			return err
+		}
+		if len(claims) == 0 {
+			break
+		}
+
+		processed := make([]uuid.UUID, 0, len(claims))
+		expiredByIssuer := make(map[string][]string)
+		for _, claim := range claims {
+			issuerDID, err := w3c.ParseDID(claim.Issuer)
+			if err != nil {
+				log.Error(ctx, "expiry sweeper: parsing issuer did", "err", err, "issuer", claim.Issuer, "credID", claim.ID)
+				continue
+			}
+			revoke := s.mustRevoke(claim)
+			if revoke {
+				if err := s.claimService.Revoke(ctx, *issuerDID, uint64(claim.RevNonce), expiredCredentialRevocationReason); err != nil {
+					log.Error(ctx, "expiry sweeper: revoking expired credential", "err", err, "issuer", claim.Issuer, "credID", claim.ID)
+					continue
+				}
+			}
+			processed = append(processed, claim.ID)
+			if !revoke {
+				expiredByIssuer[claim.Issuer] = append(expiredByIssuer[claim.Issuer], claim.ID.String())
+			}
+		}
+
+		if err := s.claimsRepo.MarkExpirationProcessed(ctx, s.storage.Pgx, processed); err != nil {
+			log.Error(ctx, "expiry sweeper: marking expired credentials as processed", "err", err)
+			return err
+		}
+		for issuer, credIDs := range expiredByIssuer {
+			if err := s.notificationPublisher.Publish(ctx, event.ExpiredCredentialsEvent, &event.ExpiredCredentials{CredentialIDs: credIDs, IssuerID: issuer}); err != nil {
+				log.Error(ctx, "expiry sweeper: publish ExpiredCredentialsEvent", "err", err, "issuer", issuer)
+			}
+		}
+		log.Info(ctx, "expiry sweeper: expired credentials processed", "count", len(processed), "failed", len(claims)-len(processed))
+
+		// none of the credentials of a short batch is left to process in this run, and the failed ones
+		// would be loaded again and again
+		if len(claims) < expirySweeperBatchSize || len(processed) == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *expirySweeper) mustRevoke(claim *domain.Claim) bool {
+	return slices.Contains(s.revokeSchemas, ExpirySweeperRevokeAll) ||
+		slices.Contains(s.revokeSchemas, claim.SchemaType) ||
+		slices.Contains(s.revokeSchemas, claim.SchemaURL)
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/event"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	networkPkg "github.com/polygonid/sh-id-platform/internal/network"
+	"github.com/polygonid/sh-id-platform/internal/pubsub"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/internal/reversehash"
+	"github.com/polygonid/sh-id-platform/internal/revocationstatus"
+)
+
+func TestExpirySweeper_Sweep(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "amoy"
+	)
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaim()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	connectionsRepository := repositories.NewConnection()
+
+	networkResolver, err := networkPkg.NewResolver(ctx, cfg, keyStore, common.CreateFile(t))
+	require.NoError(t, err)
+
+	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
+	identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactory, revocationStatusResolver)
+	iden, err := identityService.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: network, KeyType: BJJ})
+	require.NoError(t, err)
+	did, err := w3c.ParseDID(iden.Identifier)
+	require.NoError(t, err)
+
+	fixture := repositories.NewFixture(storage)
+	newClaim := func(expiration time.Time, revoked bool) uuid.UUID {
+		return fixture.CreateClaim(t, &domain.Claim{
+			Identifier:      common.ToPointer(did.String()),
+			Issuer:          did.String(),
+			OtherIdentifier: "did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5",
+			SchemaType:      "KYCAgeCredential",
+			HIndex:          uuid.NewString(),
+			Expiration:      expiration.Unix(),
+			Revoked:         revoked,
+		})
+	}
+	expiredID := newClaim(time.Now().Add(-time.Hour), false)
+	newClaim(time.Now().Add(time.Hour), false)
+	// the holder of a revoked credential was already notified of the revocation
+	newClaim(time.Now().Add(-time.Hour), true)
+
+	ps := pubsub.NewMock()
+	sweeper := NewExpirySweeper(claimsRepo, nil, ps, storage, nil)
+
+	expiredCredentials := func() []string {
+		ids := make([]string, 0)
+		for _, e := range ps.AllPublishedEvents(event.ExpiredCredentialsEvent) {
+			ev, ok := e.(*event.ExpiredCredentials)
+			require.True(t, ok)
+			if ev.IssuerID == did.String() {
+				ids = append(ids, ev.CredentialIDs...)
+			}
+		}
+		return ids
+	}
+
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.Equal(t, []string{expiredID.String()}, expiredCredentials())
+
+	// expired credentials are processed only once
+	ps.Clear(event.ExpiredCredentialsEvent)
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.Empty(t, expiredCredentials())
+}
+
+func TestExpirySweeper_mustRevoke(t *testing.T) {
+	claim := &domain.Claim{SchemaType: "KYCAgeCredential", SchemaURL: "https://example.com/kyc.json"}
+	for _, tc := range []struct {
+		name          string
+		revokeSchemas []string
+		expected      bool
+	}{
+		{name: "no policy", expected: false},
+		{name: "other schema", revokeSchemas: []string{"KYCCountryOfResidenceCredential"}, expected: false},
+		{name: "by type", revokeSchemas: []string{"KYCAgeCredential"}, expected: true},
+		{name: "by url", revokeSchemas: []string{"https://example.com/kyc.json"}, expected: true},
+		{name: "all", revokeSchemas: []string{ExpirySweeperRevokeAll}, expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sweeper := &expirySweeper{revokeSchemas: tc.revokeSchemas}
+			assert.Equal(t, tc.expected, sweeper.mustRevoke(claim))
+		})
+	}
+}
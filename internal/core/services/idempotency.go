@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+var (
+	ErrIdempotencyKeyInProgress = errors.New("a request with the same idempotency key is being processed")    // ErrIdempotencyKeyInProgress means that the first request that used the key has not finished yet
+	ErrIdempotencyKeyMismatch   = errors.New("the idempotency key was already used with a different request") // ErrIdempotencyKeyMismatch means that the key is being reused for a request with other parameters
+)
+
+type idempotency struct {
+	repo    ports.IdempotencyKeyRepository
+	storage *db.Storage
+	ttl     time.Duration
+	lease   time.Duration
+}
+
+// NewIdempotency is the idempotency service constructor. Keys are kept for ttl. A key whose request has not finished
+// after lease, e.g. because the node stopped while processing it, can be reserved again by a retry.
+func NewIdempotency(repo ports.IdempotencyKeyRepository, storage *db.Storage, ttl time.Duration, lease time.Duration) ports.IdempotencyService {
+	return &idempotency{
+		repo:    repo,
+		storage: storage,
+		ttl:     ttl,
+		lease:   lease,
+	}
+}
+
+// Do runs fn only once for the given key and returns its result encoded as json.
+// The first request reserves the key and stores the result of fn. Retries with the same key and request get the stored
+// result without running fn. If fn fails the key is released, so the request can be retried. If the first request has
+// not stored its result after the lease, a retry reserves the key again and runs fn.
+// With an empty key fn is always run.
+func (i *idempotency) Do(ctx context.Context, issuerDID w3c.DID, operation domain.IdempotentOperation, key string, request any, fn func(ctx context.Context) (any, error)) (json.RawMessage, error) {
+	if key == "" {
+		result, err := fn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(result)
+	}
+
+	requestHash, err := hashIdempotentRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	// the reservation is identified by its creation time, so it's truncated to the precision of the database
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	reservation := &domain.IdempotencyKey{
+		IssuerID:    issuerDID.String(),
+		Operation:   operation,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(i.ttl),
+	}
+	reserved, err := i.repo.Reserve(ctx, i.storage.Pgx, reservation, now.Add(-i.lease))
+	if err != nil {
+		log.Error(ctx, "reserving idempotency key", "err", err, "key", key)
+		return nil, err
+	}
+	if !reserved {
+		stored, err := i.repo.Get(ctx, i.storage.Pgx, issuerDID, operation, key)
+		if err != nil {
+			log.Error(ctx, "loading idempotency key", "err", err, "key", key)
+			return nil, err
+		}
+		if stored.RequestHash != requestHash {
+			return nil, ErrIdempotencyKeyMismatch
+		}
+		if stored.InProgress() {
+			return nil, ErrIdempotencyKeyInProgress
+		}
+		log.Info(ctx, "returning the stored response of an idempotent request", "key", key, "operation", operation)
+		return stored.Response, nil
+	}
+
+	result, err := fn(ctx)
+	if err != nil {
+		if err := i.repo.Delete(ctx, i.storage.Pgx, reservation); err != nil {
+			log.Error(ctx, "releasing idempotency key", "err", err, "key", key)
+		}
+		return nil, err
+	}
+	response, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	// The request succeeded, so a failure storing the response is not returned to the caller.
+	// The key remains in progress until the lease ends.
+	if err := i.repo.SetResponse(ctx, i.storage.Pgx, reservation, response); err != nil {
+		log.Error(ctx, "storing idempotent response", "err", err, "key", key)
+	}
+	return response, nil
+}
+
+// DeleteExpired removes the keys older than the ttl
+func (i *idempotency) DeleteExpired(ctx context.Context) error {
+	deleted, err := i.repo.DeleteExpired(ctx, i.storage.Pgx)
+	if err != nil {
+		log.Error(ctx, "deleting expired idempotency keys", "err", err)
+		return err
+	}
+	log.Debug(ctx, "expired idempotency keys deleted", "count", deleted)
+	return nil
+}
+
+func hashIdempotentRequest(request any) (string, error) {
+	raw, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(raw)
+	return hex.EncodeToString(hash[:]), nil
+}
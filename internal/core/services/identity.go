@@ -24,6 +24,7 @@ import (
 	"github.com/iden3/iden3comm/v2/packers"
 	"github.com/iden3/iden3comm/v2/protocol"
 	mtproof "github.com/iden3/merkletree-proof"
+	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
 
 	"github.com/polygonid/sh-id-platform/internal/common"
@@ -65,6 +66,18 @@ var (
 
 	// ErrWrongDIDMetada - represents an error in the identity metadata
 	ErrWrongDIDMetada = errors.New("wrong DID Metadata")
+
+	// ErrAuthClaimNotPublished - means that the current auth claim is not published yet, so it can not sign the key rotation
+	ErrAuthClaimNotPublished = errors.New("the current auth credential must be published before rotating the key")
+
+	// ErrIdentityDeactivated - means that the identity is deactivated, so it can not issue credentials, create links or publish new changes
+	ErrIdentityDeactivated = errors.New("identity is deactivated")
+
+	// ErrWebIdentityNotSupported - means that the operation needs the merkle trees or the state of an iden3 identity
+	ErrWebIdentityNotSupported = errors.New("the operation is not supported by did:web identities, they have no state")
+
+	// ErrWebIdentityKeyNotFound - means that the kms does not have the key of the did:web identity
+	ErrWebIdentityKeyNotFound = errors.New("did:web identity key not found")
 )
 
 type identity struct {
@@ -128,6 +141,11 @@ func (i *identity) Create(ctx context.Context, hostURL string, didOptions *ports
 				keyType = didOptions.KeyType
 			}
 
+			if didOptions != nil && didOptions.Method == domain.DIDMethodWeb {
+				identifier, err = i.createWebIdentity(ctx, tx, hostURL, keyType, didOptions)
+				return err
+			}
+
 			switch keyType {
 			case kms.KeyTypeEthereum:
 				identifier, _, err = i.createEthIdentity(ctx, tx, hostURL, didOptions)
@@ -157,6 +175,60 @@ func (i *identity) SignClaimEntry(ctx context.Context, authClaim *domain.Claim,
 		return nil, err
 	}
 
+	var issuerMTP verifiable.Iden3SparseMerkleTreeProof
+	err = authClaim.MTPProof.AssignTo(&issuerMTP)
+	if err != nil {
+		log.Error(ctx, "assigning to issuerMTP", "err", err)
+		return nil, ErrAssigningMTPProof
+	}
+
+	proof, err := i.signClaimEntry(ctx, keyID, claimEntry)
+	if err != nil {
+		return nil, err
+	}
+	issuerMTP.IssuerData.AuthCoreClaim, err = authClaim.CoreClaim.Get().Hex()
+	if err != nil {
+		return nil, err
+	}
+
+	proof.IssuerData = issuerMTP.IssuerData
+	proof.IssuerData.MTP = issuerMTP.MTP
+
+	return proof, nil
+}
+
+// SignWebClaimEntry signs the claim entry with the BJJ key of a did:web identity. As there is no auth claim in a
+// claims tree, the issuer data has the auth core claim of the key but no state and no merkle tree proof, so the
+// signature is verified with the key of the DID document.
+func (i *identity) SignWebClaimEntry(ctx context.Context, did w3c.DID, claimEntry *core.Claim) (*verifiable.BJJSignatureProof2021, error) {
+	keyID, err := i.webKeyID(ctx, did, kms.KeyTypeBabyJubJub)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := bjjPubKey(i.kms, keyID)
+	if err != nil {
+		return nil, err
+	}
+	authClaim, err := newAuthClaim(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	authClaim.SetRevocationNonce(0)
+
+	proof, err := i.signClaimEntry(ctx, keyID, claimEntry)
+	if err != nil {
+		return nil, err
+	}
+	proof.IssuerData.ID = did.String()
+	proof.IssuerData.AuthCoreClaim, err = authClaim.Hex()
+	if err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// signClaimEntry returns the BJJ signature proof of the claim entry without the issuer data
+func (i *identity) signClaimEntry(ctx context.Context, keyID kms.KeyID, claimEntry *core.Claim) (*verifiable.BJJSignatureProof2021, error) {
 	bjjSigner, err := primitive.NewBJJSigner(i.kms, keyID)
 	if err != nil {
 		return nil, err
@@ -168,13 +240,6 @@ func (i *identity) SignClaimEntry(ctx context.Context, authClaim *domain.Claim,
 
 	circuitSigner := signer.New(bbjSuite)
 
-	var issuerMTP verifiable.Iden3SparseMerkleTreeProof
-	err = authClaim.MTPProof.AssignTo(&issuerMTP)
-	if err != nil {
-		log.Error(ctx, "assigning to issuerMTP", "err", err)
-		return nil, ErrAssigningMTPProof
-	}
-
 	signtureBytes, err := circuitSigner.Sign(ctx, babyjubjub.SignatureType, claimEntry)
 	if err != nil {
 		return nil, err
@@ -184,20 +249,26 @@ func (i *identity) SignClaimEntry(ctx context.Context, authClaim *domain.Claim,
 	var proof verifiable.BJJSignatureProof2021
 	proof.Type = babyjubjub.SignatureType
 	proof.Signature = hex.EncodeToString(signtureBytes)
-	issuerMTP.IssuerData.AuthCoreClaim, err = authClaim.CoreClaim.Get().Hex()
+	proof.CoreClaim, err = claimEntry.Hex()
 	if err != nil {
 		return nil, err
 	}
+	return &proof, nil
+}
 
-	proof.IssuerData = issuerMTP.IssuerData
-	proof.IssuerData.MTP = issuerMTP.MTP
-
-	proof.CoreClaim, err = claimEntry.Hex()
+// webKeyID returns the key of the given type of a did:web identity
+func (i *identity) webKeyID(ctx context.Context, did w3c.DID, keyType kms.KeyType) (kms.KeyID, error) {
+	keyIDs, err := i.kms.KeysByIdentity(ctx, did)
 	if err != nil {
-		return nil, err
+		log.Error(ctx, "loading did:web identity keys", "err", err, "did", did)
+		return kms.KeyID{}, err
 	}
-
-	return &proof, nil
+	for _, keyID := range keyIDs {
+		if keyID.Type == keyType {
+			return keyID, nil
+		}
+	}
+	return kms.KeyID{}, ErrWebIdentityKeyNotFound
 }
 
 func (i *identity) Exists(ctx context.Context, identifier w3c.DID) (bool, error) {
@@ -253,9 +324,9 @@ func (i *identity) getKeyIDFromAuthClaim(ctx context.Context, authClaim *domain.
 	return keyID, errors.New("private key not found")
 }
 
-// Get - returns all the identities
-func (i *identity) Get(ctx context.Context) (identities []domain.IdentityDisplayName, err error) {
-	return i.identityRepository.Get(ctx, i.storage.Pgx)
+// Get - returns the identities that match the request and the total number of them
+func (i *identity) Get(ctx context.Context, req *ports.GetIdentitiesRequest) (identities []domain.IdentityDisplayName, total uint, err error) {
+	return i.identityRepository.Get(ctx, i.storage.Pgx, req)
 }
 
 // GetLatestStateByID get latest identity state by identifier
@@ -321,6 +392,9 @@ func (i *identity) GetKeyIDFromAuthClaim(ctx context.Context, authClaim *domain.
 }
 
 func (i *identity) UpdateState(ctx context.Context, did w3c.DID) (*domain.IdentityState, error) {
+	if domain.IsWebDID(did) {
+		return nil, ErrWebIdentityNotSupported
+	}
 	newState := &domain.IdentityState{
 		Identifier: did.String(),
 		Status:     domain.StatusCreated,
@@ -343,12 +417,21 @@ func (i *identity) UpdateState(ctx context.Context, did w3c.DID) (*domain.Identi
 				return fmt.Errorf("error getting the identifier last state: %w", err)
 			}
 
-			// Get all mtp claims with state == nil
-			claimsAddedToTree, err := i.processClaims(ctx, tx, did, iTrees)
+			// a deactivated identity only publishes its revocations
+			active, err := i.identityRepository.IsActive(ctx, tx, did)
 			if err != nil {
 				return err
 			}
 
+			// Get all mtp claims with state == nil
+			claimsAddedToTree := false
+			if active {
+				claimsAddedToTree, err = i.processClaims(ctx, tx, did, iTrees)
+				if err != nil {
+					return err
+				}
+			}
+
 			// Get all revocations with domain.RevPending status
 			updatedRevocations, err := i.revocationRepository.UpdateStatus(ctx, tx, &did)
 			if err != nil {
@@ -359,6 +442,9 @@ func (i *identity) UpdateState(ctx context.Context, did w3c.DID) (*domain.Identi
 			log.Info(ctx, "updating revocation status", "revocations", len(updatedRevocations))
 
 			if len(updatedRevocations) == 0 && !claimsAddedToTree {
+				if !active {
+					return ErrIdentityDeactivated
+				}
 				log.Info(ctx, "no claims or revocations found to process")
 				return ErrNoClaimsFoundToProcess
 			}
@@ -369,10 +455,12 @@ func (i *identity) UpdateState(ctx context.Context, did w3c.DID) (*domain.Identi
 				return err
 			}
 
-			err = i.update(ctx, tx, &did, *newState)
-			if err != nil {
-				log.Error(ctx, "updating claims", "err", err)
-				return err
+			if claimsAddedToTree {
+				err = i.update(ctx, tx, &did, *newState)
+				if err != nil {
+					log.Error(ctx, "updating claims", "err", err)
+					return err
+				}
 			}
 
 			err = i.identityStateRepository.Save(ctx, tx, *newState)
@@ -420,23 +508,349 @@ func (i *identity) UpdateState(ctx context.Context, did w3c.DID) (*domain.Identi
 	return newState, err
 }
 
-// UpdateIdentityDisplayName implements ports.IdentityService.
-func (i *identity) UpdateIdentityDisplayName(ctx context.Context, did w3c.DID, displayName string) error {
-	return i.storage.Pgx.BeginFunc(ctx,
+// RotateKey creates a new BJJ key for the identity, adds a new auth claim with it and revokes the given auth claim,
+// or the primary one if authClaimID is nil. When the primary auth claim is rotated, the new one becomes the primary.
+// The changes are published in the next state transition, that is signed with the current key because it must
+// be valid in the latest published state. Once the transition is confirmed, the new key signs the credentials.
+func (i *identity) RotateKey(ctx context.Context, did w3c.DID, authClaimID *uuid.UUID) (*domain.Claim, error) {
+	if domain.IsWebDID(did) {
+		return nil, ErrWebIdentityNotSupported
+	}
+	authHash, err := core.AuthSchemaHash.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	var authClaimModel *domain.Claim
+	err = i.storage.Pgx.BeginFunc(ctx,
+		func(tx pgx.Tx) error {
+			identity, err := i.identityRepository.GetByID(ctx, tx, did)
+			if err != nil {
+				log.Error(ctx, "getting identity for key rotation", "err", err)
+				return err
+			}
+			if !identity.IsActive() {
+				return ErrIdentityDeactivated
+			}
+
+			if authClaimID == nil {
+				if err = i.resolvePrimaryAuthClaim(ctx, tx, &did, identity, string(authHash)); err != nil {
+					return err
+				}
+				authClaimID = identity.AuthClaimID
+			}
+			currentAuthClaim, err := i.claimsRepository.GetByIdAndIssuer(ctx, tx, &did, *authClaimID)
+			if err != nil {
+				log.Error(ctx, "getting current auth claim", "err", err)
+				if errors.Is(err, repositories.ErrClaimDoesNotExist) {
+					return fmt.Errorf("%w: auth credential not found", ErrInvalidAuthClaim)
+				}
+				return err
+			}
+			if currentAuthClaim.SchemaHash != string(authHash) || currentAuthClaim.Revoked {
+				return fmt.Errorf("%w: <%s> is not an active auth credential", ErrInvalidAuthClaim, currentAuthClaim.ID)
+			}
+			if currentAuthClaim.MTPProof.Status != pgtype.Present {
+				return ErrAuthClaimNotPublished
+			}
+
+			authCs, err := currentAuthClaim.GetCredentialStatus()
+			if err != nil {
+				log.Error(ctx, "getting current auth claim credential status", "err", err)
+				return err
+			}
+
+			authClaimModel, err = i.addAuthClaim(ctx, tx, did, identity, authCs.Type)
+			if err != nil {
+				return err
+			}
+			if identity.AuthClaimID != nil && *identity.AuthClaimID == currentAuthClaim.ID {
+				identity.AuthClaimID = &authClaimModel.ID
+				if err = i.identityRepository.UpdateAuthClaimID(ctx, tx, identity); err != nil {
+					return fmt.Errorf("error updating the primary auth claim: %w", err)
+				}
+			}
+
+			mts, err := i.mtService.GetIdentityMerkleTrees(ctx, tx, &did)
+			if err != nil {
+				return fmt.Errorf("error getting merkle trees: %w", err)
+			}
+
+			err = mts.RevokeClaim(ctx, new(big.Int).SetUint64(uint64(currentAuthClaim.RevNonce)))
+			if err != nil {
+				return fmt.Errorf("error revoking the auth claim: %w", err)
+			}
+
+			revokedClaims, err := i.claimsRepository.GetByRevocationNonce(ctx, tx, &did, currentAuthClaim.RevNonce)
+			if err != nil {
+				return fmt.Errorf("error getting the auth claim by revocation nonce: %w", err)
+			}
+			for _, claim := range revokedClaims {
+				claim.Revoked = true
+				if _, err = i.claimsRepository.Save(ctx, tx, claim); err != nil {
+					return fmt.Errorf("error saving the auth claim: %w", err)
+				}
+				if err = i.revocationStatusResolver.Revoke(ctx, tx, claim); err != nil {
+					return fmt.Errorf("error updating the credential status list: %w", err)
+				}
+			}
+
+			return i.claimsRepository.RevokeNonce(ctx, tx, &domain.Revocation{
+				Identifier:  did.String(),
+				Nonce:       currentAuthClaim.RevNonce,
+				Version:     0,
+				Status:      0,
+				Description: "auth key rotation",
+			})
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return authClaimModel, nil
+}
+
+// resolvePrimaryAuthClaim sets the primary auth claim of an identity that has none to its only active auth claim.
+// The primary auth claim can't be guessed when the identity has several active ones.
+func (i *identity) resolvePrimaryAuthClaim(ctx context.Context, tx db.Querier, did *w3c.DID, identity *domain.Identity, authHash string) error {
+	if identity.AuthClaimID != nil {
+		return nil
+	}
+	authClaims, err := i.claimsRepository.GetAuthClaims(ctx, tx, did, authHash)
+	if err != nil {
+		return fmt.Errorf("error getting the auth claims: %w", err)
+	}
+	for _, authClaim := range authClaims {
+		if authClaim.Revoked {
+			continue
+		}
+		if identity.AuthClaimID != nil {
+			return fmt.Errorf("%w: the identity has several auth credentials, the one to rotate must be given", ErrInvalidAuthClaim)
+		}
+		identity.AuthClaimID = &authClaim.ID
+	}
+	if identity.AuthClaimID == nil {
+		return fmt.Errorf("%w: auth credential not found", ErrInvalidAuthClaim)
+	}
+	return nil
+}
+
+// AddAuthKey creates a new BJJ key for the identity and adds an auth claim with it.
+// The auth claim is published with the next state and it can sign credentials once the state is confirmed.
+func (i *identity) AddAuthKey(ctx context.Context, did w3c.DID) (*domain.Claim, error) {
+	if domain.IsWebDID(did) {
+		return nil, ErrWebIdentityNotSupported
+	}
+	var authClaimModel *domain.Claim
+	err := i.storage.Pgx.BeginFunc(ctx,
 		func(tx pgx.Tx) error {
 			identity, err := i.identityRepository.GetByID(ctx, tx, did)
 			if err != nil {
-				log.Error(ctx, "getting identity for update display name", "err", err)
+				log.Error(ctx, "getting identity for adding an auth key", "err", err)
 				return err
 			}
-			identity.DisplayName = &displayName
-			err = i.identityRepository.UpdateDisplayName(ctx, tx, identity)
+			if !identity.IsActive() {
+				return ErrIdentityDeactivated
+			}
+			authClaimModel, err = i.addAuthClaim(ctx, tx, did, identity, verifiable.CredentialStatusType(identity.AuthCoreClaimRevocationStatus.Type))
+			return err
+		})
+	if err != nil {
+		return nil, err
+	}
+	return authClaimModel, nil
+}
+
+// addAuthClaim creates a BJJ key linked to the identity and saves an auth claim for it, to be added to the claims tree
+// with the next state
+func (i *identity) addAuthClaim(ctx context.Context, tx db.Querier, did w3c.DID, identity *domain.Identity, status verifiable.CredentialStatusType) (*domain.Claim, error) {
+	key, err := i.kms.CreateKey(kms.KeyTypeBabyJubJub, &did)
+	if err != nil {
+		return nil, fmt.Errorf("can't create babyJubJub key: %w", err)
+	}
+
+	pubKey, err := bjjPubKey(i.kms, key)
+	if err != nil {
+		return nil, fmt.Errorf("can't get babyJubJub public key: %w", err)
+	}
+
+	authClaim, err := newAuthClaim(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("can't create auth claim: %w", err)
+	}
+
+	mts, err := i.mtService.GetIdentityMerkleTrees(ctx, tx, &did)
+	if err != nil {
+		return nil, fmt.Errorf("error getting merkle trees: %w", err)
+	}
+
+	claimsTree, err := mts.ClaimsTree()
+	if err != nil {
+		return nil, err
+	}
+
+	authClaimModel, err := i.authClaimToModel(ctx, tx, &did, identity, authClaim, claimsTree, pubKey, "", status, false)
+	if err != nil {
+		log.Error(ctx, "auth claim to model", "err", err)
+		return nil, err
+	}
+
+	authClaimModel.ID, err = i.claimsRepository.Save(ctx, tx, authClaimModel)
+	if err != nil {
+		return nil, fmt.Errorf("can't save auth claim: %w", err)
+	}
+	return authClaimModel, nil
+}
+
+// UpdateIdentity updates the display name and the profile of the identity
+func (i *identity) UpdateIdentity(ctx context.Context, did w3c.DID, req ports.UpdateIdentityRequest) (*domain.Identity, error) {
+	if req.PublishingPolicy != nil {
+		// did:web identities have no state to publish
+		if domain.IsWebDID(did) {
+			return nil, ErrWebIdentityNotSupported
+		}
+		if err := req.PublishingPolicy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var identity *domain.Identity
+	err := i.storage.Pgx.BeginFunc(ctx,
+		func(tx pgx.Tx) error {
+			var err error
+			identity, err = i.identityRepository.GetByID(ctx, tx, did)
+			if err != nil {
+				log.Error(ctx, "getting identity for update", "err", err)
+				return err
+			}
+			if req.DisplayName != nil {
+				identity.DisplayName = req.DisplayName
+			}
+			if req.Profile != nil {
+				identity.Profile = req.Profile.Apply(identity.Profile)
+				if err := identity.Profile.Validate(); err != nil {
+					return err
+				}
+			}
+			if req.PublishingPolicy != nil {
+				identity.PublishingPolicy = *req.PublishingPolicy
+			}
+			err = i.identityRepository.Update(ctx, tx, identity)
 			if err != nil {
-				log.Error(ctx, "updating identity display name", "err", err)
+				log.Error(ctx, "updating identity", "err", err)
 				return err
 			}
 			return nil
 		})
+	if err != nil {
+		if errors.Is(err, repositories.ErrDisplayNameDuplicated) {
+			return nil, ErrIdentityDisplayNameDuplicated
+		}
+		return nil, err
+	}
+	return identity, nil
+}
+
+// Deactivate retires the identity. A deactivated identity can not issue credentials, create links or manage its keys,
+// and its state transitions only publish revocations: the credentials issued and not published yet are never added
+// to its claims tree. With req.RevokeCredentials all its credentials are revoked here, so they are published together
+// in one state transition. It can still revoke credentials and the revocation status of its credentials keeps being
+// served. With req.Archive the identity is also hidden from the identities list.
+// It returns the credentials revoked.
+func (i *identity) Deactivate(ctx context.Context, did w3c.DID, req ports.DeactivateIdentityRequest) ([]*domain.Claim, error) {
+	authHash, err := core.AuthSchemaHash.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := make([]*domain.Claim, 0)
+	err = i.storage.Pgx.BeginFunc(ctx,
+		func(tx pgx.Tx) error {
+			identity, err := i.identityRepository.GetByID(ctx, tx, did)
+			if err != nil {
+				log.Error(ctx, "getting identity for deactivation", "err", err)
+				return err
+			}
+			if identity.ArchivedAt != nil || (identity.DeactivatedAt != nil && !req.Archive) {
+				return ErrIdentityDeactivated
+			}
+
+			now := time.Now()
+			if identity.DeactivatedAt == nil {
+				identity.DeactivatedAt = &now
+			}
+			if req.Archive {
+				identity.ArchivedAt = &now
+			}
+			if err := i.identityRepository.UpdateStatus(ctx, tx, identity); err != nil {
+				log.Error(ctx, "updating identity status", "err", err)
+				return err
+			}
+
+			if !req.RevokeCredentials {
+				return nil
+			}
+			credentials, _, err := i.claimsRepository.GetAllByIssuerID(ctx, tx, did, &ports.ClaimsFilter{Revoked: common.ToPointer(false)})
+			if err != nil {
+				return fmt.Errorf("error getting the credentials to revoke: %w", err)
+			}
+			// did:web identities have no revocation tree, their credentials are only revoked in the status lists
+			var mts *domain.IdentityMerkleTrees
+			if !domain.IsWebDID(did) {
+				mts, err = i.mtService.GetIdentityMerkleTrees(ctx, tx, &did)
+				if err != nil {
+					return fmt.Errorf("error getting merkle trees: %w", err)
+				}
+			}
+			revokedNonces := make(map[domain.RevNonceUint64]bool, len(credentials))
+			for _, credential := range credentials {
+				// the auth credentials are kept, they are the keys of the identity and not issued credentials
+				if credential.SchemaHash == string(authHash) {
+					continue
+				}
+				if mts != nil && !revokedNonces[credential.RevNonce] {
+					if err := mts.RevokeClaim(ctx, new(big.Int).SetUint64(uint64(credential.RevNonce))); err != nil {
+						return fmt.Errorf("error revoking the claim: %w", err)
+					}
+					if err := i.claimsRepository.RevokeNonce(ctx, tx, &domain.Revocation{
+						Identifier:  did.String(),
+						Nonce:       credential.RevNonce,
+						Description: "identity deactivated",
+					}); err != nil {
+						return fmt.Errorf("error saving the revocation: %w", err)
+					}
+					revokedNonces[credential.RevNonce] = true
+				}
+				credential.Revoked = true
+				if _, err := i.claimsRepository.Save(ctx, tx, credential); err != nil {
+					return fmt.Errorf("error saving the claim: %w", err)
+				}
+				if err := i.revocationStatusResolver.Revoke(ctx, tx, credential); err != nil {
+					return fmt.Errorf("error updating the credential status list: %w", err)
+				}
+				revoked = append(revoked, credential)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return revoked, nil
+}
+
+// CheckActive returns ErrIdentityDeactivated if the identity is deactivated or archived. conn should be the
+// transaction that makes the changes that require the identity to be active, the identity can't be deactivated
+// until it ends.
+func (i *identity) CheckActive(ctx context.Context, conn db.Querier, did w3c.DID) error {
+	active, err := i.identityRepository.IsActive(ctx, conn, did)
+	if err != nil {
+		log.Error(ctx, "checking the identity is active", "err", err, "did", did)
+		return err
+	}
+	if !active {
+		return ErrIdentityDeactivated
+	}
+	return nil
 }
 
 func (i *identity) processClaims(ctx context.Context, tx pgx.Tx, did w3c.DID, iTrees *domain.IdentityMerkleTrees) (bool, error) {
@@ -719,20 +1133,56 @@ func (i *identity) createEthIdentity(ctx context.Context, tx db.Querier, hostURL
 		return nil, nil, err
 	}
 
-	authClaimModel, err := i.authClaimToModel(ctx, did, identity, authClaim, claimsTree, bjjPubKey, hostURL, didOptions.AuthCredentialStatus, false)
+	authClaimModel, err := i.authClaimToModel(ctx, tx, did, identity, authClaim, claimsTree, bjjPubKey, hostURL, didOptions.AuthCredentialStatus, false)
 	if err != nil {
 		log.Error(ctx, "auth claim to model", "err", err)
 		return nil, nil, err
 	}
 
-	_, err = i.claimsRepository.Save(ctx, tx, authClaimModel)
+	authClaimModel.ID, err = i.claimsRepository.Save(ctx, tx, authClaimModel)
 	if err != nil {
 		return nil, nil, errors.Join(err, errors.New("can't save auth claim"))
 	}
 
+	identity.AuthClaimID = &authClaimModel.ID
+	if err = i.identityRepository.UpdateAuthClaimID(ctx, tx, identity); err != nil {
+		return nil, nil, errors.Join(err, errors.New("can't save the primary auth claim"))
+	}
+
 	return did, identity.State.TreeState().State.BigInt(), nil
 }
 
+// createWebIdentity - creates a new did:web identity hosted at hostURL and backed by a new BJJ key.
+// The identity has no merkle trees and no state, its DID document is built from the key.
+// Only BJJ keys are supported, as the credentials of the identity are issued with a BJJ signature proof.
+func (i *identity) createWebIdentity(ctx context.Context, tx db.Querier, hostURL string, keyType kms.KeyType, didOptions *ports.DIDCreationOptions) (*w3c.DID, error) {
+	if keyType != kms.KeyTypeBabyJubJub {
+		return nil, fmt.Errorf("%w: did:web identities must have a %s key", ErrWrongDIDMetada, kms.KeyTypeBabyJubJub)
+	}
+	did, err := domain.NewWebDID(hostURL, uuid.New())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrWrongDIDMetada, err)
+	}
+
+	if _, err := i.kms.CreateKey(keyType, did); err != nil {
+		return nil, fmt.Errorf("can't create %s key: %w", keyType, err)
+	}
+
+	identity := &domain.Identity{
+		Identifier:  did.String(),
+		KeyType:     string(keyType),
+		DisplayName: didOptions.DisplayName,
+	}
+
+	if err = i.identityRepository.Save(ctx, tx, identity); err != nil {
+		if errors.Is(err, repositories.ErrDisplayNameDuplicated) {
+			return nil, ErrIdentityDisplayNameDuplicated
+		}
+		return nil, fmt.Errorf("can't save identity: %w", err)
+	}
+	return did, nil
+}
+
 // createIdentity - creates a new identity
 func (i *identity) createIdentity(ctx context.Context, tx db.Querier, hostURL string, didOptions *ports.DIDCreationOptions) (*w3c.DID, *big.Int, error) {
 	if didOptions == nil {
@@ -781,17 +1231,18 @@ func (i *identity) createIdentity(ctx context.Context, tx db.Querier, hostURL st
 		return nil, nil, err
 	}
 
-	authClaimModel, err := i.authClaimToModel(ctx, did, identity, authClaim, claimsTree, pubKey, hostURL, didOptions.AuthCredentialStatus, true)
+	authClaimModel, err := i.authClaimToModel(ctx, tx, did, identity, authClaim, claimsTree, pubKey, hostURL, didOptions.AuthCredentialStatus, true)
 	if err != nil {
 		log.Error(ctx, "auth claim to model", "err", err)
 		return nil, nil, err
 	}
 
-	_, err = i.claimsRepository.Save(ctx, tx, authClaimModel)
+	authClaimModel.ID, err = i.claimsRepository.Save(ctx, tx, authClaimModel)
 	if err != nil {
 		return nil, nil, fmt.Errorf("can't save auth claim: %w", err)
 	}
 
+	identity.AuthClaimID = &authClaimModel.ID
 	if err = i.identityRepository.Save(ctx, tx, identity); err != nil {
 		if errors.Is(err, repositories.ErrDisplayNameDuplicated) {
 			return nil, nil, ErrIdentityDisplayNameDuplicated
@@ -995,6 +1446,17 @@ func (i *identity) GetFailedState(ctx context.Context, identifier w3c.DID) (*dom
 	return nil, nil
 }
 
+func (i *identity) GetTransactedState(ctx context.Context, identifier w3c.DID) (*domain.IdentityState, error) {
+	states, err := i.identityStateRepository.GetStatesByStatusAndIssuerID(ctx, i.storage.Pgx, domain.StatusTransacted, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("error getting transacted state: %w", err)
+	}
+	if len(states) > 0 {
+		return &states[0], nil
+	}
+	return nil, nil
+}
+
 func (i *identity) PublishGenesisStateToRHS(ctx context.Context, did *w3c.DID) error {
 	identity, err := i.identityRepository.GetByID(ctx, i.storage.Pgx, *did)
 	if err != nil {
@@ -1125,7 +1587,7 @@ func (i *identity) addGenesisClaimsToTree(ctx context.Context,
 	return identity, did, nil
 }
 
-func (i *identity) authClaimToModel(ctx context.Context, did *w3c.DID, identity *domain.Identity, authClaim *core.Claim, claimsTree *merkletree.MerkleTree, pubKey *babyjub.PublicKey, hostURL string, status verifiable.CredentialStatusType, isAuthInGenesis bool) (*domain.Claim, error) {
+func (i *identity) authClaimToModel(ctx context.Context, conn db.Querier, did *w3c.DID, identity *domain.Identity, authClaim *core.Claim, claimsTree *merkletree.MerkleTree, pubKey *babyjub.PublicKey, hostURL string, status verifiable.CredentialStatusType, isAuthInGenesis bool) (*domain.Claim, error) {
 	authClaimData := make(map[string]interface{})
 	authClaimData["x"] = pubKey.X.String()
 	authClaimData["y"] = pubKey.Y.String()
@@ -1163,7 +1625,7 @@ func (i *identity) authClaimToModel(ctx context.Context, did *w3c.DID, identity
 	}
 
 	authCred.ID = string(urn.FromUUID(authClaimID))
-	cs, err := i.revocationStatusResolver.GetCredentialRevocationStatus(ctx, *did, revNonce, *identity.State.State, status)
+	cs, err := i.revocationStatusResolver.GetCredentialRevocationStatus(ctx, conn, *did, revNonce, *identity.State.State, status)
 	if err != nil {
 		log.Error(ctx, "get credential status", "err", err)
 		return nil, err
@@ -1217,6 +1679,7 @@ func (i *identity) authClaimToModel(ctx context.Context, did *w3c.DID, identity
 
 	authClaimModel.Identifier = &identity.Identifier
 	authClaimModel.MtProof = true
+	authClaimModel.CreatedAt = time.Now()
 	return authClaimModel, nil
 }
 
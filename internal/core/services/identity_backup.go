@@ -0,0 +1,385 @@
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+const (
+	identityBackupSaltSize = 16
+	identityBackupKeySize  = 32 // one key signs the archive and another one encrypts the private keys
+	identityBackupScryptN  = 1 << 15
+	identityBackupScryptR  = 8
+	identityBackupScryptP  = 1
+)
+
+var (
+	ErrIdentityBackupPassphraseRequired = errors.New("a passphrase is required to sign and encrypt the backup")                 // ErrIdentityBackupPassphraseRequired means that the backup passphrase is empty
+	ErrInvalidIdentityBackup            = errors.New("invalid identity backup")                                                 // ErrInvalidIdentityBackup means that the archive can not be decoded
+	ErrIdentityBackupSignature          = errors.New("invalid identity backup signature, wrong passphrase or modified archive") // ErrIdentityBackupSignature means that the archive was not signed with the passphrase
+	ErrIdentityAlreadyExists            = errors.New("the identity already exists in this node")                                // ErrIdentityAlreadyExists means that the identity of the backup can not be restored because it is already here
+	ErrIdentityBackupStateMismatch      = errors.New("the merkle trees of the backup do not match the latest identity state")   // ErrIdentityBackupStateMismatch means that the restored merkle trees are corrupted
+)
+
+// identityBackupArchive is the signed envelope of a backup. Bundle is the gzipped json of the domain.IdentityBackup and
+// Signature its HMAC-SHA256 with a key derived from the passphrase and the salt.
+type identityBackupArchive struct {
+	Version   int    `json:"version"`
+	Salt      []byte `json:"salt"`
+	Bundle    []byte `json:"bundle"`
+	Signature []byte `json:"signature"`
+}
+
+type identityBackup struct {
+	identityRepository      ports.IndentityRepository
+	imtRepository           ports.IdentityMerkleTreeRepository
+	identityStateRepository ports.IdentityStateRepository
+	backupRepository        ports.IdentityBackupRepository
+	mtService               ports.MtService
+	kms                     kms.KMSType
+	storage                 *db.Storage
+}
+
+// NewIdentityBackup is the identity backup service constructor
+func NewIdentityBackup(keyStore kms.KMSType, identityRepository ports.IndentityRepository, imtRepository ports.IdentityMerkleTreeRepository, identityStateRepository ports.IdentityStateRepository, backupRepository ports.IdentityBackupRepository, mtService ports.MtService, storage *db.Storage) ports.IdentityBackupService {
+	return &identityBackup{
+		identityRepository:      identityRepository,
+		imtRepository:           imtRepository,
+		identityStateRepository: identityStateRepository,
+		backupRepository:        backupRepository,
+		mtService:               mtService,
+		kms:                     keyStore,
+		storage:                 storage,
+	}
+}
+
+// Export bundles the rows and merkle trees of the identity in an archive signed with the passphrase.
+// When req.IncludeKeys is set, the private keys of the identity are added encrypted with the passphrase. Only the
+// key providers that store the keys themselves (local storage) can export them.
+func (b *identityBackup) Export(ctx context.Context, did w3c.DID, req ports.IdentityBackupRequest) ([]byte, error) {
+	if req.Passphrase == "" {
+		return nil, ErrIdentityBackupPassphraseRequired
+	}
+	if _, err := b.identityRepository.GetByID(ctx, b.storage.Pgx, did); err != nil {
+		return nil, err
+	}
+
+	bundle := domain.IdentityBackup{
+		Version:    domain.IdentityBackupVersion,
+		Identifier: did.String(),
+		CreatedAt:  time.Now(),
+		Keys:       make([]domain.IdentityBackupKey, 0),
+	}
+	// a single snapshot, so the states, claims and trees are consistent if the publisher is running
+	err := b.storage.Pgx.BeginTxFunc(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		var err error
+		if bundle.Tables, err = b.backupRepository.GetTables(ctx, tx, did); err != nil {
+			return err
+		}
+		bundle.MerkleTrees, err = b.backupRepository.GetMerkleTrees(ctx, tx, did)
+		return err
+	})
+	if err != nil {
+		log.Error(ctx, "dumping identity", "err", err, "did", did)
+		return nil, err
+	}
+
+	salt := make([]byte, identityBackupSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	signingKey, encryptionKey, err := identityBackupKeys(req.Passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IncludeKeys {
+		keyIDs, err := b.kms.KeysByIdentity(ctx, did)
+		if err != nil {
+			log.Error(ctx, "loading identity keys", "err", err, "did", did)
+			return nil, err
+		}
+		for _, keyID := range keyIDs {
+			privateKey, err := b.kms.ExportPrivateKey(ctx, keyID)
+			if err != nil {
+				log.Error(ctx, "exporting identity key", "err", err, "keyID", keyID)
+				return nil, err
+			}
+			encrypted, err := encryptIdentityBackupKey(encryptionKey, privateKey)
+			if err != nil {
+				return nil, err
+			}
+			bundle.Keys = append(bundle.Keys, domain.IdentityBackupKey{Type: string(keyID.Type), ID: keyID.ID, PrivateKey: encrypted})
+		}
+	}
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if err := json.NewEncoder(zw).Encode(bundle); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(identityBackupArchive{
+		Version:   domain.IdentityBackupVersion,
+		Salt:      salt,
+		Bundle:    compressed.Bytes(),
+		Signature: signIdentityBackup(signingKey, compressed.Bytes()),
+	})
+}
+
+// Restore imports an archive created by Export. The identity must not exist in this node.
+// Everything is restored in a single transaction that is rolled back unless the merkle trees rebuilt from the
+// restored nodes hash to the latest identity state.
+func (b *identityBackup) Restore(ctx context.Context, archive []byte, passphrase string) (*w3c.DID, error) {
+	if passphrase == "" {
+		return nil, ErrIdentityBackupPassphraseRequired
+	}
+
+	var envelope identityBackupArchive
+	if err := json.Unmarshal(archive, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIdentityBackup, err)
+	}
+	if envelope.Version != domain.IdentityBackupVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidIdentityBackup, envelope.Version)
+	}
+	signingKey, encryptionKey, err := identityBackupKeys(passphrase, envelope.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(signIdentityBackup(signingKey, envelope.Bundle), envelope.Signature) {
+		return nil, ErrIdentityBackupSignature
+	}
+
+	bundle, err := decodeIdentityBackup(envelope.Bundle)
+	if err != nil {
+		return nil, err
+	}
+	did, err := w3c.ParseDID(bundle.Identifier)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIdentityBackup, err)
+	}
+
+	_, err = b.identityRepository.GetByID(ctx, b.storage.Pgx, *did)
+	if err == nil {
+		return nil, ErrIdentityAlreadyExists
+	}
+	if !errors.Is(err, repositories.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	err = b.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		for _, table := range bundle.Tables {
+			if err := b.backupRepository.RestoreTable(ctx, tx, *did, table); err != nil {
+				return err
+			}
+		}
+		for _, tree := range bundle.MerkleTrees {
+			imt, err := b.imtRepository.Save(ctx, tx, did.String(), tree.Type)
+			if err != nil {
+				return err
+			}
+			if err := b.backupRepository.RestoreMerkleTree(ctx, tx, imt.ID, tree); err != nil {
+				return err
+			}
+		}
+		if err := b.verifyState(ctx, tx, *did); err != nil {
+			return err
+		}
+
+		// keys are imported last, the key providers are not part of the transaction
+		for _, key := range bundle.Keys {
+			privateKey, err := decryptIdentityBackupKey(encryptionKey, key.PrivateKey)
+			if err != nil {
+				return err
+			}
+			if err := b.kms.ImportPrivateKey(ctx, kms.KeyID{Type: kms.KeyType(key.Type), ID: key.ID}, privateKey); err != nil {
+				log.Error(ctx, "importing identity key", "err", err, "keyID", key.ID)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, "restoring identity", "err", err, "did", did)
+		return nil, err
+	}
+	return did, nil
+}
+
+// verifyState checks every node reachable from the roots of the latest state and from the current roots, and that the
+// state is the hash of the state roots. The genesis state of an ETH identity is zero, so it can not be recomputed.
+func (b *identityBackup) verifyState(ctx context.Context, conn db.Querier, did w3c.DID) error {
+	state, err := b.identityStateRepository.GetLatestStateByIdentifier(ctx, conn, &did)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrIdentityBackupStateMismatch, err)
+	}
+	trees, err := b.mtService.GetIdentityMerkleTrees(ctx, conn, &did)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrIdentityBackupStateMismatch, err)
+	}
+
+	stateRoots := []*string{
+		MerkleTreeTypeClaims:      state.ClaimsTreeRoot,
+		MerkleTreeTypeRevocations: state.RevocationTreeRoot,
+		MerkleTreeTypeRoots:       state.RootOfRoots,
+	}
+	roots := make([]*big.Int, len(stateRoots))
+	for mtType, stateRoot := range stateRoots {
+		root := &merkletree.HashZero
+		if stateRoot != nil {
+			if root, err = merkletree.NewHashFromHex(*stateRoot); err != nil {
+				return fmt.Errorf("%w: %s", ErrIdentityBackupStateMismatch, err)
+			}
+		}
+		visited := make(map[merkletree.Hash]bool)
+		if err := verifyMerkleTreeNode(ctx, trees.Trees[mtType], root, visited); err != nil {
+			return err
+		}
+		if err := verifyMerkleTreeNode(ctx, trees.Trees[mtType], trees.Trees[mtType].Root(), visited); err != nil {
+			return err
+		}
+		roots[mtType] = root.BigInt()
+	}
+
+	if state.State == nil {
+		return ErrIdentityBackupStateMismatch
+	}
+	stateHash, err := merkletree.NewHashFromHex(*state.State)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrIdentityBackupStateMismatch, err)
+	}
+	if state.PreviousState == nil && stateHash.Equals(&merkletree.HashZero) {
+		return nil
+	}
+	recomputed, err := merkletree.HashElems(roots...)
+	if err != nil {
+		return err
+	}
+	if !recomputed.Equals(stateHash) {
+		return fmt.Errorf("%w: state %s, recomputed %s", ErrIdentityBackupStateMismatch, stateHash.Hex(), recomputed.Hex())
+	}
+	return nil
+}
+
+// verifyMerkleTreeNode checks that the node stored under key hashes to key, and the same for all its descendants
+func verifyMerkleTreeNode(ctx context.Context, tree *merkletree.MerkleTree, key *merkletree.Hash, visited map[merkletree.Hash]bool) error {
+	if visited[*key] {
+		return nil
+	}
+	node, err := tree.GetNode(ctx, key)
+	if err != nil {
+		return fmt.Errorf("%w: node %s: %s", ErrIdentityBackupStateMismatch, key.Hex(), err)
+	}
+	recomputed, err := node.Key()
+	if err != nil {
+		return err
+	}
+	if !recomputed.Equals(key) {
+		return fmt.Errorf("%w: node %s hashes to %s", ErrIdentityBackupStateMismatch, key.Hex(), recomputed.Hex())
+	}
+	visited[*key] = true
+
+	if node.Type == merkletree.NodeTypeMiddle {
+		if err := verifyMerkleTreeNode(ctx, tree, node.ChildL, visited); err != nil {
+			return err
+		}
+		return verifyMerkleTreeNode(ctx, tree, node.ChildR, visited)
+	}
+	return nil
+}
+
+func decodeIdentityBackup(compressed []byte) (*domain.IdentityBackup, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIdentityBackup, err)
+	}
+	var bundle domain.IdentityBackup
+	if err := json.NewDecoder(zr).Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIdentityBackup, err)
+	}
+	if bundle.Version != domain.IdentityBackupVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidIdentityBackup, bundle.Version)
+	}
+	return &bundle, nil
+}
+
+// identityBackupKeys derives the signing and encryption keys from the passphrase
+func identityBackupKeys(passphrase string, salt []byte) ([]byte, []byte, error) {
+	if len(salt) != identityBackupSaltSize {
+		return nil, nil, fmt.Errorf("%w: wrong salt size", ErrInvalidIdentityBackup)
+	}
+	keys, err := scrypt.Key([]byte(passphrase), salt, identityBackupScryptN, identityBackupScryptR, identityBackupScryptP, 2*identityBackupKeySize)
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys[:identityBackupKeySize], keys[identityBackupKeySize:], nil
+}
+
+func signIdentityBackup(key []byte, bundle []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(bundle)
+	return mac.Sum(nil)
+}
+
+// encryptIdentityBackupKey encrypts the private key with AES-256-GCM. The nonce is prepended to the ciphertext.
+func encryptIdentityBackupKey(key []byte, privateKey []byte) ([]byte, error) {
+	gcm, err := identityBackupCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, privateKey, nil), nil
+}
+
+func decryptIdentityBackupKey(key []byte, encrypted []byte) ([]byte, error) {
+	gcm, err := identityBackupCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted) < gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: encrypted key too short", ErrInvalidIdentityBackup)
+	}
+	privateKey, err := gcm.Open(nil, encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIdentityBackup, err)
+	}
+	return privateKey, nil
+}
+
+func identityBackupCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-merkletree-sql/v2/db/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/network"
+	"github.com/polygonid/sh-id-platform/internal/pubsub"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/internal/reversehash"
+	"github.com/polygonid/sh-id-platform/internal/revocationstatus"
+)
+
+func Test_identityBackup_ExportAndRestore(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaim()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := NewIdentityMerkleTrees(mtRepo)
+	connectionsRepository := repositories.NewConnection()
+
+	reader := common.CreateFile(t)
+	networkResolver, err := network.NewResolver(ctx, cfg, keyStore, reader)
+	require.NoError(t, err)
+
+	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
+	identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactory, revocationStatusResolver)
+	backupService := NewIdentityBackup(keyStore, identityRepo, mtRepo, identityStateRepo, repositories.NewIdentityBackup(), mtService, storage)
+
+	identity, err := identityService.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
+	require.NoError(t, err)
+	did, err := w3c.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	const passphrase = "correct horse battery staple"
+	archive, err := backupService.Export(ctx, *did, ports.IdentityBackupRequest{Passphrase: passphrase})
+	require.NoError(t, err)
+
+	t.Run("should require a passphrase", func(t *testing.T) {
+		_, err := backupService.Export(ctx, *did, ports.IdentityBackupRequest{})
+		assert.ErrorIs(t, err, ErrIdentityBackupPassphraseRequired)
+	})
+
+	t.Run("should bundle the identity rows and merkle trees", func(t *testing.T) {
+		var envelope identityBackupArchive
+		require.NoError(t, json.Unmarshal(archive, &envelope))
+		bundle, err := decodeIdentityBackup(envelope.Bundle)
+		require.NoError(t, err)
+		assert.Equal(t, did.String(), bundle.Identifier)
+		assert.Len(t, bundle.MerkleTrees, mtTypesCount)
+		assert.Empty(t, bundle.Keys)
+		rows := make(map[string]int)
+		for _, table := range bundle.Tables {
+			rows[table.Name] = len(table.Rows)
+		}
+		assert.Equal(t, 1, rows["identities"])
+		assert.Equal(t, 1, rows["identity_states"])
+		assert.Equal(t, 1, rows["claims"])
+	})
+
+	t.Run("should reject a wrong passphrase", func(t *testing.T) {
+		_, err := backupService.Restore(ctx, archive, "wrong passphrase")
+		assert.ErrorIs(t, err, ErrIdentityBackupSignature)
+	})
+
+	t.Run("should reject a modified archive", func(t *testing.T) {
+		var envelope identityBackupArchive
+		require.NoError(t, json.Unmarshal(archive, &envelope))
+		envelope.Bundle[len(envelope.Bundle)-1] ^= 0xff
+		modified, err := json.Marshal(envelope)
+		require.NoError(t, err)
+		_, err = backupService.Restore(ctx, modified, passphrase)
+		assert.ErrorIs(t, err, ErrIdentityBackupSignature)
+	})
+
+	t.Run("should not restore an identity that exists", func(t *testing.T) {
+		_, err := backupService.Restore(ctx, archive, passphrase)
+		assert.ErrorIs(t, err, ErrIdentityAlreadyExists)
+	})
+
+	t.Run("should verify the merkle trees against the latest state", func(t *testing.T) {
+		backup, ok := backupService.(*identityBackup)
+		require.True(t, ok)
+		assert.NoError(t, backup.verifyState(ctx, storage.Pgx, *did))
+	})
+}
+
+func Test_verifyMerkleTreeNode(t *testing.T) {
+	ctx := context.Background()
+	treeStorage := memory.NewMemoryStorage()
+	tree, err := merkletree.NewMerkleTree(ctx, treeStorage, mtDepth)
+	require.NoError(t, err)
+	for i := int64(1); i <= 10; i++ {
+		require.NoError(t, tree.Add(ctx, big.NewInt(i), big.NewInt(i*100)))
+	}
+
+	t.Run("should accept a consistent tree", func(t *testing.T) {
+		assert.NoError(t, verifyMerkleTreeNode(ctx, tree, tree.Root(), make(map[merkletree.Hash]bool)))
+	})
+
+	t.Run("should accept an empty tree", func(t *testing.T) {
+		assert.NoError(t, verifyMerkleTreeNode(ctx, tree, &merkletree.HashZero, make(map[merkletree.Hash]bool)))
+	})
+
+	t.Run("should reject a root that is not stored", func(t *testing.T) {
+		root, err := merkletree.NewHashFromBigInt(big.NewInt(42))
+		require.NoError(t, err)
+		assert.ErrorIs(t, verifyMerkleTreeNode(ctx, tree, root, make(map[merkletree.Hash]bool)), ErrIdentityBackupStateMismatch)
+	})
+
+	t.Run("should reject a modified leaf", func(t *testing.T) {
+		leafKey, err := merkletree.LeafKey(mustHash(t, 1), mustHash(t, 100))
+		require.NoError(t, err)
+		require.NoError(t, treeStorage.Put(ctx, leafKey[:], merkletree.NewNodeLeaf(mustHash(t, 1), mustHash(t, 101))))
+		assert.ErrorIs(t, verifyMerkleTreeNode(ctx, tree, tree.Root(), make(map[merkletree.Hash]bool)), ErrIdentityBackupStateMismatch)
+	})
+}
+
+func mustHash(t *testing.T, i int64) *merkletree.Hash {
+	t.Helper()
+	h, err := merkletree.NewHashFromBigInt(big.NewInt(i))
+	require.NoError(t, err)
+	return h
+}
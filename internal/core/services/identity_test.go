@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/iden3/go-iden3-core/v2/w3c"
 	"github.com/iden3/go-schema-processor/v2/verifiable"
 	"github.com/iden3/iden3comm/v2"
@@ -50,7 +51,7 @@ func Test_identity_CreateIdentity(t *testing.T) {
 	require.NoError(t, err)
 
 	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
-	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 	identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactory, revocationStatusResolver)
 
 	type testConfig struct {
@@ -130,7 +131,7 @@ func Test_identity_CreateIdentityWithRHSNone(t *testing.T) {
 			ID:   "pbkey",
 		}).Return(rhsPublishers, nil)
 
-		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 		identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactoryMock, revocationStatusResolver)
 		identity, err := identityService.Create(ctx, cfg.ServerUrl, &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
 		assert.NoError(t, err)
@@ -157,7 +158,7 @@ func Test_identity_CreateIdentityWithRHSNone(t *testing.T) {
 			ID:   "pbkey",
 		}).Return(rhsPublishers, nil)
 
-		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 		identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactoryMock, revocationStatusResolver)
 		_, err := identityService.Create(ctx, cfg.ServerUrl, &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
 		assert.Error(t, err)
@@ -166,7 +167,7 @@ func Test_identity_CreateIdentityWithRHSNone(t *testing.T) {
 
 	t.Run("should create ETH identity with RHS", func(t *testing.T) {
 		rhsFactoryMock := reversehash.NewMockFactory(t)
-		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 		identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactoryMock, revocationStatusResolver)
 		identity, err := identityService.Create(ctx, cfg.ServerUrl, &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: ETH})
 		assert.NoError(t, err)
@@ -194,7 +195,7 @@ func Test_identity_CreateIdentityWithRHSNone(t *testing.T) {
 			ID:   "pbkey",
 		}).Return(rhsPublishers, nil)
 
-		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 		identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactoryMock, revocationStatusResolver)
 		identity, err := identityService.Create(ctx, cfg.ServerUrl, &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
 		assert.NoError(t, err)
@@ -222,7 +223,7 @@ func Test_identity_CreateIdentityWithRHSNone(t *testing.T) {
 			ID:   "pbkey",
 		}).Return(rhsPublishers, nil)
 
-		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 		identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactoryMock, revocationStatusResolver)
 		identity, err := identityService.Create(ctx, cfg.ServerUrl, &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ, AuthCredentialStatus: verifiable.Iden3commRevocationStatusV1})
 		assert.NoError(t, err)
@@ -264,7 +265,7 @@ func Test_identity_CreateIdentityWithRHSOffChain(t *testing.T) {
 			ID:   "pbkey",
 		}).Return(rhsPublishers, nil)
 
-		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 		identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactoryMock, revocationStatusResolver)
 		identity, err := identityService.Create(ctx, cfg.ServerUrl, &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
 		assert.NoError(t, err)
@@ -291,7 +292,7 @@ func Test_identity_CreateIdentityWithRHSOffChain(t *testing.T) {
 			ID:   "pbkey",
 		}).Return(rhsPublishers, nil)
 
-		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 		identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactoryMock, revocationStatusResolver)
 		_, err := identityService.Create(ctx, cfg.ServerUrl, &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
 		assert.Error(t, err)
@@ -300,7 +301,7 @@ func Test_identity_CreateIdentityWithRHSOffChain(t *testing.T) {
 
 	t.Run("should create ETH identity with RHS", func(t *testing.T) {
 		rhsFactoryMock := reversehash.NewMockFactory(t)
-		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 		identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactoryMock, revocationStatusResolver)
 		identity, err := identityService.Create(ctx, cfg.ServerUrl, &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: ETH})
 		assert.NoError(t, err)
@@ -328,7 +329,7 @@ func Test_identity_CreateIdentityWithRHSOffChain(t *testing.T) {
 			ID:   "pbkey",
 		}).Return(rhsPublishers, nil)
 
-		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 		identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactoryMock, revocationStatusResolver)
 		identity, err := identityService.Create(ctx, cfg.ServerUrl, &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
 		assert.NoError(t, err)
@@ -356,7 +357,7 @@ func Test_identity_CreateIdentityWithRHSOffChain(t *testing.T) {
 			ID:   "pbkey",
 		}).Return(rhsPublishers, nil)
 
-		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+		revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 		identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactoryMock, revocationStatusResolver)
 		identity, err := identityService.Create(ctx, cfg.ServerUrl, &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ, AuthCredentialStatus: verifiable.Iden3ReverseSparseMerkleTreeProof})
 		assert.NoError(t, err)
@@ -387,7 +388,7 @@ func Test_identity_UpdateState(t *testing.T) {
 	require.NoError(t, err)
 
 	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
-	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 	identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactory, revocationStatusResolver)
 
 	mediaTypeManager := NewMediaTypeManager(
@@ -580,7 +581,7 @@ func Test_identity_GetByDID(t *testing.T) {
 	require.NoError(t, err)
 
 	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
-	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 	identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactory, revocationStatusResolver)
 	identity, err := identityService.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
 	assert.NoError(t, err)
@@ -637,7 +638,7 @@ func Test_identity_GetLatestStateByID(t *testing.T) {
 	require.NoError(t, err)
 
 	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
-	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
 	identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactory, revocationStatusResolver)
 	identity, err := identityService.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
 	assert.NoError(t, err)
@@ -688,3 +689,362 @@ func Test_identity_GetLatestStateByID(t *testing.T) {
 		})
 	}
 }
+
+func Test_identity_RotateKey(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaim()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := NewIdentityMerkleTrees(mtRepo)
+	connectionsRepository := repositories.NewConnection()
+
+	reader := common.CreateFile(t)
+	networkResolver, err := network.NewResolver(ctx, cfg, keyStore, reader)
+	require.NoError(t, err)
+
+	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
+	identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactory, revocationStatusResolver)
+	claimsService := NewClaim(claimsRepo, identityService, nil, mtService, identityStateRepo, docLoader, storage, cfg.ServerUrl, pubsub.NewMock(), ipfsGateway, revocationStatusResolver, nil, cfg.UniversalLinks)
+
+	identity, err := identityService.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
+	require.NoError(t, err)
+	did, err := w3c.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	previousAuthClaim, err := claimsService.GetAuthClaim(ctx, did)
+	require.NoError(t, err)
+	previousKeyID, err := identityService.GetKeyIDFromAuthClaim(ctx, previousAuthClaim)
+	require.NoError(t, err)
+
+	var rotatedAuthClaim *domain.Claim
+	t.Run("should rotate the key", func(t *testing.T) {
+		authClaim, err := identityService.RotateKey(ctx, *did, nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, previousAuthClaim.ID, authClaim.ID)
+		rotatedAuthClaim = authClaim
+
+		keyID, err := identityService.GetKeyIDFromAuthClaim(ctx, authClaim)
+		require.NoError(t, err)
+		assert.Equal(t, kms.KeyTypeBabyJubJub, keyID.Type)
+		assert.NotEqual(t, previousKeyID.ID, keyID.ID)
+
+		revoked, err := claimsRepo.GetByIdAndIssuer(ctx, storage.Pgx, did, previousAuthClaim.ID)
+		require.NoError(t, err)
+		assert.True(t, revoked.Revoked)
+
+		rotated, err := identityService.GetByDID(ctx, *did)
+		require.NoError(t, err)
+		require.NotNil(t, rotated.AuthClaimID)
+		assert.Equal(t, authClaim.ID, *rotated.AuthClaimID)
+	})
+
+	t.Run("should keep signing with the previous key until the rotation is confirmed", func(t *testing.T) {
+		currentAuthClaim, err := claimsService.GetAuthClaim(ctx, did)
+		require.NoError(t, err)
+		assert.Equal(t, previousAuthClaim.ID, currentAuthClaim.ID)
+	})
+
+	t.Run("should not rotate a key that is not published", func(t *testing.T) {
+		_, err := identityService.RotateKey(ctx, *did, nil)
+		assert.ErrorIs(t, err, ErrAuthClaimNotPublished)
+	})
+
+	t.Run("should sign the state transition with the revoked key", func(t *testing.T) {
+		state, err := identityService.UpdateState(ctx, *did)
+		require.NoError(t, err)
+
+		authClaim, err := claimsService.GetAuthClaimForPublishing(ctx, did, *state.State)
+		require.NoError(t, err)
+		assert.Equal(t, previousAuthClaim.ID, authClaim.ID)
+
+		currentAuthClaim, err := claimsService.GetAuthClaim(ctx, did)
+		require.NoError(t, err)
+		assert.Equal(t, previousAuthClaim.ID, currentAuthClaim.ID)
+
+		state.Status = domain.StatusConfirmed
+		_, err = identityStateRepo.UpdateState(ctx, storage.Pgx, state)
+		require.NoError(t, err)
+		rotatedAuthClaim.MTPProof = previousAuthClaim.MTPProof
+		_, err = claimsRepo.UpdateClaimMTP(ctx, storage.Pgx, rotatedAuthClaim)
+		require.NoError(t, err)
+
+		currentAuthClaim, err = claimsService.GetAuthClaim(ctx, did)
+		require.NoError(t, err)
+		assert.Equal(t, rotatedAuthClaim.ID, currentAuthClaim.ID)
+	})
+}
+
+func Test_identity_AddAuthKey(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaim()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := NewIdentityMerkleTrees(mtRepo)
+	connectionsRepository := repositories.NewConnection()
+
+	reader := common.CreateFile(t)
+	networkResolver, err := network.NewResolver(ctx, cfg, keyStore, reader)
+	require.NoError(t, err)
+
+	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
+	identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactory, revocationStatusResolver)
+	claimsService := NewClaim(claimsRepo, identityService, nil, mtService, identityStateRepo, docLoader, storage, cfg.ServerUrl, pubsub.NewMock(), ipfsGateway, revocationStatusResolver, nil, cfg.UniversalLinks)
+
+	identity, err := identityService.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
+	require.NoError(t, err)
+	did, err := w3c.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	genesisAuthClaim, err := claimsService.GetAuthClaim(ctx, did)
+	require.NoError(t, err)
+
+	authClaim, err := identityService.AddAuthKey(ctx, *did)
+	require.NoError(t, err)
+
+	t.Run("should list all the auth claims", func(t *testing.T) {
+		authClaims, err := claimsService.GetAuthClaims(ctx, did)
+		require.NoError(t, err)
+		require.Len(t, authClaims, 2)
+		assert.Equal(t, genesisAuthClaim.ID, authClaims[0].ID)
+		assert.Equal(t, authClaim.ID, authClaims[1].ID)
+
+		genesisKeyID, err := identityService.GetKeyIDFromAuthClaim(ctx, authClaims[0])
+		require.NoError(t, err)
+		keyID, err := identityService.GetKeyIDFromAuthClaim(ctx, authClaims[1])
+		require.NoError(t, err)
+		assert.NotEqual(t, genesisKeyID.ID, keyID.ID)
+	})
+
+	t.Run("should keep the genesis auth claim as the default one", func(t *testing.T) {
+		defaultAuthClaim, err := claimsService.GetAuthClaim(ctx, did)
+		require.NoError(t, err)
+		assert.Equal(t, genesisAuthClaim.ID, defaultAuthClaim.ID)
+	})
+
+	schema := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	credentialSubject := map[string]any{
+		"id":           "did:polygonid:polygon:amoy:2qSuD8ZDpsAG3s8WJjwzqhMsqGLz8RUG1BHVUe3Gwu",
+		"birthday":     19960424,
+		"documentType": 2,
+	}
+	newRequest := func(authClaimID *uuid.UUID) *ports.CreateClaimRequest {
+		req := ports.NewCreateClaimRequest(did, nil, schema, credentialSubject, nil, "KYCAgeCredential", nil, nil, common.ToPointer("index"),
+			ports.ClaimRequestProofs{BJJSignatureProof2021: true}, nil, false, verifiable.Iden3commRevocationStatusV1, nil, nil, nil)
+		req.AuthClaimID = authClaimID
+		return req
+	}
+
+	t.Run("should sign with the given auth claim", func(t *testing.T) {
+		claim, err := claimsService.CreateCredential(ctx, storage.Pgx, newRequest(&genesisAuthClaim.ID))
+		require.NoError(t, err)
+		var proof verifiable.BJJSignatureProof2021
+		require.NoError(t, claim.SignatureProof.AssignTo(&proof))
+		authCoreClaim, err := genesisAuthClaim.CoreClaim.Get().Hex()
+		require.NoError(t, err)
+		assert.Equal(t, authCoreClaim, proof.IssuerData.AuthCoreClaim)
+	})
+
+	t.Run("should not sign with an auth claim that is not published", func(t *testing.T) {
+		_, err := claimsService.CreateCredential(ctx, storage.Pgx, newRequest(&authClaim.ID))
+		assert.ErrorIs(t, err, ErrAssigningMTPProof)
+	})
+
+	t.Run("should not sign with an unknown auth claim", func(t *testing.T) {
+		_, err := claimsService.CreateCredential(ctx, storage.Pgx, newRequest(common.ToPointer(uuid.New())))
+		assert.ErrorIs(t, err, ErrInvalidAuthClaim)
+	})
+
+	genesisAuthCoreClaim, err := genesisAuthClaim.CoreClaim.Get().Hex()
+	require.NoError(t, err)
+	signingAuthCoreClaim := func(t *testing.T, claim *domain.Claim) string {
+		var proof verifiable.BJJSignatureProof2021
+		require.NoError(t, claim.SignatureProof.AssignTo(&proof))
+		return proof.IssuerData.AuthCoreClaim
+	}
+
+	t.Run("should keep signing with the primary auth claim once the new one is published", func(t *testing.T) {
+		authClaim.MTPProof = genesisAuthClaim.MTPProof
+		_, err := claimsRepo.UpdateClaimMTP(ctx, storage.Pgx, authClaim)
+		require.NoError(t, err)
+
+		claim, err := claimsService.CreateCredential(ctx, storage.Pgx, newRequest(nil))
+		require.NoError(t, err)
+		assert.Equal(t, genesisAuthCoreClaim, signingAuthCoreClaim(t, claim))
+	})
+
+	t.Run("should rotate the primary auth claim by default", func(t *testing.T) {
+		rotatedAuthClaim, err := identityService.RotateKey(ctx, *did, nil)
+		require.NoError(t, err)
+
+		revoked, err := claimsRepo.GetByIdAndIssuer(ctx, storage.Pgx, did, genesisAuthClaim.ID)
+		require.NoError(t, err)
+		assert.True(t, revoked.Revoked)
+		added, err := claimsRepo.GetByIdAndIssuer(ctx, storage.Pgx, did, authClaim.ID)
+		require.NoError(t, err)
+		assert.False(t, added.Revoked)
+
+		identity, err := identityService.GetByDID(ctx, *did)
+		require.NoError(t, err)
+		require.NotNil(t, identity.AuthClaimID)
+		assert.Equal(t, rotatedAuthClaim.ID, *identity.AuthClaimID)
+
+		// the rotated key signs until the state transition is confirmed
+		claim, err := claimsService.CreateCredential(ctx, storage.Pgx, newRequest(nil))
+		require.NoError(t, err)
+		assert.Equal(t, genesisAuthCoreClaim, signingAuthCoreClaim(t, claim))
+	})
+
+	t.Run("should require the auth claim to rotate if the identity has no primary one and several keys", func(t *testing.T) {
+		identity, err := identityService.GetByDID(ctx, *did)
+		require.NoError(t, err)
+		identity.AuthClaimID = nil
+		require.NoError(t, identityRepo.UpdateAuthClaimID(ctx, storage.Pgx, identity))
+
+		_, err = identityService.RotateKey(ctx, *did, nil)
+		assert.ErrorIs(t, err, ErrInvalidAuthClaim)
+	})
+}
+
+func Test_identity_Deactivate(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaim()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := NewIdentityMerkleTrees(mtRepo)
+	connectionsRepository := repositories.NewConnection()
+
+	reader := common.CreateFile(t)
+	networkResolver, err := network.NewResolver(ctx, cfg, keyStore, reader)
+	require.NoError(t, err)
+
+	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
+	identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactory, revocationStatusResolver)
+	claimsService := NewClaim(claimsRepo, identityService, nil, mtService, identityStateRepo, docLoader, storage, cfg.ServerUrl, pubsub.NewMock(), ipfsGateway, revocationStatusResolver, nil, cfg.UniversalLinks)
+
+	identity, err := identityService.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
+	require.NoError(t, err)
+	did, err := w3c.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	t.Run("should deactivate the identity and keep the auth credential", func(t *testing.T) {
+		revoked, err := identityService.Deactivate(ctx, *did, ports.DeactivateIdentityRequest{RevokeCredentials: true})
+		require.NoError(t, err)
+		assert.Empty(t, revoked)
+
+		deactivated, err := identityService.GetByDID(ctx, *did)
+		require.NoError(t, err)
+		assert.Equal(t, domain.IdentityDeactivated, deactivated.LifecycleStatus())
+
+		authClaim, err := claimsService.GetAuthClaim(ctx, did)
+		require.NoError(t, err)
+		assert.False(t, authClaim.Revoked)
+	})
+
+	t.Run("should not deactivate the identity twice", func(t *testing.T) {
+		_, err := identityService.Deactivate(ctx, *did, ports.DeactivateIdentityRequest{})
+		assert.ErrorIs(t, err, ErrIdentityDeactivated)
+	})
+
+	t.Run("should not manage the keys of a deactivated identity", func(t *testing.T) {
+		_, err := identityService.AddAuthKey(ctx, *did)
+		assert.ErrorIs(t, err, ErrIdentityDeactivated)
+		_, err = identityService.RotateKey(ctx, *did, nil)
+		assert.ErrorIs(t, err, ErrIdentityDeactivated)
+	})
+
+	t.Run("should archive a deactivated identity", func(t *testing.T) {
+		_, err := identityService.Deactivate(ctx, *did, ports.DeactivateIdentityRequest{Archive: true})
+		require.NoError(t, err)
+
+		identities, _, err := identityService.Get(ctx, &ports.GetIdentitiesRequest{})
+		require.NoError(t, err)
+		for _, identity := range identities {
+			assert.NotEqual(t, did.String(), identity.Identifier)
+		}
+
+		_, err = identityService.Deactivate(ctx, *did, ports.DeactivateIdentityRequest{Archive: true})
+		assert.ErrorIs(t, err, ErrIdentityDeactivated)
+	})
+}
+
+func Test_identity_DeactivatedStatePublishing(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaim()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := NewIdentityMerkleTrees(mtRepo)
+	connectionsRepository := repositories.NewConnection()
+
+	reader := common.CreateFile(t)
+	networkResolver, err := network.NewResolver(ctx, cfg, keyStore, reader)
+	require.NoError(t, err)
+
+	rhsFactory := reversehash.NewFactory(*networkResolver, reversehash.DefaultRHSTimeOut)
+	revocationStatusResolver := revocationstatus.NewRevocationStatusResolver(*networkResolver, repositories.NewStatusList())
+	identityService := NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, nil, claimsRepo, revocationRepository, connectionsRepository, storage, nil, nil, pubsub.NewMock(), *networkResolver, rhsFactory, revocationStatusResolver)
+	claimsService := NewClaim(claimsRepo, identityService, nil, mtService, identityStateRepo, docLoader, storage, cfg.ServerUrl, pubsub.NewMock(), ipfsGateway, revocationStatusResolver, nil, cfg.UniversalLinks)
+
+	identity, err := identityService.Create(ctx, "polygon-test", &ports.DIDCreationOptions{Method: method, Blockchain: blockchain, Network: net, KeyType: BJJ})
+	require.NoError(t, err)
+	did, err := w3c.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	schema := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	credentialSubject := map[string]any{
+		"id":           "did:polygonid:polygon:amoy:2qSuD8ZDpsAG3s8WJjwzqhMsqGLz8RUG1BHVUe3Gwu",
+		"birthday":     19960424,
+		"documentType": 2,
+	}
+	newRequest := func() *ports.CreateClaimRequest {
+		return ports.NewCreateClaimRequest(did, nil, schema, credentialSubject, common.ToPointer(time.Now().Add(time.Hour)),
+			"KYCAgeCredential", nil, nil, common.ToPointer("index"),
+			ports.ClaimRequestProofs{BJJSignatureProof2021: true, Iden3SparseMerkleTreeProof: true}, nil, false,
+			verifiable.Iden3commRevocationStatusV1, nil, nil, nil)
+	}
+
+	// issued before the deactivation and never published
+	credential, err := claimsService.Save(ctx, newRequest())
+	require.NoError(t, err)
+	_, err = identityService.Deactivate(ctx, *did, ports.DeactivateIdentityRequest{})
+	require.NoError(t, err)
+
+	t.Run("should not issue credentials", func(t *testing.T) {
+		_, err := claimsService.Save(ctx, newRequest())
+		assert.ErrorIs(t, err, ErrIdentityDeactivated)
+	})
+
+	t.Run("should not publish the credentials issued", func(t *testing.T) {
+		pending, err := identityService.HasUnprocessedStatesByID(ctx, *did)
+		require.NoError(t, err)
+		assert.False(t, pending)
+		_, err = identityService.UpdateState(ctx, *did)
+		assert.ErrorIs(t, err, ErrIdentityDeactivated)
+	})
+
+	t.Run("should revoke and publish the revocation", func(t *testing.T) {
+		require.NoError(t, claimsService.Revoke(ctx, *did, uint64(credential.RevNonce), "compromised"))
+		issuers, err := identityService.GetUnprocessedIssuersIDs(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, issuers, did)
+
+		state, err := identityService.UpdateState(ctx, *did)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusCreated, state.Status)
+
+		unpublished, err := claimsRepo.GetAllByState(ctx, storage.Pgx, did, nil)
+		require.NoError(t, err)
+		require.Len(t, unpublished, 1)
+		assert.Equal(t, credential.ID, unpublished[0].ID)
+	})
+}
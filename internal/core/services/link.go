@@ -110,7 +110,13 @@ func (ls *Link) Save(
 	}
 
 	link := domain.NewLink(did, maxIssuance, validUntil, schemaID, credentialExpiration, credentialSignatureProof, credentialMTPProof, credentialSubject, refreshService, displayMethod)
-	_, err = ls.linkRepository.Save(ctx, ls.storage.Pgx, link)
+	err = ls.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := ls.identityService.CheckActive(ctx, tx, did); err != nil {
+			return err
+		}
+		_, err := ls.linkRepository.Save(ctx, tx, link)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -291,21 +297,22 @@ func (ls *Link) IssueOrFetchClaim(ctx context.Context, issuerDID w3c.DID, userDI
 			link.DisplayMethod,
 		)
 
-		credentialIssued, err = ls.claimsService.CreateCredential(ctx, claimReq)
-		if err != nil {
-			log.Error(ctx, "cannot create the claim", "err", err.Error())
-			return nil, err
-		}
-
 		err = ls.storage.Pgx.BeginFunc(ctx,
 			func(tx pgx.Tx) error {
+				var err error
+				credentialIssued, err = ls.claimsService.CreateCredential(ctx, tx, claimReq)
+				if err != nil {
+					log.Error(ctx, "cannot create the claim", "err", err.Error())
+					return err
+				}
+
 				link.IssuedClaims += 1
-				_, err := ls.linkRepository.Save(ctx, ls.storage.Pgx, link)
+				_, err = ls.linkRepository.Save(ctx, tx, link)
 				if err != nil {
 					return err
 				}
 
-				credentialIssuedID, err = ls.claimRepository.Save(ctx, ls.storage.Pgx, credentialIssued)
+				credentialIssuedID, err = ls.claimRepository.Save(ctx, tx, credentialIssued)
 				if err != nil {
 					return err
 				}
@@ -392,7 +399,7 @@ func (ls *Link) Validate(ctx context.Context, link *domain.Link) error {
 		return ErrLinkInactive
 	}
 
-	return nil
+	return ls.identityService.CheckActive(ctx, ls.storage.Pgx, *link.IssuerCoreDID())
 }
 
 func (ls *Link) validateCredentialSubjectAgainstSchema(ctx context.Context, cSubject domain.CredentialSubject, schemaDB *domain.Schema) error {
@@ -51,6 +51,15 @@ func (n *notification) SendRevokeCredentialNotification(ctx context.Context, pay
 	return n.sendRevokeCredentialNotification(ctx, rEvent.State)
 }
 
+func (n *notification) SendExpiredCredentialNotification(ctx context.Context, payload pubsub.Message) error {
+	var eEvent event.ExpiredCredentials
+	if err := eEvent.Unmarshal(payload); err != nil {
+		return errors.New("sendExpiredCredentialNotification unexpected data type")
+	}
+
+	return n.sendExpiredCredentialNotification(ctx, eEvent.IssuerID, eEvent.CredentialIDs)
+}
+
 func (n *notification) SendCreateConnectionNotification(ctx context.Context, e pubsub.Message) error {
 	var cEvent event.CreateConnection
 	if err := cEvent.Unmarshal(e); err != nil {
@@ -105,6 +114,73 @@ func (n *notification) sendRevokeCredentialNotification(ctx context.Context, sta
 	return nil
 }
 
+// sendExpiredCredentialNotification sends a status update to the holder of each expired credential.
+// Credentials revoked since they expired are skipped, as their holders get a revocation notification.
+// A failure with one credential does not prevent the holders of the others from being notified.
+func (n *notification) sendExpiredCredentialNotification(ctx context.Context, issuerID string, credIDs []string) error {
+	issuerDID, err := w3c.ParseDID(issuerID)
+	if err != nil {
+		log.Error(ctx, "sendExpiredCredentialNotification: failed to parse issuerID", "err", err.Error(), "issuerID", issuerID)
+		return err
+	}
+
+	var errs []error
+	for _, credID := range credIDs {
+		if err := n.sendExpiredCredentialNotificationToUser(ctx, issuerDID, credID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (n *notification) sendExpiredCredentialNotificationToUser(ctx context.Context, issuerDID *w3c.DID, credID string) error {
+	credUUID, err := uuid.Parse(credID)
+	if err != nil {
+		log.Error(ctx, "sendExpiredCredentialNotification: failed to parse credID", "err", err.Error(), "issuerID", issuerDID, "credID", credID)
+		return err
+	}
+
+	credential, err := n.credService.GetByID(ctx, issuerDID, credUUID)
+	if err != nil {
+		log.Warn(ctx, "sendExpiredCredentialNotification: get credential", "err", err.Error(), "issuerID", issuerDID, "credID", credID)
+		return err
+	}
+	if credential.Revoked {
+		log.Info(ctx, "sendExpiredCredentialNotification: skipping revoked credential", "issuerID", issuerDID, "credID", credID)
+		return nil
+	}
+
+	userDID, err := w3c.ParseDID(credential.OtherIdentifier)
+	if err != nil {
+		log.Error(ctx, "sendExpiredCredentialNotification: failed to parse credential userID", "err", err.Error(), "issuerID", issuerDID, "credID", credID)
+		return err
+	}
+
+	connection, err := n.connService.GetByUserID(ctx, *issuerDID, *userDID)
+	if err != nil {
+		log.Warn(ctx, "sendExpiredCredentialNotification: get connection", "err", err.Error(), "issuerID", issuerDID, "credID", credID)
+		return err
+	}
+
+	msgBytes, subjectDIDDoc, err := getExpiredCredentialData(connection, credential)
+	if err != nil {
+		log.Error(ctx, "sendExpiredCredentialNotification: getExpiredCredentialData", "err", err.Error(), "issuerID", issuerDID, "credID", credID)
+		return err
+	}
+
+	log.Info(ctx, "sendExpiredCredentialNotification: sending notification", "issuerID", issuerDID, "subjectDIDDoc", subjectDIDDoc.ID)
+	err = n.send(ctx, msgBytes, subjectDIDDoc)
+	if err != nil {
+		log.Error(ctx, "sendExpiredCredentialNotification: send notification", "err", err.Error(), "issuerID", issuerDID, "credID", credID)
+		return err
+	}
+
+	return nil
+}
+
+// sendCreateCredentialNotification sends an offer with the given credentials to their holders.
+// Credentials are grouped by holder, so a batch of credentials for several users results in one offer per user.
 func (n *notification) sendCreateCredentialNotification(ctx context.Context, issuerID string, credIDs []string) error {
 	issuerDID, err := w3c.ParseDID(issuerID)
 	if err != nil {
@@ -112,10 +188,9 @@ func (n *notification) sendCreateCredentialNotification(ctx context.Context, iss
 		return err
 	}
 
-	credentialsUserID := ""
-	var connection *domain.Connection
-	credentials := make([]*domain.Claim, len(credIDs))
-	for i, credID := range credIDs {
+	userIDs := make([]string, 0)
+	credentialsByUser := make(map[string][]*domain.Claim)
+	for _, credID := range credIDs {
 		credUUID, err := uuid.Parse(credID)
 		if err != nil {
 			log.Error(ctx, "sendCreateCredentialNotification: failed to parse credID", "err", err.Error(), "issuerID", issuerID, "credID", credID)
@@ -128,21 +203,34 @@ func (n *notification) sendCreateCredentialNotification(ctx context.Context, iss
 			return err
 		}
 
-		if credentialsUserID == "" {
-			userDID, err := w3c.ParseDID(credential.OtherIdentifier)
-			if err != nil {
-				log.Error(ctx, "sendCreateCredentialNotification: failed to parse credential userID", "err", err.Error(), "issuerID", issuerID, "credID", credID)
-				return err
-			}
-
-			credentialsUserID = credential.OtherIdentifier
-			connection, err = n.connService.GetByUserID(ctx, *issuerDID, *userDID)
-			if err != nil {
-				log.Warn(ctx, "sendCreateCredentialNotification: get connection", "err", err.Error(), "issuerID", issuerID, "credID", credID)
-				return err
-			}
+		if _, found := credentialsByUser[credential.OtherIdentifier]; !found {
+			userIDs = append(userIDs, credential.OtherIdentifier)
 		}
-		credentials[i] = credential
+		credentialsByUser[credential.OtherIdentifier] = append(credentialsByUser[credential.OtherIdentifier], credential)
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		if err := n.sendCreateCredentialNotificationToUser(ctx, issuerDID, userID, credentialsByUser[userID]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (n *notification) sendCreateCredentialNotificationToUser(ctx context.Context, issuerDID *w3c.DID, userID string, credentials []*domain.Claim) error {
+	issuerID := issuerDID.String()
+	userDID, err := w3c.ParseDID(userID)
+	if err != nil {
+		log.Error(ctx, "sendCreateCredentialNotification: failed to parse credential userID", "err", err.Error(), "issuerID", issuerID, "userID", userID)
+		return err
+	}
+
+	connection, err := n.connService.GetByUserID(ctx, *issuerDID, *userDID)
+	if err != nil {
+		log.Warn(ctx, "sendCreateCredentialNotification: get connection", "err", err.Error(), "issuerID", issuerID, "userID", userID)
+		return err
 	}
 
 	credOfferBytes, subjectDIDDoc, err := getCredentialOfferData(connection, credentials...)
@@ -262,3 +350,17 @@ func getRevokedCredentialData(conn *domain.Connection, credential *domain.Claim)
 
 	return
 }
+
+func getExpiredCredentialData(conn *domain.Connection, credential *domain.Claim) (msgBytes []byte, subjectDIDDoc verifiable.DIDDocument, err error) {
+	msgBytes, err = notifications2.NewExpiredMsg(credential)
+	if err != nil {
+		return nil, verifiable.DIDDocument{}, fmt.Errorf("NewExpiredMsg, err: %v", err.Error())
+	}
+
+	err = json.Unmarshal(conn.UserDoc, &subjectDIDDoc)
+	if err != nil {
+		return nil, verifiable.DIDDocument{}, fmt.Errorf("unmarshal subjectDIDDoc, err: %v", err.Error())
+	}
+
+	return
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/network"
+)
+
+var (
+	// ErrNoPublishingKeyAvailable means that all the publishing keys of the network are below the minimum balance
+	ErrNoPublishingKeyAvailable = errors.New("no publishing key with enough balance")
+	// ErrPublishingKeyNotFound means that the address is not the address of a publishing key of the network
+	ErrPublishingKeyNotFound = errors.New("publishing key not found")
+)
+
+// publishingKeys is the pool of keys that publish the states of the BJJ identities of each network.
+// The keys of a network are set with publishingKeys in its resolver settings. Networks without them use the
+// global publishing key.
+type publishingKeys struct {
+	networkResolver network.Resolver
+	defaultKeyID    kms.KeyID
+	mu              sync.Mutex
+	next            map[string]int
+	inFlight        map[kms.KeyID]int
+}
+
+// NewPublishingKeys creates a new pool of publishing keys. defaultKeyPath is the key of the networks without a pool.
+func NewPublishingKeys(networkResolver network.Resolver, defaultKeyPath string) ports.PublishingKeyService {
+	return &publishingKeys{
+		networkResolver: networkResolver,
+		defaultKeyID:    kms.KeyID{Type: kms.KeyTypeEthereum, ID: defaultKeyPath},
+		next:            make(map[string]int),
+		inFlight:        make(map[kms.KeyID]int),
+	}
+}
+
+// Acquire returns the key that signs the next state transition of the network. Keys are used in turns, preferring
+// the ones without a transaction being sent. Keys whose balance is below the minimum balance of the network are
+// skipped. The returned function must be called once the transaction is sent.
+func (p *publishingKeys) Acquire(ctx context.Context, resolverPrefix string) (kms.KeyID, func(), error) {
+	keys, err := p.keys(resolverPrefix)
+	if err != nil {
+		return kms.KeyID{}, nil, err
+	}
+	minBalance, err := p.networkResolver.GetMinPublishingKeyBalance(resolverPrefix)
+	if err != nil {
+		return kms.KeyID{}, nil, err
+	}
+
+	p.mu.Lock()
+	start := p.next[resolverPrefix] % len(keys)
+	p.next[resolverPrefix] = start + 1
+	candidates := append(append([]kms.KeyID{}, keys[start:]...), keys[:start]...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return p.inFlight[candidates[i]] < p.inFlight[candidates[j]]
+	})
+	p.mu.Unlock()
+
+	for _, keyID := range candidates {
+		if minBalance != nil {
+			status, err := p.keyStatus(ctx, resolverPrefix, keyID, minBalance)
+			if err != nil {
+				log.Error(ctx, "failed to get publishing key balance", "err", err, "network", resolverPrefix)
+				continue
+			}
+			if !status.Available {
+				log.Warn(ctx, "publishing key balance below the minimum", "address", status.Address, "balance", status.Balance, "network", resolverPrefix)
+				continue
+			}
+		}
+
+		p.mu.Lock()
+		p.inFlight[keyID]++
+		p.mu.Unlock()
+		var once sync.Once
+		release := func() {
+			once.Do(func() {
+				p.mu.Lock()
+				p.inFlight[keyID]--
+				p.mu.Unlock()
+			})
+		}
+		return keyID, release, nil
+	}
+	return kms.KeyID{}, nil, fmt.Errorf("%w in %s", ErrNoPublishingKeyAvailable, resolverPrefix)
+}
+
+// KeyByAddress returns the publishing key of the network with the given address
+func (p *publishingKeys) KeyByAddress(ctx context.Context, resolverPrefix string, address common.Address) (kms.KeyID, error) {
+	keys, err := p.keys(resolverPrefix)
+	if err != nil {
+		return kms.KeyID{}, err
+	}
+	client, err := p.networkResolver.GetEthClient(resolverPrefix)
+	if err != nil {
+		return kms.KeyID{}, err
+	}
+	for _, keyID := range keys {
+		keyAddress, err := client.Address(keyID)
+		if err != nil {
+			log.Error(ctx, "failed to get publishing key address", "err", err, "network", resolverPrefix)
+			continue
+		}
+		if keyAddress == address {
+			return keyID, nil
+		}
+	}
+	return kms.KeyID{}, fmt.Errorf("%w: %s in %s", ErrPublishingKeyNotFound, address.Hex(), resolverPrefix)
+}
+
+// Status returns the balance of every publishing key of every network
+func (p *publishingKeys) Status(ctx context.Context) ([]domain.PublishingKeyStatus, error) {
+	statuses := make([]domain.PublishingKeyStatus, 0)
+	for _, supported := range p.networkResolver.GetSupportedNetworks() {
+		for _, networkName := range supported.Networks {
+			resolverPrefix := fmt.Sprintf("%s:%s", supported.Blockchain, networkName)
+			keys, err := p.keys(resolverPrefix)
+			if err != nil {
+				return nil, err
+			}
+			minBalance, err := p.networkResolver.GetMinPublishingKeyBalance(resolverPrefix)
+			if err != nil {
+				return nil, err
+			}
+			for _, keyID := range keys {
+				status, err := p.keyStatus(ctx, resolverPrefix, keyID, minBalance)
+				if err != nil {
+					log.Error(ctx, "failed to get publishing key balance", "err", err, "network", resolverPrefix)
+					return nil, err
+				}
+				status.Blockchain = supported.Blockchain
+				status.Network = networkName
+				statuses = append(statuses, *status)
+			}
+		}
+	}
+	return statuses, nil
+}
+
+func (p *publishingKeys) keys(resolverPrefix string) ([]kms.KeyID, error) {
+	paths, err := p.networkResolver.GetPublishingKeys(resolverPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return []kms.KeyID{p.defaultKeyID}, nil
+	}
+	keys := make([]kms.KeyID, 0, len(paths))
+	for _, path := range paths {
+		keys = append(keys, kms.KeyID{Type: kms.KeyTypeEthereum, ID: path})
+	}
+	return keys, nil
+}
+
+func (p *publishingKeys) keyStatus(ctx context.Context, resolverPrefix string, keyID kms.KeyID, minBalance *big.Int) (*domain.PublishingKeyStatus, error) {
+	client, err := p.networkResolver.GetEthClient(resolverPrefix)
+	if err != nil {
+		return nil, err
+	}
+	address, err := client.Address(keyID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := client.BalanceAt(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	inFlight := p.inFlight[keyID]
+	p.mu.Unlock()
+	return &domain.PublishingKeyStatus{
+		Address:    address.Hex(),
+		Balance:    balance,
+		MinBalance: minBalance,
+		InFlight:   inFlight,
+		Available:  minBalance == nil || balance.Cmp(minBalance) >= 0,
+	}, nil
+}
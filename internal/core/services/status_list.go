@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/merklize"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/internal/revocationstatus"
+)
+
+const (
+	jsonLDW3CCredentialsV2          = "https://www.w3.org/ns/credentials/v2"
+	bitstringStatusListCredential   = "BitstringStatusListCredential"
+	bitstringStatusListSubjectType  = "BitstringStatusList"
+	bitstringStatusListSubjectIDTag = "#list"
+)
+
+// ErrStatusListNotFound Cannot retrieve the given status list
+var ErrStatusListNotFound = errors.New("status list not found")
+
+type statusList struct {
+	repo                     ports.StatusListRepository
+	storage                  *db.Storage
+	claimService             ports.ClaimService
+	identityService          ports.IdentityService
+	credentialExportService  ports.CredentialExportService
+	revocationStatusResolver *revocationstatus.Resolver
+	loader                   loader.DocumentLoader
+}
+
+// NewStatusList is the status list service constructor
+func NewStatusList(repo ports.StatusListRepository, storage *db.Storage, claimService ports.ClaimService, identityService ports.IdentityService, credentialExportService ports.CredentialExportService, revocationStatusResolver *revocationstatus.Resolver, ld loader.DocumentLoader) ports.StatusListService {
+	return &statusList{
+		repo:                     repo,
+		storage:                  storage,
+		claimService:             claimService,
+		identityService:          identityService,
+		credentialExportService:  credentialExportService,
+		revocationStatusResolver: revocationStatusResolver,
+		loader:                   ld,
+	}
+}
+
+// GetCredential returns the BitstringStatusListCredential of the given status list, signed with the issuer's auth key.
+// The credential is merklized into a core claim and the core claim is signed, as it is done for any other credential.
+// The signed credential is stored with the list, so it is only signed again when an entry of the list is set or
+// when the auth key of the issuer has been rotated.
+func (s *statusList) GetCredential(ctx context.Context, issuerDID w3c.DID, id uuid.UUID) (*verifiable.W3CCredential, error) {
+	list, err := s.getList(ctx, issuerDID, id)
+	if err != nil {
+		return nil, err
+	}
+	if list.Credential != nil {
+		current, err := s.isSignedWithCurrentKey(ctx, issuerDID, list.Credential)
+		if err != nil {
+			return nil, err
+		}
+		if current {
+			return list.Credential, nil
+		}
+	}
+
+	vc, err := s.credential(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	mz, err := vc.Merklize(ctx, merklize.WithDocumentLoader(s.loader))
+	if err != nil {
+		log.Error(ctx, "merklizing status list credential", "err", err, "id", id)
+		return nil, err
+	}
+	schemaHash := common.CreateSchemaHash([]byte(jsonLDW3CCredentialsV2 + "#" + bitstringStatusListCredential))
+	coreClaim, err := core.NewClaim(schemaHash, core.WithIndexMerklizedRoot(mz.Root().BigInt()))
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := s.sign(ctx, issuerDID, coreClaim)
+	if err != nil {
+		log.Error(ctx, "status list credential: cannot sign claim entry", "err", err)
+		return nil, err
+	}
+	vc.Proof = verifiable.CredentialProofs{proof}
+
+	list.Credential = vc
+	s.saveCredentials(ctx, list)
+	return vc, nil
+}
+
+// GetCredentialJWT returns the BitstringStatusListCredential of the given status list as a JWT-VC signed with the
+// issuer's ETH key (ES256K), so it can be verified by W3C verifiers that do not support BJJ signature proofs.
+// Like the JSON-LD credential, it is only signed again when an entry of the list is set or when the ETH key of the
+// issuer has changed.
+func (s *statusList) GetCredentialJWT(ctx context.Context, issuerDID w3c.DID, id uuid.UUID) (string, error) {
+	list, err := s.getList(ctx, issuerDID, id)
+	if err != nil {
+		return "", err
+	}
+	if list.CredentialJWT != nil {
+		current, err := s.credentialExportService.IsSignedWithCurrentKey(ctx, issuerDID, *list.CredentialJWT)
+		if err != nil {
+			return "", err
+		}
+		if current {
+			return *list.CredentialJWT, nil
+		}
+	}
+
+	vc, err := s.credential(ctx, list)
+	if err != nil {
+		return "", err
+	}
+	token, err := s.credentialExportService.ExportW3CCredential(ctx, issuerDID, vc, ports.CredentialExportFormatJWTVC)
+	if err != nil {
+		return "", err
+	}
+
+	list.CredentialJWT = &token
+	s.saveCredentials(ctx, list)
+	return token, nil
+}
+
+func (s *statusList) getList(ctx context.Context, issuerDID w3c.DID, id uuid.UUID) (*domain.StatusList, error) {
+	list, err := s.repo.GetByID(ctx, s.storage.Pgx, issuerDID, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrStatusListDoesNotExist) {
+			return nil, ErrStatusListNotFound
+		}
+		return nil, err
+	}
+	return list, nil
+}
+
+// credential returns the BitstringStatusListCredential of the list without proofs
+func (s *statusList) credential(ctx context.Context, list *domain.StatusList) (*verifiable.W3CCredential, error) {
+	credentialID, err := s.revocationStatusResolver.StatusListCredentialURL(ctx, list.IssuerDID, list.ID)
+	if err != nil {
+		return nil, err
+	}
+	encodedList, err := list.EncodedList()
+	if err != nil {
+		return nil, err
+	}
+	return &verifiable.W3CCredential{
+		ID:      credentialID,
+		Context: []string{jsonLDW3CCredentialsV2},
+		Type:    []string{verifiable.TypeW3CVerifiableCredential, bitstringStatusListCredential},
+		Issuer:  list.IssuerDID.String(),
+		CredentialSubject: map[string]any{
+			"id":            credentialID + bitstringStatusListSubjectIDTag,
+			"type":          bitstringStatusListSubjectType,
+			"statusPurpose": string(list.Purpose),
+			"encodedList":   encodedList,
+		},
+	}, nil
+}
+
+// saveCredentials stores the signed credentials of the list. A failure is not returned, the credentials are signed
+// again on the next request.
+func (s *statusList) saveCredentials(ctx context.Context, list *domain.StatusList) {
+	if err := s.repo.SaveCredentials(ctx, s.storage.Pgx, list); err != nil {
+		log.Warn(ctx, "status list credential: cannot store the signed credential", "err", err, "id", list.ID)
+	}
+}
+
+// isSignedWithCurrentKey tells whether the signature proof of a stored status list credential was made with the key
+// the issuer signs with now. did:web issuers can not rotate their key.
+func (s *statusList) isSignedWithCurrentKey(ctx context.Context, issuerDID w3c.DID, vc *verifiable.W3CCredential) (bool, error) {
+	if domain.IsWebDID(issuerDID) {
+		return true, nil
+	}
+	if len(vc.Proof) != 1 {
+		return false, nil
+	}
+	proof, ok := vc.Proof[0].(*verifiable.BJJSignatureProof2021)
+	if !ok {
+		return false, nil
+	}
+	authClaim, err := s.claimService.GetAuthClaim(ctx, &issuerDID)
+	if err != nil {
+		log.Error(ctx, "status list credential: cannot retrieve the auth claim", "err", err)
+		return false, err
+	}
+	authCoreClaim, err := authClaim.CoreClaim.Get().Hex()
+	if err != nil {
+		return false, err
+	}
+	return proof.IssuerData.AuthCoreClaim == authCoreClaim, nil
+}
+
+// sign returns the BJJ signature proof of the status list core claim. did:web issuers sign with the key of their
+// DID document.
+func (s *statusList) sign(ctx context.Context, issuerDID w3c.DID, coreClaim *core.Claim) (*verifiable.BJJSignatureProof2021, error) {
+	if domain.IsWebDID(issuerDID) {
+		return s.identityService.SignWebClaimEntry(ctx, issuerDID, coreClaim)
+	}
+
+	authClaim, err := s.claimService.GetAuthClaim(ctx, &issuerDID)
+	if err != nil {
+		log.Error(ctx, "status list credential: cannot retrieve the auth claim", "err", err)
+		return nil, err
+	}
+	return s.identityService.SignClaimEntry(ctx, authClaim, coreClaim)
+}
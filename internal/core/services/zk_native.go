@@ -8,9 +8,11 @@ import (
 	"github.com/iden3/go-circuits/v2"
 	"github.com/iden3/go-rapidsnark/prover"
 	"github.com/iden3/go-rapidsnark/types"
+	"github.com/iden3/go-rapidsnark/verifier"
 	"github.com/iden3/go-rapidsnark/witness/v2"
 	"github.com/iden3/go-rapidsnark/witness/wazero"
 
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/log"
 	"github.com/polygonid/sh-id-platform/pkg/loaders"
 )
@@ -66,3 +68,17 @@ func (s *NativeProverService) Generate(ctx context.Context, inputs json.RawMessa
 	}
 	return p, nil
 }
+
+// VerifyProof verifies a groth16 proof with the verification key of the circuit.
+// It returns ports.ErrInvalidProof if the proof does not verify.
+func (s *NativeProverService) VerifyProof(ctx context.Context, zkProof *types.ZKProof, circuitName string) error {
+	verificationKey, err := s.config.CircuitsLoader.LoadVerificationKey(circuits.CircuitID(circuitName))
+	if err != nil {
+		return err
+	}
+	if err := verifier.VerifyGroth16(*zkProof, verificationKey); err != nil {
+		log.Error(ctx, "proof verification failed", "err", err, "circuit", circuitName)
+		return fmt.Errorf("%w for circuit %s: %s", ports.ErrInvalidProof, circuitName, err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-rapidsnark/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/pkg/loaders"
+)
+
+func TestNativeProverService_VerifyProof(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should verify a proof generated by the circuit", func(t *testing.T) {
+		// testdata/circuits/authV2/authV2.json is the verification key of the proving key that generated this proof
+		prover := NewNativeProverService(&NativeProverConfig{CircuitsLoader: loaders.NewCircuits("testdata/circuits")})
+		const authV2Proof = `{
+			"proof": {
+				"pi_a": ["19159089100093442364564241907845391881339447491570686599417204350515814761415", "4480863834681568361265257833922959153899404530916715096123875553646376305439", "1"],
+				"pi_b": [
+					["10726496159894040251106209290925394705519456259206068111416884202632436879500", "3890164975933943066579827996071724489610455844559446020198842440799302742999"],
+					["1968629097803325155273203531322860514377950990595901171850425847681764356535", "4569676159872804609433721718016763164735403096886159211846050170659951176581"],
+					["1", "0"]
+				],
+				"pi_c": ["17883453862142682625062700951315484895200492708099837073560276179893934974621", "7758826600536057050576031018644098642831119346837682133459282288711282306638", "1"],
+				"protocol": "groth16"
+			},
+			"pub_signals": [
+				"19229084873704550357232887142774605442297337229176579229011342091594174977",
+				"6110517768249559238193477435454792024732173865488900270849624328650765691494",
+				"1243904711429961858774220647610724273798918457991486031567244100767259239747"
+			]
+		}`
+		var proof types.ZKProof
+		require.NoError(t, json.Unmarshal([]byte(authV2Proof), &proof))
+		assert.NoError(t, prover.VerifyProof(ctx, &proof, string(circuits.AuthV2CircuitID)))
+
+		// the same proof with other public signals
+		proof.PubSignals[2] = "1"
+		assert.ErrorIs(t, prover.VerifyProof(ctx, &proof, string(circuits.AuthV2CircuitID)), ports.ErrInvalidProof)
+	})
+
+	t.Run("should reject a proof not generated by the circuit", func(t *testing.T) {
+		prover := NewNativeProverService(&NativeProverConfig{CircuitsLoader: loaders.NewCircuits("../../../pkg/credentials/circuits")})
+		proof := &types.ZKProof{
+			Proof: &types.ProofData{
+				A: []string{"1", "2", "1"},
+				B: [][]string{
+					{"10857046999023057135944570762232829481370756359578518086990519993285655852781", "11559732032986387107991004021392285783925812861821192530917403151452391805634"},
+					{"8495653923123431417604973247489272438418190587263600148770280649306958101930", "4082367875863433681332203403145435568316851327593401208105741076214120093531"},
+					{"1", "0"},
+				},
+				C:        []string{"1", "2", "1"},
+				Protocol: "groth16",
+			},
+			PubSignals: []string{"1", "2", "3", "0"},
+		}
+		err := prover.VerifyProof(ctx, proof, string(circuits.StateTransitionCircuitID))
+		assert.ErrorIs(t, err, ports.ErrInvalidProof)
+	})
+}
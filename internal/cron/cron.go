@@ -0,0 +1,156 @@
+// Package cron parses standard 5-field cron expressions (minute, hour, day of month, month and day of week)
+// and computes their activation times.
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears bounds the search of the next activation of a schedule. Every valid schedule fires at
+// least once in this period, including the ones that fire on the 29th of February.
+const maxSearchYears = 5
+
+// ErrInvalidExpression means that the cron expression can not be parsed or never fires
+var ErrInvalidExpression = errors.New("invalid cron expression")
+
+type field struct {
+	name     string
+	min, max uint
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7}, // 0 and 7 are sunday
+}
+
+// Schedule is a parsed cron expression. Each field is a bitmask of the values that match.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar are true if the day fields are *. When both day fields are restricted,
+	// a day matches if it matches any of them, like in the standard cron.
+	domStar, dowStar bool
+}
+
+// Parse parses a 5-field cron expression. Each field is *, a value, a range (a-b) or a list of them separated
+// by commas, optionally with a step (*/n, a-b/n).
+func Parse(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("%w: %q must have %d fields", ErrInvalidExpression, expr, len(fields))
+	}
+	masks := make([]uint64, len(fields))
+	for i, f := range fields {
+		mask, err := parseField(parts[i], f)
+		if err != nil {
+			return nil, err
+		}
+		masks[i] = mask
+	}
+
+	dow := masks[4]
+	if dow&(1<<7) != 0 {
+		dow |= 1
+	}
+	s := &Schedule{
+		minute:  masks[0],
+		hour:    masks[1],
+		dom:     masks[2],
+		month:   masks[3],
+		dow:     dow &^ (1 << 7),
+		domStar: parts[2] == "*",
+		dowStar: parts[4] == "*",
+	}
+	if s.Next(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return nil, fmt.Errorf("%w: %q never fires", ErrInvalidExpression, expr)
+	}
+	return s, nil
+}
+
+// Next returns the first activation of the schedule after t, with the precision of a minute.
+// It returns the zero time if the schedule does not fire in the following years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !match(s.month, uint(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !match(s.hour, uint(t.Hour())):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !match(s.minute, uint(t.Minute())):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchDay(t time.Time) bool {
+	dom := match(s.dom, uint(t.Day()))
+	dow := match(s.dow, uint(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func match(mask uint64, value uint) bool {
+	return mask&(1<<value) != 0
+}
+
+func parseField(expr string, f field) (uint64, error) {
+	var mask uint64
+	for _, item := range strings.Split(expr, ",") {
+		rng, step, hasStep := strings.Cut(item, "/")
+		var err error
+		increment := uint64(1)
+		if hasStep {
+			increment, err = strconv.ParseUint(step, 10, 8)
+			if err != nil || increment == 0 {
+				return 0, fmt.Errorf("%w: invalid step %q in the %s field", ErrInvalidExpression, step, f.name)
+			}
+		}
+
+		from, to := f.min, f.max
+		if rng != "*" {
+			first, last, isRange := strings.Cut(rng, "-")
+			if from, err = parseValue(first, f); err != nil {
+				return 0, err
+			}
+			to = from
+			if isRange {
+				if to, err = parseValue(last, f); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				to = f.max
+			}
+			if from > to {
+				return 0, fmt.Errorf("%w: invalid range %q in the %s field", ErrInvalidExpression, rng, f.name)
+			}
+		}
+		for v := from; v <= to; v += uint(increment) {
+			mask |= 1 << v
+		}
+	}
+	return mask, nil
+}
+
+func parseValue(value string, f field) (uint, error) {
+	v, err := strconv.ParseUint(value, 10, 8)
+	if err != nil || uint(v) < f.min || uint(v) > f.max {
+		return 0, fmt.Errorf("%w: invalid value %q in the %s field", ErrInvalidExpression, value, f.name)
+	}
+	return uint(v), nil
+}
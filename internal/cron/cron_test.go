@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"a * * * *",
+		"0 0 30 2 *",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := Parse(expr)
+			assert.ErrorIs(t, err, ErrInvalidExpression)
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	from := time.Date(2024, time.October, 17, 10, 42, 30, 0, time.UTC) // thursday
+	type testConfig struct {
+		expr     string
+		expected time.Time
+	}
+	for _, tc := range []testConfig{
+		{expr: "* * * * *", expected: time.Date(2024, time.October, 17, 10, 43, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", expected: time.Date(2024, time.October, 17, 10, 45, 0, 0, time.UTC)},
+		{expr: "0 * * * *", expected: time.Date(2024, time.October, 17, 11, 0, 0, 0, time.UTC)},
+		{expr: "30 9,18 * * *", expected: time.Date(2024, time.October, 17, 18, 30, 0, 0, time.UTC)},
+		{expr: "0 0 * * *", expected: time.Date(2024, time.October, 18, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 8 * * 1-5", expected: time.Date(2024, time.October, 18, 8, 0, 0, 0, time.UTC)},
+		{expr: "0 8 * * 0", expected: time.Date(2024, time.October, 20, 8, 0, 0, 0, time.UTC)},
+		{expr: "0 8 * * 7", expected: time.Date(2024, time.October, 20, 8, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 * *", expected: time.Date(2024, time.November, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 1 *", expected: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", expected: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// both day fields restricted: the first of the month or any monday
+		{expr: "0 0 1 * 1", expected: time.Date(2024, time.October, 21, 0, 0, 0, 0, time.UTC)},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			schedule, err := Parse(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, schedule.Next(from))
+		})
+	}
+}
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE credential_imports
+(
+    id          uuid        PRIMARY KEY NOT NULL,
+    issuer_id   text        NOT NULL,
+    schema_id   uuid        NOT NULL,
+    format      text        NOT NULL,
+    status      text        NOT NULL,
+    total       integer     NOT NULL DEFAULT 0,
+    processed   integer     NOT NULL DEFAULT 0,
+    issued      integer     NOT NULL DEFAULT 0,
+    rejected    jsonb       NOT NULL DEFAULT '[]'::jsonb,
+    created_at  timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT credential_imports_schemas_id_key foreign key (schema_id) references schemas (id),
+    CONSTRAINT credential_imports_identities_id_key foreign key (issuer_id) references identities (identifier)
+);
+CREATE INDEX credential_imports_issuer_id_idx ON credential_imports (issuer_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS credential_imports;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE claims ADD COLUMN previous_id uuid NULL;
+ALTER TABLE claims ADD CONSTRAINT claims_previous_id_fkey foreign key (previous_id) references claims (id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE claims DROP CONSTRAINT IF EXISTS claims_previous_id_fkey;
+ALTER TABLE claims DROP COLUMN IF EXISTS previous_id;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE status_lists
+(
+    id          uuid        PRIMARY KEY NOT NULL,
+    issuer_id   text        NOT NULL,
+    purpose     text        NOT NULL,
+    size        integer     NOT NULL,
+    allocated   integer     NOT NULL DEFAULT 0,
+    bits        bytea       NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT status_lists_identities_id_key foreign key (issuer_id) references identities (identifier)
+);
+CREATE INDEX status_lists_issuer_id_purpose_idx ON status_lists (issuer_id, purpose);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS status_lists;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE claims ADD COLUMN expiration_processed boolean NOT NULL DEFAULT false;
+-- the credentials that expired before the upgrade are not notified nor revoked
+UPDATE claims SET expiration_processed = true WHERE expiration > 0 AND expiration < extract(epoch FROM now());
+CREATE INDEX claims_expiration_pending_idx ON claims (expiration) WHERE expiration > 0 AND expiration_processed = false AND revoked IS NOT TRUE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS claims_expiration_pending_idx;
+ALTER TABLE claims DROP COLUMN IF EXISTS expiration_processed;
+-- +goose StatementEnd
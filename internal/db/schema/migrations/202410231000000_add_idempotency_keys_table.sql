@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE idempotency_keys
+(
+    issuer_id    text        NOT NULL,
+    operation    text        NOT NULL,
+    key          text        NOT NULL,
+    request_hash text        NOT NULL,
+    response     jsonb,
+    created_at   timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at   timestamptz NOT NULL,
+    PRIMARY KEY (issuer_id, operation, key),
+    CONSTRAINT idempotency_keys_identities_id_key foreign key (issuer_id) references identities (identifier)
+);
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
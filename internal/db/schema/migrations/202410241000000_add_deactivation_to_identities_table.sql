@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE identities ADD COLUMN deactivated_at timestamptz;
+ALTER TABLE identities ADD COLUMN archived_at timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE identities DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE identities DROP COLUMN IF EXISTS deactivated_at;
+-- +goose StatementEnd
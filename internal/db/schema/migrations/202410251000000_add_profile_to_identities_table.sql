@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE identities ADD COLUMN profile jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE identities DROP COLUMN IF EXISTS profile;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE identities ADD COLUMN created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP;
+UPDATE identities SET created_at = genesis.created_at
+    FROM (SELECT identifier, MIN(created_at) AS created_at FROM identity_states GROUP BY identifier) AS genesis
+    WHERE genesis.identifier = identities.identifier;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE identities DROP COLUMN IF EXISTS created_at;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE identities ADD COLUMN publishing_policy jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE identities DROP COLUMN IF EXISTS publishing_policy;
+-- +goose StatementEnd
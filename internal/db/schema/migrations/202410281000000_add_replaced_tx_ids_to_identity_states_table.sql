@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE identity_states ADD COLUMN replaced_tx_ids text[] NOT NULL DEFAULT '{}';
+ALTER TABLE identity_states ADD COLUMN cancel_tx_id varchar(66) NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE identity_states DROP COLUMN IF EXISTS cancel_tx_id;
+ALTER TABLE identity_states DROP COLUMN IF EXISTS replaced_tx_ids;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE publishing_nonces
+(
+    chain_id    bigint      NOT NULL,
+    address     text        NOT NULL,
+    nonce       bigint      NOT NULL,
+    status      text        NOT NULL,
+    tx_id       varchar(66),
+    modified_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (chain_id, address, nonce)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS publishing_nonces;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE identity_states ADD COLUMN block_hash varchar(66) NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE identity_states DROP COLUMN IF EXISTS block_hash;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE identity_states ADD COLUMN failure_reason text NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE identity_states DROP COLUMN IF EXISTS failure_reason;
+-- +goose StatementEnd
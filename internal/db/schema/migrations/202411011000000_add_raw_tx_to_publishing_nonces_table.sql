@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE publishing_nonces ADD COLUMN raw_tx bytea;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE publishing_nonces DROP COLUMN IF EXISTS raw_tx;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE status_lists ADD COLUMN credential jsonb;
+ALTER TABLE status_lists ADD COLUMN credential_jwt text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE status_lists DROP COLUMN IF EXISTS credential_jwt;
+ALTER TABLE status_lists DROP COLUMN IF EXISTS credential;
+-- +goose StatementEnd
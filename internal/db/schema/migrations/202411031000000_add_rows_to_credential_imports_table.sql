@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE credential_imports
+    ADD COLUMN expiration             timestamptz,
+    ADD COLUMN signature_proof        boolean NOT NULL DEFAULT true,
+    ADD COLUMN mtp_proof              boolean NOT NULL DEFAULT false,
+    ADD COLUMN credential_status_type text    NOT NULL DEFAULT '',
+    ADD COLUMN error                  text;
+CREATE INDEX credential_imports_status_idx ON credential_imports (status);
+
+CREATE TABLE credential_import_rows
+(
+    import_id uuid    NOT NULL,
+    row       integer NOT NULL,
+    subject   jsonb,
+    error     text,
+    PRIMARY KEY (import_id, row),
+    CONSTRAINT credential_import_rows_credential_imports_id_key foreign key (import_id) references credential_imports (id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS credential_import_rows;
+DROP INDEX IF EXISTS credential_imports_status_idx;
+ALTER TABLE credential_imports
+    DROP COLUMN IF EXISTS expiration,
+    DROP COLUMN IF EXISTS signature_proof,
+    DROP COLUMN IF EXISTS mtp_proof,
+    DROP COLUMN IF EXISTS credential_status_type,
+    DROP COLUMN IF EXISTS error;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE identities ADD COLUMN auth_claim_id uuid NULL;
+-- the primary auth claim of the existing identities is their oldest active one
+UPDATE identities
+SET auth_claim_id = (SELECT claims.id
+                     FROM claims
+                     WHERE claims.identifier = identities.identifier
+                       AND claims.schema_type = 'https://schema.iden3.io/core/jsonld/auth.jsonld#AuthBJJCredential'
+                       AND claims.revoked = false
+                     ORDER BY claims.created_at
+                     LIMIT 1);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE identities DROP COLUMN IF EXISTS auth_claim_id;
+-- +goose StatementEnd
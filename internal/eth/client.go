@@ -35,6 +35,10 @@ const (
 	gasPriceIncrement               = 10
 	transactionUnderpricedIncrement = 30
 	feeIncrement                    = 1.25
+	// minReplacementBump is the minimum fee increase, in percent, the nodes accept to replace a pending transaction
+	minReplacementBump = 10
+	percentBase        = 100
+	cancelGasLimit     = 21000
 )
 
 var (
@@ -46,6 +50,10 @@ var (
 	ErrReceiptNotReceived = errors.New("receipt not available")
 	// ErrTransactionNotFound transaction doesn't exist on blockchain
 	ErrTransactionNotFound = errors.New("transaction not found")
+	// ErrTransactionNotPending when the transaction to replace is already mined
+	ErrTransactionNotPending = errors.New("transaction is not pending")
+	// ErrMaxGasPriceReached when the fees of a replacement transaction would exceed the max gas price
+	ErrMaxGasPriceReached = errors.New("the fees needed to replace the transaction exceed the max gas price")
 	// CompressedPublicKeyLength is the length of a compressed public key
 	CompressedPublicKeyLength = 33
 	// AwsKmsPublicKeyLength is the length of a public key from AWS KMS
@@ -71,6 +79,8 @@ type ClientConfig struct {
 	RPCResponseTimeout     time.Duration `json:"rpc_response_time_out"`
 	WaitReceiptCycleTime   time.Duration `json:"wait_receipt*eth.Client_cycle_time_out"`
 	WaitBlockCycleTime     time.Duration `json:"wait_block_cycle_time_out"`
+	GasBumpPercent         int           `json:"gas_bump_percent"`
+	StuckTxTimeout         time.Duration `json:"stuck_tx_timeout"`
 }
 
 // NewClient creates a Client instance.
@@ -97,6 +107,12 @@ func (c *Client) GetConfirmationConfirmationTimeout() time.Duration {
 	return c.Config.ConfirmationTimeout
 }
 
+// GetStuckTxTimeout returns how long a transaction can be pending before it is replaced with higher fees.
+// Pending transactions are never replaced automatically if it is 0.
+func (c *Client) GetStuckTxTimeout() time.Duration {
+	return c.Config.StuckTxTimeout
+}
+
 // BalanceAt retrieves information about the default account
 func (c *Client) BalanceAt(ctx context.Context, addr common.Address) (*big.Int, error) {
 	_ctx, cancel := context.WithTimeout(ctx, c.Config.RPCResponseTimeout)
@@ -257,6 +273,20 @@ func (c *Client) CurrentBlock(ctx context.Context) (*big.Int, error) {
 	return header.Number, nil
 }
 
+// NonceAt returns the nonce of the address in the latest block, that is, the number of its mined transactions
+func (c *Client) NonceAt(ctx context.Context, address common.Address) (uint64, error) {
+	_ctx, cancel := context.WithTimeout(ctx, c.Config.RPCResponseTimeout)
+	defer cancel()
+	return c.client.NonceAt(_ctx, address, nil)
+}
+
+// PendingNonceAt returns the next nonce of the address, counting its transactions in the pool of the node
+func (c *Client) PendingNonceAt(ctx context.Context, address common.Address) (uint64, error) {
+	_ctx, cancel := context.WithTimeout(ctx, c.Config.RPCResponseTimeout)
+	defer cancel()
+	return c.client.PendingNonceAt(_ctx, address)
+}
+
 // ChainID get chain id.
 func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
 	_ctx, cancel := context.WithTimeout(ctx, c.Config.RPCResponseTimeout)
@@ -321,6 +351,113 @@ func (c *Client) GetTransactionByID(ctx context.Context, txID string) (*types.Tr
 	return c.client.TransactionByHash(ctx, common.HexToHash(txID))
 }
 
+// ReplaceTx replaces tx, a pending transaction sent from the address of kmsKey, with a new one with the same nonce and
+// higher fees. tx is the transaction as it was signed, so it can be replaced after the node dropped it. With cancel, the new transaction is an empty transfer to the sender, so the call of the original one
+// is never executed. The fees are raised GasBumpPercent, at least 10%, and at least to the suggested values of the
+// network, but never above MaxGasPrice when it is set.
+func (c *Client) ReplaceTx(ctx context.Context, kmsKey kms.KeyID, tx *types.Transaction, cancel bool) (*types.Transaction, error) {
+	from, err := c.getAddress(kmsKey)
+	if err != nil {
+		return nil, err
+	}
+	to, data, value, gas := tx.To(), tx.Data(), tx.Value(), tx.Gas()
+	if cancel {
+		to, data, value, gas = &from, nil, big.NewInt(0), cancelGasLimit
+	}
+
+	bump := max(c.Config.GasBumpPercent, minReplacementBump)
+	var txData types.TxData
+	if tx.Type() == types.DynamicFeeTxType {
+		tip, err := c.suggestGasTipCap(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tip = bigMax(tip, bumpFee(tx.GasTipCap(), bump))
+
+		header, err := c.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		feeCap := bumpFee(tx.GasFeeCap(), bump)
+		if header.BaseFee != nil {
+			feeCap = bigMax(feeCap, new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), tip))
+		}
+		if feeCap, err = c.capGasPrice(feeCap, bumpFee(tx.GasFeeCap(), minReplacementBump)); err != nil {
+			return nil, err
+		}
+		if tip.Cmp(feeCap) == Gt {
+			tip = feeCap
+		}
+		if tip.Cmp(bumpFee(tx.GasTipCap(), minReplacementBump)) == Lt {
+			return nil, ErrMaxGasPriceReached
+		}
+		txData = &types.DynamicFeeTx{
+			ChainID:   tx.ChainId(),
+			Nonce:     tx.Nonce(),
+			GasTipCap: tip,
+			GasFeeCap: feeCap,
+			Gas:       gas,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		}
+	} else {
+		_ctx, cancelCtx := context.WithTimeout(ctx, c.Config.RPCResponseTimeout)
+		defer cancelCtx()
+		gasPrice, err := c.client.SuggestGasPrice(_ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get suggested gas price: %v", err)
+		}
+		gasPrice = bigMax(gasPrice, bumpFee(tx.GasPrice(), bump))
+		if gasPrice, err = c.capGasPrice(gasPrice, bumpFee(tx.GasPrice(), minReplacementBump)); err != nil {
+			return nil, err
+		}
+		txData = &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: gasPrice,
+			Gas:      gas,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		}
+	}
+
+	signed, err := c.signerFnFactory(ctx, kmsKey)(from, types.NewTx(txData))
+	if err != nil {
+		return nil, err
+	}
+	if err := c.SendRawTx(ctx, signed); err != nil {
+		return nil, err
+	}
+	log.Info(ctx, "transaction replaced", "tx", tx.Hash().Hex(), "replacement", signed.Hash().Hex(), "nonce", signed.Nonce(),
+		"cancel", cancel, "gasFeeCap", signed.GasFeeCap(), "gasTipCap", signed.GasTipCap())
+	return signed, nil
+}
+
+// capGasPrice lowers the price to MaxGasPrice when it is set. It fails if the result is below the minimum.
+func (c *Client) capGasPrice(price, minimum *big.Int) (*big.Int, error) {
+	if c.Config.MaxGasPrice != nil && c.Config.MaxGasPrice.Sign() > 0 && price.Cmp(c.Config.MaxGasPrice) == Gt {
+		price = new(big.Int).Set(c.Config.MaxGasPrice)
+	}
+	if price.Cmp(minimum) == Lt {
+		return nil, ErrMaxGasPriceReached
+	}
+	return price, nil
+}
+
+// bumpFee returns the fee increased by percent
+func bumpFee(fee *big.Int, percent int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(int64(percentBase+percent)))
+	return bumped.Div(bumped, big.NewInt(percentBase))
+}
+
+func bigMax(a, b *big.Int) *big.Int {
+	if a.Cmp(b) == Lt {
+		return b
+	}
+	return a
+}
+
 // CreateTxOpts creates a new transaction signer
 func (c *Client) CreateTxOpts(ctx context.Context, kmsKey kms.KeyID) (*bind.TransactOpts, error) {
 	//nolint:all
@@ -514,12 +651,22 @@ func (c *Client) getGasPrice(ctx context.Context) (*big.Int, error) {
 	return gasPrice, err
 }
 
+// Address returns the address of the ethereum key
+func (c *Client) Address(k kms.KeyID) (common.Address, error) {
+	return c.getAddress(k)
+}
+
 // getAddress - get address by keyID
 func (c *Client) getAddress(k kms.KeyID) (common.Address, error) {
-	if c.kms == nil {
+	return KeyAddress(c.kms, k)
+}
+
+// KeyAddress returns the ethereum address of the key stored in the kms
+func KeyAddress(keyStore *kms.KMS, k kms.KeyID) (common.Address, error) {
+	if keyStore == nil {
 		return common.Address{}, errors.Join(errors.New("the signer is read-only"))
 	}
-	bytesPubKey, err := c.kms.PublicKey(k)
+	bytesPubKey, err := keyStore.PublicKey(k)
 	if err != nil {
 		return common.Address{}, err
 	}
@@ -0,0 +1,247 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// nonceReservationTimeout is how long a nonce stays reserved without its transaction being sent. After it, the
+// process that reserved it is considered gone and the nonce can be allocated again.
+const nonceReservationTimeout = 5 * time.Minute
+
+// NonceManager allocates the nonces of the transactions sent from an address locally, instead of relying on the
+// pending nonce of the node, so many transactions of the same address can be sent concurrently.
+// The nonces in use are stored in the database, per chain and address, so they are shared by all the processes
+// and survive restarts. A nonce whose transaction could not be sent is released and is the next one allocated,
+// so the gap it leaves does not block the following transactions. A transaction the node drops from its pool is
+// sent again, or its nonce is released if it can't be sent.
+type NonceManager struct {
+	repo    ports.PublishingNonceRepository
+	storage *db.Storage
+}
+
+// NewNonceManager creates a new nonce manager
+func NewNonceManager(repo ports.PublishingNonceRepository, storage *db.Storage) *NonceManager {
+	return &NonceManager{
+		repo:    repo,
+		storage: storage,
+	}
+}
+
+// Send allocates a nonce for opts.From, sets it in opts and sends the transaction with send.
+// The nonce is released if the transaction is not sent.
+func (m *NonceManager) Send(ctx context.Context, client *Client, opts *bind.TransactOpts, send func(opts *bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := m.reserve(ctx, client, chainID.Uint64(), opts.From)
+	if err != nil {
+		log.Error(ctx, "failed to reserve nonce", "err", err, "address", opts.From.Hex())
+		return nil, err
+	}
+
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+	tx, err := send(opts)
+	if err != nil {
+		if errRelease := m.repo.Delete(ctx, m.storage.Pgx, chainID.Uint64(), opts.From.Hex(), nonce); errRelease != nil {
+			log.Error(ctx, "failed to release nonce", "err", errRelease, "address", opts.From.Hex(), "nonce", nonce)
+		}
+		return nil, err
+	}
+
+	// the transaction is already sent, so a failure to record it is not returned. The reservation expires and
+	// the nonce is not allocated again while the node reports the transaction as pending.
+	if err := m.save(ctx, chainID.Uint64(), opts.From, tx); err != nil {
+		log.Error(ctx, "failed to record sent transaction nonce", "err", err, "tx", tx.Hash().Hex(), "nonce", nonce)
+	}
+	return tx, nil
+}
+
+// Replaced records the transaction that replaces the one sent before with the same nonce
+func (m *NonceManager) Replaced(ctx context.Context, client *Client, tx *types.Transaction) error {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return err
+	}
+	return m.save(ctx, chainID.Uint64(), from, tx)
+}
+
+// SentTransaction returns the signed transaction txID sent with a nonce in use, so it can be replaced even if the
+// node dropped it from its pool. Transactions not recorded by this manager are looked up in the node.
+// It returns ErrTransactionNotFound if neither knows the transaction and ErrTransactionNotPending if it was mined.
+func (m *NonceManager) SentTransaction(ctx context.Context, client *Client, txID string) (*types.Transaction, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := m.repo.GetByTxID(ctx, m.storage.Pgx, chainID.Uint64(), txID)
+	if err != nil && !errors.Is(err, ports.ErrPublishingNonceNotFound) {
+		return nil, err
+	}
+	if nonce == nil || len(nonce.RawTx) == 0 {
+		tx, isPending, err := client.GetTransactionByID(ctx, txID)
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				return nil, ErrTransactionNotFound
+			}
+			return nil, err
+		}
+		if !isPending {
+			return nil, ErrTransactionNotPending
+		}
+		return tx, nil
+	}
+
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(nonce.RawTx); err != nil {
+		return nil, err
+	}
+	mined, err := client.NonceAt(ctx, common.HexToAddress(nonce.Address))
+	if err != nil {
+		return nil, err
+	}
+	if tx.Nonce() < mined {
+		return nil, ErrTransactionNotPending
+	}
+	return &tx, nil
+}
+
+// reserve allocates the lowest nonce not in use from the pending nonce of the node.
+// Nonces below the pending nonce are taken by transactions known by the node. From it on, the nonces in use
+// are reserved or sent by this manager, and the first hole among them is a released nonce. The sent transactions
+// the node does not know anymore are recovered first, so their nonces do not leave a gap.
+func (m *NonceManager) reserve(ctx context.Context, client *Client, chainID uint64, address common.Address) (uint64, error) {
+	mined, err := client.NonceAt(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	pending, err := client.PendingNonceAt(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	var nonce uint64
+	err = m.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := m.repo.Lock(ctx, tx, chainID, address.Hex()); err != nil {
+			return err
+		}
+		now := time.Now()
+		if _, err := m.repo.DeleteExpired(ctx, tx, chainID, address.Hex(), mined, now.Add(-nonceReservationTimeout)); err != nil {
+			return err
+		}
+		if err := m.recoverDropped(ctx, tx, client, chainID, address, pending); err != nil {
+			return err
+		}
+		inUse, err := m.repo.GetFrom(ctx, tx, chainID, address.Hex(), pending)
+		if err != nil {
+			return err
+		}
+		nonce = firstFreeNonce(pending, inUse)
+		return m.repo.Save(ctx, tx, &domain.PublishingNonce{
+			ChainID:    chainID,
+			Address:    address.Hex(),
+			Nonce:      nonce,
+			Status:     domain.PublishingNonceReserved,
+			ModifiedAt: now,
+		})
+	})
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to reserve nonce"), err)
+	}
+	return nonce, nil
+}
+
+// recoverDropped checks the transactions sent with nonces from the pending nonce of the node, which may have been
+// dropped from the pool of the node. The ones the node does not know are sent again, and the nonce of the ones
+// that can't be sent again is released.
+func (m *NonceManager) recoverDropped(ctx context.Context, conn db.Querier, client *Client, chainID uint64, address common.Address, pending uint64) error {
+	sent, err := m.repo.GetSentFrom(ctx, conn, chainID, address.Hex(), pending)
+	if err != nil {
+		return err
+	}
+	for _, nonce := range sent {
+		if nonce.TxID != nil {
+			_, _, err := client.GetTransactionByID(ctx, *nonce.TxID)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, ethereum.NotFound) {
+				return err
+			}
+		}
+		if resendTransaction(ctx, client, nonce.RawTx) {
+			log.Warn(ctx, "transaction dropped by the node sent again", "tx", nonce.TxID, "address", address.Hex(), "nonce", nonce.Nonce)
+			continue
+		}
+		log.Warn(ctx, "releasing nonce of a transaction dropped by the node", "tx", nonce.TxID, "address", address.Hex(), "nonce", nonce.Nonce)
+		if err := m.repo.Delete(ctx, conn, chainID, address.Hex(), nonce.Nonce); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resendTransaction sends the signed transaction again. It returns whether the node accepted it.
+func resendTransaction(ctx context.Context, client *Client, rawTx []byte) bool {
+	if len(rawTx) == 0 {
+		return false
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		log.Error(ctx, "failed to decode sent transaction", "err", err)
+		return false
+	}
+	if err := client.SendRawTx(ctx, &tx); err != nil && !strings.Contains(err.Error(), "already known") {
+		log.Warn(ctx, "failed to send again dropped transaction", "err", err, "tx", tx.Hash().Hex())
+		return false
+	}
+	return true
+}
+
+func (m *NonceManager) save(ctx context.Context, chainID uint64, from common.Address, tx *types.Transaction) error {
+	txID := tx.Hash().Hex()
+	rawTx, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return m.repo.Save(ctx, m.storage.Pgx, &domain.PublishingNonce{
+		ChainID:    chainID,
+		Address:    from.Hex(),
+		Nonce:      tx.Nonce(),
+		Status:     domain.PublishingNonceSent,
+		TxID:       &txID,
+		RawTx:      rawTx,
+		ModifiedAt: time.Now(),
+	})
+}
+
+// firstFreeNonce returns the lowest nonce from from that is not in inUse, which is sorted in ascending order
+func firstFreeNonce(from uint64, inUse []uint64) uint64 {
+	nonce := from
+	for _, n := range inUse {
+		if n != nonce {
+			break
+		}
+		nonce++
+	}
+	return nonce
+}
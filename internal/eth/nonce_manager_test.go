@@ -0,0 +1,209 @@
+package eth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestFirstFreeNonce(t *testing.T) {
+	type testConfig struct {
+		name     string
+		from     uint64
+		inUse    []uint64
+		expected uint64
+	}
+	for _, tc := range []testConfig{
+		{name: "nothing in use", from: 7, inUse: []uint64{}, expected: 7},
+		{name: "contiguous", from: 7, inUse: []uint64{7, 8, 9}, expected: 10},
+		{name: "released nonce", from: 7, inUse: []uint64{7, 9, 10}, expected: 8},
+		{name: "pending nonce released", from: 7, inUse: []uint64{8, 9}, expected: 7},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, firstFreeNonce(tc.from, tc.inUse))
+		})
+	}
+}
+
+func TestNonceManager_ConcurrentSend(t *testing.T) {
+	ctx := context.Background()
+	backend, client, key := newNonceManagerTestBackend(t)
+	manager := NewNonceManager(repositories.NewPublishingNonce(), storage)
+
+	const transactions = 10
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		txs []*types.Transaction
+	)
+	for i := 0; i < transactions; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			opts := newNonceManagerTestOpts(t, key)
+			tx, err := manager.Send(ctx, client, opts, transferFn(ctx, backend))
+			assert.NoError(t, err)
+			mu.Lock()
+			txs = append(txs, tx)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Len(t, txs, transactions)
+
+	nonces := make([]uint64, 0, transactions)
+	for _, tx := range txs {
+		nonces = append(nonces, tx.Nonce())
+		waitMined(t, backend, tx)
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	for i, nonce := range nonces {
+		assert.Equal(t, uint64(i), nonce)
+	}
+}
+
+func TestNonceManager_RecoverDroppedTransaction(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewPublishingNonce()
+	manager := NewNonceManager(repo, storage)
+
+	t.Run("should send again a dropped transaction", func(t *testing.T) {
+		backend, client, key := newNonceManagerTestBackend(t)
+		from := crypto.PubkeyToAddress(key.PublicKey)
+
+		// the transaction with nonce 0 was sent but the node does not know it
+		opts := newNonceManagerTestOpts(t, key)
+		opts.Nonce = big.NewInt(0)
+		dropped, err := signTransfer(opts)
+		require.NoError(t, err)
+		rawTx, err := dropped.MarshalBinary()
+		require.NoError(t, err)
+		txID := dropped.Hash().Hex()
+		require.NoError(t, repo.Save(ctx, storage.Pgx, &domain.PublishingNonce{ChainID: SimulatedChainID, Address: from.Hex(), Nonce: 0, Status: domain.PublishingNonceSent, TxID: &txID, RawTx: rawTx, ModifiedAt: time.Now()}))
+
+		tx, err := manager.Send(ctx, client, newNonceManagerTestOpts(t, key), transferFn(ctx, backend))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), tx.Nonce())
+		waitMined(t, backend, dropped)
+		waitMined(t, backend, tx)
+	})
+
+	t.Run("should release the nonce of a dropped transaction that can't be sent again", func(t *testing.T) {
+		backend, client, key := newNonceManagerTestBackend(t)
+		from := crypto.PubkeyToAddress(key.PublicKey)
+
+		txID := common.HexToHash("0x01").Hex()
+		require.NoError(t, repo.Save(ctx, storage.Pgx, &domain.PublishingNonce{ChainID: SimulatedChainID, Address: from.Hex(), Nonce: 0, Status: domain.PublishingNonceSent, TxID: &txID, ModifiedAt: time.Now()}))
+
+		tx, err := manager.Send(ctx, client, newNonceManagerTestOpts(t, key), transferFn(ctx, backend))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), tx.Nonce())
+		waitMined(t, backend, tx)
+	})
+}
+
+func TestNonceManager_SentTransaction(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewPublishingNonce()
+	manager := NewNonceManager(repo, storage)
+	backend, client, key := newNonceManagerTestBackend(t)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	t.Run("should return a transaction dropped by the node", func(t *testing.T) {
+		opts := newNonceManagerTestOpts(t, key)
+		opts.Nonce = big.NewInt(0)
+		dropped, err := signTransfer(opts)
+		require.NoError(t, err)
+		rawTx, err := dropped.MarshalBinary()
+		require.NoError(t, err)
+		txID := dropped.Hash().Hex()
+		require.NoError(t, repo.Save(ctx, storage.Pgx, &domain.PublishingNonce{ChainID: SimulatedChainID, Address: from.Hex(), Nonce: 0, Status: domain.PublishingNonceSent, TxID: &txID, RawTx: rawTx, ModifiedAt: time.Now()}))
+
+		tx, err := manager.SentTransaction(ctx, client, txID)
+		require.NoError(t, err)
+		assert.Equal(t, txID, tx.Hash().Hex())
+		assert.Equal(t, dropped.To(), tx.To())
+		assert.Equal(t, dropped.Gas(), tx.Gas())
+		assert.Equal(t, dropped.GasPrice(), tx.GasPrice())
+	})
+
+	t.Run("should return that a mined transaction is not pending", func(t *testing.T) {
+		tx, err := manager.Send(ctx, client, newNonceManagerTestOpts(t, key), transferFn(ctx, backend))
+		require.NoError(t, err)
+		waitMined(t, backend, tx)
+
+		_, err = manager.SentTransaction(ctx, client, tx.Hash().Hex())
+		assert.ErrorIs(t, err, ErrTransactionNotPending)
+	})
+
+	t.Run("should return that an unknown transaction is not found", func(t *testing.T) {
+		_, err := manager.SentTransaction(ctx, client, common.HexToHash("0x01").Hex())
+		assert.ErrorIs(t, err, ErrTransactionNotFound)
+	})
+}
+
+func newNonceManagerTestBackend(t *testing.T) (*SimulatedBackend, *Client, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	backend, err := NewSimulatedBackend(SimulatedBackendConfig{
+		StateContract: common.HexToAddress("0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124"),
+		Alloc:         types.GenesisAlloc{crypto.PubkeyToAddress(key.PublicKey): {Balance: big.NewInt(params.Ether)}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, backend.Close()) })
+	client := NewClient(backend.Client(), &ClientConfig{RPCResponseTimeout: 5 * time.Second}, nil)
+	return backend, client, key
+}
+
+func newNonceManagerTestOpts(t *testing.T, key *ecdsa.PrivateKey) *bind.TransactOpts {
+	t.Helper()
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(SimulatedChainID))
+	require.NoError(t, err)
+	opts.GasPrice = big.NewInt(10 * params.GWei)
+	return opts
+}
+
+func signTransfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return opts.Signer(opts.From, types.NewTx(&types.LegacyTx{
+		Nonce:    opts.Nonce.Uint64(),
+		To:       &opts.From,
+		Value:    big.NewInt(1),
+		Gas:      cancelGasLimit,
+		GasPrice: opts.GasPrice,
+	}))
+}
+
+func transferFn(ctx context.Context, backend *SimulatedBackend) func(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		tx, err := signTransfer(opts)
+		if err != nil {
+			return nil, err
+		}
+		return tx, backend.Client().SendTransaction(ctx, tx)
+	}
+}
+
+func waitMined(t *testing.T, backend *SimulatedBackend, tx *types.Transaction) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := bind.WaitMined(ctx, backend.Client(), tx)
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+}
@@ -0,0 +1,134 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethService "github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/catalyst"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// SimulatedChainID is the chain id of the simulated chain
+const SimulatedChainID = 1337
+
+// SimulatedBackendConfig configures the simulated chain
+type SimulatedBackendConfig struct {
+	// StateContract is the address the State contract is installed at
+	StateContract common.Address
+	// Alloc is the genesis balance of the accounts
+	Alloc types.GenesisAlloc
+	// BlockPeriod is how often a block is produced. If it is less than a second a block is produced
+	// for every transaction.
+	BlockPeriod time.Duration
+}
+
+// SimulatedBackend is an in-process Ethereum chain with the State contract installed, so the state transitions can
+// be published and confirmed without a network.
+type SimulatedBackend struct {
+	node   *node.Node
+	beacon *catalyst.SimulatedBeacon
+	client *ethclient.Client
+}
+
+// NewSimulatedBackend starts a simulated chain. The chain is kept in memory and exposed with an in-process
+// client and a JSON-RPC endpoint listening on a random local port.
+func NewSimulatedBackend(cfg SimulatedBackendConfig) (*SimulatedBackend, error) {
+	stateCode, err := simulatedStateCode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the state contract code: %w", err)
+	}
+	alloc := types.GenesisAlloc{cfg.StateContract: {Code: stateCode, Balance: common.Big0}}
+	for addr, account := range cfg.Alloc {
+		if addr == cfg.StateContract {
+			return nil, errors.New("the state contract address can't be allocated to an account")
+		}
+		alloc[addr] = account
+	}
+
+	nodeConf := node.DefaultConfig
+	nodeConf.DataDir = ""
+	nodeConf.P2P = p2p.Config{NoDiscovery: true}
+	nodeConf.IPCPath = ""
+	nodeConf.HTTPHost = "127.0.0.1"
+	nodeConf.HTTPPort = 0
+	nodeConf.HTTPModules = []string{"eth", "net", "web3"}
+
+	ethConf := ethconfig.Defaults
+	ethConf.Genesis = &core.Genesis{
+		Config:   params.AllDevChainProtocolChanges,
+		GasLimit: ethconfig.Defaults.Miner.GasCeil,
+		Alloc:    alloc,
+	}
+	ethConf.SyncMode = downloader.FullSync
+	ethConf.TxPool.NoLocals = true
+
+	stack, err := node.New(&nodeConf)
+	if err != nil {
+		return nil, err
+	}
+	backend, err := ethService.New(stack, &ethConf)
+	if err != nil {
+		_ = stack.Close()
+		return nil, err
+	}
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "eth",
+		Service:   filters.NewFilterAPI(filters.NewFilterSystem(backend.APIBackend, filters.Config{})),
+	}})
+	beacon, err := catalyst.NewSimulatedBeacon(uint64(cfg.BlockPeriod/time.Second), backend)
+	if err != nil {
+		_ = stack.Close()
+		return nil, err
+	}
+	// with a zero period the beacon api mines a block for every transaction
+	catalyst.RegisterSimulatedBeaconAPIs(stack, beacon)
+	stack.RegisterLifecycle(beacon)
+	if err := stack.Start(); err != nil {
+		_ = stack.Close()
+		return nil, err
+	}
+
+	return &SimulatedBackend{
+		node:   stack,
+		beacon: beacon,
+		client: ethclient.NewClient(stack.Attach()),
+	}, nil
+}
+
+// Client returns an in-process client of the simulated chain
+func (b *SimulatedBackend) Client() *ethclient.Client {
+	return b.client
+}
+
+// URL returns the JSON-RPC endpoint of the simulated chain
+func (b *SimulatedBackend) URL() string {
+	return b.node.HTTPEndpoint()
+}
+
+// Commit produces a new block with the pending transactions and returns its hash
+func (b *SimulatedBackend) Commit() common.Hash {
+	return b.beacon.Commit()
+}
+
+// Fork sets the head of the chain to the block with parentHash, so the next blocks replace the ones after it
+func (b *SimulatedBackend) Fork(parentHash common.Hash) error {
+	return b.beacon.Fork(parentHash)
+}
+
+// Close stops the simulated chain
+func (b *SimulatedBackend) Close() error {
+	b.client.Close()
+	return b.node.Close()
+}
@@ -0,0 +1,125 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/iden3/contracts-abi/state/go/abi"
+)
+
+// simulatedStateCode returns the runtime code of the State contract installed in the simulated chain.
+//
+// The compiled iden3 State contract, with its verifier and SMT libraries, is not vendored in this repository nor
+// published as a Go module (github.com/iden3/contracts-abi ships only the ABI bindings), so the simulated chain runs a
+// minimal contract with the same ABI for the methods the issuer node uses:
+//   - transitState and transitStateGeneric revert unless oldState is the latest state of the identity (or the
+//     identity has no state yet and isOldStateGenesis is true) and store newState as the latest state.
+//   - getStateInfoById returns the id and its latest state, or reverts if the identity has no state.
+//
+// Proofs are not verified and the state history, GIST and timestamps are not tracked.
+func simulatedStateCode() ([]byte, error) {
+	stateABI, err := abi.StateMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	selectors := make(map[string][]byte)
+	for _, name := range []string{"transitState", "transitStateGeneric", "getStateInfoById"} {
+		method, ok := stateABI.Methods[name]
+		if !ok {
+			return nil, fmt.Errorf("method %s not found in the state contract abi", name)
+		}
+		selectors[name] = method.ID
+	}
+
+	// calldata offsets of the arguments shared by transitState and transitStateGeneric
+	const (
+		idArg                = 0x04
+		oldStateArg          = 0x24
+		newStateArg          = 0x44
+		isOldStateGenesisArg = 0x64
+		stateInfoFields      = 7
+	)
+
+	a := newAssembler()
+	// dispatch on the method selector
+	a.push(0).op(vm.CALLDATALOAD).push(0xe0).op(vm.SHR)
+	a.op(vm.DUP1).pushBytes(selectors["transitState"]).op(vm.EQ).pushLabel("transit").op(vm.JUMPI)
+	a.op(vm.DUP1).pushBytes(selectors["transitStateGeneric"]).op(vm.EQ).pushLabel("transit").op(vm.JUMPI)
+	a.op(vm.DUP1).pushBytes(selectors["getStateInfoById"]).op(vm.EQ).pushLabel("info").op(vm.JUMPI)
+	a.label("revert").push(0).op(vm.DUP1, vm.REVERT)
+
+	// transitState(id, oldState, newState, isOldStateGenesis, ...)
+	a.label("transit").push(idArg).op(vm.CALLDATALOAD, vm.SLOAD)
+	a.push(isOldStateGenesisArg).op(vm.CALLDATALOAD).pushLabel("genesis").op(vm.JUMPI)
+	a.op(vm.DUP1, vm.ISZERO).pushLabel("revert").op(vm.JUMPI)
+	a.push(oldStateArg).op(vm.CALLDATALOAD, vm.EQ, vm.ISZERO).pushLabel("revert").op(vm.JUMPI)
+	a.pushLabel("store").op(vm.JUMP)
+	a.label("genesis").pushLabel("revert").op(vm.JUMPI)
+	a.label("store").push(newStateArg).op(vm.CALLDATALOAD, vm.DUP1, vm.ISZERO).pushLabel("revert").op(vm.JUMPI)
+	a.push(idArg).op(vm.CALLDATALOAD, vm.SSTORE, vm.STOP)
+
+	// getStateInfoById(id)
+	a.label("info").push(idArg).op(vm.CALLDATALOAD, vm.DUP1, vm.SLOAD)
+	a.op(vm.DUP1, vm.ISZERO).pushLabel("revert").op(vm.JUMPI)
+	a.push(0x20).op(vm.MSTORE).push(0).op(vm.MSTORE)
+	a.push(stateInfoFields * common.HashLength).push(0).op(vm.RETURN)
+
+	return a.assemble()
+}
+
+// assembler builds EVM code, resolving jump labels to two bytes offsets.
+type assembler struct {
+	code   []byte
+	labels map[string]int
+	jumps  map[int]string
+}
+
+func newAssembler() *assembler {
+	return &assembler{labels: make(map[string]int), jumps: make(map[int]string)}
+}
+
+func (a *assembler) op(ops ...vm.OpCode) *assembler {
+	for _, op := range ops {
+		a.code = append(a.code, byte(op))
+	}
+	return a
+}
+
+func (a *assembler) push(v uint64) *assembler {
+	return a.pushBytes(new(big.Int).SetUint64(v).Bytes())
+}
+
+func (a *assembler) pushBytes(b []byte) *assembler {
+	if len(b) == 0 {
+		b = []byte{0}
+	}
+	a.code = append(a.code, byte(vm.PUSH1)+byte(len(b)-1))
+	a.code = append(a.code, b...)
+	return a
+}
+
+func (a *assembler) pushLabel(name string) *assembler {
+	a.code = append(a.code, byte(vm.PUSH2))
+	a.jumps[len(a.code)] = name
+	a.code = append(a.code, 0, 0)
+	return a
+}
+
+func (a *assembler) label(name string) *assembler {
+	a.labels[name] = len(a.code)
+	return a.op(vm.JUMPDEST)
+}
+
+func (a *assembler) assemble() ([]byte, error) {
+	for pos, name := range a.jumps {
+		dest, ok := a.labels[name]
+		if !ok {
+			return nil, fmt.Errorf("undefined label %s", name)
+		}
+		a.code[pos] = byte(dest >> 8)
+		a.code[pos+1] = byte(dest)
+	}
+	return a.code, nil
+}
@@ -0,0 +1,72 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/contracts-abi/state/go/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulatedBackend_StateTransitions(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	contractAddress := common.HexToAddress("0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124")
+
+	backend, err := NewSimulatedBackend(SimulatedBackendConfig{
+		StateContract: contractAddress,
+		Alloc:         types.GenesisAlloc{from: {Balance: big.NewInt(1e18)}},
+	})
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, backend.Close()) }()
+
+	chainID, err := backend.Client().ChainID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(SimulatedChainID), chainID.Int64())
+
+	state, err := abi.NewState(contractAddress, backend.Client())
+	require.NoError(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	require.NoError(t, err)
+	opts.Context = ctx
+
+	id := big.NewInt(42)
+	_, err = state.GetStateInfoById(&bind.CallOpts{Context: ctx}, id)
+	assert.Error(t, err)
+
+	transit := func(oldState, newState int64, isOldStateGenesis bool) error {
+		tx, err := state.TransitStateGeneric(opts, id, big.NewInt(oldState), big.NewInt(newState), isOldStateGenesis, big.NewInt(1), []byte{})
+		if err != nil {
+			return err
+		}
+		backend.Commit()
+		receipt, err := backend.Client().TransactionReceipt(ctx, tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		return nil
+	}
+
+	require.NoError(t, transit(1, 2, true))
+	info, err := state.GetStateInfoById(&bind.CallOpts{Context: ctx}, id)
+	require.NoError(t, err)
+	assert.Equal(t, id, info.Id)
+	assert.Equal(t, big.NewInt(2), info.State)
+
+	assert.Error(t, transit(1, 3, true), "genesis transition of an identity with state")
+	assert.Error(t, transit(1, 3, false), "transition from a state that is not the latest")
+	require.NoError(t, transit(2, 3, false))
+
+	info, err = state.GetStateInfoById(&bind.CallOpts{Context: ctx}, id)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(3), info.State)
+
+	assert.NotEmpty(t, backend.URL())
+}
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/google/uuid"
 	"github.com/iden3/go-circuits/v2"
@@ -22,6 +23,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/core/event"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/eth"
 	"github.com/polygonid/sh-id-platform/internal/kms"
 	"github.com/polygonid/sh-id-platform/internal/log"
 	"github.com/polygonid/sh-id-platform/internal/network"
@@ -38,6 +40,14 @@ var (
 	ErrStateIsBeingProcessed = errors.New("the state is being processed")
 	// ErrNoFailedStatesToProcess - No fialed states to process
 	ErrNoFailedStatesToProcess = errors.New("no failed states to process")
+	// ErrNoPendingStateToReplace - No state transition waiting to be mined
+	ErrNoPendingStateToReplace = errors.New("no pending state transition to replace")
+	// ErrStateTransitionCancelled - The state transition is being cancelled
+	ErrStateTransitionCancelled = errors.New("the state transition is being cancelled")
+	// ErrStateTransitionReorged - The block that includes the state transition is no longer in the canonical chain
+	ErrStateTransitionReorged = errors.New("the block of the state transition was reorged out")
+	// ErrStateTransitionSimulationFailed - The state contract rejects the state transition
+	ErrStateTransitionSimulationFailed = errors.New("state transition simulation failed")
 )
 
 const (
@@ -49,6 +59,7 @@ const (
 // PublisherGateway - Define the interface for publishers.
 type PublisherGateway interface {
 	PublishState(ctx context.Context, identifier *w3c.DID, latestState *merkletree.Hash, newState *merkletree.Hash, isOldStateGenesis bool, proof *rstypes.ProofData, identity *domain.Identity) (*string, error)
+	ReplaceState(ctx context.Context, identifier *w3c.DID, identity *domain.Identity, txID string, cancel bool) (*string, error)
 }
 
 type publisher struct {
@@ -117,6 +128,68 @@ func (p *publisher) RetryPublishState(ctx context.Context, identifier *w3c.DID)
 	return newState, err
 }
 
+// SpeedUpPublishState replaces the pending transaction of the state transition of the identity with a new one
+// with the same nonce and higher fees
+func (p *publisher) SpeedUpPublishState(ctx context.Context, identifier *w3c.DID) (*domain.IdentityState, error) {
+	return p.replacePendingState(ctx, identifier, false)
+}
+
+// CancelPublishState replaces the pending transaction of the state transition of the identity with an empty one
+// with the same nonce and higher fees. Once the cancellation is confirmed, the state is marked as failed, so it
+// can be published again with the retry endpoint.
+func (p *publisher) CancelPublishState(ctx context.Context, identifier *w3c.DID) (*domain.IdentityState, error) {
+	return p.replacePendingState(ctx, identifier, true)
+}
+
+func (p *publisher) replacePendingState(ctx context.Context, identifier *w3c.DID, cancel bool) (*domain.IdentityState, error) {
+	state, err := p.identityService.GetTransactedState(ctx, *identifier)
+	if err != nil {
+		log.Error(ctx, "error fetching transacted state", "err", err)
+		return nil, err
+	}
+	if state == nil || state.TxID == nil {
+		return nil, ErrNoPendingStateToReplace
+	}
+	if err := p.replaceState(ctx, state, cancel); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// replaceState sends the replacement of the transaction of the state and records it. A speed up keeps the
+// replaced transaction in ReplacedTxIDs, a cancellation is recorded in CancelTxID.
+func (p *publisher) replaceState(ctx context.Context, state *domain.IdentityState, cancel bool) error {
+	if state.CancelTxID != nil {
+		return ErrStateTransitionCancelled
+	}
+	did, err := w3c.ParseDID(state.Identifier)
+	if err != nil {
+		return err
+	}
+	identity, err := p.identityService.GetByDID(ctx, *did)
+	if err != nil {
+		return err
+	}
+
+	txID, err := p.publisherGateway.ReplaceState(ctx, did, identity, *state.TxID, cancel)
+	if err != nil {
+		log.Error(ctx, "replacing state transaction", "err", err, "did", state.Identifier, "tx", *state.TxID, "cancel", cancel)
+		return err
+	}
+	if cancel {
+		state.CancelTxID = txID
+	} else {
+		state.ReplacedTxIDs = append(state.ReplacedTxIDs, *state.TxID)
+		state.TxID = txID
+	}
+	if err := p.identityService.UpdateIdentityState(ctx, state); err != nil {
+		log.Error(ctx, "saving state transaction replacement", "err", err, "did", state.Identifier, "tx", *txID)
+		return err
+	}
+	log.Info(ctx, "state transaction replaced", "did", state.Identifier, "tx", *txID, "cancel", cancel)
+	return nil
+}
+
 func (p *publisher) publishState(ctx context.Context, identifier *w3c.DID) (*domain.PublishedState, error) {
 	exists, err := p.identityService.HasUnprocessedStatesByID(ctx, *identifier)
 	if err != nil {
@@ -140,9 +213,7 @@ func (p *publisher) publishState(ctx context.Context, identifier *w3c.DID) (*dom
 	if err != nil {
 		// TODO: Handle RHS status already published
 		log.Error(ctx, "Error during publishing proof:", "err", err, "did", identifier.String())
-		updatedState.Status = domain.StatusFailed
-		errUpdating := p.identityService.UpdateIdentityState(ctx, updatedState)
-		if errUpdating != nil {
+		if errUpdating := p.markStateFailed(ctx, updatedState, err); errUpdating != nil {
 			log.Error(ctx, "Error saving the state as failed:", "err", err, "did", identifier.String())
 			return nil, errUpdating
 		}
@@ -173,6 +244,9 @@ func (p *publisher) retryPublishFailedState(ctx context.Context, identifier *w3c
 	txID, err := p.publishProof(ctx, identifier, *failedState)
 	if err != nil {
 		log.Error(ctx, "Error during publishing proof:", "err", err, "did", identifier.String())
+		if errUpdating := p.markStateFailed(ctx, failedState, err); errUpdating != nil {
+			log.Error(ctx, "Error saving the failure reason:", "err", errUpdating, "did", identifier.String())
+		}
 		return nil, err
 	}
 
@@ -185,6 +259,14 @@ func (p *publisher) retryPublishFailedState(ctx context.Context, identifier *w3c
 	}, nil
 }
 
+// markStateFailed marks the state as failed with the reason why it was not published: an invalid proof, a state
+// transition rejected by the contract in the simulation or any other error
+func (p *publisher) markStateFailed(ctx context.Context, state *domain.IdentityState, reason error) error {
+	state.Status = domain.StatusFailed
+	state.FailureReason = common.ToPointer(reason.Error())
+	return p.identityService.UpdateIdentityState(ctx, state)
+}
+
 // PublishProof publishes new proof using the latest state
 func (p *publisher) publishProof(ctx context.Context, identifier *w3c.DID, newState domain.IdentityState) (*string, error) {
 	did, err := w3c.ParseDID(newState.Identifier)
@@ -288,6 +370,10 @@ func (p *publisher) publishProof(ctx context.Context, identifier *w3c.DID, newSt
 		if err != nil {
 			return nil, err
 		}
+		// a proof that does not verify would be rejected by the state contract after paying for the transaction
+		if err := p.zkService.VerifyProof(ctx, zkProof, string(circuits.StateTransitionCircuitID)); err != nil {
+			return nil, err
+		}
 
 		zkProofData = zkProof.Proof
 	}
@@ -305,6 +391,7 @@ func (p *publisher) publishProof(ctx context.Context, identifier *w3c.DID, newSt
 
 	newState.Status = domain.StatusTransacted
 	newState.TxID = txID
+	newState.FailureReason = nil
 
 	err = p.identityService.UpdateIdentityState(ctx, &newState)
 	if err != nil {
@@ -433,8 +520,16 @@ func (p *publisher) updateIdentityStateTxStatus(ctx context.Context, identity *d
 		return err
 	}
 
+	// the receipt can belong to a block that was reorged out, the state is only updated if it is canonical
+	if header.Hash() != receipt.BlockHash {
+		log.Warn(ctx, "the block of the transaction is not canonical", "tx", receipt.TxHash.Hex(), "block", receipt.BlockNumber,
+			"hash", receipt.BlockHash.Hex(), "canonical hash", header.Hash().Hex())
+		return ErrStateTransitionReorged
+	}
+
 	blockNumber := int(receipt.BlockNumber.Int64())
 	state.BlockNumber = &blockNumber
+	state.BlockHash = common.ToPointer(receipt.BlockHash.Hex())
 
 	blockTime := int(header.Time)
 	state.BlockTimestamp = &blockTime
@@ -559,27 +654,26 @@ func (p *publisher) checkStatus(ctx context.Context, identity *domain.Identity,
 		}
 	}
 
-	// GetEthClient receipt and check status
-	receipt, err := p.transactionService.GetTransactionReceiptByID(ctx, identity, *state.TxID)
+	receipt, err := p.findReceipt(ctx, identity, state)
 	if err != nil {
-		log.Error(ctx, "error during receipt receiving:", "err", err, "state-id", *state.TxID)
-		return fmt.Errorf("error during receipt receiving::%s: %w", *state.TxID, err)
-	}
-
-	resolverPrefix, err := identity.GetResolverPrefix()
-	if err != nil {
-		log.Error(ctx, "failed to get networkResolver prefix", "err", err)
 		return err
 	}
-
-	confirmationBlockCount, err := p.networkResolver.GetConfirmationBlockCount(resolverPrefix)
-	if err != nil {
-		log.Error(ctx, "failed to get confirmation block count", "err", err)
+	if receipt == nil {
+		if state.BlockHash != nil {
+			return p.handleReorg(ctx, identity, state)
+		}
+		if state.CancelTxID != nil {
+			return p.checkCancellation(ctx, identity, state)
+		}
+		p.replaceStuckState(ctx, identity, state)
+		return ErrStateIsBeingProcessed
+	}
+	if err := p.trackBlock(ctx, identity, state, receipt); err != nil {
 		return err
 	}
 
 	// Check if transaction has enough confirmation blocks
-	confirmed, err := p.transactionService.CheckConfirmation(ctx, identity, receipt, confirmationBlockCount)
+	confirmed, err := p.isConfirmed(ctx, identity, receipt)
 	if err != nil {
 		log.Error(ctx, fmt.Sprintf("transaction receipt is found, but confirmation is not checked - %s", *state.TxID), "err", err)
 		return fmt.Errorf("transaction receipt is found, but confirmation is not checked:%s - %w", *state.TxID, err)
@@ -592,6 +686,10 @@ func (p *publisher) checkStatus(ctx context.Context, identity *domain.Identity,
 
 	err = p.updateIdentityStateTxStatus(ctx, identity, state, receipt)
 	if err != nil {
+		if errors.Is(err, ErrStateTransitionReorged) {
+			// the receipt is stale, the reorg is handled once the node returns the new one or none
+			return ErrStateIsBeingProcessed
+		}
 		log.Error(ctx, "error during identity state update: ", "err", err)
 		return err
 	}
@@ -599,3 +697,207 @@ func (p *publisher) checkStatus(ctx context.Context, identity *domain.Identity,
 	log.Info(ctx, "transaction status updated", "tx", *state.TxID)
 	return nil
 }
+
+// trackBlock stores the block that includes the transaction of the state transition, so the state can be moved
+// back to pending if the block is reorged out before the transaction is confirmed.
+// A receipt from another block is only tracked once that block is canonical, as a node that is behind can still
+// return the receipt of the reorged block.
+func (p *publisher) trackBlock(ctx context.Context, identity *domain.Identity, state *domain.IdentityState, receipt *types.Receipt) error {
+	blockHash := receipt.BlockHash.Hex()
+	if state.BlockHash != nil && *state.BlockHash == blockHash {
+		return nil
+	}
+	if state.BlockHash != nil {
+		reorged, err := p.isReorged(ctx, identity, receipt.BlockNumber, blockHash)
+		if err != nil {
+			return err
+		}
+		if reorged {
+			log.Warn(ctx, "receipt of the state transition belongs to a block that is not canonical", "did", state.Identifier,
+				"tx", *state.TxID, "block", receipt.BlockNumber, "hash", blockHash)
+			return ErrStateIsBeingProcessed
+		}
+		log.Warn(ctx, "state transition included in another block after a reorg", "did", state.Identifier, "tx", *state.TxID,
+			"block", receipt.BlockNumber, "hash", blockHash, "previous hash", *state.BlockHash)
+	}
+	blockNumber := int(receipt.BlockNumber.Int64())
+	state.BlockNumber = &blockNumber
+	state.BlockHash = &blockHash
+	if err := p.identityService.UpdateIdentityState(ctx, state); err != nil {
+		log.Error(ctx, "state block is not updated", "err", err)
+		return err
+	}
+	return nil
+}
+
+// handleReorg moves the state back to pending when the block that included its transaction is no longer canonical.
+// The transaction usually returns to the pool of the node and is mined again. If none of the transactions of the
+// state transition is known by the node, the state is marked as failed and published again.
+// A missing receipt alone is not a reorg, the node can fail to return it for a while, so the state is only moved
+// back when the canonical block at its height has another hash.
+func (p *publisher) handleReorg(ctx context.Context, identity *domain.Identity, state *domain.IdentityState) error {
+	reorged, err := p.isReorged(ctx, identity, big.NewInt(int64(*state.BlockNumber)), *state.BlockHash)
+	if err != nil {
+		return err
+	}
+	if !reorged {
+		log.Warn(ctx, "receipt of the state transition not found in its block", "did", state.Identifier, "tx", *state.TxID,
+			"hash", *state.BlockHash)
+		return ErrStateIsBeingProcessed
+	}
+	log.Warn(ctx, "state transition reorged out", "did", state.Identifier, "tx", *state.TxID, "hash", *state.BlockHash)
+	state.BlockNumber = nil
+	state.BlockHash = nil
+	state.BlockTimestamp = nil
+	return p.republishIfDropped(ctx, identity, state)
+}
+
+// republishIfDropped keeps the state as transacted while any of its transactions is pending in the node.
+// Otherwise the state transition is lost, so the state is marked as failed and published again.
+func (p *publisher) republishIfDropped(ctx context.Context, identity *domain.Identity, state *domain.IdentityState) error {
+	for _, txID := range state.TxIDs() {
+		pending, err := p.transactionService.IsTransactionPending(ctx, identity, txID)
+		if err != nil {
+			log.Error(ctx, "error checking if the transaction is pending", "err", err, "tx", txID)
+			return err
+		}
+		if pending {
+			state.Status = domain.StatusTransacted
+			if err := p.identityService.UpdateIdentityState(ctx, state); err != nil {
+				log.Error(ctx, "state is not updated", "err", err)
+				return err
+			}
+			return ErrStateIsBeingProcessed
+		}
+	}
+
+	state.Status = domain.StatusFailed
+	if err := p.identityService.UpdateIdentityState(ctx, state); err != nil {
+		log.Error(ctx, "state is not updated", "err", err)
+		return err
+	}
+	did, err := w3c.ParseDID(state.Identifier)
+	if err != nil {
+		log.Error(ctx, "error getting did from state: ", "err", err, "state", state.StateID)
+		return err
+	}
+	published, err := p.retryPublishFailedState(ctx, did)
+	if err != nil {
+		log.Error(ctx, "dropped state transition not published again", "err", err, "did", state.Identifier)
+		return err
+	}
+	log.Info(ctx, "dropped state transition published again", "did", state.Identifier, "tx", *published.TxID)
+	return nil
+}
+
+// isReorged returns true if the canonical block at blockNumber has a different hash than blockHash.
+// A block the node doesn't have yet is not considered reorged.
+func (p *publisher) isReorged(ctx context.Context, identity *domain.Identity, blockNumber *big.Int, blockHash string) (bool, error) {
+	header, err := p.transactionService.GetHeaderByNumber(ctx, identity, blockNumber)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return false, nil
+		}
+		log.Error(ctx, "couldn't get the canonical block", "err", err, "block", blockNumber)
+		return false, err
+	}
+	return header.Hash().Hex() != blockHash, nil
+}
+
+// findReceipt returns the receipt of the transaction of the state transition that was mined, or nil if none was.
+// After a replacement, any of the transactions sent can be the mined one, so the state is updated to point to it.
+func (p *publisher) findReceipt(ctx context.Context, identity *domain.Identity, state *domain.IdentityState) (*types.Receipt, error) {
+	for _, txID := range state.TxIDs() {
+		receipt, err := p.transactionService.GetTransactionReceiptByID(ctx, identity, txID)
+		if err != nil {
+			if isReceiptNotFound(err) {
+				continue
+			}
+			log.Error(ctx, "error during receipt receiving:", "err", err, "state-id", txID)
+			return nil, fmt.Errorf("error during receipt receiving::%s: %w", txID, err)
+		}
+		if txID != *state.TxID {
+			log.Info(ctx, "a replaced transaction of the state transition was mined", "tx", txID, "did", state.Identifier)
+			state.TxID = common.ToPointer(txID)
+		}
+		return receipt, nil
+	}
+	return nil, nil
+}
+
+// checkCancellation marks the state as failed once the transaction that cancels it is confirmed
+func (p *publisher) checkCancellation(ctx context.Context, identity *domain.Identity, state *domain.IdentityState) error {
+	receipt, err := p.transactionService.GetTransactionReceiptByID(ctx, identity, *state.CancelTxID)
+	if err != nil {
+		if isReceiptNotFound(err) {
+			return ErrStateIsBeingProcessed
+		}
+		return fmt.Errorf("error during receipt receiving::%s: %w", *state.CancelTxID, err)
+	}
+	confirmed, err := p.isConfirmed(ctx, identity, receipt)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return ErrStateIsBeingProcessed
+	}
+
+	state.Status = domain.StatusFailed
+	if err := p.identityService.UpdateIdentityState(ctx, state); err != nil {
+		log.Error(ctx, "state is not updated", "err", err)
+		return err
+	}
+	log.Info(ctx, "state transition cancelled", "did", state.Identifier, "tx", *state.CancelTxID)
+	return nil
+}
+
+// replaceStuckState speeds up the transaction of the state if it is pending for longer than the stuck transaction
+// timeout of the network. Nothing is replaced if the timeout is not set. A transaction that is lost is published
+// again.
+func (p *publisher) replaceStuckState(ctx context.Context, identity *domain.Identity, state *domain.IdentityState) {
+	resolverPrefix, err := identity.GetResolverPrefix()
+	if err != nil {
+		log.Error(ctx, "failed to get networkResolver prefix", "err", err)
+		return
+	}
+	stuckTxTimeout, err := p.networkResolver.GetStuckTxTimeout(resolverPrefix)
+	if err != nil {
+		log.Error(ctx, "failed to get stuck transaction timeout", "err", err)
+		return
+	}
+	if stuckTxTimeout == 0 || time.Since(state.ModifiedAt) < stuckTxTimeout {
+		return
+	}
+	err = p.replaceState(ctx, state, false)
+	if errors.Is(err, eth.ErrTransactionNotFound) {
+		// neither the node nor the nonce manager know the transaction anymore
+		log.Warn(ctx, "stuck state transaction dropped", "did", state.Identifier, "tx", *state.TxID)
+		// the errors are logged by republishIfDropped
+		_ = p.republishIfDropped(ctx, identity, state)
+		return
+	}
+	if err != nil {
+		log.Warn(ctx, "stuck state transaction not replaced", "err", err, "did", state.Identifier, "tx", *state.TxID)
+	}
+}
+
+func (p *publisher) isConfirmed(ctx context.Context, identity *domain.Identity, receipt *types.Receipt) (bool, error) {
+	resolverPrefix, err := identity.GetResolverPrefix()
+	if err != nil {
+		log.Error(ctx, "failed to get networkResolver prefix", "err", err)
+		return false, err
+	}
+
+	confirmationBlockCount, err := p.networkResolver.GetConfirmationBlockCount(resolverPrefix)
+	if err != nil {
+		log.Error(ctx, "failed to get confirmation block count", "err", err)
+		return false, err
+	}
+
+	return p.transactionService.CheckConfirmation(ctx, identity, receipt, confirmationBlockCount)
+}
+
+// isReceiptNotFound returns true if the error means that the transaction is not mined
+func isReceiptNotFound(err error) bool {
+	return errors.Is(err, ethereum.NotFound) || errors.Is(err, eth.ErrReceiptNotReceived)
+}
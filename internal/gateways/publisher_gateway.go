@@ -3,10 +3,12 @@ package gateways
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/iden3/contracts-abi/state/go/abi"
 	core "github.com/iden3/go-iden3-core/v2"
@@ -16,6 +18,7 @@ import (
 
 	"github.com/polygonid/sh-id-platform/internal/common"
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/eth"
 	"github.com/polygonid/sh-id-platform/internal/kms"
 	"github.com/polygonid/sh-id-platform/internal/log"
@@ -24,40 +27,36 @@ import (
 
 // PublisherEthGateway interact with blockchain
 type PublisherEthGateway struct {
-	rw                    *sync.RWMutex
 	kms                   *kms.KMS
-	publishingKeyID       kms.KeyID
+	publishingKeys        ports.PublishingKeyService
 	ethRPCResponseTimeout time.Duration
 	networkResolver       network.Resolver
+	nonceManager          *eth.NonceManager
 }
 
 const rpcTimeout = 10 * time.Second
 
-// NewPublisherEthGateway creates new instance of publishing service
-func NewPublisherEthGateway(resolver network.Resolver, keyStore *kms.KMS, publishingKeyPath string) (*PublisherEthGateway, error) {
+// NewPublisherEthGateway creates new instance of publishing service.
+// The state transitions of BJJ identities are signed with a key of publishingKeys. The nonces of the state
+// transitions are allocated by nonceManager, so many identities can publish concurrently with the same key.
+func NewPublisherEthGateway(resolver network.Resolver, keyStore *kms.KMS, publishingKeys ports.PublishingKeyService, nonceManager *eth.NonceManager) (*PublisherEthGateway, error) {
 	// TODO: make timeout configurable
 
-	return newStateService(resolver, rpcTimeout, keyStore, kms.KeyID{
-		Type: kms.KeyTypeEthereum,
-		ID:   publishingKeyPath,
-	})
+	return newStateService(resolver, rpcTimeout, keyStore, publishingKeys, nonceManager)
 }
 
-func newStateService(resolver network.Resolver, to time.Duration, kServ *kms.KMS, kPath kms.KeyID) (*PublisherEthGateway, error) {
+func newStateService(resolver network.Resolver, to time.Duration, kServ *kms.KMS, publishingKeys ports.PublishingKeyService, nonceManager *eth.NonceManager) (*PublisherEthGateway, error) {
 	return &PublisherEthGateway{
 		networkResolver:       resolver,
-		rw:                    &sync.RWMutex{},
 		kms:                   kServ,
-		publishingKeyID:       kPath,
+		publishingKeys:        publishingKeys,
 		ethRPCResponseTimeout: to,
+		nonceManager:          nonceManager,
 	}, nil
 }
 
 // PublishState creates or updates state in the blockchain
 func (pb *PublisherEthGateway) PublishState(ctx context.Context, identifier *w3c.DID, latestState, newState *merkletree.Hash, isOldStateGenesis bool, proof *rstypes.ProofData, identity *domain.Identity) (*string, error) {
-	pb.rw.Lock()
-	defer pb.rw.Unlock()
-
 	if common.CompareMerkleTreeHash(newState, latestState) {
 		return nil, errors.New("state hasn't been changed")
 	}
@@ -69,21 +68,14 @@ func (pb *PublisherEthGateway) PublishState(ctx context.Context, identifier *w3c
 		return nil, err
 	}
 
+	sigKeyID, release, err := pb.signingKeyID(ctx, identifier, identity)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	switch identity.KeyType {
 	case string(kms.KeyTypeEthereum):
-		keyIDs, err := pb.kms.KeysByIdentity(ctx, *identifier)
-		if err != nil {
-			return nil, err
-		}
-
-		var sigKeyID kms.KeyID
-		for _, v := range keyIDs {
-			if v.Type == kms.KeyTypeEthereum {
-				sigKeyID = v
-				break
-			}
-		}
-
 		ctxWT, cancel := context.WithTimeout(ctx, pb.ethRPCResponseTimeout)
 		defer cancel()
 
@@ -112,7 +104,12 @@ func (pb *PublisherEthGateway) PublishState(ctx context.Context, identifier *w3c
 			return nil, err
 		}
 
-		tx, err = contractBinding.TransitStateGeneric(opts, id.BigInt(), latestState.BigInt(), newState.BigInt(), isOldStateGenesis, big.NewInt(1), []byte{})
+		if err := simulateStateTransition(ctxWT, contractBinding, opts.From, "transitStateGeneric", id.BigInt(), latestState.BigInt(), newState.BigInt(), isOldStateGenesis, big.NewInt(1), []byte{}); err != nil {
+			return nil, err
+		}
+		tx, err = pb.nonceManager.Send(ctx, client, opts, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contractBinding.TransitStateGeneric(opts, id.BigInt(), latestState.BigInt(), newState.BigInt(), isOldStateGenesis, big.NewInt(1), []byte{})
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -127,7 +124,7 @@ func (pb *PublisherEthGateway) PublishState(ctx context.Context, identifier *w3c
 			return nil, err
 		}
 
-		opts, err := client.CreateTxOpts(ctxWT, pb.publishingKeyID)
+		opts, err := client.CreateTxOpts(ctxWT, sigKeyID)
 		if err != nil {
 			log.Error(ctx, "failed to create tx opts", "err", err)
 			return nil, err
@@ -150,7 +147,12 @@ func (pb *PublisherEthGateway) PublishState(ctx context.Context, identifier *w3c
 			log.Error(ctx, "failed to get contract binding", "err", err)
 			return nil, err
 		}
-		tx, err = contractBinding.TransitState(opts, id.BigInt(), latestState.BigInt(), newState.BigInt(), isOldStateGenesis, a, b, c)
+		if err := simulateStateTransition(ctxWT, contractBinding, opts.From, "transitState", id.BigInt(), latestState.BigInt(), newState.BigInt(), isOldStateGenesis, a, b, c); err != nil {
+			return nil, err
+		}
+		tx, err = pb.nonceManager.Send(ctx, client, opts, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contractBinding.TransitState(opts, id.BigInt(), latestState.BigInt(), newState.BigInt(), isOldStateGenesis, a, b, c)
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -163,6 +165,85 @@ func (pb *PublisherEthGateway) PublishState(ctx context.Context, identifier *w3c
 	return &txID, nil
 }
 
+// ReplaceState replaces the pending transaction of a state transition with a new one with higher fees, signed with
+// the same key. With cancel, the new transaction cancels the state transition. It returns the new transaction id.
+func (pb *PublisherEthGateway) ReplaceState(ctx context.Context, identifier *w3c.DID, identity *domain.Identity, txID string, cancel bool) (*string, error) {
+	client, err := getEthClient(ctx, identity, pb.networkResolver)
+	if err != nil {
+		log.Error(ctx, "failed to get client", "err", err)
+		return nil, err
+	}
+	sent, err := pb.nonceManager.SentTransaction(ctx, client, txID)
+	if err != nil {
+		return nil, err
+	}
+	sigKeyID, err := pb.senderKeyID(ctx, identifier, identity, sent)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := client.ReplaceTx(ctx, sigKeyID, sent, cancel)
+	if err != nil {
+		return nil, err
+	}
+	if err := pb.nonceManager.Replaced(ctx, client, tx); err != nil {
+		log.Error(ctx, "failed to record replacement transaction nonce", "err", err, "tx", tx.Hash().Hex())
+	}
+	newTxID := tx.Hash().Hex()
+	return &newTxID, nil
+}
+
+// signingKeyID returns the key that signs the next state transition of the identity: its own key for ETH identities
+// and a key of the publishing keys of its network for BJJ identities. release must be called once the transaction
+// is sent.
+func (pb *PublisherEthGateway) signingKeyID(ctx context.Context, identifier *w3c.DID, identity *domain.Identity) (keyID kms.KeyID, release func(), err error) {
+	switch identity.KeyType {
+	case string(kms.KeyTypeEthereum):
+		keyID, err := pb.identityKeyID(ctx, identifier)
+		return keyID, func() {}, err
+	case string(kms.KeyTypeBabyJubJub):
+		resolverPrefix, err := identity.GetResolverPrefix()
+		if err != nil {
+			return kms.KeyID{}, nil, err
+		}
+		return pb.publishingKeys.Acquire(ctx, resolverPrefix)
+	default:
+		return kms.KeyID{}, nil, errors.New("unsupported key type for publishing")
+	}
+}
+
+// senderKeyID returns the key that signed the transaction tx of a state transition of the identity
+func (pb *PublisherEthGateway) senderKeyID(ctx context.Context, identifier *w3c.DID, identity *domain.Identity, tx *types.Transaction) (kms.KeyID, error) {
+	switch identity.KeyType {
+	case string(kms.KeyTypeEthereum):
+		return pb.identityKeyID(ctx, identifier)
+	case string(kms.KeyTypeBabyJubJub):
+		resolverPrefix, err := identity.GetResolverPrefix()
+		if err != nil {
+			return kms.KeyID{}, err
+		}
+		sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return kms.KeyID{}, err
+		}
+		return pb.publishingKeys.KeyByAddress(ctx, resolverPrefix, sender)
+	default:
+		return kms.KeyID{}, errors.New("unsupported key type for publishing")
+	}
+}
+
+func (pb *PublisherEthGateway) identityKeyID(ctx context.Context, identifier *w3c.DID) (kms.KeyID, error) {
+	keyIDs, err := pb.kms.KeysByIdentity(ctx, *identifier)
+	if err != nil {
+		return kms.KeyID{}, err
+	}
+	for _, v := range keyIDs {
+		if v.Type == kms.KeyTypeEthereum {
+			return v, nil
+		}
+	}
+	return kms.KeyID{}, errors.New("ethereum key of the identity not found")
+}
+
 func (pb *PublisherEthGateway) adaptProofToAbi(proof *rstypes.ProofData) (proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, err error) {
 	a, err := common.ArrayStringToBigInt(proof.A)
 	if err != nil {
@@ -186,6 +267,19 @@ func (pb *PublisherEthGateway) adaptProofToAbi(proof *rstypes.ProofData) (proofA
 	return
 }
 
+// simulateStateTransition calls the method of the state contract with eth_call from the sender, so a state
+// transition rejected by the contract is not sent. It returns ErrStateTransitionSimulationFailed with the revert
+// reason if the call fails.
+func simulateStateTransition(ctx context.Context, binding *abi.State, from ethCommon.Address, method string, params ...interface{}) error {
+	raw := &abi.StateRaw{Contract: binding}
+	var out []interface{}
+	if err := raw.Call(&bind.CallOpts{From: from, Context: ctx}, &out, method, params...); err != nil {
+		log.Warn(ctx, "state transition simulation failed", "err", err, "method", method, "from", from.Hex())
+		return fmt.Errorf("%w: %s", ErrStateTransitionSimulationFailed, err)
+	}
+	return nil
+}
+
 func getContractBinding(ethClient *eth.Client, resolverPrefix string, resolver network.Resolver) (*abi.State, error) {
 	c := ethClient.GetEthereumClient()
 	addr, err := resolver.GetContractAddress(resolverPrefix)
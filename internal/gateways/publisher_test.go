@@ -0,0 +1,480 @@
+package gateways
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/event"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/eth"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/network"
+	"github.com/polygonid/sh-id-platform/internal/pubsub"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+const publisherTestDID = "did:polygonid:polygon:amoy:2qSuD8ZDpsAG3s8WJjwzqhMsqGLz8RUG1BHVUe3Gwu"
+
+func TestPublisher_ReplaceStuckState(t *testing.T) {
+	ctx := context.Background()
+	resolver := newPublisherTestResolver(t)
+	identity := &domain.Identity{Identifier: publisherTestDID, KeyType: "BJJ"}
+
+	t.Run("should not replace a transaction that is not stuck", func(t *testing.T) {
+		identityService, gateway := &fakeIdentityService{}, &fakePublisherGateway{}
+		p := NewPublisher(nil, identityService, nil, nil, nil, &fakeTransactionService{}, nil, gateway, resolver, nil)
+		state := newPublisherTestState(time.Now())
+
+		p.replaceStuckState(ctx, identity, state)
+		assert.Empty(t, gateway.replaced)
+		assert.Empty(t, identityService.updated)
+	})
+
+	t.Run("should speed up a stuck transaction", func(t *testing.T) {
+		identityService := &fakeIdentityService{}
+		gateway := &fakePublisherGateway{replacement: "0x02"}
+		p := NewPublisher(nil, identityService, nil, nil, nil, &fakeTransactionService{}, nil, gateway, resolver, nil)
+		state := newPublisherTestState(time.Now().Add(-time.Hour))
+
+		p.replaceStuckState(ctx, identity, state)
+		assert.Equal(t, []string{"0x01"}, gateway.replaced)
+		assert.Equal(t, "0x02", *state.TxID)
+		assert.Equal(t, []string{"0x01"}, state.ReplacedTxIDs)
+		assert.Equal(t, domain.StatusTransacted, state.Status)
+		require.Len(t, identityService.updated, 1)
+	})
+
+	t.Run("should keep a mined transaction", func(t *testing.T) {
+		identityService := &fakeIdentityService{}
+		gateway := &fakePublisherGateway{err: eth.ErrTransactionNotPending}
+		p := NewPublisher(nil, identityService, nil, nil, nil, &fakeTransactionService{}, nil, gateway, resolver, nil)
+		state := newPublisherTestState(time.Now().Add(-time.Hour))
+
+		p.replaceStuckState(ctx, identity, state)
+		assert.Equal(t, "0x01", *state.TxID)
+		assert.Equal(t, domain.StatusTransacted, state.Status)
+		assert.Empty(t, identityService.updated)
+	})
+
+	t.Run("should wait for a replaced transaction still pending", func(t *testing.T) {
+		identityService := &fakeIdentityService{}
+		gateway := &fakePublisherGateway{err: eth.ErrTransactionNotFound}
+		transactionService := &fakeTransactionService{pending: map[string]bool{"0x00": true}}
+		p := NewPublisher(nil, identityService, nil, nil, nil, transactionService, nil, gateway, resolver, nil)
+		state := newPublisherTestState(time.Now().Add(-time.Hour))
+		state.ReplacedTxIDs = []string{"0x00"}
+
+		p.replaceStuckState(ctx, identity, state)
+		assert.Equal(t, domain.StatusTransacted, state.Status)
+	})
+
+	t.Run("should publish again a dropped transaction", func(t *testing.T) {
+		identityService := &fakeIdentityService{}
+		gateway := &fakePublisherGateway{err: eth.ErrTransactionNotFound}
+		p := NewPublisher(nil, identityService, nil, nil, nil, &fakeTransactionService{}, nil, gateway, resolver, nil)
+		state := newPublisherTestState(time.Now().Add(-time.Hour))
+		identityService.failed = state
+
+		p.replaceStuckState(ctx, identity, state)
+		require.NotEmpty(t, identityService.updated)
+		assert.Equal(t, domain.StatusFailed, identityService.updated[0])
+		// the state is published again from the failed state
+		assert.True(t, identityService.failedStateRead)
+	})
+}
+
+func TestPublisher_CheckCancellation(t *testing.T) {
+	ctx := context.Background()
+	resolver := newPublisherTestResolver(t)
+	identity := &domain.Identity{Identifier: publisherTestDID, KeyType: "BJJ"}
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(10)}
+
+	t.Run("should wait for the cancellation to be mined", func(t *testing.T) {
+		identityService := &fakeIdentityService{}
+		p := NewPublisher(nil, identityService, nil, nil, nil, &fakeTransactionService{}, nil, &fakePublisherGateway{}, resolver, nil)
+		state := newPublisherTestState(time.Now())
+		state.CancelTxID = common.ToPointer("0x03")
+
+		assert.ErrorIs(t, p.checkCancellation(ctx, identity, state), ErrStateIsBeingProcessed)
+		assert.Equal(t, domain.StatusTransacted, state.Status)
+		assert.Empty(t, identityService.updated)
+	})
+
+	t.Run("should wait for the cancellation to be confirmed", func(t *testing.T) {
+		identityService := &fakeIdentityService{}
+		transactionService := &fakeTransactionService{receipts: map[string]*types.Receipt{"0x03": receipt}}
+		p := NewPublisher(nil, identityService, nil, nil, nil, transactionService, nil, &fakePublisherGateway{}, resolver, nil)
+		state := newPublisherTestState(time.Now())
+		state.CancelTxID = common.ToPointer("0x03")
+
+		assert.ErrorIs(t, p.checkCancellation(ctx, identity, state), ErrStateIsBeingProcessed)
+		assert.Equal(t, domain.StatusTransacted, state.Status)
+		assert.Empty(t, identityService.updated)
+	})
+
+	t.Run("should mark the state as failed once the cancellation is confirmed", func(t *testing.T) {
+		identityService := &fakeIdentityService{}
+		transactionService := &fakeTransactionService{receipts: map[string]*types.Receipt{"0x03": receipt}, confirmed: true}
+		p := NewPublisher(nil, identityService, nil, nil, nil, transactionService, nil, &fakePublisherGateway{}, resolver, nil)
+		state := newPublisherTestState(time.Now())
+		state.CancelTxID = common.ToPointer("0x03")
+
+		require.NoError(t, p.checkCancellation(ctx, identity, state))
+		assert.Equal(t, domain.StatusFailed, state.Status)
+		assert.Equal(t, []domain.IdentityStatus{domain.StatusFailed}, identityService.updated)
+	})
+
+	t.Run("should return the errors of the node", func(t *testing.T) {
+		transactionService := &fakeTransactionService{err: errors.New("connection refused")}
+		p := NewPublisher(nil, &fakeIdentityService{}, nil, nil, nil, transactionService, nil, &fakePublisherGateway{}, resolver, nil)
+		state := newPublisherTestState(time.Now())
+		state.CancelTxID = common.ToPointer("0x03")
+
+		err := p.checkCancellation(ctx, identity, state)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrStateIsBeingProcessed)
+	})
+}
+
+func TestPublisher_Reorg(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	resolver := newPublisherTestResolver(t, crypto.PubkeyToAddress(key.PublicKey))
+	backend, err := resolver.GetSimulatedBackend("polygon:amoy")
+	require.NoError(t, err)
+	transactionService, err := NewTransaction(*resolver)
+	require.NoError(t, err)
+	identity := &domain.Identity{Identifier: publisherTestDID, KeyType: "BJJ"}
+
+	tx := sendPublisherTestTransfer(t, backend, key, 0)
+	receipt, err := bind.WaitMined(ctx, backend.Client(), tx)
+	require.NoError(t, err)
+	block, err := backend.Client().HeaderByHash(ctx, receipt.BlockHash)
+	require.NoError(t, err)
+
+	newState := func(txID ethCommon.Hash, blockNumber *big.Int, blockHash ethCommon.Hash) *domain.IdentityState {
+		state := newPublisherTestState(time.Now())
+		state.TxID = common.ToPointer(txID.Hex())
+		state.BlockNumber = common.ToPointer(int(blockNumber.Int64()))
+		state.BlockHash = common.ToPointer(blockHash.Hex())
+		return state
+	}
+	// unknownTxID is the id of a transaction that has no receipt in the node
+	unknownTxID := ethCommon.HexToHash("0x01")
+
+	t.Run("should wait when the receipt is missing but its block is canonical", func(t *testing.T) {
+		identityService := &fakeIdentityService{}
+		p := NewPublisher(nil, identityService, nil, nil, nil, transactionService, nil, &fakePublisherGateway{}, resolver, nil)
+		state := newState(unknownTxID, receipt.BlockNumber, receipt.BlockHash)
+
+		assert.ErrorIs(t, p.checkStatus(ctx, identity, state), ErrStateIsBeingProcessed)
+		assert.Equal(t, receipt.BlockHash.Hex(), *state.BlockHash)
+		assert.Empty(t, identityService.updated)
+	})
+
+	t.Run("should wait when the block is not known by the node", func(t *testing.T) {
+		identityService := &fakeIdentityService{}
+		p := NewPublisher(nil, identityService, nil, nil, nil, transactionService, nil, &fakePublisherGateway{}, resolver, nil)
+		state := newState(unknownTxID, big.NewInt(1000), ethCommon.HexToHash("0x0b"))
+
+		assert.ErrorIs(t, p.checkStatus(ctx, identity, state), ErrStateIsBeingProcessed)
+		assert.NotNil(t, state.BlockHash)
+		assert.Empty(t, identityService.updated)
+	})
+
+	// the block of the transfer is replaced by an empty one and the transfer is dropped
+	require.NoError(t, backend.Fork(block.ParentHash))
+	backend.Commit()
+
+	t.Run("should publish again a state whose block is reorged out", func(t *testing.T) {
+		identityService := &fakeIdentityService{}
+		p := NewPublisher(nil, identityService, nil, nil, nil, transactionService, nil, &fakePublisherGateway{}, resolver, nil)
+		state := newState(tx.Hash(), receipt.BlockNumber, receipt.BlockHash)
+		identityService.failed = state
+
+		_ = p.checkStatus(ctx, identity, state)
+		assert.Nil(t, state.BlockHash)
+		require.NotEmpty(t, identityService.updated)
+		assert.Equal(t, domain.StatusFailed, identityService.updated[0])
+		assert.True(t, identityService.failedStateRead)
+	})
+
+	t.Run("should not track the block of a stale receipt", func(t *testing.T) {
+		identityService := &fakeIdentityService{}
+		staleReceipts := &fakeTransactionService{receipts: map[string]*types.Receipt{tx.Hash().Hex(): receipt}}
+		transactionService := &canonicalTransactionService{fakeTransactionService: staleReceipts, transactionService: transactionService}
+		p := NewPublisher(nil, identityService, nil, nil, nil, transactionService, nil, &fakePublisherGateway{}, resolver, nil)
+		state := newState(tx.Hash(), receipt.BlockNumber, ethCommon.HexToHash("0x0c"))
+
+		assert.ErrorIs(t, p.checkStatus(ctx, identity, state), ErrStateIsBeingProcessed)
+		assert.Equal(t, ethCommon.HexToHash("0x0c").Hex(), *state.BlockHash)
+		assert.Empty(t, identityService.updated)
+	})
+
+	t.Run("should track the new block of a transaction mined again after a reorg", func(t *testing.T) {
+		require.NoError(t, backend.Client().SendTransaction(ctx, tx))
+		minedAgain, err := bind.WaitMined(ctx, backend.Client(), tx)
+		require.NoError(t, err)
+		identityService := &fakeIdentityService{}
+		p := NewPublisher(nil, identityService, nil, nil, nil, transactionService, nil, &fakePublisherGateway{}, resolver, nil)
+		state := newState(tx.Hash(), receipt.BlockNumber, receipt.BlockHash)
+
+		assert.ErrorIs(t, p.checkStatus(ctx, identity, state), ErrStateIsBeingProcessed)
+		assert.Equal(t, minedAgain.BlockHash.Hex(), *state.BlockHash)
+		assert.Equal(t, []domain.IdentityStatus{domain.StatusTransacted}, identityService.updated)
+	})
+}
+
+func TestPublisher_SimulatedNetwork(t *testing.T) {
+	ctx := context.Background()
+	did, err := w3c.ParseDID(publisherTestDID)
+	require.NoError(t, err)
+
+	keysFile := filepath.Join(t.TempDir(), "kms.json")
+	require.NoError(t, os.WriteFile(keysFile, []byte("[]"), 0o600))
+	keyStore := kms.NewKMS()
+	require.NoError(t, keyStore.RegisterKeyProvider(kms.KeyTypeEthereum, kms.NewLocalStorageEthKeyProvider(kms.KeyTypeEthereum, kms.NewLocalStorageFileManager(keysFile))))
+	keyID, err := keyStore.CreateKey(kms.KeyTypeEthereum, did)
+	require.NoError(t, err)
+	address, err := eth.KeyAddress(keyStore, keyID)
+	require.NoError(t, err)
+
+	resolver := newPublisherTestResolver(t, address)
+	backend, err := resolver.GetSimulatedBackend("polygon:amoy")
+	require.NoError(t, err)
+
+	gateway, err := NewPublisherEthGateway(*resolver, keyStore, nil, eth.NewNonceManager(repositories.NewPublishingNonce(), storage))
+	require.NoError(t, err)
+	transactionService, err := NewTransaction(*resolver)
+	require.NoError(t, err)
+
+	identity := &domain.Identity{Identifier: publisherTestDID, KeyType: string(kms.KeyTypeEthereum)}
+	genesisState, err := merkletree.NewHashFromString("1")
+	require.NoError(t, err)
+	newState, err := merkletree.NewHashFromString("2")
+	require.NoError(t, err)
+	txID, err := gateway.PublishState(ctx, did, genesisState, newState, true, nil, identity)
+	require.NoError(t, err)
+
+	state := newPublisherTestState(time.Now().Add(-time.Hour))
+	state.State = common.ToPointer(newState.String())
+	state.TxID = txID
+	identityService := &fakeIdentityService{transacted: []domain.IdentityState{*state}}
+	claimService := &fakeClaimService{credentials: []*domain.Claim{{ID: uuid.New(), OtherIdentifier: "did:polygonid:polygon:amoy:2qQ68JkRcf3xrHPQPWZei3YeVzHPP58wYNxx2mEouR"}}}
+	notifications := pubsub.NewMock()
+	p := NewPublisher(nil, identityService, claimService, nil, nil, transactionService, nil, gateway, resolver, notifications)
+
+	client, err := resolver.GetEthClient("polygon:amoy")
+	require.NoError(t, err)
+	receipt, err := client.WaitTransactionReceiptByID(ctx, *txID)
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+
+	t.Run("should wait for the confirmation blocks", func(t *testing.T) {
+		p.CheckTransactionStatus(ctx, nil)
+		assert.Nil(t, claimService.confirmed)
+		assert.Empty(t, notifications.AllPublishedEvents(event.CreateStateEvent))
+	})
+
+	t.Run("should confirm the state and send the notifications", func(t *testing.T) {
+		backend.Commit()
+		p.CheckTransactionStatus(ctx, nil)
+		require.NotNil(t, claimService.confirmed)
+		assert.Equal(t, domain.StatusConfirmed, claimService.confirmed.Status)
+		assert.Equal(t, receipt.BlockHash.Hex(), *claimService.confirmed.BlockHash)
+		require.Len(t, notifications.AllPublishedEvents(event.CreateCredentialEvent), 1)
+		require.Len(t, notifications.AllPublishedEvents(event.CreateStateEvent), 1)
+		assert.Equal(t, &event.CreateState{State: newState.String()}, notifications.AllPublishedEvents(event.CreateStateEvent)[0])
+	})
+}
+
+// newPublisherTestResolver returns a resolver for a simulated amoy network that funds accounts and considers stuck
+// the transactions pending for more than a minute
+func newPublisherTestResolver(t *testing.T, accounts ...ethCommon.Address) *network.Resolver {
+	t.Helper()
+	addresses := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		addresses = append(addresses, account.Hex())
+	}
+	yamlData := fmt.Sprintf(`polygon:
+  amoy:
+    contractAddress: 0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124
+    defaultGasLimit: 600000
+    confirmationTimeout: 10s
+    confirmationBlockCount: 1
+    receiptTimeout: 10s
+    rpcResponseTimeout: 5s
+    waitReceiptCycleTime: 100ms
+    waitBlockCycleTime: 100ms
+    stuckTxTimeout: 1m
+    simulated:
+      blockPeriod: 0s
+      accounts: [%s]
+    rhsSettings:
+      mode: None
+`, strings.Join(addresses, ", "))
+	resolver, err := network.NewResolver(context.Background(), config.Configuration{ServerUrl: "https://issuer-node.privado.id"}, nil, common.NewMyYAMLReader([]byte(yamlData)))
+	require.NoError(t, err)
+	backend, err := resolver.GetSimulatedBackend("polygon:amoy")
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, backend.Close()) })
+	return resolver
+}
+
+func newPublisherTestState(modifiedAt time.Time) *domain.IdentityState {
+	return &domain.IdentityState{
+		Identifier: publisherTestDID,
+		State:      common.ToPointer(ethCommon.HexToHash("0x0a").Hex()),
+		TxID:       common.ToPointer("0x01"),
+		Status:     domain.StatusTransacted,
+		ModifiedAt: modifiedAt,
+	}
+}
+
+// fakeIdentityService records the statuses the states are updated with
+type fakeIdentityService struct {
+	ports.IdentityService
+	updated         []domain.IdentityStatus
+	failed          *domain.IdentityState
+	failedStateRead bool
+	transacted      []domain.IdentityState
+}
+
+func (s *fakeIdentityService) GetTransactedStates(_ context.Context) ([]domain.IdentityState, error) {
+	return s.transacted, nil
+}
+
+func (s *fakeIdentityService) GetByDID(_ context.Context, identifier w3c.DID) (*domain.Identity, error) {
+	return &domain.Identity{Identifier: identifier.String(), KeyType: "BJJ"}, nil
+}
+
+func (s *fakeIdentityService) UpdateIdentityState(_ context.Context, state *domain.IdentityState) error {
+	s.updated = append(s.updated, state.Status)
+	return nil
+}
+
+func (s *fakeIdentityService) GetFailedState(_ context.Context, _ w3c.DID) (*domain.IdentityState, error) {
+	s.failedStateRead = true
+	return s.failed, nil
+}
+
+func (s *fakeIdentityService) GetLatestStateByID(_ context.Context, _ w3c.DID) (*domain.IdentityState, error) {
+	return nil, errors.New("latest state not available")
+}
+
+// fakeClaimService returns credentials as the ones published in the confirmed state
+type fakeClaimService struct {
+	ports.ClaimService
+	credentials []*domain.Claim
+	confirmed   *domain.IdentityState
+}
+
+func (s *fakeClaimService) UpdateClaimsMTPAndState(_ context.Context, state *domain.IdentityState) error {
+	s.confirmed = state
+	return nil
+}
+
+func (s *fakeClaimService) GetByStateIDWithMTPProof(_ context.Context, _ *w3c.DID, _ string) ([]*domain.Claim, error) {
+	return s.credentials, nil
+}
+
+type fakeTransactionService struct {
+	receipts  map[string]*types.Receipt
+	pending   map[string]bool
+	confirmed bool
+	err       error
+}
+
+func (s *fakeTransactionService) WaitForTransactionReceipt(_ context.Context, _ *domain.Identity, _ string) (*types.Receipt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeTransactionService) WaitForConfirmation(_ context.Context, _ *domain.Identity, _ *types.Receipt) (bool, error) {
+	return s.confirmed, nil
+}
+
+func (s *fakeTransactionService) GetHeaderByNumber(_ context.Context, _ *domain.Identity, _ *big.Int) (*types.Header, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeTransactionService) CheckConfirmation(_ context.Context, _ *domain.Identity, _ *types.Receipt, _ int64) (bool, error) {
+	return s.confirmed, nil
+}
+
+func (s *fakeTransactionService) GetTransactionReceiptByID(_ context.Context, _ *domain.Identity, txID string) (*types.Receipt, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	receipt, ok := s.receipts[txID]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (s *fakeTransactionService) IsTransactionPending(_ context.Context, _ *domain.Identity, txID string) (bool, error) {
+	return s.pending[txID], nil
+}
+
+// canonicalTransactionService returns the receipts of fakeTransactionService and the blocks of the network
+type canonicalTransactionService struct {
+	*fakeTransactionService
+	transactionService ports.TransactionService
+}
+
+func (s *canonicalTransactionService) GetHeaderByNumber(ctx context.Context, identity *domain.Identity, blockNumber *big.Int) (*types.Header, error) {
+	return s.transactionService.GetHeaderByNumber(ctx, identity, blockNumber)
+}
+
+// sendPublisherTestTransfer sends a transfer to the zero address from key
+func sendPublisherTestTransfer(t *testing.T, backend *eth.SimulatedBackend, key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+	t.Helper()
+	ctx := context.Background()
+	chainID, err := backend.Client().ChainID(ctx)
+	require.NoError(t, err)
+	gasPrice, err := backend.Client().SuggestGasPrice(ctx)
+	require.NoError(t, err)
+	tx, err := types.SignTx(types.NewTransaction(nonce, ethCommon.Address{}, big.NewInt(1), 21000, gasPrice, nil), types.LatestSignerForChainID(chainID), key)
+	require.NoError(t, err)
+	require.NoError(t, backend.Client().SendTransaction(ctx, tx))
+	return tx
+}
+
+// fakePublisherGateway replaces the transactions with replacement, or fails with err
+type fakePublisherGateway struct {
+	PublisherGateway
+	replacement string
+	err         error
+	replaced    []string
+}
+
+func (g *fakePublisherGateway) ReplaceState(_ context.Context, _ *w3c.DID, _ *domain.Identity, txID string, _ bool) (*string, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	g.replaced = append(g.replaced, txID)
+	return &g.replacement, nil
+}
@@ -2,8 +2,10 @@ package gateways
 
 import (
 	"context"
+	"errors"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
@@ -97,6 +99,23 @@ func (tr *transaction) GetTransactionReceiptByID(ctx context.Context, identity *
 	return receipt, nil
 }
 
+// IsTransactionPending returns true if the transaction is waiting to be mined in the pool of the node
+func (tr *transaction) IsTransactionPending(ctx context.Context, identity *domain.Identity, txID string) (bool, error) {
+	client, err := getEthClient(ctx, identity, tr.networkResolver)
+	if err != nil {
+		log.Error(ctx, "failed to get client", "err", err)
+		return false, err
+	}
+	_, isPending, err := client.GetTransactionByID(ctx, txID)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return isPending, nil
+}
+
 // WaitForConfirmation wait until transaction will be confirmed
 func (tr *transaction) WaitForConfirmation(ctx context.Context, identity *domain.Identity, receipt *types.Receipt) (bool, error) {
 	client, err := getEthClient(ctx, identity, tr.networkResolver)
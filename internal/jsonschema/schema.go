@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 
 	core "github.com/iden3/go-iden3-core/v2"
 	jsonSuite "github.com/iden3/go-schema-processor/v2/json"
@@ -50,6 +51,22 @@ func (a Attribute) String() string {
 	return a.ID
 }
 
+// ParseValue converts the textual representation of a value, like a csv cell, into the type of the attribute
+func (a Attribute) ParseValue(value string) (any, error) {
+	switch a.Type {
+	case "integer":
+		return strconv.ParseInt(value, 10, 64)
+	case "number":
+		return strconv.ParseFloat(value, 64)
+	case "boolean":
+		return strconv.ParseBool(value)
+	case "string", "":
+		return value, nil
+	default:
+		return nil, fmt.Errorf("attribute <%s> of type <%s> cannot be parsed from text", a.ID, a.Type)
+	}
+}
+
 // JSONSchema provides some methods to load a schema and do some inspections over it.
 type JSONSchema struct {
 	content map[string]any
@@ -200,3 +200,68 @@ func TestValidateCredentialSubject(t *testing.T) {
 		})
 	}
 }
+
+func TestAttribute_ParseValue(t *testing.T) {
+	type config struct {
+		name          string
+		attribute     Attribute
+		value         string
+		expected      any
+		expectedError bool
+	}
+
+	for _, tc := range []config{
+		{
+			name:      "integer",
+			attribute: Attribute{ID: "documentType", Type: "integer"},
+			value:     "4",
+			expected:  int64(4),
+		},
+		{
+			name:          "invalid integer",
+			attribute:     Attribute{ID: "documentType", Type: "integer"},
+			value:         "four",
+			expectedError: true,
+		},
+		{
+			name:      "number",
+			attribute: Attribute{ID: "salary", Type: "number"},
+			value:     "1234.5",
+			expected:  1234.5,
+		},
+		{
+			name:      "boolean",
+			attribute: Attribute{ID: "ZKPexperiance", Type: "boolean"},
+			value:     "true",
+			expected:  true,
+		},
+		{
+			name:          "invalid boolean",
+			attribute:     Attribute{ID: "ZKPexperiance", Type: "boolean"},
+			value:         "yes",
+			expectedError: true,
+		},
+		{
+			name:      "string",
+			attribute: Attribute{ID: "hireDate", Type: "string", Format: "date"},
+			value:     "2022-10-10",
+			expected:  "2022-10-10",
+		},
+		{
+			name:          "object",
+			attribute:     Attribute{ID: "address", Type: "object"},
+			value:         "{}",
+			expectedError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := tc.attribute.ParseValue(tc.value)
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
@@ -24,6 +24,8 @@ type KMSType interface {
 	Sign(ctx context.Context, keyID KeyID, data []byte) ([]byte, error)
 	KeysByIdentity(ctx context.Context, identity w3c.DID) ([]KeyID, error)
 	LinkToIdentity(ctx context.Context, keyID KeyID, identity w3c.DID) (KeyID, error)
+	ExportPrivateKey(ctx context.Context, keyID KeyID) ([]byte, error)
+	ImportPrivateKey(ctx context.Context, keyID KeyID, privateKey []byte) error
 }
 
 // ConfigProvider is a key provider configuration
@@ -72,6 +74,19 @@ type KeyProvider interface {
 	LinkToIdentity(ctx context.Context, keyID KeyID, identity w3c.DID) (KeyID, error)
 }
 
+// PrivateKeyExporter is implemented by the key providers that can hand out the raw private keys they store.
+type PrivateKeyExporter interface {
+	// PrivateKey returns the raw private key
+	PrivateKey(ctx context.Context, keyID KeyID) ([]byte, error)
+}
+
+// PrivateKeyImporter is implemented by the key providers that can store an existing private key.
+type PrivateKeyImporter interface {
+	// ImportPrivateKey stores the raw private key under the given key ID.
+	// Importing a key that is already stored with the same material is a no-op.
+	ImportPrivateKey(ctx context.Context, keyID KeyID, privateKey []byte) error
+}
+
 // KMS stores keys and secrets
 type KMS struct {
 	registry map[KeyType]KeyProvider
@@ -99,6 +114,16 @@ var ErrKeyTypeConflict = stderr.New("key type already registered")
 // ErrPermissionDenied raises when we register new key provider with key type
 var ErrPermissionDenied = stderr.New("permission denied")
 
+// ErrKeyExportNotSupported raises when the key provider keeps the private keys to itself (vault plugin, aws)
+var ErrKeyExportNotSupported = stderr.New("key provider does not support exporting private keys")
+
+// ErrKeyImportNotSupported raises when the key provider can not store existing private keys
+var ErrKeyImportNotSupported = stderr.New("key provider does not support importing private keys")
+
+// ErrKeyMaterialMismatch raises when the imported private key does not match the key ID
+// or another key is already stored under the key ID
+var ErrKeyMaterialMismatch = stderr.New("private key does not match the key ID")
+
 // KeyID is a key unique identifier
 type KeyID struct {
 	Type KeyType
@@ -210,6 +235,34 @@ func (k *KMS) LinkToIdentity(ctx context.Context, keyID KeyID, identity w3c.DID)
 	return kp.LinkToIdentity(ctx, keyID, identity)
 }
 
+// ExportPrivateKey returns the raw private key for the key ID.
+// Returns ErrKeyExportNotSupported if the key provider does not expose private keys.
+func (k *KMS) ExportPrivateKey(ctx context.Context, keyID KeyID) ([]byte, error) {
+	kp, ok := k.registry[keyID.Type]
+	if !ok {
+		return nil, errors.WithStack(ErrUnknownKeyType)
+	}
+	exporter, ok := kp.(PrivateKeyExporter)
+	if !ok {
+		return nil, ErrKeyExportNotSupported
+	}
+	return exporter.PrivateKey(ctx, keyID)
+}
+
+// ImportPrivateKey stores the raw private key under the key ID, used to move identities between nodes.
+// Returns ErrKeyImportNotSupported if the key provider can not store existing keys.
+func (k *KMS) ImportPrivateKey(ctx context.Context, keyID KeyID, privateKey []byte) error {
+	kp, ok := k.registry[keyID.Type]
+	if !ok {
+		return errors.WithStack(ErrUnknownKeyType)
+	}
+	importer, ok := kp.(PrivateKeyImporter)
+	if !ok {
+		return ErrKeyImportNotSupported
+	}
+	return importer.ImportPrivateKey(ctx, keyID, privateKey)
+}
+
 // Open returns an initialized KMS
 func Open(pluginIden3MountPath string, vault *api.Client) (*KMS, error) {
 	bjjKeyProvider, err := NewVaultPluginIden3KeyProvider(vault, pluginIden3MountPath, KeyTypeBabyJubJub)
@@ -1,6 +1,7 @@
 package kms
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
@@ -121,6 +122,36 @@ func (ls *localStorageBJJKeyProvider) LinkToIdentity(ctx context.Context, keyID
 	return keyID, nil
 }
 
+// PrivateKey returns the raw private key
+func (ls *localStorageBJJKeyProvider) PrivateKey(ctx context.Context, keyID KeyID) ([]byte, error) {
+	return ls.privateKey(ctx, keyID)
+}
+
+// ImportPrivateKey stores the private key. The key ID must end with the public key of the private key.
+func (ls *localStorageBJJKeyProvider) ImportPrivateKey(ctx context.Context, keyID KeyID, privateKey []byte) error {
+	if keyID.Type != ls.keyType {
+		return ErrIncorrectKeyType
+	}
+
+	privKey, err := decodeBJJPrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+
+	pubKey, err := ls.PublicKey(keyID)
+	if err != nil {
+		return err
+	}
+
+	compPubKey := privKey.Public().Compress()
+	if !bytes.Equal(pubKey, compPubKey[:]) {
+		log.Error(ctx, "private key does not match the key ID", "keyID", keyID)
+		return ErrKeyMaterialMismatch
+	}
+
+	return importPrivateKeyToFile(ctx, ls.localStorageFileManager, keyID, privateKey)
+}
+
 func (ls *localStorageBJJKeyProvider) privateKey(ctx context.Context, keyID KeyID) ([]byte, error) {
 	if keyID.Type != ls.keyType {
 		return nil, ErrIncorrectKeyType
@@ -1,6 +1,7 @@
 package kms
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
@@ -123,6 +124,38 @@ func (ls *localStorageEthKeyProvider) ListByIdentity(ctx context.Context, identi
 	return ls.localStorageFileManager.searchByIdentityInFile(ctx, identity, ls.keyType)
 }
 
+// PrivateKey returns the raw private key
+func (ls *localStorageEthKeyProvider) PrivateKey(ctx context.Context, keyID KeyID) ([]byte, error) {
+	return ls.privateKey(ctx, keyID)
+}
+
+// ImportPrivateKey stores the private key.
+// When the key ID ends with a public key, it must be the public key of the private key.
+func (ls *localStorageEthKeyProvider) ImportPrivateKey(ctx context.Context, keyID KeyID, privateKey []byte) error {
+	if keyID.Type != ls.keyType {
+		return ErrIncorrectKeyType
+	}
+
+	privKey, err := decodeETHPrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+
+	ss := ls.reIdenKeyPathHex.FindStringSubmatch(keyID.ID)
+	if len(ss) == partsNumber {
+		pubKey, err := hex.DecodeString(ss[1])
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(pubKey, crypto.CompressPubkey(&privKey.PublicKey)) {
+			log.Error(ctx, "private key does not match the key ID", "keyID", keyID)
+			return ErrKeyMaterialMismatch
+		}
+	}
+
+	return importPrivateKeyToFile(ctx, ls.localStorageFileManager, keyID, privateKey)
+}
+
 // nolint
 func (ls *localStorageEthKeyProvider) privateKey(ctx context.Context, keyID KeyID) ([]byte, error) {
 	if keyID.Type != ls.keyType {
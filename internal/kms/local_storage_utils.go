@@ -2,6 +2,7 @@ package kms
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"os"
@@ -152,6 +153,25 @@ func (ls *localStorageFileManager) searchPrivateKeyInFile(ctx context.Context, k
 	return "", errors.New("key not found")
 }
 
+// importPrivateKeyToFile saves the private key unless it is already stored under the key ID
+func importPrivateKeyToFile(ctx context.Context, fileManager LocalStorageFileManager, keyID KeyID, privateKey []byte) error {
+	privateKeyHex := hex.EncodeToString(privateKey)
+	stored, err := fileManager.searchPrivateKeyInFile(ctx, keyID)
+	if err == nil {
+		if !strings.EqualFold(stored, privateKeyHex) {
+			log.Error(ctx, "another private key is stored for the key ID", "keyID", keyID)
+			return ErrKeyMaterialMismatch
+		}
+		return nil
+	}
+
+	keyMaterial := map[string]string{
+		jsonKeyType: string(keyID.Type),
+		jsonKeyData: privateKeyHex,
+	}
+	return fileManager.saveKeyMaterialToFile(ctx, keyMaterial, keyID.ID)
+}
+
 func readContentFile(ctx context.Context, file string) ([]localStorageBJJKeyProviderFileContent, error) {
 	fileContent, err := os.ReadFile(file)
 	if err != nil {
@@ -175,3 +175,54 @@ func createTestFile(t *testing.T) (*os.File, error) {
 	require.NoError(t, tmpFile.Close())
 	return tmpFile, err
 }
+
+func TestLocalStorageKeyProviders_ExportAndImportPrivateKey(t *testing.T) {
+	ctx := context.Background()
+	did, err := w3c.ParseDID("did:polygonid:polygon:amoy:2qQ68JkRcf3ybQNvgRV9BP6qLgBrXmUezqBi4wsEuV")
+	require.NoError(t, err)
+
+	for _, keyType := range []KeyType{KeyTypeBabyJubJub, KeyTypeEthereum} {
+		t.Run(string(keyType), func(t *testing.T) {
+			newKMS := func(file string) *KMS {
+				k := NewKMS()
+				fileManager := NewLocalStorageFileManager(file)
+				require.NoError(t, k.RegisterKeyProvider(KeyTypeBabyJubJub, NewLocalStorageBJJKeyProvider(KeyTypeBabyJubJub, fileManager)))
+				require.NoError(t, k.RegisterKeyProvider(KeyTypeEthereum, NewLocalStorageEthKeyProvider(KeyTypeEthereum, fileManager)))
+				return k
+			}
+			source, err := createTestFile(t)
+			require.NoError(t, err)
+			//nolint:errcheck
+			defer os.Remove(source.Name())
+			destination, err := os.CreateTemp(t.TempDir(), "kms-*.json")
+			require.NoError(t, err)
+			_, err = destination.WriteString("[]")
+			require.NoError(t, err)
+			require.NoError(t, destination.Close())
+
+			sourceKMS := newKMS(source.Name())
+			keyID, err := sourceKMS.CreateKey(keyType, did)
+			require.NoError(t, err)
+			privateKey, err := sourceKMS.ExportPrivateKey(ctx, keyID)
+			require.NoError(t, err)
+
+			destinationKMS := newKMS(destination.Name())
+			require.NoError(t, destinationKMS.ImportPrivateKey(ctx, keyID, privateKey))
+			// importing the same key again is a no-op
+			require.NoError(t, destinationKMS.ImportPrivateKey(ctx, keyID, privateKey))
+
+			keyIDs, err := destinationKMS.KeysByIdentity(ctx, *did)
+			require.NoError(t, err)
+			assert.Equal(t, []KeyID{keyID}, keyIDs)
+			imported, err := destinationKMS.ExportPrivateKey(ctx, keyID)
+			require.NoError(t, err)
+			assert.Equal(t, privateKey, imported)
+
+			otherKeyID, err := sourceKMS.CreateKey(keyType, did)
+			require.NoError(t, err)
+			otherPrivateKey, err := sourceKMS.ExportPrivateKey(ctx, otherKeyID)
+			require.NoError(t, err)
+			assert.ErrorIs(t, destinationKMS.ImportPrivateKey(ctx, keyID, otherPrivateKey), ErrKeyMaterialMismatch)
+		})
+	}
+}
@@ -13,16 +13,18 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/hashicorp/vault/api"
 	"github.com/iden3/go-iden3-core/v2/w3c"
 	"github.com/iden3/go-iden3-crypto/utils"
 )
 
 const (
-	keyDest      = "dest"
-	keyData      = "data"
-	keySignature = "signature"
-	keyPublicKey = "public_key"
+	keyDest       = "dest"
+	keyData       = "data"
+	keySignature  = "signature"
+	keyPublicKey  = "public_key"
+	keyPrivateKey = "private_key"
 )
 
 type pluginIden3KeyTp string
@@ -161,6 +163,40 @@ func (v *vaultPluginIden3KeyProvider) New(identity *w3c.DID) (KeyID, error) {
 	return keyID, nil
 }
 
+// ImportPrivateKey stores the private key in vault. The key ID must end with the public key of the private key.
+// The plugin never returns private keys, so there is no way to export them back.
+func (v *vaultPluginIden3KeyProvider) ImportPrivateKey(_ context.Context, keyID KeyID, privateKey []byte) error {
+	if keyID.Type != v.keyType {
+		return ErrIncorrectKeyType
+	}
+
+	pubKeyStr, err := publicKeyFromPrivateKey(v.keyType, privateKey)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(path.Base(keyID.ID), v.keyFileName(pubKeyStr)) {
+		return ErrKeyMaterialMismatch
+	}
+
+	keyPath := v.keyPathFromID(keyID)
+	if storedPubKeyStr, err := publicKey(v.vaultCli, keyPath); err == nil {
+		if !strings.EqualFold(storedPubKeyStr, pubKeyStr) {
+			return ErrKeyMaterialMismatch
+		}
+		return nil
+	}
+
+	pluginKeyType, err := toPluginKeyType(v.keyType)
+	if err != nil {
+		return err
+	}
+	_, err = v.vaultCli.Logical().Write(keyPath.importKey(), map[string]interface{}{
+		jsonKeyType:   pluginKeyType,
+		keyPrivateKey: hex.EncodeToString(privateKey),
+	})
+	return err
+}
+
 func (v *vaultPluginIden3KeyProvider) randomKeyPath() (keyPathT, error) {
 	var rnd [16]byte
 	_, err := rand.Read(rnd[:])
@@ -367,6 +403,30 @@ func (p keyPathT) new() string {
 	return p.join("new")
 }
 
+func (p keyPathT) importKey() string {
+	return p.join("import")
+}
+
+// publicKeyFromPrivateKey returns the hex of the compressed public key, the format used in key IDs
+func publicKeyFromPrivateKey(keyType KeyType, privateKey []byte) (string, error) {
+	switch keyType {
+	case KeyTypeBabyJubJub:
+		privKey, err := decodeBJJPrivateKey(privateKey)
+		if err != nil {
+			return "", err
+		}
+		return privKey.Public().String(), nil
+	case KeyTypeEthereum:
+		privKey, err := decodeETHPrivateKey(privateKey)
+		if err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(crypto.CompressPubkey(&privKey.PublicKey)), nil
+	default:
+		return "", errors.New("unsupported key type")
+	}
+}
+
 func toPluginKeyType(keyType KeyType) (pluginIden3KeyTp, error) {
 	switch keyType {
 	case KeyTypeBabyJubJub:
@@ -43,8 +43,10 @@ type StateResolvers map[string]pubsignals.StateResolver
 
 // ResolverClientConfig holds the resolver client config
 type ResolverClientConfig struct {
-	client          *eth.Client
-	contractAddress string
+	client                  *eth.Client
+	contractAddress         string
+	publishingKeys          []string
+	minPublishingKeyBalance *big.Int
 }
 
 // Resolver holds the resolver
@@ -54,6 +56,7 @@ type Resolver struct {
 	supportedContracts map[string]*abi.State
 	stateResolvers     map[string]pubsignals.StateResolver
 	supportedNetworks  []SupportedNetworks
+	simulatedBackends  map[resolverPrefix]*eth.SimulatedBackend
 }
 
 // SupportedNetworks holds the chain and networks supoprted
@@ -75,23 +78,28 @@ type RhsSettings struct {
 
 // ResolverSettings holds the resolver settings
 type ResolverSettings map[string]map[string]struct {
-	ContractAddress        string        `yaml:"contractAddress"`
-	NetworkURL             string        `yaml:"networkURL"`
-	DefaultGasLimit        int           `yaml:"defaultGasLimit"`
-	ConfirmationTimeout    time.Duration `yaml:"confirmationTimeout"`
-	ConfirmationBlockCount int64         `yaml:"confirmationBlockCount"`
-	ReceiptTimeout         time.Duration `yaml:"receiptTimeout"`
-	MinGasPrice            int           `yaml:"minGasPrice"`
-	MaxGasPrice            int           `yaml:"maxGasPrice"`
-	RPCResponseTimeout     time.Duration `yaml:"rpcResponseTimeout"`
-	WaitReceiptCycleTime   time.Duration `yaml:"waitReceiptCycleTime"`
-	WaitBlockCycleTime     time.Duration `yaml:"waitBlockCycleTime"`
-	GasLess                bool          `yaml:"gasLess"`
-	TransferAmountWei      *big.Int      `yaml:"transferAmountWei"`
-	RhsSettings            RhsSettings   `yaml:"rhsSettings"`
-	NetworkFlag            byte          `yaml:"networkFlag"`
-	ChainID                string        `yaml:"chainID"`
-	Method                 string        `yaml:"method"`
+	ContractAddress         string             `yaml:"contractAddress"`
+	NetworkURL              string             `yaml:"networkURL"`
+	DefaultGasLimit         int                `yaml:"defaultGasLimit"`
+	ConfirmationTimeout     time.Duration      `yaml:"confirmationTimeout"`
+	ConfirmationBlockCount  int64              `yaml:"confirmationBlockCount"`
+	ReceiptTimeout          time.Duration      `yaml:"receiptTimeout"`
+	MinGasPrice             int                `yaml:"minGasPrice"`
+	MaxGasPrice             int                `yaml:"maxGasPrice"`
+	RPCResponseTimeout      time.Duration      `yaml:"rpcResponseTimeout"`
+	WaitReceiptCycleTime    time.Duration      `yaml:"waitReceiptCycleTime"`
+	WaitBlockCycleTime      time.Duration      `yaml:"waitBlockCycleTime"`
+	StuckTxTimeout          time.Duration      `yaml:"stuckTxTimeout"`
+	GasBumpPercent          int                `yaml:"gasBumpPercent"`
+	GasLess                 bool               `yaml:"gasLess"`
+	TransferAmountWei       *big.Int           `yaml:"transferAmountWei"`
+	PublishingKeys          []string           `yaml:"publishingKeys"`
+	MinPublishingKeyBalance *big.Int           `yaml:"minPublishingKeyBalance"`
+	Simulated               *SimulatedSettings `yaml:"simulated"`
+	RhsSettings             RhsSettings        `yaml:"rhsSettings"`
+	NetworkFlag             byte               `yaml:"networkFlag"`
+	ChainID                 string             `yaml:"chainID"`
+	Method                  string             `yaml:"method"`
 }
 
 // NewResolver returns a new Network Resolver
@@ -106,6 +114,7 @@ func NewResolver(ctx context.Context, cfg config.Configuration, kms *kms.KMS, re
 	rhsSettings := make(map[resolverPrefix]RhsSettings)
 	supportedContracts := make(map[string]*abi.State)
 	stateResolvers := make(map[string]pubsignals.StateResolver)
+	simulatedBackends := make(map[resolverPrefix]*eth.SimulatedBackend)
 
 	log.Info(ctx, "the issuer node will use the resolver settings file for configuring multi chain feature")
 	var printer strings.Builder
@@ -123,10 +132,25 @@ func NewResolver(ctx context.Context, cfg config.Configuration, kms *kms.KMS, re
 				}
 			}
 			resolverPrefixKey := getResolverPrefixKey(chainName, networkName)
-			ethClient, err := ethclient.Dial(networkSettings.NetworkURL)
-			if err != nil {
-				log.Error(ctx, "cannot connect to ethereum network", "err", err, "networkURL", networkSettings.NetworkURL)
-				return nil, err
+			networkURL := networkSettings.NetworkURL
+			var ethClient *ethclient.Client
+			if networkSettings.Simulated != nil {
+				publishingKeys := append([]string{cfg.PublishingKeyPath, networkSettings.RhsSettings.PublishingKey}, networkSettings.PublishingKeys...)
+				backend, err := newSimulatedBackend(ctx, kms, networkSettings.ContractAddress, networkSettings.ChainID, publishingKeys, *networkSettings.Simulated)
+				if err != nil {
+					log.Error(ctx, "cannot start simulated ethereum network", "err", err, "network", resolverPrefixKey)
+					return nil, err
+				}
+				simulatedBackends[resolverPrefix(resolverPrefixKey)] = backend
+				ethClient = backend.Client()
+				networkURL = backend.URL()
+				log.Info(ctx, "using simulated ethereum network", "network", resolverPrefixKey, "networkURL", networkURL)
+			} else {
+				ethClient, err = ethclient.Dial(networkURL)
+				if err != nil {
+					log.Error(ctx, "cannot connect to ethereum network", "err", err, "networkURL", networkURL)
+					return nil, err
+				}
 			}
 
 			client := eth.NewClient(ethClient, &eth.ClientConfig{
@@ -140,11 +164,15 @@ func NewResolver(ctx context.Context, cfg config.Configuration, kms *kms.KMS, re
 				RPCResponseTimeout:     networkSettings.RPCResponseTimeout,
 				WaitReceiptCycleTime:   networkSettings.WaitReceiptCycleTime,
 				WaitBlockCycleTime:     networkSettings.WaitBlockCycleTime,
+				StuckTxTimeout:         networkSettings.StuckTxTimeout,
+				GasBumpPercent:         networkSettings.GasBumpPercent,
 			}, kms)
 
 			resolverClientConfig := &ResolverClientConfig{
-				client:          client,
-				contractAddress: networkSettings.ContractAddress,
+				client:                  client,
+				contractAddress:         networkSettings.ContractAddress,
+				publishingKeys:          networkSettings.PublishingKeys,
+				minPublishingKeyBalance: networkSettings.MinPublishingKeyBalance,
 			}
 
 			ethereumClients[resolverPrefix(resolverPrefixKey)] = *resolverClientConfig
@@ -171,7 +199,7 @@ func NewResolver(ctx context.Context, cfg config.Configuration, kms *kms.KMS, re
 			supportedContracts[resolverPrefixKey] = stateContract
 
 			stateResolvers[resolverPrefixKey] = state.ETHResolver{
-				RPCUrl:          networkSettings.NetworkURL,
+				RPCUrl:          networkURL,
 				ContractAddress: common.HexToAddress(networkSettings.ContractAddress),
 			}
 		}
@@ -187,6 +215,7 @@ func NewResolver(ctx context.Context, cfg config.Configuration, kms *kms.KMS, re
 		supportedContracts: supportedContracts,
 		stateResolvers:     stateResolvers,
 		supportedNetworks:  supportedNetworks,
+		simulatedBackends:  simulatedBackends,
 	}, nil
 }
 
@@ -199,6 +228,15 @@ func (r *Resolver) GetEthClient(resolverPrefixKey string) (*eth.Client, error) {
 	return resolverClientConfig.client, nil
 }
 
+// GetSimulatedBackend returns the simulated chain of the network, if the network is simulated
+func (r *Resolver) GetSimulatedBackend(resolverPrefixKey string) (*eth.SimulatedBackend, error) {
+	backend, ok := r.simulatedBackends[resolverPrefix(resolverPrefixKey)]
+	if !ok {
+		return nil, fmt.Errorf("simulated network not found for %s", resolverPrefixKey)
+	}
+	return backend, nil
+}
+
 // GetContractAddress returns the contract address
 func (r *Resolver) GetContractAddress(resolverPrefixKey string) (*common.Address, error) {
 	resolverClientConfig, ok := r.ethereumClients[resolverPrefix(resolverPrefixKey)]
@@ -255,6 +293,35 @@ func (r *Resolver) GetConfirmationTimeout(resolverPrefixKey string) (time.Durati
 	return confirmationTimeout, nil
 }
 
+// GetStuckTxTimeout returns how long a state transition can be pending before it is replaced with higher fees
+func (r *Resolver) GetStuckTxTimeout(resolverPrefixKey string) (time.Duration, error) {
+	resolverClientConfig, ok := r.ethereumClients[resolverPrefix(resolverPrefixKey)]
+	if !ok {
+		return 0, fmt.Errorf("ethClient not found for %s", resolverPrefixKey)
+	}
+	return resolverClientConfig.client.GetStuckTxTimeout(), nil
+}
+
+// GetPublishingKeys returns the paths of the keys that publish the states of the BJJ identities of the network.
+// It is empty if the network uses the global publishing key.
+func (r *Resolver) GetPublishingKeys(resolverPrefixKey string) ([]string, error) {
+	resolverClientConfig, ok := r.ethereumClients[resolverPrefix(resolverPrefixKey)]
+	if !ok {
+		return nil, fmt.Errorf("ethClient not found for %s", resolverPrefixKey)
+	}
+	return resolverClientConfig.publishingKeys, nil
+}
+
+// GetMinPublishingKeyBalance returns the balance, in wei, below which a publishing key of the network is not used.
+// It is nil if the balance of the keys is not checked.
+func (r *Resolver) GetMinPublishingKeyBalance(resolverPrefixKey string) (*big.Int, error) {
+	resolverClientConfig, ok := r.ethereumClients[resolverPrefix(resolverPrefixKey)]
+	if !ok {
+		return nil, fmt.Errorf("ethClient not found for %s", resolverPrefixKey)
+	}
+	return resolverClientConfig.minPublishingKeyBalance, nil
+}
+
 // GetSupportedContracts returns the supported contracts
 func (r *Resolver) GetSupportedContracts() map[string]*abi.State {
 	return r.supportedContracts
@@ -0,0 +1,65 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/polygonid/sh-id-platform/internal/eth"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// defaultSimulatedBalance is the genesis balance of the funded accounts, 1000 ether
+var defaultSimulatedBalance = new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+
+// SimulatedSettings holds the settings of an in-process simulated chain that is used instead of networkURL
+type SimulatedSettings struct {
+	BlockPeriod time.Duration `yaml:"blockPeriod"`
+	Balance     *big.Int      `yaml:"balance"`
+	Accounts    []string      `yaml:"accounts"`
+}
+
+// newSimulatedBackend starts a simulated chain with the state contract installed at contractAddress and the
+// publishing keys and accounts funded. Keys that can't be found in the kms are not funded.
+func newSimulatedBackend(ctx context.Context, keyStore *kms.KMS, contractAddress string, chainID string, publishingKeys []string, settings SimulatedSettings) (*eth.SimulatedBackend, error) {
+	if chainID != "" && chainID != fmt.Sprint(eth.SimulatedChainID) {
+		return nil, fmt.Errorf("the chain id of a simulated network must be %d", eth.SimulatedChainID)
+	}
+	if !common.IsHexAddress(contractAddress) {
+		return nil, fmt.Errorf("invalid contract address %q", contractAddress)
+	}
+	balance := settings.Balance
+	if balance == nil {
+		balance = defaultSimulatedBalance
+	}
+
+	alloc := make(types.GenesisAlloc)
+	for _, account := range settings.Accounts {
+		if !common.IsHexAddress(account) {
+			return nil, fmt.Errorf("invalid simulated account %q", account)
+		}
+		alloc[common.HexToAddress(account)] = types.Account{Balance: balance}
+	}
+	for _, key := range publishingKeys {
+		if key == "" {
+			continue
+		}
+		addr, err := eth.KeyAddress(keyStore, kms.KeyID{Type: kms.KeyTypeEthereum, ID: key})
+		if err != nil {
+			log.Warn(ctx, "publishing key not funded in the simulated network", "err", err, "key", key)
+			continue
+		}
+		alloc[addr] = types.Account{Balance: balance}
+	}
+
+	return eth.NewSimulatedBackend(eth.SimulatedBackendConfig{
+		StateContract: common.HexToAddress(contractAddress),
+		Alloc:         alloc,
+		BlockPeriod:   settings.BlockPeriod,
+	})
+}
@@ -0,0 +1,67 @@
+package network
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/iden3/contracts-abi/state/go/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/config"
+)
+
+// simulatedAccountKey is the private key of the account funded in common.CreateFileWithSimulatedNetwork
+const simulatedAccountKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+func TestResolver_SimulatedNetwork(t *testing.T) {
+	ctx := context.Background()
+	resolver, err := NewResolver(ctx, config.Configuration{ServerUrl: "https://issuer-node.privado.id"}, nil, common.CreateFileWithSimulatedNetwork(t))
+	require.NoError(t, err)
+	const prefix = "polygon:amoy"
+	backend, err := resolver.GetSimulatedBackend(prefix)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, backend.Close()) }()
+
+	client, err := resolver.GetEthClient(prefix)
+	require.NoError(t, err)
+	contractAddress, err := resolver.GetContractAddress(prefix)
+	require.NoError(t, err)
+	privateKey, err := crypto.HexToECDSA(simulatedAccountKey)
+	require.NoError(t, err)
+
+	id := big.NewInt(1)
+	tx, err := client.CallAuth(ctx, 0, privateKey, func(c *ethclient.Client, opts *bind.TransactOpts) (*types.Transaction, error) {
+		state, err := abi.NewState(*contractAddress, c)
+		if err != nil {
+			return nil, err
+		}
+		return state.TransitStateGeneric(opts, id, big.NewInt(1), big.NewInt(2), true, big.NewInt(1), []byte{})
+	})
+	require.NoError(t, err)
+
+	receipt, err := client.WaitTransactionReceiptByID(ctx, tx.Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+
+	info, err := client.GetLatestStateByID(ctx, *contractAddress, id)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(2), info.State)
+
+	// the state resolvers reach the simulated network through its json-rpc endpoint
+	rpcClient, err := ethclient.Dial(backend.URL())
+	require.NoError(t, err)
+	defer rpcClient.Close()
+	blockNumber, err := rpcClient.BlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, receipt.BlockNumber.Uint64(), blockNumber)
+
+	_, err = resolver.GetSimulatedBackend("polygon:main")
+	assert.Error(t, err)
+}
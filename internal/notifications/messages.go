@@ -42,6 +42,15 @@ func NewOfferMsg(fetchURL string, credentials ...*domain.Claim) (*protocol.Crede
 
 // NewRevokedMsg returns a revoked message
 func NewRevokedMsg(claim *domain.Claim) ([]byte, error) {
+	return newStatusUpdateMsg(claim, "claim was revoked")
+}
+
+// NewExpiredMsg returns a status update message telling the holder that the claim has expired
+func NewExpiredMsg(claim *domain.Claim) ([]byte, error) {
+	return newStatusUpdateMsg(claim, "claim has expired")
+}
+
+func newStatusUpdateMsg(claim *domain.Claim, reason string) ([]byte, error) {
 	msgID := uuid.NewString()
 	statusUpdate := &protocol.CredentialStatusUpdateMessage{
 		ID:       msgID,
@@ -50,7 +59,7 @@ func NewRevokedMsg(claim *domain.Claim) ([]byte, error) {
 		ThreadID: msgID,
 		Body: protocol.CredentialStatusUpdateMessageBody{
 			ID:     claim.ID.String(),
-			Reason: "claim was revoked",
+			Reason: reason,
 		},
 		From: claim.Issuer,
 		To:   claim.OtherIdentifier,
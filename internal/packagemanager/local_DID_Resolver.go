@@ -0,0 +1,30 @@
+package packagemanager
+
+import (
+	"context"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+	"github.com/iden3/iden3comm/v2/packers"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// NewLocalDIDResolverHandler creates a DID resolver handler that builds the DID documents of the identities of
+// the node locally. The rest of the DIDs are resolved with the fallback handler, usually the universal resolver.
+func NewLocalDIDResolverHandler(didDocumentService ports.DIDDocumentService, fallback packers.DIDResolverHandlerFunc) packers.DIDResolverHandlerFunc {
+	return func(did string) (*verifiable.DIDDocument, error) {
+		ctx := context.Background()
+		parsedDID, err := w3c.ParseDID(did)
+		if err != nil {
+			return fallback(did)
+		}
+		resolution, err := didDocumentService.Resolve(ctx, *parsedDID)
+		if err != nil {
+			log.Debug(ctx, "did is not resolved locally, using the fallback resolver", "did", did, "err", err)
+			return fallback(did)
+		}
+		return resolution.Document, nil
+	}
+}
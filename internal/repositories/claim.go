@@ -142,8 +142,9 @@ func (c *claim) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
                     index_hash,
 					mtp, 
 					link_id,
-                    created_at)
-		VALUES ($1,  $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+                    created_at,
+					previous_id)
+		VALUES ($1,  $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
 		RETURNING id`
 
 		err = conn.QueryRow(ctx, s,
@@ -167,7 +168,8 @@ func (c *claim) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
 			claim.HIndex,
 			claim.MtProof,
 			claim.LinkID,
-			claim.CreatedAt).Scan(&id)
+			claim.CreatedAt,
+			claim.PreviousID).Scan(&id)
 	} else {
 		s := `INSERT INTO claims (
 					id,
@@ -191,10 +193,11 @@ func (c *claim) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
                     index_hash,
 					mtp,
 					link_id,
-                    created_at
+                    created_at,
+					previous_id
 		)
 		VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23
 		)
 		ON CONFLICT ON CONSTRAINT claims_pkey 
 		DO UPDATE SET 
@@ -226,7 +229,8 @@ func (c *claim) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
 			claim.HIndex,
 			claim.MtProof,
 			claim.LinkID,
-			claim.CreatedAt).Scan(&id)
+			claim.CreatedAt,
+			claim.PreviousID).Scan(&id)
 	}
 
 	if err == nil {
@@ -240,7 +244,7 @@ func (c *claim) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
 		}
 	}
 
-	log.Errorf("error saving the claim: %v", "err", err.Error())
+	log.Errorf("error saving the claim: %v", err.Error())
 	return uuid.Nil, fmt.Errorf("error saving the claim: %w", err)
 }
 
@@ -371,7 +375,9 @@ func (c *claim) FindOneClaimBySchemaHash(ctx context.Context, conn db.Querier, s
 		WHERE claims.identifier=$1  
 				AND ( claims.other_identifier = $1 or claims.other_identifier = '') 
 				AND claims.schema_hash = $2 
-				AND claims.revoked = false`, subject.String(), schemaHash)
+				AND claims.revoked = false
+		ORDER BY claims.created_at
+		LIMIT 1`, subject.String(), schemaHash)
 
 	err := row.Scan(&claim.ID,
 		&claim.Issuer,
@@ -434,7 +440,8 @@ func (c *claim) GetByIdAndIssuer(ctx context.Context, conn db.Querier, identifie
        				core_claim,
 					mtp,
 					revoked,
-					link_id
+					link_id,
+					previous_id
         FROM claims
         WHERE claims.identifier = $1 AND claims.id = $2`, identifier.String(), claimID).Scan(
 		&claim.ID,
@@ -456,7 +463,8 @@ func (c *claim) GetByIdAndIssuer(ctx context.Context, conn db.Querier, identifie
 		&claim.CoreClaim,
 		&claim.MtProof,
 		&claim.Revoked,
-		&claim.LinkID)
+		&claim.LinkID,
+		&claim.PreviousID)
 
 	if err != nil && err == pgx.ErrNoRows {
 		return nil, ErrClaimDoesNotExist
@@ -534,6 +542,52 @@ func (c *claim) GetNonRevokedByConnectionAndIssuerID(ctx context.Context, conn d
 	return processClaims(rows)
 }
 
+// GetExpiredNotProcessed returns up to limit non revoked credentials of any issuer that expired before expiredOn and
+// have not been processed by the expiry sweeper yet
+func (c *claim) GetExpiredNotProcessed(ctx context.Context, conn db.Querier, expiredOn time.Time, limit int) ([]*domain.Claim, error) {
+	query := `SELECT claims.id,
+				   issuer,
+				   schema_hash,
+				   schema_url,
+				   schema_type,
+				   other_identifier,
+				   expiration,
+				   updatable,
+				   claims.version,
+				   rev_nonce,
+				   signature_proof,
+				   mtp_proof,
+				   data,
+				   claims.identifier,
+				   identity_state,
+				   identity_states.status,
+				   credential_status,
+				   core_claim,
+				   revoked,
+				   mtp,
+				   claims.created_at
+			FROM claims
+			LEFT JOIN identity_states  ON claims.identity_state = identity_states.state
+			WHERE claims.expiration > 0 AND claims.expiration < $1
+			AND claims.expiration_processed = false AND claims.revoked IS NOT TRUE
+			ORDER BY claims.expiration
+			LIMIT $2`
+
+	rows, err := conn.Query(ctx, query, expiredOn.Unix(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return processClaims(rows)
+}
+
+// MarkExpirationProcessed flags the given credentials as processed by the expiry sweeper
+func (c *claim) MarkExpirationProcessed(ctx context.Context, conn db.Querier, ids []uuid.UUID) error {
+	_, err := conn.Exec(ctx, `UPDATE claims SET expiration_processed = true WHERE id = ANY($1)`, ids)
+	return err
+}
+
 func (c *claim) GetAllByState(ctx context.Context, conn db.Querier, did *w3c.DID, state *merkletree.Hash) (claims []domain.Claim, err error) {
 	claims = make([]domain.Claim, 0)
 	var rows pgx.Rows
@@ -805,7 +859,10 @@ func buildGetAllQueryAndFilters(issuerID w3c.DID, filter *ports.ClaimsFilter) (q
 		filters = append(filters, fmt.Sprintf("%s%%", filter.SchemaHash))
 		query = fmt.Sprintf("%s and schema_hash like $%d", query, len(filters))
 	}
-	if filter.SchemaType != "" {
+	if filter.SchemaType != "" && filter.ExactSchemaType {
+		filters = append(filters, filter.SchemaType)
+		query = fmt.Sprintf("%s and schema_type = $%d", query, len(filters))
+	} else if filter.SchemaType != "" {
 		filters = append(filters, fmt.Sprintf("%%%s%%", filter.SchemaType))
 		query = fmt.Sprintf("%s and schema_type like $%d", query, len(filters))
 	}
@@ -817,11 +874,28 @@ func buildGetAllQueryAndFilters(issuerID w3c.DID, filter *ports.ClaimsFilter) (q
 		filters = append(filters, filter.QueryField, filter.QueryFieldValue)
 		query = fmt.Sprintf("%s and data -> 'credentialSubject'  ->>$%d = $%d ", query, len(filters)-1, len(filters))
 	}
+	if filter.SchemaURL != "" {
+		filters = append(filters, filter.SchemaURL)
+		query = fmt.Sprintf("%s AND claims.schema_url = $%d", query, len(filters))
+	}
+	for _, condition := range filter.Attributes {
+		var cond string
+		cond, filters = buildAttributeCondition(condition, filters)
+		query = fmt.Sprintf("%s AND %s", query, cond)
+	}
 	if filter.ExpiredOn != nil {
 		t := *filter.ExpiredOn
 		filters = append(filters, t.Unix())
 		query = fmt.Sprintf("%s AND claims.expiration>0 AND claims.expiration<$%d", query, len(filters))
 	}
+	if filter.CreatedAfter != nil {
+		filters = append(filters, *filter.CreatedAfter)
+		query = fmt.Sprintf("%s AND claims.created_at >= $%d", query, len(filters))
+	}
+	if filter.CreatedBefore != nil {
+		filters = append(filters, *filter.CreatedBefore)
+		query = fmt.Sprintf("%s AND claims.created_at < $%d", query, len(filters))
+	}
 	if len(filter.Proofs) > 0 {
 		for _, proof := range filter.Proofs {
 			switch proof {
@@ -863,6 +937,44 @@ func buildGetAllQueryAndFilters(issuerID w3c.DID, filter *ports.ClaimsFilter) (q
 	return query, countQuery, filters
 }
 
+// buildAttributeCondition translates a typed attribute condition into a predicate over the credential subject.
+// Numbers and booleans are only cast when the json value has the right type, so a credential with an
+// unexpected value does not make the whole query fail.
+func buildAttributeCondition(condition ports.AttributeCondition, filters []interface{}) (string, []interface{}) {
+	filters = append(filters, condition.Field)
+	field := len(filters)
+
+	var expr, cast string
+	switch condition.Type {
+	case "integer", "number":
+		expr = fmt.Sprintf("(CASE WHEN jsonb_typeof(data -> 'credentialSubject' -> $%d) = 'number' THEN (data -> 'credentialSubject' ->> $%d)::numeric END)", field, field)
+		cast = "numeric"
+	case "boolean":
+		expr = fmt.Sprintf("(CASE WHEN jsonb_typeof(data -> 'credentialSubject' -> $%d) = 'boolean' THEN (data -> 'credentialSubject' ->> $%d)::boolean END)", field, field)
+		cast = "boolean"
+	default:
+		expr = fmt.Sprintf("(data -> 'credentialSubject' ->> $%d)", field)
+		cast = "text"
+	}
+
+	operator := "="
+	switch condition.Operator {
+	case ports.AttributeQueryOperatorNe:
+		operator = "IS DISTINCT FROM"
+	case ports.AttributeQueryOperatorLt:
+		operator = "<"
+	case ports.AttributeQueryOperatorGt:
+		operator = ">"
+	}
+
+	conds := make([]string, 0, len(condition.Values))
+	for _, value := range condition.Values {
+		filters = append(filters, value)
+		conds = append(conds, fmt.Sprintf("%s %s $%d::%s", expr, operator, len(filters), cast))
+	}
+	return "(" + strings.Join(conds, " OR ") + ")", filters
+}
+
 func (c *claim) UpdateClaimMTP(ctx context.Context, conn db.Querier, claim *domain.Claim) (int64, error) {
 	query := "UPDATE claims SET mtp_proof = $1 WHERE id = $2 AND identifier = $3"
 	res, err := conn.Exec(ctx, query, claim.MTPProof, claim.ID, claim.Identifier)
@@ -872,7 +984,108 @@ func (c *claim) UpdateClaimMTP(ctx context.Context, conn db.Querier, claim *doma
 	return res.RowsAffected(), nil
 }
 
-// GetAuthClaimsForPublishing of all claims for identity
+// GetSigningAuthClaim returns the auth claim that signs the credentials of the identity among the ones that are valid
+// in the latest confirmed state: they have a MTP proof and they are not revoked, or their revocation is not confirmed
+// yet. It is the primary auth claim of the identity, or while the rotation of the primary key is not confirmed, the
+// key it replaced. Identities without a primary auth claim sign with the newest one.
+func (c *claim) GetSigningAuthClaim(ctx context.Context, conn db.Querier, identifier *w3c.DID, schemaHash string) (*domain.Claim, error) {
+	query := `SELECT claims.id,
+		issuer,
+       	schema_hash,
+       	schema_type,
+       	schema_url,
+       	other_identifier,
+       	expiration,
+       	updatable,
+       	claims.version,
+		rev_nonce,
+       	signature_proof,
+       	mtp_proof,
+       	data,
+       	claims.identifier,
+		identity_state,
+		identity_states.status,
+       	credential_status,
+       	core_claim,
+       	revoked,
+		mtp,
+		claims.created_at
+	FROM claims
+	JOIN identities ON claims.identifier = identities.identifier
+	LEFT JOIN identity_states ON claims.identity_state = identity_states.state
+	LEFT JOIN revocation ON claims.rev_nonce = revocation.nonce AND claims.issuer = revocation.identifier
+	WHERE claims.identifier = $1 
+			AND (claims.other_identifier = $1 OR claims.other_identifier = '')
+			AND claims.schema_hash = $2
+			AND claims.mtp_proof IS NOT NULL
+			AND (revocation.nonce IS NULL OR NOT EXISTS (
+				SELECT 1 FROM identity_states confirmed
+				WHERE confirmed.identifier = $1
+					AND confirmed.status = $3
+					AND revocation.status = $4
+					AND confirmed.created_at >= revocation.modified_at))
+	ORDER BY COALESCE(claims.id = identities.auth_claim_id, false) DESC,
+		revocation.nonce IS NOT NULL DESC,
+		claims.created_at DESC
+	LIMIT 1`
+
+	rows, err := conn.Query(ctx, query, identifier.String(), schemaHash, domain.StatusConfirmed, domain.RevPublished)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	claims, err := processClaims(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(claims) == 0 {
+		return nil, ErrClaimDoesNotExist
+	}
+	return claims[0], nil
+}
+
+// GetAuthClaims returns all the auth claims of the identity, revoked or not, oldest first
+func (c *claim) GetAuthClaims(ctx context.Context, conn db.Querier, identifier *w3c.DID, schemaHash string) ([]*domain.Claim, error) {
+	query := `SELECT claims.id,
+		issuer,
+       	schema_hash,
+       	schema_type,
+       	schema_url,
+       	other_identifier,
+       	expiration,
+       	updatable,
+       	claims.version,
+		rev_nonce,
+       	signature_proof,
+       	mtp_proof,
+       	data,
+       	claims.identifier,
+		identity_state,
+		identity_states.status,
+       	credential_status,
+       	core_claim,
+       	revoked,
+		mtp,
+		claims.created_at
+	FROM claims
+	LEFT JOIN identity_states  ON claims.identity_state = identity_states.state
+	WHERE claims.identifier = $1 
+			AND claims.schema_hash = $2
+	ORDER BY claims.created_at`
+
+	rows, err := conn.Query(ctx, query, identifier.String(), schemaHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return processClaims(rows)
+}
+
+// GetAuthClaimsForPublishing of all claims for identity.
+// A revocation is published in the transaction that creates the state, so an auth claim whose revocation was published
+// after the previous state is still valid to sign the publishing state transition, as the one revoked by a key rotation.
 func (c *claim) GetAuthClaimsForPublishing(ctx context.Context, conn db.Querier, identifier *w3c.DID, publishingState string, schemaHash string) ([]*domain.Claim, error) {
 	var err error
 	query := `SELECT claims.id,
@@ -899,10 +1112,15 @@ func (c *claim) GetAuthClaimsForPublishing(ctx context.Context, conn db.Querier,
 	FROM claims
 	LEFT JOIN identity_states  ON claims.identity_state = identity_states.state
 	LEFT JOIN revocation  ON claims.rev_nonce = revocation.nonce AND claims.issuer = revocation.identifier
+		AND revocation.modified_at <= (SELECT previous.created_at 
+			FROM identity_states publishing
+			JOIN identity_states previous ON previous.state = publishing.previous_state
+			WHERE publishing.state = $2)
 	WHERE claims.identifier = $1 
 			AND state != $2
 			AND claims.schema_hash = $3
-			AND revocation.nonce IS NULL `
+			AND revocation.nonce IS NULL 
+	ORDER BY claims.created_at`
 
 	rows, err := conn.Query(ctx, query, identifier.String(), publishingState, schemaHash)
 	if err != nil {
@@ -396,6 +396,21 @@ func TestGetAllByIssuerID(t *testing.T) {
 			filter:   ports.ClaimsFilter{QueryField: "number", QueryFieldValue: "1"},
 			expected: 1,
 		},
+		{
+			name:     "filter.SchemaType matches part of the schema type",
+			filter:   ports.ClaimsFilter{SchemaType: "AuthBJJ"},
+			expected: 1,
+		},
+		{
+			name:     "filter.SchemaType exact does not match part of the schema type",
+			filter:   ports.ClaimsFilter{SchemaType: "AuthBJJ", ExactSchemaType: true},
+			expected: 0,
+		},
+		{
+			name:     "filter.SchemaType exact matches the schema type",
+			filter:   ports.ClaimsFilter{SchemaType: "AuthBJJCredential", ExactSchemaType: true},
+			expected: 1,
+		},
 		{
 			name:     "filter.Subject should return one entry",
 			filter:   ports.ClaimsFilter{Subject: userDID.String()},
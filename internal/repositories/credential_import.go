@@ -0,0 +1,234 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// ErrCredentialImportDoesNotExist credential import does not exist
+var ErrCredentialImportDoesNotExist = errors.New("credential import does not exist")
+
+const credentialImportFields = `id, issuer_id, schema_id, format, status, total, processed, issued, rejected, expiration,
+	signature_proof, mtp_proof, credential_status_type, COALESCE(error, ''), created_at, modified_at`
+
+type credentialImport struct{}
+
+// NewCredentialImport returns a new credential import repository
+func NewCredentialImport() *credentialImport {
+	return &credentialImport{}
+}
+
+// Save creates a credential import job
+func (r *credentialImport) Save(ctx context.Context, conn db.Querier, ci *domain.CredentialImport) error {
+	const insert = `INSERT INTO credential_imports (id, issuer_id, schema_id, format, status, total, processed, issued, rejected,
+			expiration, signature_proof, mtp_proof, credential_status_type, error, created_at, modified_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NULLIF($14, ''), $15, $16)`
+
+	rejected, err := json.Marshal(ci.Rejected)
+	if err != nil {
+		return err
+	}
+	ci.ModifiedAt = time.Now().UTC()
+	_, err = conn.Exec(ctx, insert,
+		ci.ID,
+		ci.IssuerDID.String(),
+		ci.SchemaID,
+		ci.Format,
+		ci.Status,
+		ci.Total,
+		ci.Processed,
+		ci.Issued,
+		rejected,
+		ci.Expiration,
+		ci.SignatureProof,
+		ci.MTPProof,
+		ci.CredentialStatusType,
+		ci.Error,
+		ci.CreatedAt,
+		ci.ModifiedAt)
+	return err
+}
+
+// UpdateProgress updates the status and the counters of a credential import job.
+// The rows of the job are removed once it is finished or failed.
+func (r *credentialImport) UpdateProgress(ctx context.Context, conn db.Querier, ci *domain.CredentialImport) error {
+	const update = `UPDATE credential_imports
+		SET status = $2, processed = $3, issued = $4, rejected = $5, error = NULLIF($6, ''), modified_at = $7
+		WHERE id = $1`
+
+	rejected, err := json.Marshal(ci.Rejected)
+	if err != nil {
+		return err
+	}
+	ci.ModifiedAt = time.Now().UTC()
+	tag, err := conn.Exec(ctx, update, ci.ID, ci.Status, ci.Processed, ci.Issued, rejected, ci.Error, ci.ModifiedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCredentialImportDoesNotExist
+	}
+	if ci.IsDone() {
+		_, err = conn.Exec(ctx, `DELETE FROM credential_import_rows WHERE import_id = $1`, ci.ID)
+	}
+	return err
+}
+
+// Claim takes over a credential import job if its progress has not been saved since it was read, and returns
+// false otherwise. It prevents two processes from resuming the same job.
+func (r *credentialImport) Claim(ctx context.Context, conn db.Querier, ci *domain.CredentialImport) (bool, error) {
+	const claim = `UPDATE credential_imports SET modified_at = $3 WHERE id = $1 AND modified_at = $2`
+
+	modifiedAt := time.Now().UTC()
+	tag, err := conn.Exec(ctx, claim, ci.ID, ci.ModifiedAt, modifiedAt)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	ci.ModifiedAt = modifiedAt
+	return true, nil
+}
+
+// GetByID returns the credential import job of the issuer with the given id
+func (r *credentialImport) GetByID(ctx context.Context, conn db.Querier, issuerDID w3c.DID, id uuid.UUID) (*domain.CredentialImport, error) {
+	const byID = `SELECT ` + credentialImportFields + `
+		FROM credential_imports
+		WHERE issuer_id = $1 AND id = $2`
+
+	ci, err := scanCredentialImport(conn.QueryRow(ctx, byID, issuerDID.String(), id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCredentialImportDoesNotExist
+	}
+	return ci, err
+}
+
+// GetUnfinished returns the pending and running credential import jobs of all the issuers
+func (r *credentialImport) GetUnfinished(ctx context.Context, conn db.Querier) ([]*domain.CredentialImport, error) {
+	const unfinished = `SELECT ` + credentialImportFields + `
+		FROM credential_imports
+		WHERE status IN ($1, $2)
+		ORDER BY created_at`
+
+	rows, err := conn.Query(ctx, unfinished, domain.CredentialImportPending, domain.CredentialImportRunning)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]*domain.CredentialImport, 0)
+	for rows.Next() {
+		ci, err := scanCredentialImport(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, ci)
+	}
+	return jobs, rows.Err()
+}
+
+// SaveRows stores rows of the file of a credential import job
+func (r *credentialImport) SaveRows(ctx context.Context, conn db.Querier, importID uuid.UUID, rows []domain.CredentialImportRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(rows))
+	args := make([]interface{}, 0, len(rows)*4)
+	for _, row := range rows {
+		var subject []byte
+		if row.Subject != nil {
+			var err error
+			if subject, err = json.Marshal(row.Subject); err != nil {
+				return err
+			}
+		}
+		args = append(args, importID, row.Number, subject, row.Error)
+		n := len(args)
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, NULLIF($%d, ''))", n-3, n-2, n-1, n))
+	}
+	_, err := conn.Exec(ctx, `INSERT INTO credential_import_rows (import_id, row, subject, error) VALUES `+strings.Join(values, ", "), args...)
+	return err
+}
+
+// GetRows returns up to limit rows of a credential import job that come after the given row number, in file order
+func (r *credentialImport) GetRows(ctx context.Context, conn db.Querier, importID uuid.UUID, after int, limit int) ([]domain.CredentialImportRow, error) {
+	const byImport = `SELECT row, subject, COALESCE(error, '')
+		FROM credential_import_rows
+		WHERE import_id = $1 AND row > $2
+		ORDER BY row
+		LIMIT $3`
+
+	rows, err := conn.Query(ctx, byImport, importID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]domain.CredentialImportRow, 0, limit)
+	for rows.Next() {
+		var (
+			row     domain.CredentialImportRow
+			subject []byte
+		)
+		if err := rows.Scan(&row.Number, &subject, &row.Error); err != nil {
+			return nil, err
+		}
+		if subject != nil {
+			if err := json.Unmarshal(subject, &row.Subject); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+func scanCredentialImport(row pgx.Row) (*domain.CredentialImport, error) {
+	var (
+		ci       domain.CredentialImport
+		issuerID string
+		rejected []byte
+	)
+	err := row.Scan(
+		&ci.ID,
+		&issuerID,
+		&ci.SchemaID,
+		&ci.Format,
+		&ci.Status,
+		&ci.Total,
+		&ci.Processed,
+		&ci.Issued,
+		&rejected,
+		&ci.Expiration,
+		&ci.SignatureProof,
+		&ci.MTPProof,
+		&ci.CredentialStatusType,
+		&ci.Error,
+		&ci.CreatedAt,
+		&ci.ModifiedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	did, err := w3c.ParseDID(issuerID)
+	if err != nil {
+		return nil, err
+	}
+	ci.IssuerDID = *did
+	if err := json.Unmarshal(rejected, &ci.Rejected); err != nil {
+		return nil, err
+	}
+	return &ci, nil
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+func TestCredentialImport(t *testing.T) {
+	ctx := context.Background()
+	const idStr = "did:polygonid:polygon:amoy:2qQ68JkRcf3xrHPQPWZei3YeVzHPP58wYNxx2mEouR"
+	issuerDID, err := w3c.ParseDID(idStr)
+	require.NoError(t, err)
+	fixture := NewFixture(storage)
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+	schema := &domain.Schema{
+		ID:        uuid.New(),
+		IssuerDID: *issuerDID,
+		URL:       "https://domain.org/this/is/an/url",
+		Type:      "schemaType",
+		CreatedAt: time.Now(),
+	}
+	schema.Hash = common.CreateSchemaHash([]byte(schema.URL + "#" + schema.Type))
+	fixture.CreateSchema(t, ctx, schema)
+
+	repo := NewCredentialImport()
+	job := domain.NewCredentialImport(*issuerDID, schema.ID, domain.CredentialImportFormatCSV, 3)
+	job.Expiration = common.ToPointer(time.Now().Add(time.Hour).UTC().Truncate(time.Second))
+	job.SignatureProof = true
+	job.CredentialStatusType = "Iden3commRevocationStatusV1.0"
+	require.NoError(t, repo.Save(ctx, storage.Pgx, job))
+	require.NoError(t, repo.SaveRows(ctx, storage.Pgx, job.ID, []domain.CredentialImportRow{
+		{Number: 1, Subject: map[string]any{"id": "did:iden3:privado:main:2Scn2RfosbkQDMQzQM5nCz3Nk5GnbzZCWzGCd3tc2G", "age": float64(20)}},
+		{Number: 2, Error: "missing credential subject id"},
+		{Number: 3, Subject: map[string]any{"id": "did:iden3:privado:main:2Scn2RfosbkQDMQzQM5nCz3Nk5GnbzZCWzGCd3tc2G", "age": float64(30)}},
+	}))
+
+	t.Run("should return the job", func(t *testing.T) {
+		stored, err := repo.GetByID(ctx, storage.Pgx, *issuerDID, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.CredentialImportPending, stored.Status)
+		assert.Equal(t, 3, stored.Total)
+		assert.True(t, job.Expiration.Equal(*stored.Expiration))
+		assert.True(t, stored.SignatureProof)
+		assert.False(t, stored.MTPProof)
+		assert.Equal(t, job.CredentialStatusType, stored.CredentialStatusType)
+		assert.Empty(t, stored.Error)
+
+		_, err = repo.GetByID(ctx, storage.Pgx, *issuerDID, uuid.New())
+		assert.ErrorIs(t, err, ErrCredentialImportDoesNotExist)
+	})
+
+	t.Run("should return the rows after the processed ones", func(t *testing.T) {
+		rows, err := repo.GetRows(ctx, storage.Pgx, job.ID, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, 2, rows[0].Number)
+		assert.Nil(t, rows[0].Subject)
+		assert.Equal(t, "missing credential subject id", rows[0].Error)
+		assert.Equal(t, 3, rows[1].Number)
+		assert.Equal(t, float64(30), rows[1].Subject["age"])
+		assert.Empty(t, rows[1].Error)
+	})
+
+	t.Run("should let only one process claim the job", func(t *testing.T) {
+		unfinished, err := repo.GetUnfinished(ctx, storage.Pgx)
+		require.NoError(t, err)
+		var first, second *domain.CredentialImport
+		for _, ci := range unfinished {
+			if ci.ID == job.ID {
+				first, second = ci, &domain.CredentialImport{ID: ci.ID, ModifiedAt: ci.ModifiedAt}
+			}
+		}
+		require.NotNil(t, first)
+
+		claimed, err := repo.Claim(ctx, storage.Pgx, first)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+		claimed, err = repo.Claim(ctx, storage.Pgx, second)
+		require.NoError(t, err)
+		assert.False(t, claimed)
+	})
+
+	t.Run("should remove the rows of a failed job", func(t *testing.T) {
+		job.Status = domain.CredentialImportRunning
+		job.Processed = 1
+		job.Issued = 1
+		require.NoError(t, repo.UpdateProgress(ctx, storage.Pgx, job))
+		rows, err := repo.GetRows(ctx, storage.Pgx, job.ID, 0, 10)
+		require.NoError(t, err)
+		assert.Len(t, rows, 3)
+
+		job.Fail(errors.New("connection lost"))
+		require.NoError(t, repo.UpdateProgress(ctx, storage.Pgx, job))
+		stored, err := repo.GetByID(ctx, storage.Pgx, *issuerDID, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.CredentialImportFailed, stored.Status)
+		assert.Equal(t, "connection lost", stored.Error)
+		assert.Equal(t, 1, stored.Processed)
+		rows, err = repo.GetRows(ctx, storage.Pgx, job.ID, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, rows)
+
+		unfinished, err := repo.GetUnfinished(ctx, storage.Pgx)
+		require.NoError(t, err)
+		for _, ci := range unfinished {
+			assert.NotEqual(t, job.ID, ci.ID)
+		}
+	})
+}
@@ -6,7 +6,10 @@ import (
 	"strings"
 )
 
-var didCharacters = regexp.MustCompile(`[^a-zA-Z0-9:]+`)
+var (
+	didCharacters = regexp.MustCompile(`[^a-zA-Z0-9:]+`)
+	likeEscaper   = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+)
 
 func tokenizeQuery(query string) []string {
 	words := strings.Split(strings.ReplaceAll(query, ",", " "), " ")
@@ -60,6 +63,11 @@ func escapeDID(s string) string {
 	return didCharacters.ReplaceAllString(s, "")
 }
 
+// escapeLike escapes the wildcards of a LIKE pattern, so s is matched literally
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
 func inArray(needle string, haystack []string) bool {
 	for _, word := range haystack {
 		if needle == word {
@@ -204,3 +204,10 @@ func TestBuildPartialQueryLikes(t *testing.T) {
 		})
 	}
 }
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, "did:polygonid", escapeLike("did:polygonid"))
+	assert.Equal(t, `100\%`, escapeLike("100%"))
+	assert.Equal(t, `display\_name`, escapeLike("display_name"))
+	assert.Equal(t, `back\\slash`, escapeLike(`back\slash`))
+}
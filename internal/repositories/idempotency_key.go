@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// ErrIdempotencyKeyDoesNotExist idempotency key does not exist
+var ErrIdempotencyKeyDoesNotExist = errors.New("idempotency key does not exist")
+
+type idempotencyKey struct{}
+
+// NewIdempotencyKey returns a new idempotency keys repository
+func NewIdempotencyKey() ports.IdempotencyKeyRepository {
+	return &idempotencyKey{}
+}
+
+// Reserve stores the key without a response. It returns false if the key is already in use. An expired key, or a key
+// without a response reserved before staleBefore, is replaced, so it can be reserved again.
+func (r *idempotencyKey) Reserve(ctx context.Context, conn db.Querier, key *domain.IdempotencyKey, staleBefore time.Time) (bool, error) {
+	const reserve = `INSERT INTO idempotency_keys (issuer_id, operation, key, request_hash, response, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, NULL, $5, $6)
+		ON CONFLICT (issuer_id, operation, key) DO UPDATE
+			SET request_hash = EXCLUDED.request_hash, response = NULL, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at < EXCLUDED.created_at
+				OR (idempotency_keys.response IS NULL AND idempotency_keys.created_at < $7)`
+	res, err := conn.Exec(ctx, reserve, key.IssuerID, key.Operation, key.Key, key.RequestHash, key.CreatedAt, key.ExpiresAt, staleBefore)
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+// Get returns the key with the stored response, if any
+func (r *idempotencyKey) Get(ctx context.Context, conn db.Querier, issuerDID w3c.DID, operation domain.IdempotentOperation, key string) (*domain.IdempotencyKey, error) {
+	const byKey = `SELECT issuer_id, operation, key, request_hash, response, created_at, expires_at
+		FROM idempotency_keys
+		WHERE issuer_id = $1 AND operation = $2 AND key = $3`
+	var k domain.IdempotencyKey
+	var response []byte
+	err := conn.QueryRow(ctx, byKey, issuerDID.String(), operation, key).Scan(
+		&k.IssuerID,
+		&k.Operation,
+		&k.Key,
+		&k.RequestHash,
+		&response,
+		&k.CreatedAt,
+		&k.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrIdempotencyKeyDoesNotExist
+		}
+		return nil, err
+	}
+	if response != nil {
+		k.Response = response
+	}
+	return &k, nil
+}
+
+// SetResponse stores the response of the request that reserved the key. It returns ErrIdempotencyKeyDoesNotExist if
+// the key has been reserved again by another request since then.
+func (r *idempotencyKey) SetResponse(ctx context.Context, conn db.Querier, key *domain.IdempotencyKey, response json.RawMessage) error {
+	const update = `UPDATE idempotency_keys SET response = $5 WHERE issuer_id = $1 AND operation = $2 AND key = $3 AND created_at = $4`
+	res, err := conn.Exec(ctx, update, key.IssuerID, key.Operation, key.Key, key.CreatedAt, string(response))
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrIdempotencyKeyDoesNotExist
+	}
+	return nil
+}
+
+// Delete releases a key, so the request can be retried with it. A key reserved again by another request is kept.
+func (r *idempotencyKey) Delete(ctx context.Context, conn db.Querier, key *domain.IdempotencyKey) error {
+	const del = `DELETE FROM idempotency_keys WHERE issuer_id = $1 AND operation = $2 AND key = $3 AND created_at = $4`
+	_, err := conn.Exec(ctx, del, key.IssuerID, key.Operation, key.Key, key.CreatedAt)
+	return err
+}
+
+// DeleteExpired removes the expired keys and returns how many were removed
+func (r *idempotencyKey) DeleteExpired(ctx context.Context, conn db.Querier) (int64, error) {
+	const del = `DELETE FROM idempotency_keys WHERE expires_at < NOW()`
+	res, err := conn.Exec(ctx, del)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
@@ -2,9 +2,11 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/iden3/go-iden3-core/v2/w3c"
 	"github.com/jackc/pgconn"
@@ -12,6 +14,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/sqltools"
 )
 
 // ErrIdentityNotFound - identity not found error
@@ -29,7 +32,7 @@ func NewIdentity() ports.IndentityRepository {
 
 // Save - Create new identity
 func (i *identity) Save(ctx context.Context, conn db.Querier, identity *domain.Identity) error {
-	_, err := conn.Exec(ctx, `INSERT INTO identities (identifier, address, keyType, display_name) VALUES ($1, $2, $3, $4)`, identity.Identifier, identity.Address, identity.KeyType, identity.DisplayName)
+	_, err := conn.Exec(ctx, `INSERT INTO identities (identifier, address, keyType, display_name, auth_claim_id) VALUES ($1, $2, $3, $4, $5)`, identity.Identifier, identity.Address, identity.KeyType, identity.DisplayName, identity.AuthClaimID)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == duplicateViolationErrorCode {
@@ -40,10 +43,25 @@ func (i *identity) Save(ctx context.Context, conn db.Querier, identity *domain.I
 	return err
 }
 
-// UpdateDisplayName - Update identity displayName field
-func (i *identity) UpdateDisplayName(ctx context.Context, conn db.Querier, identity *domain.Identity) error {
-	_, err := conn.Exec(ctx, `UPDATE identities SET display_name = $1 where identifier = $2`, identity.DisplayName, identity.Identifier)
-	return err
+// Update - Update identity displayName, profile and publishing policy fields
+func (i *identity) Update(ctx context.Context, conn db.Querier, identity *domain.Identity) error {
+	profile, err := json.Marshal(identity.Profile)
+	if err != nil {
+		return err
+	}
+	publishingPolicy, err := json.Marshal(identity.PublishingPolicy)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Exec(ctx, `UPDATE identities SET display_name = $1, profile = $2, publishing_policy = $3 where identifier = $4`, identity.DisplayName, profile, publishingPolicy, identity.Identifier)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == duplicateViolationErrorCode {
+			return ErrDisplayNameDuplicated
+		}
+		return err
+	}
+	return nil
 }
 
 func (i *identity) GetByID(ctx context.Context, conn db.Querier, identifier w3c.DID) (*domain.Identity, error) {
@@ -55,7 +73,12 @@ func (i *identity) GetByID(ctx context.Context, conn db.Querier, identifier w3c.
 						identities.keyType,
 						identities.address,
 						identities.display_name,
-       					state_id,
+						identities.deactivated_at,
+						identities.archived_at,
+						COALESCE(identities.profile, '{}'::jsonb),
+						COALESCE(identities.publishing_policy, '{"mode":"always"}'::jsonb),
+						identities.auth_claim_id,
+       					COALESCE(state_id, 0),
    						state,           
     					root_of_roots,
     					revocation_tree_root,
@@ -64,10 +87,10 @@ func (i *identity) GetByID(ctx context.Context, conn db.Querier, identifier w3c.
     					block_number,
     					tx_id,
     					previous_state,
-    					status,               
-    					modified_at,
-    					identity_states.created_at,
-				        claims.credential_status
+    					COALESCE(status::text, ''),
+    					COALESCE(modified_at, 'epoch'::timestamptz),
+    					COALESCE(identity_states.created_at, 'epoch'::timestamptz),
+				        COALESCE(claims.credential_status, '{}'::jsonb)
 			   FROM identities
 			   LEFT JOIN identity_states ON identities.identifier = identity_states.identifier
                LEFT JOIN claims ON claims.identifier = identities.identifier and claims.schema_type = 'https://schema.iden3.io/core/jsonld/auth.jsonld#AuthBJJCredential'
@@ -75,12 +98,18 @@ func (i *identity) GetByID(ctx context.Context, conn db.Querier, identifier w3c.
 			        AND ( status = 'transacted' OR status = 'confirmed')
     				OR (identities.identifier=$1 AND status = 'created' AND previous_state is null
 				AND NOT EXISTS (SELECT 1 FROM identity_states WHERE identifier=$1 AND (status = 'transacted' OR status = 'confirmed')))
+				OR (identities.identifier=$1 AND identity_states.state_id IS NULL)
 				ORDER BY state_id DESC LIMIT 1`, identifier.String())
 
 	err := row.Scan(&identity.Identifier,
 		&identity.KeyType,
 		&identity.Address,
 		&identity.DisplayName,
+		&identity.DeactivatedAt,
+		&identity.ArchivedAt,
+		&identity.Profile,
+		&identity.PublishingPolicy,
+		&identity.AuthClaimID,
 		&identity.State.StateID,
 		&identity.State.State,
 		&identity.State.RootOfRoots,
@@ -103,26 +132,156 @@ func (i *identity) GetByID(ctx context.Context, conn db.Querier, identifier w3c.
 	return &identity, err
 }
 
-func (i *identity) Get(ctx context.Context, conn db.Querier) (identities []domain.IdentityDisplayName, err error) {
-	rows, err := conn.Query(ctx, `SELECT identifier, display_name FROM identities`)
+// UpdateStatus - Update identity deactivated_at and archived_at fields
+func (i *identity) UpdateStatus(ctx context.Context, conn db.Querier, identity *domain.Identity) error {
+	_, err := conn.Exec(ctx, `UPDATE identities SET deactivated_at = $1, archived_at = $2 where identifier = $3`, identity.DeactivatedAt, identity.ArchivedAt, identity.Identifier)
+	return err
+}
+
+// UpdateAuthClaimID - Update the primary auth claim of the identity
+func (i *identity) UpdateAuthClaimID(ctx context.Context, conn db.Querier, identity *domain.Identity) error {
+	_, err := conn.Exec(ctx, `UPDATE identities SET auth_claim_id = $1 where identifier = $2`, identity.AuthClaimID, identity.Identifier)
+	return err
+}
+
+// IsActive - returns whether the identity is not deactivated. The identity is locked in share mode until the end of the
+// transaction of conn, so it can't be deactivated while the changes that require it to be active are made.
+func (i *identity) IsActive(ctx context.Context, conn db.Querier, identifier w3c.DID) (bool, error) {
+	var deactivatedAt *time.Time
+	err := conn.QueryRow(ctx, `SELECT deactivated_at FROM identities WHERE identifier = $1 FOR SHARE`, identifier.String()).Scan(&deactivatedAt)
 	if err != nil {
-		return nil, err
+		if strings.Contains(err.Error(), "no rows in result set") {
+			return false, ErrIdentityNotFound
+		}
+		return false, err
+	}
+	return deactivatedAt == nil, nil
+}
+
+// Get - returns the identities that match the request with their credential counters.
+// The total is the number of identities that match the filters, regardless of the page.
+func (i *identity) Get(ctx context.Context, conn db.Querier, req *ports.GetIdentitiesRequest) (identities []domain.IdentityDisplayName, total uint, err error) {
+	query, countQuery, args := buildGetIdentitiesQuery(req)
+
+	if req.Pagination.Page != nil {
+		if err := conn.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+			return nil, 0, err
+		}
 	}
 
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
 	defer rows.Close()
 
+	identities = make([]domain.IdentityDisplayName, 0)
 	for rows.Next() {
-		var identityDisplayName domain.IdentityDisplayName
-		err = rows.Scan(&identityDisplayName.Identifier, &identityDisplayName.DisplayName)
+		var identity domain.IdentityDisplayName
+		var deactivatedAt, archivedAt *time.Time
+		err = rows.Scan(&identity.Identifier,
+			&identity.DisplayName,
+			&deactivatedAt,
+			&archivedAt,
+			&identity.KeyType,
+			&identity.CreatedAt,
+			&identity.CredentialStatusType,
+			&identity.CredentialsIssued,
+			&identity.CredentialsRevoked,
+			&identity.PendingStateChanges)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
-		identities = append(identities, identityDisplayName)
+		identity.Status = domain.NewIdentityLifecycleStatus(deactivatedAt, archivedAt)
+		identities = append(identities, identity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if req.Pagination.Page == nil {
+		total = uint(len(identities))
+	}
+	return identities, total, nil
+}
+
+func buildGetIdentitiesQuery(req *ports.GetIdentitiesRequest) (query string, countQuery string, args []interface{}) {
+	const identifierField sqltools.SQLFieldName = "identities.identifier"
+	fields := []string{
+		"identities.identifier",
+		"identities.display_name",
+		"identities.deactivated_at",
+		"identities.archived_at",
+		"identities.keytype",
+		"identities.created_at",
+		"COALESCE(auth.credential_status_type, '')",
+		"credentials.issued",
+		"credentials.revoked",
+		"pending.changes",
+	}
+
+	args = []interface{}{domain.AuthBJJCredentialSchemaType, req.IncludeArchived}
+	query = `SELECT ##QUERYFIELDS## FROM identities
+		LEFT JOIN LATERAL (
+			SELECT claims.credential_status ->> 'type' AS credential_status_type FROM claims
+			WHERE claims.identifier = identities.identifier AND claims.schema_type = $1
+			ORDER BY claims.created_at LIMIT 1
+		) auth ON true
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS issued, COUNT(*) FILTER (WHERE claims.revoked) AS revoked FROM claims
+			WHERE claims.identifier = identities.identifier AND claims.schema_type <> $1
+		) credentials ON true
+		LEFT JOIN LATERAL (
+			SELECT (SELECT COUNT(*) FROM claims
+					WHERE claims.identifier = identities.identifier AND claims.issuer = identities.identifier
+					AND claims.identity_state IS NULL AND claims.mtp = true)
+				+ (SELECT COUNT(*) FROM revocation
+					WHERE revocation.identifier = identities.identifier AND revocation.status = 0) AS changes
+		) pending ON true
+		WHERE ($2 OR identities.archived_at IS NULL)`
+
+	if req.Query != "" {
+		args = append(args, escapeLike(req.Query))
+		query = fmt.Sprintf("%s AND (identities.display_name ILIKE '%%' || $%d || '%%' OR identities.identifier ILIKE '%%' || $%d || '%%')", query, len(args), len(args))
+	}
+	if req.Method != "" {
+		args = append(args, req.Method)
+		query = fmt.Sprintf("%s AND split_part(identities.identifier, ':', 2) = $%d", query, len(args))
+	}
+	// did:web identities have the host where the blockchain and the network of iden3 DIDs are
+	if req.Blockchain != "" {
+		args = append(args, req.Blockchain)
+		query = fmt.Sprintf("%s AND identities.identifier NOT LIKE 'did:web:%%' AND split_part(identities.identifier, ':', 3) = $%d", query, len(args))
 	}
+	if req.Network != "" {
+		args = append(args, req.Network)
+		query = fmt.Sprintf("%s AND identities.identifier NOT LIKE 'did:web:%%' AND split_part(identities.identifier, ':', 4) = $%d", query, len(args))
+	}
+	if req.KeyType != "" {
+		args = append(args, req.KeyType)
+		query = fmt.Sprintf("%s AND identities.keytype = $%d", query, len(args))
+	}
+	if req.CredentialStatusType != "" {
+		args = append(args, req.CredentialStatusType)
+		query = fmt.Sprintf("%s AND auth.credential_status_type = $%d", query, len(args))
+	}
+
+	countQuery = strings.Replace(query, "##QUERYFIELDS##", "COUNT(*)", 1)
+	query = strings.Replace(query, "##QUERYFIELDS##", strings.Join(fields, ","), 1)
 
-	return identities, err
+	orderBy := append(sqltools.OrderByFilters{}, req.OrderBy...)
+	_ = orderBy.Add(ports.IdentitiesCreatedAt, true)
+	_ = orderBy.Add(identifierField, false)
+	query += " ORDER BY " + orderBy.String()
+
+	if req.Pagination.Page != nil {
+		query += fmt.Sprintf(" OFFSET %d LIMIT %d", req.Pagination.GetOffset(), req.Pagination.GetLimit())
+	}
+	return query, countQuery, args
 }
 
+// GetUnprocessedIssuersIDs - returns the issuers with changes to publish and no state transition in progress.
+// Deactivated identities only publish their revocations.
 func (i *identity) GetUnprocessedIssuersIDs(ctx context.Context, conn db.Querier) (issuersIDs []*w3c.DID, err error) {
 	rows, err := conn.Query(ctx,
 		`WITH issuers_to_process AS
@@ -130,6 +289,7 @@ func (i *identity) GetUnprocessedIssuersIDs(ctx context.Context, conn db.Querier
     SELECT  issuer 
 		FROM claims
 		WHERE identity_state ISNULL AND identifier = issuer
+			AND issuer NOT IN (SELECT identifier FROM identities WHERE deactivated_at IS NOT NULL)
 			UNION
 		SELECT identifier FROM revocation where status = 0
 ), transacted_issuers AS
@@ -137,7 +297,7 @@ func (i *identity) GetUnprocessedIssuersIDs(ctx context.Context, conn db.Querier
     SELECT identifier from identity_states WHERE status = 'transacted'
 )
 
-SELECT issuer FROM issuers_to_process WHERE issuer NOT IN (SELECT identifier FROM transacted_issuers);
+SELECT issuer FROM issuers_to_process WHERE issuer NOT IN (SELECT identifier FROM transacted_issuers) AND issuer NOT LIKE 'did:web:%';
 `)
 	if err != nil {
 		return nil, err
@@ -169,6 +329,30 @@ SELECT issuer FROM issuers_to_process WHERE issuer NOT IN (SELECT identifier FRO
 	return issuersIDs, nil
 }
 
+// GetPendingStateChanges - returns the changes of the identity not published yet. The last publication is the
+// newest state sent to the blockchain, or the creation of the identity if its state was never published.
+func (i *identity) GetPendingStateChanges(ctx context.Context, conn db.Querier, identifier w3c.DID) (*domain.PendingStateChanges, error) {
+	var pending domain.PendingStateChanges
+	err := conn.QueryRow(ctx,
+		`SELECT (SELECT COUNT(*) FROM claims
+				WHERE claims.identifier = identities.identifier AND claims.issuer = identities.identifier
+				AND claims.identity_state IS NULL AND claims.mtp = true),
+			(SELECT COUNT(*) FROM revocation
+				WHERE revocation.identifier = identities.identifier AND revocation.status = 0),
+			COALESCE((SELECT MAX(identity_states.created_at) FROM identity_states
+				WHERE identity_states.identifier = identities.identifier AND identity_states.previous_state IS NOT NULL
+				AND identity_states.status IN ('transacted', 'confirmed')), identities.created_at)
+		FROM identities
+		WHERE identities.identifier = $1`, identifier.String()).Scan(&pending.Credentials, &pending.Revocations, &pending.LastPublishedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows in result set") {
+			return nil, ErrIdentityNotFound
+		}
+		return nil, err
+	}
+	return &pending, nil
+}
+
 func (i *identity) HasUnprocessedStatesByID(ctx context.Context, conn db.Querier, identifier *w3c.DID) (bool, error) {
 	row := conn.QueryRow(ctx,
 		`WITH issuers_to_process AS
@@ -176,6 +360,7 @@ func (i *identity) HasUnprocessedStatesByID(ctx context.Context, conn db.Querier
 					SELECT  issuer 
 						FROM claims
 						WHERE identity_state ISNULL AND identifier = issuer
+							AND issuer NOT IN (SELECT identifier FROM identities WHERE deactivated_at IS NOT NULL)
 							UNION
 						SELECT identifier FROM revocation where status = 0
 				), transacted_issuers AS
@@ -200,6 +385,7 @@ func (i *identity) HasUnprocessedAndFailedStatesByID(ctx context.Context, conn d
              SELECT  issuer
              FROM claims
              WHERE identity_state ISNULL AND identifier = issuer AND (mtp = true OR revoked = true)
+               AND issuer NOT IN (SELECT identifier FROM identities WHERE deactivated_at IS NOT NULL)
              UNION
              SELECT identifier FROM revocation where status = 0
          ), transacted_issuers AS
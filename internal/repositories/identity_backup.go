@@ -0,0 +1,204 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+var (
+	ErrUnknownBackupTable   = errors.New("unknown identity backup table")                       // ErrUnknownBackupTable means that the backup has a table that is not part of an identity backup
+	ErrBackupRowNotRestored = errors.New("identity backup row does not belong to the identity") // ErrBackupRowNotRestored means that some rows of a table were not inserted
+)
+
+type identityBackupTable struct {
+	name             string
+	identifierColumn string
+	orderBy          string
+}
+
+// identityBackupTables are the tables of an identity in restore order, so the foreign keys are satisfied
+var identityBackupTables = []identityBackupTable{
+	{name: "identities", identifierColumn: "identifier", orderBy: "identifier"},
+	{name: "identity_states", identifierColumn: "identifier", orderBy: "state_id"},
+	{name: "schemas", identifierColumn: "issuer_id", orderBy: "created_at, id"},
+	{name: "links", identifierColumn: "issuer_id", orderBy: "created_at, id"},
+	{name: "connections", identifierColumn: "issuer_id", orderBy: "created_at, id"},
+	{name: "claims", identifierColumn: "identifier", orderBy: "created_at, id"},
+	{name: "revocation", identifierColumn: "identifier", orderBy: "id"},
+	{name: "status_lists", identifierColumn: "issuer_id", orderBy: "created_at, id"},
+}
+
+type identityBackup struct{}
+
+// NewIdentityBackup returns a new identity backup repository
+func NewIdentityBackup() ports.IdentityBackupRepository {
+	return &identityBackup{}
+}
+
+// GetTables returns the rows of every table that belong to the identity
+func (r *identityBackup) GetTables(ctx context.Context, conn db.Querier, identifier w3c.DID) ([]domain.IdentityBackupTable, error) {
+	tables := make([]domain.IdentityBackupTable, 0, len(identityBackupTables))
+	for _, t := range identityBackupTables {
+		query := fmt.Sprintf(`SELECT row_to_json(t)::text FROM %s t WHERE %s = $1 ORDER BY %s`,
+			pgx.Identifier{t.name}.Sanitize(), pgx.Identifier{t.identifierColumn}.Sanitize(), t.orderBy)
+		rows, err := r.jsonRows(ctx, conn, query, identifier.String())
+		if err != nil {
+			return nil, fmt.Errorf("dumping table %s: %w", t.name, err)
+		}
+		tables = append(tables, domain.IdentityBackupTable{Name: t.name, Rows: rows})
+	}
+	return tables, nil
+}
+
+// GetMerkleTrees returns the nodes and roots of the identity merkle trees
+func (r *identityBackup) GetMerkleTrees(ctx context.Context, conn db.Querier, identifier w3c.DID) ([]domain.IdentityBackupMerkleTree, error) {
+	rows, err := conn.Query(ctx, `SELECT id, type FROM identity_mts WHERE identifier = $1 ORDER BY type`, identifier.String())
+	if err != nil {
+		return nil, err
+	}
+	type mt struct {
+		id     uint64
+		mtType uint16
+	}
+	var mts []mt
+	for rows.Next() {
+		var m mt
+		if err := rows.Scan(&m.id, &m.mtType); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		mts = append(mts, m)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	trees := make([]domain.IdentityBackupMerkleTree, 0, len(mts))
+	for _, m := range mts {
+		nodes, err := r.jsonRows(ctx, conn, `SELECT row_to_json(n)::text FROM mt_nodes n WHERE mt_id = $1 ORDER BY key`, m.id)
+		if err != nil {
+			return nil, err
+		}
+		roots, err := r.jsonRows(ctx, conn, `SELECT row_to_json(r)::text FROM mt_roots r WHERE mt_id = $1`, m.id)
+		if err != nil {
+			return nil, err
+		}
+		trees = append(trees, domain.IdentityBackupMerkleTree{Type: m.mtType, Nodes: nodes, Roots: roots})
+	}
+	return trees, nil
+}
+
+// RestoreTable inserts the rows of the table. Every row must belong to the identity.
+// Identity columns, like identity_states.state_id, are generated again.
+func (r *identityBackup) RestoreTable(ctx context.Context, conn db.Querier, identifier w3c.DID, table domain.IdentityBackupTable) error {
+	var t *identityBackupTable
+	for i := range identityBackupTables {
+		if identityBackupTables[i].name == table.Name {
+			t = &identityBackupTables[i]
+			break
+		}
+	}
+	if t == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownBackupTable, table.Name)
+	}
+	return r.insertRows(ctx, conn, t.name, table.Rows, "", func(name, columns string) string {
+		return fmt.Sprintf(`INSERT INTO %[1]s (%[2]s) SELECT %[2]s FROM json_populate_recordset(NULL::%[1]s, $1) WHERE %[3]s = $2`,
+			name, columns, pgx.Identifier{t.identifierColumn}.Sanitize())
+	}, identifier.String())
+}
+
+// RestoreMerkleTree inserts the nodes and roots of the tree under the merkle tree id mtID
+func (r *identityBackup) RestoreMerkleTree(ctx context.Context, conn db.Querier, mtID uint64, tree domain.IdentityBackupMerkleTree) error {
+	buildQuery := func(name, columns string) string {
+		return fmt.Sprintf(`INSERT INTO %[1]s (mt_id, %[2]s) SELECT $2, %[2]s FROM json_populate_recordset(NULL::%[1]s, $1)`, name, columns)
+	}
+	if err := r.insertRows(ctx, conn, "mt_nodes", tree.Nodes, "mt_id", buildQuery, mtID); err != nil {
+		return err
+	}
+	return r.insertRows(ctx, conn, "mt_roots", tree.Roots, "mt_id", buildQuery, mtID)
+}
+
+func (r *identityBackup) jsonRows(ctx context.Context, conn db.Querier, query string, args ...any) ([]json.RawMessage, error) {
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]json.RawMessage, 0)
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return nil, err
+		}
+		result = append(result, json.RawMessage(row))
+	}
+	return result, rows.Err()
+}
+
+// insertRows inserts all the rows with a single statement built by buildQuery, taking the columns present in both
+// the table and the rows. The generated columns and the excluded one are left out.
+func (r *identityBackup) insertRows(ctx context.Context, conn db.Querier, table string, rows []json.RawMessage, exclude string, buildQuery func(name, columns string) string, arg any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(rows[0], &fields); err != nil {
+		return err
+	}
+	tableColumns, err := r.insertableColumns(ctx, conn, table)
+	if err != nil {
+		return err
+	}
+	columns := make([]string, 0, len(tableColumns))
+	for _, column := range tableColumns {
+		if _, ok := fields[column]; ok && column != exclude {
+			columns = append(columns, pgx.Identifier{column}.Sanitize())
+		}
+	}
+
+	recordset, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+	res, err := conn.Exec(ctx, buildQuery(pgx.Identifier{table}.Sanitize(), strings.Join(columns, ", ")), string(recordset), arg)
+	if err != nil {
+		return fmt.Errorf("restoring table %s: %w", table, err)
+	}
+	if res.RowsAffected() != int64(len(rows)) {
+		return fmt.Errorf("%w: %s", ErrBackupRowNotRestored, table)
+	}
+	return nil
+}
+
+func (r *identityBackup) insertableColumns(ctx context.Context, conn db.Querier, table string) ([]string, error) {
+	rows, err := conn.Query(ctx, `SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1 AND is_identity = 'NO' AND is_generated = 'NEVER'
+		ORDER BY ordinal_position`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	return columns, rows.Err()
+}
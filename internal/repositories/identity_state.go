@@ -13,6 +13,10 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/db"
 )
 
+// identityStateFields are the columns of identity_states read by scanIdentityState
+const identityStateFields = `state_id, identifier, state, root_of_roots, claims_tree_root, revocation_tree_root, block_timestamp,
+	block_number, tx_id, previous_state, status, modified_at, created_at, replaced_tx_ids, cancel_tx_id, block_hash, failure_reason`
+
 type identityState struct{}
 
 // NewIdentityState returns a new identity state repository
@@ -55,24 +59,11 @@ func (isr *identityState) Save(ctx context.Context, conn db.Querier, state domai
 // Firstly try to return a 'confirmed' and non-genesis state.
 // If 'confirmed' and non-genesis state are not found. Return genesis state.
 func (isr *identityState) GetLatestStateByIdentifier(ctx context.Context, conn db.Querier, identifier *w3c.DID) (*domain.IdentityState, error) {
-	row := conn.QueryRow(ctx, `SELECT state_id, identifier, state, root_of_roots, claims_tree_root, 
-       revocation_tree_root, block_timestamp, block_number, tx_id, previous_state, status, modified_at, created_at 
+	row := conn.QueryRow(ctx, `SELECT `+identityStateFields+`
 FROM identity_states
 WHERE identifier=$1 AND status = 'confirmed' ORDER BY state_id DESC LIMIT 1`, identifier.String())
 	state := domain.IdentityState{}
-	if err := row.Scan(&state.StateID,
-		&state.Identifier,
-		&state.State,
-		&state.RootOfRoots,
-		&state.ClaimsTreeRoot,
-		&state.RevocationTreeRoot,
-		&state.BlockTimestamp,
-		&state.BlockNumber,
-		&state.TxID,
-		&state.PreviousState,
-		&state.Status,
-		&state.ModifiedAt,
-		&state.CreatedAt); err != nil {
+	if err := scanIdentityState(row, &state); err != nil {
 		return nil, fmt.Errorf("error trying to get latest state:%w", err)
 	}
 
@@ -81,8 +72,7 @@ WHERE identifier=$1 AND status = 'confirmed' ORDER BY state_id DESC LIMIT 1`, id
 
 // GetStatesByStatus returns states which are not transacted
 func (isr *identityState) GetStatesByStatus(ctx context.Context, conn db.Querier, status domain.IdentityStatus) ([]domain.IdentityState, error) {
-	rows, err := conn.Query(ctx, `SELECT state_id, identifier, state, root_of_roots, claims_tree_root, revocation_tree_root, block_timestamp, block_number, 
-       tx_id, previous_state, status, modified_at, created_at 
+	rows, err := conn.Query(ctx, `SELECT `+identityStateFields+`
 	FROM identity_states WHERE status = $1 and previous_state IS NOT NULL`, status)
 	if err != nil {
 		return nil, err
@@ -117,8 +107,9 @@ func (isr *identityState) GetStates(ctx context.Context, conn db.Querier, issuer
 
 func (isr *identityState) UpdateState(ctx context.Context, conn db.Querier, state *domain.IdentityState) (int64, error) {
 	tag, err := conn.Exec(ctx, `UPDATE identity_states 
-		SET block_timestamp=$1, block_number=$2, tx_id=$3, status=$4 WHERE state = $5 `,
-		state.BlockTimestamp, state.BlockNumber, state.TxID, state.Status, state.State)
+		SET block_timestamp=$1, block_number=$2, tx_id=$3, status=$4, replaced_tx_ids=COALESCE($5::text[], '{}'), cancel_tx_id=$6, block_hash=$7, failure_reason=$8
+		WHERE state = $9 `,
+		state.BlockTimestamp, state.BlockNumber, state.TxID, state.Status, state.ReplacedTxIDs, state.CancelTxID, state.BlockHash, state.FailureReason, state.State)
 	if err != nil {
 		return 0, err
 	}
@@ -128,8 +119,7 @@ func (isr *identityState) UpdateState(ctx context.Context, conn db.Querier, stat
 
 // GetStatesByStatusAndIssuerID returns states which are not transacted
 func (isr *identityState) GetStatesByStatusAndIssuerID(ctx context.Context, conn db.Querier, status domain.IdentityStatus, issuerID w3c.DID) ([]domain.IdentityState, error) {
-	rows, err := conn.Query(ctx, `SELECT state_id, identifier, state, root_of_roots, claims_tree_root, revocation_tree_root, block_timestamp, block_number, 
-       tx_id, previous_state, status, modified_at, created_at 
+	rows, err := conn.Query(ctx, `SELECT `+identityStateFields+`
 	FROM identity_states WHERE identifier = $1 and status = $2 and previous_state IS NOT NULL
 	ORDER BY created_at DESC
 	`, issuerID.String(), status)
@@ -141,19 +131,7 @@ func (isr *identityState) GetStatesByStatusAndIssuerID(ctx context.Context, conn
 	states := []domain.IdentityState{}
 	for rows.Next() {
 		var state domain.IdentityState
-		if err := rows.Scan(&state.StateID,
-			&state.Identifier,
-			&state.State,
-			&state.RootOfRoots,
-			&state.ClaimsTreeRoot,
-			&state.RevocationTreeRoot,
-			&state.BlockTimestamp,
-			&state.BlockNumber,
-			&state.TxID,
-			&state.PreviousState,
-			&state.Status,
-			&state.ModifiedAt,
-			&state.CreatedAt); err != nil {
+		if err := scanIdentityState(rows, &state); err != nil {
 			return nil, err
 		}
 		states = append(states, state)
@@ -168,20 +146,8 @@ func (isr *identityState) GetStatesByStatusAndIssuerID(ctx context.Context, conn
 
 func (isr *identityState) GetGenesisState(ctx context.Context, conn db.Querier, identifier string) (*domain.IdentityState, error) {
 	state := domain.IdentityState{}
-	row := conn.QueryRow(ctx, "SELECT * FROM identity_states WHERE identifier=$1 AND previous_state IS NULL ", identifier)
-	if err := row.Scan(&state.StateID,
-		&state.Identifier,
-		&state.State,
-		&state.RootOfRoots,
-		&state.RevocationTreeRoot,
-		&state.ClaimsTreeRoot,
-		&state.BlockTimestamp,
-		&state.BlockNumber,
-		&state.TxID,
-		&state.PreviousState,
-		&state.Status,
-		&state.ModifiedAt,
-		&state.CreatedAt); err != nil {
+	row := conn.QueryRow(ctx, "SELECT "+identityStateFields+" FROM identity_states WHERE identifier=$1 AND previous_state IS NULL ", identifier)
+	if err := scanIdentityState(row, &state); err != nil {
 		return nil, err
 	}
 
@@ -203,6 +169,10 @@ func buildGetStatesQuery(filter *ports.GetStateTransactionsRequest) (string, str
 		"status",
 		"modified_at",
 		"created_at",
+		"replaced_tx_ids",
+		"cancel_tx_id",
+		"block_hash",
+		"failure_reason",
 	}
 
 	q := `
@@ -225,19 +195,7 @@ func toIdentityStatesDomain(rows pgx.Rows) ([]domain.IdentityState, error) {
 	var states []domain.IdentityState
 	for rows.Next() {
 		var state domain.IdentityState
-		if err := rows.Scan(&state.StateID,
-			&state.Identifier,
-			&state.State,
-			&state.RootOfRoots,
-			&state.ClaimsTreeRoot,
-			&state.RevocationTreeRoot,
-			&state.BlockTimestamp,
-			&state.BlockNumber,
-			&state.TxID,
-			&state.PreviousState,
-			&state.Status,
-			&state.ModifiedAt,
-			&state.CreatedAt); err != nil {
+		if err := scanIdentityState(rows, &state); err != nil {
 			return nil, err
 		}
 		states = append(states, state)
@@ -249,3 +207,23 @@ func toIdentityStatesDomain(rows pgx.Rows) ([]domain.IdentityState, error) {
 
 	return states, nil
 }
+
+func scanIdentityState(row pgx.Row, state *domain.IdentityState) error {
+	return row.Scan(&state.StateID,
+		&state.Identifier,
+		&state.State,
+		&state.RootOfRoots,
+		&state.ClaimsTreeRoot,
+		&state.RevocationTreeRoot,
+		&state.BlockTimestamp,
+		&state.BlockNumber,
+		&state.TxID,
+		&state.PreviousState,
+		&state.Status,
+		&state.ModifiedAt,
+		&state.CreatedAt,
+		&state.ReplacedTxIDs,
+		&state.CancelTxID,
+		&state.BlockHash,
+		&state.FailureReason)
+}
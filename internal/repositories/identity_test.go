@@ -3,10 +3,17 @@ package repositories
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/iden3/go-iden3-core/v2/w3c"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/polygonid/sh-id-platform/internal/common"
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/pagination"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/sqltools"
 )
 
 func TestGetIdentities(t *testing.T) {
@@ -26,8 +33,107 @@ func TestGetIdentities(t *testing.T) {
 
 	identityRepo := NewIdentity()
 	t.Run("should get identities", func(t *testing.T) {
-		identities, err := identityRepo.Get(context.Background(), storage.Pgx)
+		identities, total, err := identityRepo.Get(context.Background(), storage.Pgx, &ports.GetIdentitiesRequest{})
 		assert.NoError(t, err)
 		assert.True(t, len(identities) >= 2)
+		assert.Equal(t, uint(len(identities)), total)
 	})
+
+	t.Run("should hide archived identities", func(t *testing.T) {
+		now := time.Now()
+		identity2.DeactivatedAt = &now
+		identity2.ArchivedAt = &now
+		assert.NoError(t, identityRepo.UpdateStatus(context.Background(), storage.Pgx, identity2))
+
+		did1, err := w3c.ParseDID(idStr1)
+		require.NoError(t, err)
+		did2, err := w3c.ParseDID(idStr2)
+		require.NoError(t, err)
+		active, err := identityRepo.IsActive(context.Background(), storage.Pgx, *did2)
+		require.NoError(t, err)
+		assert.False(t, active)
+		active, err = identityRepo.IsActive(context.Background(), storage.Pgx, *did1)
+		require.NoError(t, err)
+		assert.True(t, active)
+
+		identities, _, err := identityRepo.Get(context.Background(), storage.Pgx, &ports.GetIdentitiesRequest{})
+		assert.NoError(t, err)
+		for _, identity := range identities {
+			assert.NotEqual(t, idStr2, identity.Identifier)
+		}
+
+		identities, _, err = identityRepo.Get(context.Background(), storage.Pgx, &ports.GetIdentitiesRequest{IncludeArchived: true})
+		assert.NoError(t, err)
+		found := false
+		for _, identity := range identities {
+			if identity.Identifier == idStr2 {
+				found = true
+				assert.Equal(t, domain.IdentityArchived, identity.Status)
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("should search, filter and paginate identities", func(t *testing.T) {
+		identities, _, err := identityRepo.Get(context.Background(), storage.Pgx, &ports.GetIdentitiesRequest{
+			IncludeArchived: true,
+			Query:           "2qgqlpdt2vyqfq1",
+			Blockchain:      "polygon",
+			Network:         "mumbai",
+		})
+		require.NoError(t, err)
+		require.Len(t, identities, 1)
+		assert.Equal(t, idStr1, identities[0].Identifier)
+		assert.Zero(t, identities[0].CredentialsIssued)
+		assert.Zero(t, identities[0].PendingStateChanges)
+
+		// the wildcards are matched literally
+		for _, query := range []string{"%", "2qgq_pdt"} {
+			identities, _, err = identityRepo.Get(context.Background(), storage.Pgx, &ports.GetIdentitiesRequest{IncludeArchived: true, Query: query})
+			require.NoError(t, err)
+			assert.Empty(t, identities)
+		}
+
+		identities, _, err = identityRepo.Get(context.Background(), storage.Pgx, &ports.GetIdentitiesRequest{Network: "unknown"})
+		require.NoError(t, err)
+		assert.Empty(t, identities)
+
+		orderBy := sqltools.OrderByFilters{}
+		require.NoError(t, orderBy.Add(ports.IdentitiesCreatedAt, true))
+		identities, total, err := identityRepo.Get(context.Background(), storage.Pgx, &ports.GetIdentitiesRequest{
+			IncludeArchived: true,
+			Pagination:      pagination.Filter{MaxResults: 1, Page: common.ToPointer(uint(1))},
+			OrderBy:         orderBy,
+		})
+		require.NoError(t, err)
+		assert.Len(t, identities, 1)
+		assert.True(t, total >= 2)
+	})
+}
+
+func TestGetPendingStateChanges(t *testing.T) {
+	ctx := context.Background()
+	fixture := NewFixture(storage)
+	idStr := "did:polygonid:polygon:amoy:2qV9QXdhXXmN5sKjN1YueMjxgRbnJcEGK2kGpvk3cq"
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+
+	claim := fixture.NewClaim(t, idStr)
+	claim.MtProof = true
+	fixture.CreateClaim(t, claim)
+	signatureClaim := fixture.NewClaim(t, idStr)
+	fixture.CreateClaim(t, signatureClaim)
+
+	did, err := w3c.ParseDID(idStr)
+	require.NoError(t, err)
+	identityRepo := NewIdentity()
+	pending, err := identityRepo.GetPendingStateChanges(ctx, storage.Pgx, *did)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), pending.Credentials)
+	assert.Zero(t, pending.Revocations)
+	assert.False(t, pending.LastPublishedAt.IsZero())
+
+	unknown, err := w3c.ParseDID("did:polygonid:polygon:amoy:2qQ8S2VKdQv7xYgzCn7KW2xgzUWrTRQjoZDYavJHBq")
+	require.NoError(t, err)
+	_, err = identityRepo.GetPendingStateChanges(ctx, storage.Pgx, *unknown)
+	assert.ErrorIs(t, err, ErrIdentityNotFound)
 }
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+type publishingNonce struct{}
+
+// NewPublishingNonce returns a new publishing nonces repository
+func NewPublishingNonce() ports.PublishingNonceRepository {
+	return &publishingNonce{}
+}
+
+// Lock takes a lock on the nonces of the address until the end of the transaction of conn, so the nonce
+// allocations of the address are serialized between all the processes that share the database.
+func (r *publishingNonce) Lock(ctx context.Context, conn db.Querier, chainID uint64, address string) error {
+	const lock = `SELECT pg_advisory_xact_lock(hashtext($1))`
+	_, err := conn.Exec(ctx, lock, fmt.Sprintf("publishing_nonces:%d:%s", chainID, address))
+	return err
+}
+
+// GetFrom returns the nonces of the address in use that are greater or equal than from, in ascending order
+func (r *publishingNonce) GetFrom(ctx context.Context, conn db.Querier, chainID uint64, address string, from uint64) ([]uint64, error) {
+	const byAddress = `SELECT nonce FROM publishing_nonces WHERE chain_id = $1 AND address = $2 AND nonce >= $3 ORDER BY nonce`
+	rows, err := conn.Query(ctx, byAddress, chainID, address, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	nonces := make([]uint64, 0)
+	for rows.Next() {
+		var nonce uint64
+		if err := rows.Scan(&nonce); err != nil {
+			return nil, err
+		}
+		nonces = append(nonces, nonce)
+	}
+	return nonces, rows.Err()
+}
+
+// GetSentFrom returns the nonces of the address greater or equal than from whose transaction was sent,
+// in ascending order
+func (r *publishingNonce) GetSentFrom(ctx context.Context, conn db.Querier, chainID uint64, address string, from uint64) ([]*domain.PublishingNonce, error) {
+	const sent = `SELECT chain_id, address, nonce, status, tx_id, raw_tx, modified_at
+		FROM publishing_nonces
+		WHERE chain_id = $1 AND address = $2 AND nonce >= $3 AND status = $4
+		ORDER BY nonce`
+	rows, err := conn.Query(ctx, sent, chainID, address, from, domain.PublishingNonceSent)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	nonces := make([]*domain.PublishingNonce, 0)
+	for rows.Next() {
+		var nonce domain.PublishingNonce
+		if err := rows.Scan(&nonce.ChainID, &nonce.Address, &nonce.Nonce, &nonce.Status, &nonce.TxID, &nonce.RawTx, &nonce.ModifiedAt); err != nil {
+			return nil, err
+		}
+		nonces = append(nonces, &nonce)
+	}
+	return nonces, rows.Err()
+}
+
+// GetByTxID returns the nonce whose last transaction sent is txID, with the signed transaction. It returns
+// ports.ErrPublishingNonceNotFound if the nonce is not in use anymore.
+func (r *publishingNonce) GetByTxID(ctx context.Context, conn db.Querier, chainID uint64, txID string) (*domain.PublishingNonce, error) {
+	const byTxID = `SELECT chain_id, address, nonce, status, tx_id, raw_tx, modified_at
+		FROM publishing_nonces
+		WHERE chain_id = $1 AND tx_id = $2
+		ORDER BY modified_at DESC
+		LIMIT 1`
+	var nonce domain.PublishingNonce
+	err := conn.QueryRow(ctx, byTxID, chainID, txID).Scan(&nonce.ChainID, &nonce.Address, &nonce.Nonce, &nonce.Status, &nonce.TxID, &nonce.RawTx, &nonce.ModifiedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ports.ErrPublishingNonceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &nonce, nil
+}
+
+// Save stores the nonce, or updates its status and transaction if it is already stored
+func (r *publishingNonce) Save(ctx context.Context, conn db.Querier, nonce *domain.PublishingNonce) error {
+	const save = `INSERT INTO publishing_nonces (chain_id, address, nonce, status, tx_id, raw_tx, modified_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (chain_id, address, nonce) DO UPDATE
+			SET status = EXCLUDED.status, tx_id = EXCLUDED.tx_id, raw_tx = EXCLUDED.raw_tx, modified_at = EXCLUDED.modified_at`
+	_, err := conn.Exec(ctx, save, nonce.ChainID, nonce.Address, nonce.Nonce, nonce.Status, nonce.TxID, nonce.RawTx, nonce.ModifiedAt)
+	return err
+}
+
+// Delete releases a nonce, so it can be allocated again
+func (r *publishingNonce) Delete(ctx context.Context, conn db.Querier, chainID uint64, address string, nonce uint64) error {
+	const del = `DELETE FROM publishing_nonces WHERE chain_id = $1 AND address = $2 AND nonce = $3`
+	_, err := conn.Exec(ctx, del, chainID, address, nonce)
+	return err
+}
+
+// DeleteExpired removes the nonces of the address that are below minedBelow, whose transactions are already mined,
+// and the reservations older than reservedBefore, left by a process that stopped before sending its transaction.
+// It returns how many were removed.
+func (r *publishingNonce) DeleteExpired(ctx context.Context, conn db.Querier, chainID uint64, address string, minedBelow uint64, reservedBefore time.Time) (int64, error) {
+	const del = `DELETE FROM publishing_nonces
+		WHERE chain_id = $1 AND address = $2 AND (nonce < $3 OR (status = $4 AND modified_at < $5))`
+	res, err := conn.Exec(ctx, del, chainID, address, minedBelow, domain.PublishingNonceReserved, reservedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
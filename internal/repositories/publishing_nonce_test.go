@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+func TestPublishingNonce(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPublishingNonce()
+	const chainID = 80002
+	const address = "0x85f0Bf2D4E0A3a4B4A7A69E3d9F1A8Bfb4e9bD31"
+	now := time.Now()
+	txID := "0x4f6e2e6f2c1a7e7d1c9b7a1f5d2c3b4a5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b"
+
+	for _, n := range []uint64{10, 11, 13} {
+		require.NoError(t, repo.Save(ctx, storage.Pgx, &domain.PublishingNonce{ChainID: chainID, Address: address, Nonce: n, Status: domain.PublishingNonceSent, TxID: &txID, ModifiedAt: now}))
+	}
+	require.NoError(t, repo.Save(ctx, storage.Pgx, &domain.PublishingNonce{ChainID: chainID, Address: address, Nonce: 14, Status: domain.PublishingNonceReserved, ModifiedAt: now.Add(-time.Hour)}))
+
+	nonces, err := repo.GetFrom(ctx, storage.Pgx, chainID, address, 11)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{11, 13, 14}, nonces)
+
+	rawTx := []byte{0x02, 0xf8}
+	replacementTxID := "0x9a0f8e7d6c5b4a3f2e1d0c9b8a7f6e5d4c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9f"
+	require.NoError(t, repo.Save(ctx, storage.Pgx, &domain.PublishingNonce{ChainID: chainID, Address: address, Nonce: 13, Status: domain.PublishingNonceSent, TxID: &replacementTxID, RawTx: rawTx, ModifiedAt: now}))
+	sent, err := repo.GetSentFrom(ctx, storage.Pgx, chainID, address, 11)
+	require.NoError(t, err)
+	require.Len(t, sent, 2)
+	assert.Equal(t, uint64(11), sent[0].Nonce)
+	assert.Nil(t, sent[0].RawTx)
+	assert.Equal(t, uint64(13), sent[1].Nonce)
+	assert.Equal(t, rawTx, sent[1].RawTx)
+	assert.Equal(t, replacementTxID, *sent[1].TxID)
+
+	byTxID, err := repo.GetByTxID(ctx, storage.Pgx, chainID, replacementTxID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(13), byTxID.Nonce)
+	assert.Equal(t, address, byTxID.Address)
+	assert.Equal(t, rawTx, byTxID.RawTx)
+	_, err = repo.GetByTxID(ctx, storage.Pgx, chainID, "0x01")
+	assert.ErrorIs(t, err, ports.ErrPublishingNonceNotFound)
+
+	// nonce 10 is mined and nonce 14 is an old reservation
+	deleted, err := repo.DeleteExpired(ctx, storage.Pgx, chainID, address, 11, now.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	require.NoError(t, repo.Delete(ctx, storage.Pgx, chainID, address, 13))
+	nonces, err = repo.GetFrom(ctx, storage.Pgx, chainID, address, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{11}, nonces)
+}
@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+const bitsPerByte = 8
+
+// ErrStatusListDoesNotExist status list does not exist
+var ErrStatusListDoesNotExist = errors.New("status list does not exist")
+
+type statusList struct{}
+
+// NewStatusList returns a new status list repository
+func NewStatusList() ports.StatusListRepository {
+	return &statusList{}
+}
+
+// Allocate reserves the next free entry of a status list of the issuer. A new list is created when all of them are full.
+// It returns the list and the reserved index. The allocations of the issuer for the purpose are locked until conn is
+// committed, so two of them never create a list at the same time. conn should be the transaction that stores the
+// credential, otherwise the index is lost if the credential is not stored.
+func (r *statusList) Allocate(ctx context.Context, conn db.Querier, issuerDID w3c.DID, purpose domain.StatusPurpose) (*domain.StatusList, int, error) {
+	const (
+		lock      = `SELECT pg_advisory_xact_lock(hashtext($1))`
+		available = `SELECT id, issuer_id, purpose, size, allocated, created_at, modified_at
+			FROM status_lists
+			WHERE issuer_id = $1 AND purpose = $2 AND allocated < size
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE`
+		insert = `INSERT INTO status_lists (id, issuer_id, purpose, size, allocated, bits, created_at, modified_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		allocate = `UPDATE status_lists SET allocated = allocated + 1, modified_at = $3 WHERE issuer_id = $1 AND id = $2`
+	)
+
+	if _, err := conn.Exec(ctx, lock, fmt.Sprintf("status_lists:%s:%s", issuerDID.String(), purpose)); err != nil {
+		return nil, 0, err
+	}
+	list, err := scanStatusList(conn.QueryRow(ctx, available, issuerDID.String(), purpose))
+	if errors.Is(err, ErrStatusListDoesNotExist) {
+		list = domain.NewStatusList(issuerDID, purpose, domain.StatusListSize)
+		_, err = conn.Exec(ctx, insert, list.ID, issuerDID.String(), list.Purpose, list.Size, list.Allocated, list.Bits, list.CreatedAt, list.ModifiedAt)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	index := list.Allocated
+	list.Allocated++
+	list.ModifiedAt = time.Now().UTC()
+	if _, err = conn.Exec(ctx, allocate, issuerDID.String(), list.ID, list.ModifiedAt); err != nil {
+		return nil, 0, err
+	}
+	return list, index, nil
+}
+
+// Next returns the status list and the index that the next call to Allocate would reserve, without reserving it.
+// If all the lists are full, the returned list is a new one that is not stored.
+func (r *statusList) Next(ctx context.Context, conn db.Querier, issuerDID w3c.DID, purpose domain.StatusPurpose) (*domain.StatusList, int, error) {
+	const available = `SELECT id, issuer_id, purpose, size, allocated, created_at, modified_at
+		FROM status_lists
+		WHERE issuer_id = $1 AND purpose = $2 AND allocated < size
+		ORDER BY created_at
+		LIMIT 1`
+
+	list, err := scanStatusList(conn.QueryRow(ctx, available, issuerDID.String(), purpose))
+	if errors.Is(err, ErrStatusListDoesNotExist) {
+		list, err = domain.NewStatusList(issuerDID, purpose, domain.StatusListSize), nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, list.Allocated, nil
+}
+
+// GetByID returns the status list of the issuer with the given id, including its bits and its signed credentials
+func (r *statusList) GetByID(ctx context.Context, conn db.Querier, issuerDID w3c.DID, id uuid.UUID) (*domain.StatusList, error) {
+	const byID = `SELECT id, issuer_id, purpose, size, allocated, created_at, modified_at, bits, credential, credential_jwt
+		FROM status_lists
+		WHERE issuer_id = $1 AND id = $2`
+
+	var list domain.StatusList
+	var issuer string
+	var credential pgtype.JSONB
+	err := conn.QueryRow(ctx, byID, issuerDID.String(), id).Scan(
+		&list.ID,
+		&issuer,
+		&list.Purpose,
+		&list.Size,
+		&list.Allocated,
+		&list.CreatedAt,
+		&list.ModifiedAt,
+		&list.Bits,
+		&credential,
+		&list.CredentialJWT)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStatusListDoesNotExist
+		}
+		return nil, err
+	}
+	if credential.Status == pgtype.Present {
+		if err := credential.AssignTo(&list.Credential); err != nil {
+			return nil, err
+		}
+	}
+	list.IssuerDID = issuerDID
+	return &list, nil
+}
+
+// Set sets to 1 the entry at the given index of a status list and clears its signed credentials. The index counts
+// from the left-most bit of the list, while postgres set_bit counts from the least significant bit of each byte.
+func (r *statusList) Set(ctx context.Context, conn db.Querier, issuerDID w3c.DID, id uuid.UUID, index int) error {
+	const set = `UPDATE status_lists
+		SET bits = set_bit(bits, $3, 1), credential = NULL, credential_jwt = NULL, modified_at = $4
+		WHERE issuer_id = $1 AND id = $2 AND $3 < size`
+
+	bit := index - index%bitsPerByte + (bitsPerByte - 1 - index%bitsPerByte)
+	tag, err := conn.Exec(ctx, set, issuerDID.String(), id, bit, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStatusListDoesNotExist
+	}
+	return nil
+}
+
+// SaveCredentials stores the signed credentials of the list. They are only stored if the bits of the list did not
+// change since they were signed, so a credential with an outdated list is never served.
+func (r *statusList) SaveCredentials(ctx context.Context, conn db.Querier, list *domain.StatusList) error {
+	const save = `UPDATE status_lists SET credential = $3, credential_jwt = $4 WHERE issuer_id = $1 AND id = $2 AND bits = $5`
+
+	credential := pgtype.JSONB{Status: pgtype.Null}
+	if list.Credential != nil {
+		if err := credential.Set(list.Credential); err != nil {
+			return err
+		}
+	}
+	_, err := conn.Exec(ctx, save, list.IssuerDID.String(), list.ID, credential, list.CredentialJWT, list.Bits)
+	return err
+}
+
+func scanStatusList(row pgx.Row) (*domain.StatusList, error) {
+	var list domain.StatusList
+	var issuer string
+	err := row.Scan(&list.ID, &issuer, &list.Purpose, &list.Size, &list.Allocated, &list.CreatedAt, &list.ModifiedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStatusListDoesNotExist
+		}
+		return nil, err
+	}
+	did, err := w3c.ParseDID(issuer)
+	if err != nil {
+		return nil, err
+	}
+	list.IssuerDID = *did
+	return &list, nil
+}
@@ -0,0 +1,37 @@
+package revocationstatus
+
+import (
+	"context"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/network"
+)
+
+type bitstringStatusListResolver struct {
+	statusLists ports.StatusListRepository
+}
+
+// resolve allocates an entry of a status list of the issuer with conn, that should be the transaction that stores
+// the credential
+func (r *bitstringStatusListResolver) resolve(ctx context.Context, conn db.Querier, credentialStatusSettings network.RhsSettings, issuerDID w3c.DID, nonce uint64, _ string) (any, error) {
+	list, index, err := r.statusLists.Allocate(ctx, conn, issuerDID, domain.StatusPurposeRevocation)
+	if err != nil {
+		return nil, err
+	}
+	statusListCredential := buildStatusListCredentialURL(credentialStatusSettings.Iden3CommAgentStatus, issuerDID, list.ID.String())
+	return domain.NewBitstringStatusListEntryStatus(statusListCredential, list.Purpose, index, nonce), nil
+}
+
+// preview returns the entry that resolve would return without allocating it
+func (r *bitstringStatusListResolver) preview(ctx context.Context, conn db.Querier, credentialStatusSettings network.RhsSettings, issuerDID w3c.DID, nonce uint64, _ string) (any, error) {
+	list, index, err := r.statusLists.Next(ctx, conn, issuerDID, domain.StatusPurposeRevocation)
+	if err != nil {
+		return nil, err
+	}
+	statusListCredential := buildStatusListCredentialURL(credentialStatusSettings.Iden3CommAgentStatus, issuerDID, list.ID.String())
+	return domain.NewBitstringStatusListEntryStatus(statusListCredential, list.Purpose, index, nonce), nil
+}
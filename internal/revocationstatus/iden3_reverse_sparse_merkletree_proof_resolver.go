@@ -1,17 +1,19 @@
 package revocationstatus
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/iden3/go-iden3-core/v2/w3c"
 	"github.com/iden3/go-schema-processor/v2/verifiable"
 
+	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/network"
 )
 
 type iden3ReverseSparseMerkleTreeProofResolver struct{}
 
-func (r *iden3ReverseSparseMerkleTreeProofResolver) resolve(credentialStatusSettings network.RhsSettings, _ w3c.DID, nonce uint64, issuerState string) *verifiable.CredentialStatus {
+func (r *iden3ReverseSparseMerkleTreeProofResolver) resolve(_ context.Context, _ db.Querier, credentialStatusSettings network.RhsSettings, _ w3c.DID, nonce uint64, issuerState string) (any, error) {
 	return &verifiable.CredentialStatus{
 		ID:              buildRHSRevocationURL(*credentialStatusSettings.RhsUrl, issuerState),
 		Type:            verifiable.Iden3ReverseSparseMerkleTreeProof,
@@ -21,5 +23,5 @@ func (r *iden3ReverseSparseMerkleTreeProofResolver) resolve(credentialStatusSett
 			Type:            verifiable.Iden3commRevocationStatusV1,
 			RevocationNonce: nonce,
 		},
-	}
+	}, nil
 }
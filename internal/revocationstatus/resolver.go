@@ -2,43 +2,84 @@ package revocationstatus
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/iden3/go-iden3-core/v2/w3c"
 	"github.com/iden3/go-schema-processor/v2/verifiable"
+	"github.com/jackc/pgtype"
 
 	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/network"
 )
 
-const resolversLength = 3
+const resolversLength = 4
+
+// ErrWebDIDCredentialStatus means that the credential status type needs an iden3 identity
+var ErrWebDIDCredentialStatus = errors.New("did:web identities only support the BitstringStatusListEntry credential status")
 
 type revocationCredentialStatusResolver interface {
-	resolve(credentialStatusSettings network.RhsSettings, issuerDID w3c.DID, nonce uint64, issuerState string) *verifiable.CredentialStatus
+	resolve(ctx context.Context, conn db.Querier, credentialStatusSettings network.RhsSettings, issuerDID w3c.DID, nonce uint64, issuerState string) (any, error)
+}
+
+// revocationCredentialStatusPreviewer is implemented by the resolvers that store something when they resolve a status.
+// preview returns the same status without storing anything.
+type revocationCredentialStatusPreviewer interface {
+	preview(ctx context.Context, conn db.Querier, credentialStatusSettings network.RhsSettings, issuerDID w3c.DID, nonce uint64, issuerState string) (any, error)
 }
 
 // Resolver resolves credential status.
 type Resolver struct {
 	networkResolver network.Resolver
 	resolvers       map[verifiable.CredentialStatusType]revocationCredentialStatusResolver
+	statusLists     ports.StatusListRepository
 }
 
 // NewRevocationStatusResolver - constructor
-func NewRevocationStatusResolver(networkResolver network.Resolver) *Resolver {
+// statusLists is optional. If nil, the BitstringStatusListEntry credential status type is not supported.
+func NewRevocationStatusResolver(networkResolver network.Resolver, statusLists ports.StatusListRepository) *Resolver {
 	resolvers := make(map[verifiable.CredentialStatusType]revocationCredentialStatusResolver, resolversLength)
 	resolvers[verifiable.Iden3ReverseSparseMerkleTreeProof] = &iden3ReverseSparseMerkleTreeProofResolver{}
 	resolvers[verifiable.Iden3commRevocationStatusV1] = &iden3CommRevocationStatusV1Resolver{}
 	resolvers[verifiable.Iden3OnchainSparseMerkleTreeProof2023] = &iden3OnChainSparseMerkleTreeProof2023Resolver{}
+	if statusLists != nil {
+		resolvers[domain.BitstringStatusListEntry] = &bitstringStatusListResolver{statusLists: statusLists}
+	}
 	return &Resolver{
 		networkResolver: networkResolver,
 		resolvers:       resolvers,
+		statusLists:     statusLists,
 	}
 }
 
 // GetCredentialRevocationStatus - return a way to check credential revocation status.
 // If status is not supported, an error is returned.
 // If status is supported, a way to check revocation status is returned.
-func (rsr *Resolver) GetCredentialRevocationStatus(ctx context.Context, issuerDID w3c.DID, nonce uint64, issuerState string, credentialStatusType verifiable.CredentialStatusType) (*verifiable.CredentialStatus, error) {
+// Anything reserved for the credential, e.g. an entry in a status list, is stored with conn, so it should be the
+// transaction that stores the credential.
+func (rsr *Resolver) GetCredentialRevocationStatus(ctx context.Context, conn db.Querier, issuerDID w3c.DID, nonce uint64, issuerState string, credentialStatusType verifiable.CredentialStatusType) (any, error) {
+	return rsr.credentialRevocationStatus(ctx, conn, issuerDID, nonce, issuerState, credentialStatusType, false)
+}
+
+// PreviewCredentialRevocationStatus - return the same status as GetCredentialRevocationStatus, but nothing is reserved
+// for the credential, e.g. an entry in a status list. The status must not be used in an issued credential.
+func (rsr *Resolver) PreviewCredentialRevocationStatus(ctx context.Context, conn db.Querier, issuerDID w3c.DID, nonce uint64, issuerState string, credentialStatusType verifiable.CredentialStatusType) (any, error) {
+	return rsr.credentialRevocationStatus(ctx, conn, issuerDID, nonce, issuerState, credentialStatusType, true)
+}
+
+func (rsr *Resolver) credentialRevocationStatus(ctx context.Context, conn db.Querier, issuerDID w3c.DID, nonce uint64, issuerState string, credentialStatusType verifiable.CredentialStatusType, preview bool) (any, error) {
+	if domain.IsWebDID(issuerDID) {
+		if credentialStatusType == "" {
+			credentialStatusType = domain.BitstringStatusListEntry
+		}
+		if credentialStatusType != domain.BitstringStatusListEntry {
+			return nil, ErrWebDIDCredentialStatus
+		}
+	}
 	if credentialStatusType == "" {
 		credentialStatusType = verifiable.Iden3commRevocationStatusV1
 	}
@@ -47,15 +88,67 @@ func (rsr *Resolver) GetCredentialRevocationStatus(ctx context.Context, issuerDI
 		return nil, errors.New("unsupported credential credentialStatusType type")
 	}
 
-	resolverPrefix, err := common.ResolverPrefix(&issuerDID)
+	settings, err := rsr.settings(ctx, issuerDID)
 	if err != nil {
 		return nil, err
 	}
 
-	settings, err := rsr.networkResolver.GetRhsSettings(ctx, resolverPrefix)
+	if previewer, ok := resolver.(revocationCredentialStatusPreviewer); ok && preview {
+		return previewer.preview(ctx, conn, *settings, issuerDID, nonce, issuerState)
+	}
+	return resolver.resolve(ctx, conn, *settings, issuerDID, nonce, issuerState)
+}
+
+// StatusListCredentialURL returns the public url of the BitstringStatusListCredential of a status list
+func (rsr *Resolver) StatusListCredentialURL(ctx context.Context, issuerDID w3c.DID, id uuid.UUID) (string, error) {
+	settings, err := rsr.settings(ctx, issuerDID)
+	if err != nil {
+		return "", err
+	}
+	return buildStatusListCredentialURL(settings.Iden3CommAgentStatus, issuerDID, id.String()), nil
+}
+
+// settings returns the credential status settings of the issuer network. did:web issuers have no network, their
+// status lists are served by the node that hosts them.
+func (rsr *Resolver) settings(ctx context.Context, issuerDID w3c.DID) (*network.RhsSettings, error) {
+	if domain.IsWebDID(issuerDID) {
+		hostURL, err := domain.WebDIDHostURL(issuerDID)
+		if err != nil {
+			return nil, err
+		}
+		return &network.RhsSettings{Iden3CommAgentStatus: hostURL}, nil
+	}
+	resolverPrefix, err := common.ResolverPrefix(&issuerDID)
 	if err != nil {
 		return nil, err
 	}
+	return rsr.networkResolver.GetRhsSettings(ctx, resolverPrefix)
+}
+
+// Revoke updates the status lists that keep the status of the given credential, if any.
+// Credentials with iden3 credential status types are revoked in the revocation tree, so nothing is done for them.
+func (rsr *Resolver) Revoke(ctx context.Context, conn db.Querier, claim *domain.Claim) error {
+	if claim.CredentialStatus.Status != pgtype.Present {
+		return nil
+	}
+	var entry domain.BitstringStatusListEntryStatus
+	if err := json.Unmarshal(claim.CredentialStatus.Bytes, &entry); err != nil {
+		return err
+	}
+	if entry.Type != domain.BitstringStatusListEntry {
+		return nil
+	}
+	if rsr.statusLists == nil {
+		return errors.New("bitstring status lists are not supported")
+	}
 
-	return resolver.resolve(*settings, issuerDID, nonce, issuerState), nil
+	issuerDID, err := w3c.ParseDID(claim.Issuer)
+	if err != nil {
+		return err
+	}
+	id, index, err := entry.StatusList()
+	if err != nil {
+		return err
+	}
+	return rsr.statusLists.Set(ctx, conn, *issuerDID, id, index)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/iden3/go-iden3-core/v2/w3c"
 	"github.com/iden3/go-schema-processor/v2/verifiable"
 	"github.com/stretchr/testify/require"
@@ -135,10 +136,38 @@ func TestRevocationStatusResolver_GetCredentialRevocationStatus(t *testing.T) {
 			}
 			networkResolver, err := network.NewResolver(context.Background(), *cfg, nil, common.CreateFile(t))
 			require.NoError(t, err)
-			rsr := NewRevocationStatusResolver(*networkResolver)
-			credentialStatus, err := rsr.GetCredentialRevocationStatus(context.Background(), *didW3c, tc.nonce, tc.issuerState, tc.credentialStatusType)
+			rsr := NewRevocationStatusResolver(*networkResolver, nil)
+			credentialStatus, err := rsr.GetCredentialRevocationStatus(context.Background(), nil, *didW3c, tc.nonce, tc.issuerState, tc.credentialStatusType)
 			require.Equal(t, tc.expected.CredentialStatus, credentialStatus)
 			require.NoError(t, err)
 		})
 	}
 }
+
+func TestRevocationStatusResolver_WebDID(t *testing.T) {
+	const did = "did:web:issuer-node.privado.id:identities:0b0f7b6e-1a2b-4c3d-9e8f-001122334455"
+	didW3c, err := w3c.ParseDID(did)
+	require.NoError(t, err)
+
+	cfg := &config.Configuration{
+		ServerUrl:           "https://issuer-node.privado.id",
+		NetworkResolverPath: "",
+	}
+	networkResolver, err := network.NewResolver(context.Background(), *cfg, nil, common.CreateFile(t))
+	require.NoError(t, err)
+	rsr := NewRevocationStatusResolver(*networkResolver, nil)
+
+	t.Run("should reject iden3 credential statuses", func(t *testing.T) {
+		for _, statusType := range []verifiable.CredentialStatusType{verifiable.Iden3commRevocationStatusV1, verifiable.Iden3ReverseSparseMerkleTreeProof, verifiable.Iden3OnchainSparseMerkleTreeProof2023} {
+			_, err := rsr.GetCredentialRevocationStatus(context.Background(), nil, *didW3c, 1, "", statusType)
+			require.ErrorIs(t, err, ErrWebDIDCredentialStatus)
+		}
+	})
+
+	t.Run("should serve the status lists from the did host", func(t *testing.T) {
+		id := uuid.MustParse("6f2c2d2e-3b0a-4f55-9a55-6e3bbf1c1a10")
+		url, err := rsr.StatusListCredentialURL(context.Background(), *didW3c, id)
+		require.NoError(t, err)
+		require.Equal(t, "https://issuer-node.privado.id/v2/identities/"+did+"/credentials/status-lists/"+id.String(), url)
+	})
+}
@@ -17,3 +17,7 @@ func buildRHSRevocationURL(host, issuerState string) string {
 func buildIden3OnchainSMTProofURL(issuerDID w3c.DID, nonce uint64, contractAddress ethcommon.Address, chainID string, stateHex string) string {
 	return fmt.Sprintf("%s/credentialStatus?revocationNonce=%v&contractAddress=%s:%s&state=%s", issuerDID.String(), nonce, chainID, contractAddress.Hex(), stateHex)
 }
+
+func buildStatusListCredentialURL(host string, issuerDID w3c.DID, statusListID string) string {
+	return fmt.Sprintf("%s/v2/identities/%s/credentials/status-lists/%s", host, issuerDID.String(), statusListID)
+}
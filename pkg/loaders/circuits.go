@@ -1,8 +1,10 @@
 package loaders
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 
@@ -12,7 +14,7 @@ import (
 const (
 	wasmFile            = "circuit.wasm"
 	proofingKeyFile     = "circuit_final.zkey"
-	verificationKeyFile = "authV2.json"
+	verificationKeyFile = "verification_key.json"
 )
 
 // CircuitFilesSet set circuits files.
@@ -55,8 +57,13 @@ func (l *Circuits) Load(circuitID circuits.CircuitID) (*CircuitFilesSet, error)
 }
 
 // LoadVerificationKey load verification key by circuit ID.
+// The key is verification_key.json, or <circuitID>.json for the circuits that name it after the circuit, like authV2.
 func (l *Circuits) LoadVerificationKey(circuitID circuits.CircuitID) ([]byte, error) {
-	return l.getPathToFile(circuitID, verificationKeyFile)
+	data, err := l.getPathToFile(circuitID, verificationKeyFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l.getPathToFile(circuitID, string(circuitID)+".json")
+	}
+	return data, err
 }
 
 // LoadProvingKey load proof key by circuit ID.
@@ -73,7 +80,7 @@ func (l *Circuits) getPathToFile(circuitID circuits.CircuitID, fileName string)
 	path := filepath.Join(l.basePath, string(circuitID), fileName)
 	f, err := os.Open(filepath.Clean(path))
 	if err != nil {
-		return nil, fmt.Errorf("failed open file '%s' by path '%s': %v", fileName, path, err)
+		return nil, fmt.Errorf("failed open file '%s' by path '%s': %w", fileName, path, err)
 	}
 	data, err := io.ReadAll(f)
 	if err != nil {
@@ -0,0 +1,50 @@
+package loaders
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iden3/go-circuits/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuits_LoadVerificationKey(t *testing.T) {
+	basePath := t.TempDir()
+	write := func(circuitID circuits.CircuitID, fileName string, key string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(basePath, string(circuitID)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(basePath, string(circuitID), fileName), []byte(key), 0o600))
+	}
+	write(circuits.StateTransitionCircuitID, "verification_key.json", `{"key": "verification_key.json"}`)
+	write(circuits.StateTransitionCircuitID, "stateTransition.json", `{"key": "stateTransition.json"}`)
+	write(circuits.AuthV2CircuitID, "authV2.json", `{"key": "authV2.json"}`)
+	loader := NewCircuits(basePath)
+
+	keyFile := func(data []byte) string {
+		var key struct {
+			Key string `json:"key"`
+		}
+		require.NoError(t, json.Unmarshal(data, &key))
+		return key.Key
+	}
+
+	t.Run("should load verification_key.json first", func(t *testing.T) {
+		data, err := loader.LoadVerificationKey(circuits.StateTransitionCircuitID)
+		require.NoError(t, err)
+		assert.Equal(t, "verification_key.json", keyFile(data))
+	})
+
+	t.Run("should fall back to the key named after the circuit", func(t *testing.T) {
+		data, err := loader.LoadVerificationKey(circuits.AuthV2CircuitID)
+		require.NoError(t, err)
+		assert.Equal(t, "authV2.json", keyFile(data))
+	})
+
+	t.Run("should fail if the circuit has no key", func(t *testing.T) {
+		_, err := loader.LoadVerificationKey(circuits.AtomicQueryV3CircuitID)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}